@@ -18,6 +18,7 @@ package api
 
 import (
 	"fmt"
+	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/kops/pkg/values"
@@ -54,6 +55,9 @@ type AddonSpec struct {
 	// Manifest is the URL to the manifest that should be applied
 	Manifest *string `json:"manifest,omitempty"`
 
+	// Helm is a Helm chart that should be rendered and applied, instead of a manifest.
+	Helm *HelmChartSpec `json:"helm,omitempty"`
+
 	// ManifestHash is the sha256 hash of our manifest
 	ManifestHash string `json:"manifestHash,omitempty"`
 
@@ -80,6 +84,60 @@ type AddonSpec struct {
 
 	// PruneSpec specifies how old objects should be removed (pruned).
 	Prune *PruneSpec `json:"prune,omitempty"`
+
+	// DependsOn lists the names of the addons that must be applied, and healthy, before this addon is applied.
+	DependsOn []string `json:"dependsOn,omitempty"`
+
+	// HealthCheck specifies how to wait for the addon to become healthy after it is applied.
+	// The version of the addon is only recorded once it is healthy, so an unhealthy addon is applied again.
+	HealthCheck *HealthCheckSpec `json:"healthCheck,omitempty"`
+}
+
+// HealthCheckSpec specifies how to check that an addon is healthy.
+type HealthCheckSpec struct {
+	// Objects are the objects that must be healthy. If empty, all the objects of the addon must be healthy.
+	Objects []HealthCheckObjectSpec `json:"objects,omitempty"`
+
+	// Timeout is how long to wait for the addon to become healthy, defaulting to 5 minutes.
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// HealthCheckObjectSpec specifies an object that must be healthy.
+type HealthCheckObjectSpec struct {
+	// Group is the group of the object.
+	Group string `json:"group,omitempty"`
+	// Kind is the kind of the object (required).
+	Kind string `json:"kind,omitempty"`
+	// Namespace is the namespace of a namespaced object, defaulting to the namespace of the addon.
+	Namespace string `json:"namespace,omitempty"`
+	// Name is the name of the object (required).
+	Name string `json:"name,omitempty"`
+
+	// Condition is the type of a status condition that must be True for the object to be healthy.
+	// If empty, Deployments, DaemonSets and StatefulSets must have completed their rollout,
+	// and other objects must not have a False condition.
+	Condition string `json:"condition,omitempty"`
+}
+
+// HelmChartSpec specifies a Helm chart that is rendered into the manifest of the addon.
+type HelmChartSpec struct {
+	// Chart is the location of the chart, either an OCI reference (oci://registry/repository/chart),
+	// or the URL of a packaged chart (.tgz), which may be relative to the channel, e.g. in the state store.
+	Chart string `json:"chart,omitempty"`
+
+	// Version is the version of the chart, for OCI references.
+	Version string `json:"version,omitempty"`
+
+	// ReleaseName is the name of the release the chart is rendered for, defaulting to the name of the addon.
+	ReleaseName string `json:"releaseName,omitempty"`
+
+	// Values are the values the chart is rendered with.
+	Values map[string]interface{} `json:"values,omitempty"`
+}
+
+// IsOCI returns true if the chart is an OCI reference.
+func (h *HelmChartSpec) IsOCI() bool {
+	return strings.HasPrefix(h.Chart, "oci://")
 }
 
 // PruneSpec specifies how old objects should be removed (pruned).
@@ -107,14 +165,53 @@ type PruneKindSpec struct {
 }
 
 func (a *Addons) Verify() error {
+	names := make(map[string]bool)
+	for _, addon := range a.Spec.Addons {
+		if addon != nil {
+			names[values.StringValue(addon.Name)] = true
+		}
+	}
+
 	for _, addon := range a.Spec.Addons {
 		if addon == nil {
 			continue
 		}
+		for _, dependency := range addon.DependsOn {
+			if !names[dependency] {
+				return fmt.Errorf("bootstrap addon %q depends on unknown addon %q", values.StringValue(addon.Name), dependency)
+			}
+		}
+		if addon.HealthCheck != nil {
+			for _, object := range addon.HealthCheck.Objects {
+				if object.Kind == "" || object.Name == "" {
+					return fmt.Errorf("bootstrap addon %q has a health check object without a kind or a name", values.StringValue(addon.Name))
+				}
+			}
+		}
 		if addon.KubernetesVersion != "" {
 			return fmt.Errorf("bootstrap addon %q has a KubernetesVersion", values.StringValue(addon.Name))
 		}
+		if err := addon.VerifySource(); err != nil {
+			return fmt.Errorf("bootstrap addon %q: %w", values.StringValue(addon.Name), err)
+		}
 	}
 
 	return nil
 }
+
+// VerifySource checks that the addon has exactly one source, a manifest or a Helm chart.
+func (a *AddonSpec) VerifySource() error {
+	if a.Helm == nil {
+		return nil
+	}
+	if values.StringValue(a.Manifest) != "" {
+		return fmt.Errorf("manifest and helm are mutually exclusive")
+	}
+	if a.Helm.Chart == "" {
+		return fmt.Errorf("helm chart is required")
+	}
+	if a.Helm.IsOCI() && a.Helm.Version == "" {
+		return fmt.Errorf("helm version is required for OCI chart %q", a.Helm.Chart)
+	}
+	return nil
+}
@@ -157,7 +157,7 @@ func (a *Addon) GetManifestFullUrl() (*url.URL, error) {
 	return manifestURL, nil
 }
 
-func (a *Addon) EnsureUpdated(ctx context.Context, vfsContext *vfs.VFSContext, k8sClient kubernetes.Interface, cmClient certmanager.Interface, pruner *Pruner, applier Applier, existingVersion *ChannelVersion) (*AddonUpdate, error) {
+func (a *Addon) EnsureUpdated(ctx context.Context, vfsContext *vfs.VFSContext, k8sClient kubernetes.Interface, cmClient certmanager.Interface, pruner *Pruner, applier Applier, helmRenderer *HelmRenderer, healthChecker *HealthChecker, existingVersion *ChannelVersion) (*AddonUpdate, error) {
 	required, err := a.GetRequiredUpdates(ctx, k8sClient, cmClient, existingVersion)
 	if err != nil {
 		return nil, err
@@ -169,7 +169,7 @@ func (a *Addon) EnsureUpdated(ctx context.Context, vfsContext *vfs.VFSContext, k
 	var merr error
 
 	if required.NewVersion != nil {
-		err := a.updateAddon(ctx, k8sClient, vfsContext, pruner, applier, required)
+		err := a.updateAddon(ctx, k8sClient, vfsContext, pruner, applier, helmRenderer, healthChecker, required)
 		if err != nil {
 			merr = multierr.Append(merr, err)
 		}
@@ -183,18 +183,32 @@ func (a *Addon) EnsureUpdated(ctx context.Context, vfsContext *vfs.VFSContext, k
 	return required, merr
 }
 
-func (a *Addon) updateAddon(ctx context.Context, k8sClient kubernetes.Interface, vfsContext *vfs.VFSContext, pruner *Pruner, applier Applier, required *AddonUpdate) error {
-	manifestURL, err := a.GetManifestFullUrl()
-	if err != nil {
-		return err
-	}
+func (a *Addon) updateAddon(ctx context.Context, k8sClient kubernetes.Interface, vfsContext *vfs.VFSContext, pruner *Pruner, applier Applier, helmRenderer *HelmRenderer, healthChecker *HealthChecker, required *AddonUpdate) error {
+	var source string
+	var data []byte
+	if a.Spec.Helm != nil {
+		source = a.Spec.Helm.Chart
+		klog.Infof("Applying update from chart %q", source)
 
-	klog.Infof("Applying update from %q", manifestURL)
+		rendered, err := helmRenderer.Render(ctx, a)
+		if err != nil {
+			return err
+		}
+		data = rendered
+	} else {
+		manifestURL, err := a.GetManifestFullUrl()
+		if err != nil {
+			return err
+		}
+		source = manifestURL.String()
 
-	// We copy the manifest to a temp file because it is likely e.g. an s3 URL, which kubectl can't read
-	data, err := vfsContext.ReadFile(manifestURL.String())
-	if err != nil {
-		return fmt.Errorf("error reading manifest: %w", err)
+		klog.Infof("Applying update from %q", manifestURL)
+
+		// We copy the manifest to a temp file because it is likely e.g. an s3 URL, which kubectl can't read
+		data, err = vfsContext.ReadFile(manifestURL.String())
+		if err != nil {
+			return fmt.Errorf("error reading manifest: %w", err)
+		}
 	}
 
 	var merr error
@@ -219,7 +233,12 @@ func (a *Addon) updateAddon(ctx context.Context, k8sClient kubernetes.Interface,
 	}
 
 	if merr != nil {
-		return fmt.Errorf("error updating addon from %q: %w", manifestURL, merr)
+		return fmt.Errorf("error updating addon from %q: %w", source, merr)
+	}
+
+	// We only record the new version once the addon is healthy, so that we try again if it isn't
+	if err := healthChecker.WaitForHealthy(ctx, a, data); err != nil {
+		return err
 	}
 
 	if err := a.AddNeedsUpdateLabel(ctx, k8sClient, required); err != nil {
@@ -227,7 +246,13 @@ func (a *Addon) updateAddon(ctx context.Context, k8sClient kubernetes.Interface,
 	}
 
 	channel := a.buildChannel()
-	err = channel.SetInstalledVersion(ctx, k8sClient, a.ChannelVersion())
+
+	// Failing to record the manifest only prevents rolling back to it, so it doesn't fail the update
+	if _, err := channel.RecordRevision(ctx, k8sClient, a.ChannelVersion(), a.Spec.Prune, data, DefaultHistoryLimit); err != nil {
+		klog.Warningf("error recording revision of addon %q: %v", a.Name, err)
+	}
+
+	err := channel.SetInstalledVersion(ctx, k8sClient, a.ChannelVersion())
 	if err != nil {
 		return fmt.Errorf("error applying annotation to record addon installation: %v", err)
 	}
@@ -54,6 +54,10 @@ type ChannelVersion struct {
 	// SystemGeneration holds the generation of the channels functionality.
 	// It is used so that we reapply when we introduce new features, such as prune.
 	SystemGeneration int `json:"systemGeneration,omitempty"`
+
+	// PinnedRevision is set when the addon was rolled back to a recorded revision.
+	// A pinned addon is not updated when applying the channel, until it is unpinned.
+	PinnedRevision int `json:"pinnedRevision,omitempty"`
 }
 
 func stringValue(s *string) string {
@@ -72,6 +76,9 @@ func (c *ChannelVersion) String() string {
 		s += " ManifestHash=" + c.ManifestHash
 	}
 	s += " SystemGeneration=" + strconv.Itoa(c.SystemGeneration)
+	if c.PinnedRevision != 0 {
+		s += " PinnedRevision=" + strconv.Itoa(c.PinnedRevision)
+	}
 	return s
 }
 
@@ -118,6 +125,11 @@ func (c *Channel) AnnotationName() string {
 func (c *ChannelVersion) replaces(name string, existing *ChannelVersion) bool {
 	klog.V(6).Infof("Checking existing config for %q: %v compared to new channel: %v", name, existing, c)
 
+	if existing.PinnedRevision != 0 {
+		klog.Infof("addon %q is pinned to revision %d; will not replace", name, existing.PinnedRevision)
+		return false
+	}
+
 	if c.Id != existing.Id {
 		klog.V(4).Infof("cluster has different ids for %q (%q vs %q); will replace", name, c.Id, existing.Id)
 		return true
@@ -0,0 +1,73 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package channels
+
+import (
+	"fmt"
+	"sort"
+)
+
+// SortByDependencies orders the addons so that each addon comes after the addons it depends on.
+// Dependencies that are not in the list are ignored; addons are otherwise sorted by name.
+func SortByDependencies(addons []*Addon) ([]*Addon, error) {
+	byName := make(map[string]*Addon)
+	for _, addon := range addons {
+		byName[addon.Name] = addon
+	}
+
+	var sorted []*Addon
+	visited := make(map[string]bool)
+	visiting := make(map[string]bool)
+
+	var visit func(addon *Addon, path []string) error
+	visit = func(addon *Addon, path []string) error {
+		if visited[addon.Name] {
+			return nil
+		}
+		if visiting[addon.Name] {
+			return fmt.Errorf("addons have circular dependencies: %v", append(path, addon.Name))
+		}
+		visiting[addon.Name] = true
+		for _, dependency := range addon.Spec.DependsOn {
+			if dependencyAddon := byName[dependency]; dependencyAddon != nil {
+				if err := visit(dependencyAddon, append(path, addon.Name)); err != nil {
+					return err
+				}
+			}
+		}
+		visiting[addon.Name] = false
+		visited[addon.Name] = true
+		sorted = append(sorted, addon)
+		return nil
+	}
+
+	for _, name := range sortedAddonNames(byName) {
+		if err := visit(byName[name], nil); err != nil {
+			return nil, err
+		}
+	}
+	return sorted, nil
+}
+
+func sortedAddonNames(addons map[string]*Addon) []string {
+	var names []string
+	for name := range addons {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
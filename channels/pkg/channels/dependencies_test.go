@@ -0,0 +1,71 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package channels
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/kops/channels/pkg/api"
+)
+
+func dependentAddon(name string, dependsOn ...string) *Addon {
+	return &Addon{
+		Name: name,
+		Spec: &api.AddonSpec{
+			DependsOn: dependsOn,
+		},
+	}
+}
+
+func TestSortByDependencies(t *testing.T) {
+	addons := []*Addon{
+		dependentAddon("widgets", "widget-operator"),
+		dependentAddon("widget-operator", "cert-manager"),
+		dependentAddon("cert-manager"),
+		dependentAddon("aaa"),
+		dependentAddon("metrics", "kube-proxy"),
+	}
+
+	sorted, err := SortByDependencies(addons)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var names []string
+	for _, addon := range sorted {
+		names = append(names, addon.Name)
+	}
+	expected := []string{"aaa", "cert-manager", "metrics", "widget-operator", "widgets"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("unexpected order %v, expected %v", names, expected)
+	}
+}
+
+func TestSortByDependenciesCycle(t *testing.T) {
+	addons := []*Addon{
+		dependentAddon("a", "b"),
+		dependentAddon("b", "c"),
+		dependentAddon("c", "a"),
+	}
+
+	_, err := SortByDependencies(addons)
+	if err == nil || !strings.Contains(err.Error(), "circular dependencies: [a b c a]") {
+		t.Errorf("expected circular dependency error, got %v", err)
+	}
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package channels
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/applylib/applyset"
+	"k8s.io/kops/pkg/kubemanifest"
+)
+
+// DefaultHealthCheckTimeout is how long we wait for an addon to become healthy, if the addon doesn't specify it.
+const DefaultHealthCheckTimeout = 5 * time.Minute
+
+// HealthChecker waits for addons to become healthy after they are applied.
+type HealthChecker struct {
+	Client     dynamic.Interface
+	RESTMapper meta.RESTMapper
+
+	// Interval is how often the objects are checked, defaulting to 5 seconds.
+	Interval time.Duration
+}
+
+// healthCheckTarget is an object that must be healthy.
+type healthCheckTarget struct {
+	gvk       schema.GroupVersionKind
+	namespace string
+	name      string
+	condition string
+}
+
+func (t *healthCheckTarget) String() string {
+	s := t.gvk.Kind
+	if t.gvk.Group != "" {
+		s += "." + t.gvk.Group
+	}
+	s += ":"
+	if t.namespace != "" {
+		s += t.namespace + "/"
+	}
+	return s + t.name
+}
+
+// WaitForHealthy waits until the addon is healthy, according to its health check.
+// manifest holds the objects that were applied for the addon.
+func (h *HealthChecker) WaitForHealthy(ctx context.Context, a *Addon, manifest []byte) error {
+	healthCheck := a.Spec.HealthCheck
+	if healthCheck == nil {
+		return nil
+	}
+
+	targets, err := h.buildTargets(a, manifest)
+	if err != nil {
+		return err
+	}
+
+	timeout := DefaultHealthCheckTimeout
+	if healthCheck.Timeout != nil {
+		timeout = healthCheck.Timeout.Duration
+	}
+	interval := h.Interval
+	if interval == 0 {
+		interval = 5 * time.Second
+	}
+
+	klog.Infof("waiting up to %v for addon %q to become healthy", timeout, a.Name)
+
+	var unhealthy *healthCheckTarget
+	err = wait.PollUntilContextTimeout(ctx, interval, timeout, true, func(ctx context.Context) (bool, error) {
+		for _, target := range targets {
+			healthy, err := h.isHealthy(ctx, target)
+			if err != nil {
+				return false, err
+			}
+			if !healthy {
+				unhealthy = target
+				return false, nil
+			}
+		}
+		return true, nil
+	})
+	if err != nil {
+		if unhealthy != nil && wait.Interrupted(err) {
+			return fmt.Errorf("addon %q did not become healthy within %v: %v is not healthy", a.Name, timeout, unhealthy)
+		}
+		return fmt.Errorf("error checking health of addon %q: %w", a.Name, err)
+	}
+
+	klog.Infof("addon %q is healthy", a.Name)
+	return nil
+}
+
+// buildTargets returns the objects to check: the objects of the health check, or all the objects of the manifest.
+func (h *HealthChecker) buildTargets(a *Addon, manifest []byte) ([]*healthCheckTarget, error) {
+	var targets []*healthCheckTarget
+
+	if len(a.Spec.HealthCheck.Objects) != 0 {
+		for i := range a.Spec.HealthCheck.Objects {
+			object := &a.Spec.HealthCheck.Objects[i]
+			if object.Kind == "" || object.Name == "" {
+				return nil, fmt.Errorf("health check objects of addon %q require a kind and a name", a.Name)
+			}
+
+			gk := schema.GroupKind{Group: object.Group, Kind: object.Kind}
+			mapping, err := h.RESTMapper.RESTMapping(gk)
+			if err != nil {
+				return nil, fmt.Errorf("unable to find resource for %s: %w", gk, err)
+			}
+
+			namespace := ""
+			if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+				namespace = object.Namespace
+				if namespace == "" {
+					namespace = a.GetNamespace()
+				}
+			}
+
+			targets = append(targets, &healthCheckTarget{
+				gvk:       mapping.GroupVersionKind,
+				namespace: namespace,
+				name:      object.Name,
+				condition: object.Condition,
+			})
+		}
+		return targets, nil
+	}
+
+	objects, err := kubemanifest.LoadObjectsFrom(manifest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse objects: %w", err)
+	}
+	for _, object := range objects {
+		if object.IsEmptyObject() {
+			continue
+		}
+		targets = append(targets, &healthCheckTarget{
+			gvk:       object.GroupVersionKind(),
+			namespace: object.GetNamespace(),
+			name:      object.GetName(),
+		})
+	}
+	return targets, nil
+}
+
+// isHealthy checks the health of a single object.
+func (h *HealthChecker) isHealthy(ctx context.Context, target *healthCheckTarget) (bool, error) {
+	mapping, err := h.RESTMapper.RESTMapping(target.gvk.GroupKind(), target.gvk.Version)
+	if err != nil {
+		return false, fmt.Errorf("unable to find resource for %s: %w", target.gvk, err)
+	}
+
+	var resource dynamic.ResourceInterface = h.Client.Resource(mapping.Resource)
+	if target.namespace != "" {
+		resource = h.Client.Resource(mapping.Resource).Namespace(target.namespace)
+	}
+
+	u, err := resource.Get(ctx, target.name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			klog.Infof("waiting for %v to be created", target)
+			return false, nil
+		}
+		return false, fmt.Errorf("error getting %v: %w", target, err)
+	}
+
+	if target.condition != "" {
+		return applyset.HasTrueCondition(u, target.condition), nil
+	}
+	return applyset.IsReady(u), nil
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package channels
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+	"k8s.io/kops/channels/pkg/api"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+const healthManifest = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: operator
+  namespace: kube-system
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: operator-config
+  namespace: kube-system
+`
+
+func deployment(availableReplicas int64) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]interface{}{
+			"name":       "operator",
+			"namespace":  "kube-system",
+			"generation": int64(1),
+		},
+		"spec": map[string]interface{}{
+			"replicas": int64(1),
+		},
+		"status": map[string]interface{}{
+			"observedGeneration": int64(1),
+			"replicas":           int64(1),
+			"updatedReplicas":    int64(1),
+			"availableReplicas":  availableReplicas,
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "Available", "status": "True"},
+			},
+		},
+	}}
+}
+
+func configMap() *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]interface{}{
+			"name":      "operator-config",
+			"namespace": "kube-system",
+		},
+	}}
+}
+
+func newHealthChecker(objects ...runtime.Object) *HealthChecker {
+	restMapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{{Group: "apps", Version: "v1"}, {Version: "v1"}})
+	restMapper.Add(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, meta.RESTScopeNamespace)
+	restMapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}, meta.RESTScopeNamespace)
+
+	client := fakedynamic.NewSimpleDynamicClient(runtime.NewScheme(), objects...)
+	return &HealthChecker{
+		Client:     client,
+		RESTMapper: restMapper,
+		Interval:   10 * time.Millisecond,
+	}
+}
+
+func TestWaitForHealthy(t *testing.T) {
+	grid := []struct {
+		Name        string
+		HealthCheck *api.HealthCheckSpec
+		Objects     []runtime.Object
+		Expected    string
+	}{
+		{
+			Name:        "no health check",
+			HealthCheck: nil,
+		},
+		{
+			Name:        "all objects healthy",
+			HealthCheck: &api.HealthCheckSpec{},
+			Objects:     []runtime.Object{deployment(1), configMap()},
+		},
+		{
+			Name:        "deployment not rolled out",
+			HealthCheck: &api.HealthCheckSpec{Timeout: &metav1.Duration{Duration: 50 * time.Millisecond}},
+			Objects:     []runtime.Object{deployment(0), configMap()},
+			Expected:    "Deployment.apps:kube-system/operator is not healthy",
+		},
+		{
+			Name:        "object missing",
+			HealthCheck: &api.HealthCheckSpec{Timeout: &metav1.Duration{Duration: 50 * time.Millisecond}},
+			Objects:     []runtime.Object{deployment(1)},
+			Expected:    "ConfigMap:kube-system/operator-config is not healthy",
+		},
+		{
+			Name: "custom condition",
+			HealthCheck: &api.HealthCheckSpec{
+				Objects: []api.HealthCheckObjectSpec{
+					{Group: "apps", Kind: "Deployment", Name: "operator", Condition: "Available"},
+				},
+			},
+			Objects: []runtime.Object{deployment(0)},
+		},
+		{
+			Name: "custom condition not true",
+			HealthCheck: &api.HealthCheckSpec{
+				Objects: []api.HealthCheckObjectSpec{
+					{Group: "apps", Kind: "Deployment", Name: "operator", Condition: "Ready"},
+				},
+				Timeout: &metav1.Duration{Duration: 50 * time.Millisecond},
+			},
+			Objects:  []runtime.Object{deployment(1)},
+			Expected: "did not become healthy",
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.Name, func(t *testing.T) {
+			addon := &Addon{
+				Name: "operator.addons.k8s.io",
+				Spec: &api.AddonSpec{
+					Name:        fi.PtrTo("operator.addons.k8s.io"),
+					HealthCheck: g.HealthCheck,
+				},
+			}
+
+			err := newHealthChecker(g.Objects...).WaitForHealthy(context.Background(), addon, []byte(healthManifest))
+			if g.Expected == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+			} else if err == nil || !strings.Contains(err.Error(), g.Expected) {
+				t.Errorf("expected error containing %q, got %v", g.Expected, err)
+			}
+		})
+	}
+}
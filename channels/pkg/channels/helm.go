@@ -0,0 +1,304 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package channels
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/engine"
+	"helm.sh/helm/v3/pkg/registry"
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/releaseutil"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/kubemanifest"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+// HelmRenderer renders the Helm charts of addons into manifests,
+// which are then applied and pruned like any other addon manifest.
+type HelmRenderer struct {
+	VFSContext *vfs.VFSContext
+
+	// RESTMapper is used to find which objects are namespaced, as Helm leaves the namespace to the client.
+	// If it is nil, as when rendering without a cluster, only the built-in cluster-scoped kinds are not namespaced.
+	RESTMapper meta.RESTMapper
+
+	// KubernetesVersion is the version of the cluster, exposed to the chart as .Capabilities.KubeVersion.
+	KubernetesVersion string
+
+	// loadChart can be replaced for tests.
+	loadChart func(ctx context.Context, a *Addon) (*chart.Chart, error)
+}
+
+// GetHelmChartFullUrl returns the URL of a packaged chart, resolved relative to the channel.
+func (a *Addon) GetHelmChartFullUrl() (*url.URL, error) {
+	if a.Spec.Helm == nil || a.Spec.Helm.Chart == "" {
+		return nil, field.Required(field.NewPath("spec", "helm", "chart"), "")
+	}
+
+	chartLocation := a.Spec.Helm.Chart
+	chartURL, err := url.Parse(chartLocation)
+	if err != nil {
+		return nil, field.Invalid(field.NewPath("spec", "helm", "chart"), chartLocation, "Not a valid URL")
+	}
+	if !chartURL.IsAbs() {
+		chartURL = a.ChannelLocation.ResolveReference(chartURL)
+	}
+	return chartURL, nil
+}
+
+// Render renders the Helm chart of the addon into a manifest.
+func (r *HelmRenderer) Render(ctx context.Context, a *Addon) ([]byte, error) {
+	if err := a.Spec.VerifySource(); err != nil {
+		return nil, err
+	}
+
+	loadChart := r.loadChart
+	if loadChart == nil {
+		loadChart = r.fetchChart
+	}
+	chrt, err := loadChart(ctx, a)
+	if err != nil {
+		return nil, err
+	}
+	return r.renderChart(ctx, a, chrt)
+}
+
+// RenderChart renders a packaged chart, as returned by FetchChart, into the manifest of the addon.
+func (r *HelmRenderer) RenderChart(ctx context.Context, a *Addon, chartData []byte) ([]byte, error) {
+	if err := a.Spec.VerifySource(); err != nil {
+		return nil, err
+	}
+	chrt, err := loader.LoadArchive(bytes.NewReader(chartData))
+	if err != nil {
+		return nil, fmt.Errorf("error loading chart %q: %w", a.Spec.Helm.Chart, err)
+	}
+	return r.renderChart(ctx, a, chrt)
+}
+
+func (r *HelmRenderer) renderChart(ctx context.Context, a *Addon, chrt *chart.Chart) ([]byte, error) {
+	releaseName := a.Spec.Helm.ReleaseName
+	if releaseName == "" {
+		releaseName = a.Name
+	}
+
+	// Render the chart as `helm template` would, without the release storage of helm
+	caps := chartutil.DefaultCapabilities.Copy()
+	if r.KubernetesVersion != "" {
+		kubeVersion, err := chartutil.ParseKubeVersion(r.KubernetesVersion)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing kubernetes version %q: %w", r.KubernetesVersion, err)
+		}
+		caps.KubeVersion = *kubeVersion
+	}
+	if chrt.Metadata.KubeVersion != "" && !chartutil.IsCompatibleRange(chrt.Metadata.KubeVersion, caps.KubeVersion.String()) {
+		return nil, fmt.Errorf("chart %q requires kubeVersion %s, which is incompatible with Kubernetes %s", a.Spec.Helm.Chart, chrt.Metadata.KubeVersion, caps.KubeVersion.String())
+	}
+
+	values := a.Spec.Helm.Values
+	if values == nil {
+		values = map[string]interface{}{}
+	}
+	if err := chartutil.ProcessDependenciesWithMerge(chrt, values); err != nil {
+		return nil, fmt.Errorf("error processing dependencies of chart %q: %w", a.Spec.Helm.Chart, err)
+	}
+	options := chartutil.ReleaseOptions{
+		Name:      releaseName,
+		Namespace: a.GetNamespace(),
+		Revision:  1,
+		IsInstall: true,
+	}
+	renderValues, err := chartutil.ToRenderValues(chrt, values, options, caps)
+	if err != nil {
+		return nil, fmt.Errorf("error building values of chart %q: %w", a.Spec.Helm.Chart, err)
+	}
+	files, err := engine.Render(chrt, renderValues)
+	if err != nil {
+		return nil, fmt.Errorf("error rendering chart %q: %w", a.Spec.Helm.Chart, err)
+	}
+	for name := range files {
+		if strings.HasSuffix(name, "NOTES.txt") {
+			delete(files, name)
+		}
+	}
+	hooks, rendered, err := releaseutil.SortManifests(files, nil, releaseutil.InstallOrder)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing rendered chart %q: %w", a.Spec.Helm.Chart, err)
+	}
+
+	var manifests []string
+	for _, crd := range chrt.CRDObjects() {
+		manifests = append(manifests, string(crd.File.Data))
+	}
+	for _, m := range rendered {
+		manifests = append(manifests, m.Content)
+	}
+	for _, hook := range hooks {
+		if isTestHook(hook) {
+			continue
+		}
+		// There is no release lifecycle for the hooks to run in, so we apply them with the other objects.
+		klog.V(2).Infof("applying hook %s of chart %q as a regular object", hook.Path, a.Spec.Helm.Chart)
+		manifests = append(manifests, hook.Manifest)
+	}
+
+	objects, err := kubemanifest.LoadObjectsFrom([]byte(strings.Join(manifests, "\n---\n")))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing rendered chart %q: %w", a.Spec.Helm.Chart, err)
+	}
+	if err := r.setNamespaces(objects, a.GetNamespace()); err != nil {
+		return nil, err
+	}
+	return objects.ToYAML()
+}
+
+// fetchChart loads the chart from the OCI registry or from the packaged chart.
+func (r *HelmRenderer) fetchChart(ctx context.Context, a *Addon) (*chart.Chart, error) {
+	data, err := r.FetchChart(ctx, a)
+	if err != nil {
+		return nil, err
+	}
+	chrt, err := loader.LoadArchive(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("error loading chart %q: %w", a.Spec.Helm.Chart, err)
+	}
+	return chrt, nil
+}
+
+// FetchChart returns the packaged chart of the addon, from the OCI registry or from the packaged chart.
+func (r *HelmRenderer) FetchChart(ctx context.Context, a *Addon) ([]byte, error) {
+	if err := a.Spec.VerifySource(); err != nil {
+		return nil, err
+	}
+
+	if a.Spec.Helm.IsOCI() {
+		ref := strings.TrimPrefix(a.Spec.Helm.Chart, "oci://") + ":" + a.Spec.Helm.Version
+		klog.Infof("Pulling chart %q", ref)
+
+		client, err := registry.NewClient()
+		if err != nil {
+			return nil, fmt.Errorf("error building registry client: %w", err)
+		}
+		result, err := client.Pull(ref, registry.PullOptWithChart(true))
+		if err != nil {
+			return nil, fmt.Errorf("error pulling chart %q: %w", ref, err)
+		}
+		return result.Chart.Data, nil
+	}
+
+	chartURL, err := a.GetHelmChartFullUrl()
+	if err != nil {
+		return nil, err
+	}
+	klog.Infof("Loading chart %q", chartURL)
+
+	data, err := r.VFSContext.ReadFile(chartURL.String())
+	if err != nil {
+		return nil, fmt.Errorf("error reading chart: %w", err)
+	}
+	return data, nil
+}
+
+// clusterScopedKinds are the built-in kinds that are not namespaced, used when rendering without a cluster.
+var clusterScopedKinds = sets.New(
+	schema.GroupKind{Group: "", Kind: "Namespace"},
+	schema.GroupKind{Group: "", Kind: "Node"},
+	schema.GroupKind{Group: "", Kind: "PersistentVolume"},
+	schema.GroupKind{Group: "admissionregistration.k8s.io", Kind: "MutatingWebhookConfiguration"},
+	schema.GroupKind{Group: "admissionregistration.k8s.io", Kind: "ValidatingAdmissionPolicy"},
+	schema.GroupKind{Group: "admissionregistration.k8s.io", Kind: "ValidatingAdmissionPolicyBinding"},
+	schema.GroupKind{Group: "admissionregistration.k8s.io", Kind: "ValidatingWebhookConfiguration"},
+	schema.GroupKind{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"},
+	schema.GroupKind{Group: "apiregistration.k8s.io", Kind: "APIService"},
+	schema.GroupKind{Group: "certificates.k8s.io", Kind: "CertificateSigningRequest"},
+	schema.GroupKind{Group: "flowcontrol.apiserver.k8s.io", Kind: "FlowSchema"},
+	schema.GroupKind{Group: "flowcontrol.apiserver.k8s.io", Kind: "PriorityLevelConfiguration"},
+	schema.GroupKind{Group: "networking.k8s.io", Kind: "IngressClass"},
+	schema.GroupKind{Group: "node.k8s.io", Kind: "RuntimeClass"},
+	schema.GroupKind{Group: "rbac.authorization.k8s.io", Kind: "ClusterRole"},
+	schema.GroupKind{Group: "rbac.authorization.k8s.io", Kind: "ClusterRoleBinding"},
+	schema.GroupKind{Group: "scheduling.k8s.io", Kind: "PriorityClass"},
+	schema.GroupKind{Group: "storage.k8s.io", Kind: "CSIDriver"},
+	schema.GroupKind{Group: "storage.k8s.io", Kind: "CSINode"},
+	schema.GroupKind{Group: "storage.k8s.io", Kind: "StorageClass"},
+	schema.GroupKind{Group: "storage.k8s.io", Kind: "VolumeAttachment"},
+)
+
+// setNamespaces sets the namespace of the namespaced objects that don't specify one,
+// as helm would do when installing the chart.
+func (r *HelmRenderer) setNamespaces(objects kubemanifest.ObjectList, namespace string) error {
+	// The chart may include the definitions of the custom resources it creates, which the cluster doesn't know about yet
+	crdScopes := make(map[schema.GroupKind]string)
+	for _, object := range objects {
+		if object.GroupVersionKind().GroupKind() != (schema.GroupKind{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}) {
+			continue
+		}
+		u := object.ToUnstructured()
+		group, _, _ := unstructured.NestedString(u.Object, "spec", "group")
+		kind, _, _ := unstructured.NestedString(u.Object, "spec", "names", "kind")
+		scope, _, _ := unstructured.NestedString(u.Object, "spec", "scope")
+		crdScopes[schema.GroupKind{Group: group, Kind: kind}] = scope
+	}
+
+	for _, object := range objects {
+		if object.IsEmptyObject() || object.GetNamespace() != "" {
+			continue
+		}
+		gvk := object.GroupVersionKind()
+		gk := gvk.GroupKind()
+
+		namespaced := false
+		if scope, found := crdScopes[gk]; found {
+			namespaced = scope == "Namespaced"
+		} else if r.RESTMapper == nil {
+			namespaced = !clusterScopedKinds.Has(gk)
+		} else {
+			mapping, err := r.RESTMapper.RESTMapping(gk, gvk.Version)
+			if err != nil {
+				return fmt.Errorf("unable to find resource for %s: %w", gk, err)
+			}
+			namespaced = mapping.Scope.Name() == meta.RESTScopeNameNamespace
+		}
+
+		if namespaced {
+			object.ToUnstructured().SetNamespace(namespace)
+		}
+	}
+	return nil
+}
+
+// isTestHook returns true if the hook only runs for helm test.
+func isTestHook(hook *release.Hook) bool {
+	for _, event := range hook.Events {
+		if event == release.HookTest {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,282 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package channels
+
+import (
+	"context"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kops/channels/pkg/api"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+func testChart() *chart.Chart {
+	return &chart.Chart{
+		Metadata: &chart.Metadata{
+			APIVersion: chart.APIVersionV2,
+			Name:       "example",
+			Version:    "1.2.3",
+		},
+		Values: map[string]interface{}{
+			"replicas": 1,
+		},
+		Templates: []*chart.File{
+			{
+				Name: "templates/configmap.yaml",
+				Data: []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ .Release.Name }}
+data:
+  replicas: "{{ .Values.replicas }}"
+  namespace: {{ .Release.Namespace }}
+`),
+			},
+			{
+				Name: "templates/clusterrole.yaml",
+				Data: []byte(`apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: {{ .Release.Name }}
+`),
+			},
+			{
+				Name: "templates/widget.yaml",
+				Data: []byte(`apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: {{ .Release.Name }}
+`),
+			},
+			{
+				Name: "templates/test.yaml",
+				Data: []byte(`apiVersion: v1
+kind: Pod
+metadata:
+  name: {{ .Release.Name }}-test
+  annotations:
+    helm.sh/hook: test
+`),
+			},
+			{
+				Name: "templates/NOTES.txt",
+				Data: []byte(`Installed {{ .Release.Name }}`),
+			},
+		},
+		Files: []*chart.File{
+			{
+				Name: "crds/widgets.yaml",
+				Data: []byte(`apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: widgets.example.com
+spec:
+  group: example.com
+  names:
+    kind: Widget
+    plural: widgets
+  scope: Namespaced
+`),
+			},
+		},
+	}
+}
+
+func testRESTMapper() meta.RESTMapper {
+	restMapper := meta.NewDefaultRESTMapper(nil)
+	restMapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}, meta.RESTScopeNamespace)
+	restMapper.Add(schema.GroupVersionKind{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "ClusterRole"}, meta.RESTScopeRoot)
+	restMapper.Add(schema.GroupVersionKind{Group: "apiextensions.k8s.io", Version: "v1", Kind: "CustomResourceDefinition"}, meta.RESTScopeRoot)
+	return restMapper
+}
+
+// testChartManifest is the manifest rendered from testChart
+const testChartManifest = `apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: widgets.example.com
+spec:
+  group: example.com
+  names:
+    kind: Widget
+    plural: widgets
+  scope: Namespaced
+
+---
+
+apiVersion: v1
+data:
+  namespace: example
+  replicas: "3"
+kind: ConfigMap
+metadata:
+  name: example
+  namespace: example
+
+---
+
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: example
+
+---
+
+apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: example
+  namespace: example
+`
+
+func TestHelmRender(t *testing.T) {
+	chartDir := t.TempDir()
+	chartPath, err := chartutil.Save(testChart(), chartDir)
+	if err != nil {
+		t.Fatalf("error saving chart: %v", err)
+	}
+
+	channelLocation, err := url.Parse("file://" + filepath.Join(chartDir, "channel.yaml"))
+	if err != nil {
+		t.Fatalf("error parsing channel location: %v", err)
+	}
+
+	addon := &Addon{
+		Name:            "example.addons.k8s.io",
+		ChannelLocation: *channelLocation,
+		Spec: &api.AddonSpec{
+			Name:      fi.PtrTo("example.addons.k8s.io"),
+			Namespace: fi.PtrTo("example"),
+			Helm: &api.HelmChartSpec{
+				Chart:       filepath.Base(chartPath),
+				ReleaseName: "example",
+				Values: map[string]interface{}{
+					"replicas": 3,
+				},
+			},
+		},
+	}
+
+	renderer := &HelmRenderer{
+		VFSContext:        vfs.Context,
+		RESTMapper:        testRESTMapper(),
+		KubernetesVersion: "v1.34.1",
+	}
+	manifest, err := renderer.Render(context.Background(), addon)
+	if err != nil {
+		t.Fatalf("error rendering chart: %v", err)
+	}
+
+	if strings.TrimSpace(string(manifest)) != strings.TrimSpace(testChartManifest) {
+		t.Errorf("unexpected manifest:\n%s\nexpected:\n%s", manifest, testChartManifest)
+	}
+}
+
+// TestHelmRenderChartWithoutCluster renders the chart as kops update cluster does, without a cluster to find the namespaced kinds
+func TestHelmRenderChartWithoutCluster(t *testing.T) {
+	ctx := context.Background()
+
+	chartDir := t.TempDir()
+	chartPath, err := chartutil.Save(testChart(), chartDir)
+	if err != nil {
+		t.Fatalf("error saving chart: %v", err)
+	}
+
+	addon := &Addon{
+		Name: "example.addons.k8s.io",
+		Spec: &api.AddonSpec{
+			Name:      fi.PtrTo("example.addons.k8s.io"),
+			Namespace: fi.PtrTo("example"),
+			Helm: &api.HelmChartSpec{
+				Chart:       "file://" + chartPath,
+				ReleaseName: "example",
+				Values: map[string]interface{}{
+					"replicas": 3,
+				},
+			},
+		},
+	}
+
+	renderer := &HelmRenderer{
+		VFSContext:        vfs.Context,
+		KubernetesVersion: "v1.34.1",
+	}
+	chartData, err := renderer.FetchChart(ctx, addon)
+	if err != nil {
+		t.Fatalf("error fetching chart: %v", err)
+	}
+	manifest, err := renderer.RenderChart(ctx, addon, chartData)
+	if err != nil {
+		t.Fatalf("error rendering chart: %v", err)
+	}
+	if strings.TrimSpace(string(manifest)) != strings.TrimSpace(testChartManifest) {
+		t.Errorf("unexpected manifest:\n%s\nexpected:\n%s", manifest, testChartManifest)
+	}
+}
+
+func TestHelmRenderInvalidSource(t *testing.T) {
+	grid := []struct {
+		Name     string
+		Spec     *api.AddonSpec
+		Expected string
+	}{
+		{
+			Name: "manifest and chart",
+			Spec: &api.AddonSpec{
+				Manifest: fi.PtrTo("example.yaml"),
+				Helm:     &api.HelmChartSpec{Chart: "example-1.2.3.tgz"},
+			},
+			Expected: "manifest and helm are mutually exclusive",
+		},
+		{
+			Name: "no chart",
+			Spec: &api.AddonSpec{
+				Helm: &api.HelmChartSpec{},
+			},
+			Expected: "helm chart is required",
+		},
+		{
+			Name: "oci without version",
+			Spec: &api.AddonSpec{
+				Helm: &api.HelmChartSpec{Chart: "oci://registry.example.com/charts/example"},
+			},
+			Expected: "helm version is required",
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.Name, func(t *testing.T) {
+			renderer := &HelmRenderer{
+				RESTMapper: testRESTMapper(),
+				loadChart: func(ctx context.Context, a *Addon) (*chart.Chart, error) {
+					return testChart(), nil
+				},
+			}
+			_, err := renderer.Render(context.Background(), &Addon{Name: "example", Spec: g.Spec})
+			if err == nil || !strings.Contains(err.Error(), g.Expected) {
+				t.Errorf("expected error containing %q, got %v", g.Expected, err)
+			}
+		})
+	}
+}
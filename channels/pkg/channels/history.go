@@ -0,0 +1,253 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package channels
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/channels/pkg/api"
+)
+
+// DefaultHistoryLimit is the number of applied manifests we keep for each addon.
+const DefaultHistoryLimit = 5
+
+// AddonRevision is a manifest that was applied for an addon, which we can roll back to.
+type AddonRevision struct {
+	Revision  int            `json:"revision"`
+	Version   ChannelVersion `json:"version"`
+	AppliedAt metav1.Time    `json:"appliedAt"`
+	// Prune is the prune spec of the addon, so the objects added after this revision are pruned on rollback.
+	Prune *api.PruneSpec `json:"prune,omitempty"`
+
+	// Manifest is the applied manifest; it is stored separately, compressed.
+	Manifest []byte `json:"-"`
+}
+
+// HistorySecretName is the name of the secret holding the applied manifests of the addon.
+// We use a secret because manifests can contain credentials.
+func (c *Channel) HistorySecretName() string {
+	return "addon-history." + c.Name
+}
+
+// RecordRevision stores the manifest applied for a version of the addon, keeping the last limit revisions.
+func (c *Channel) RecordRevision(ctx context.Context, k8sClient kubernetes.Interface, version *ChannelVersion, prune *api.PruneSpec, manifest []byte, limit int) (*AddonRevision, error) {
+	secrets := k8sClient.CoreV1().Secrets(c.Namespace)
+
+	create := false
+	secret, err := secrets.Get(ctx, c.HistorySecretName(), metav1.GetOptions{})
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return nil, fmt.Errorf("error reading history of addon %q: %w", c.Name, err)
+		}
+		create = true
+		secret = &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      c.HistorySecretName(),
+				Namespace: c.Namespace,
+				Labels: map[string]string{
+					"app.kubernetes.io/managed-by": "kops",
+				},
+			},
+		}
+	}
+
+	revisions, err := parseRevisions(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	revision := &AddonRevision{
+		Revision:  1,
+		Version:   *version,
+		AppliedAt: metav1.NewTime(time.Now().UTC()),
+		Prune:     prune,
+		Manifest:  manifest,
+	}
+	// The pin applies to the installed version, not to the revision
+	revision.Version.PinnedRevision = 0
+	if len(revisions) != 0 {
+		revision.Revision = revisions[len(revisions)-1].Revision + 1
+	}
+	revisions = append(revisions, revision)
+	if limit > 0 && len(revisions) > limit {
+		revisions = revisions[len(revisions)-limit:]
+	}
+
+	if err := encodeRevisions(secret, revisions); err != nil {
+		return nil, err
+	}
+
+	if create {
+		_, err = secrets.Create(ctx, secret, metav1.CreateOptions{})
+	} else {
+		_, err = secrets.Update(ctx, secret, metav1.UpdateOptions{})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error writing history of addon %q: %w", c.Name, err)
+	}
+
+	klog.Infof("recorded revision %d of addon %q", revision.Revision, c.Name)
+	return revision, nil
+}
+
+// ListRevisions returns the recorded revisions of the addon, oldest first.
+func (c *Channel) ListRevisions(ctx context.Context, k8sClient kubernetes.Interface) ([]*AddonRevision, error) {
+	secret, err := k8sClient.CoreV1().Secrets(c.Namespace).Get(ctx, c.HistorySecretName(), metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error reading history of addon %q: %w", c.Name, err)
+	}
+	return parseRevisions(secret)
+}
+
+// FindInstalledRevision returns the revision matching the installed version, or nil if it was not recorded.
+func FindInstalledRevision(installed *ChannelVersion, revisions []*AddonRevision) *AddonRevision {
+	if installed.PinnedRevision != 0 {
+		for _, r := range revisions {
+			if r.Revision == installed.PinnedRevision {
+				return r
+			}
+		}
+		return nil
+	}
+
+	// The latest revision matching the installed version
+	for i := len(revisions) - 1; i >= 0; i-- {
+		r := revisions[i]
+		if r.Version.ManifestHash == installed.ManifestHash && r.Version.Id == installed.Id {
+			return r
+		}
+	}
+	return nil
+}
+
+// Rollback re-applies a recorded revision of the addon, and pins the addon to it,
+// so that applying the channel doesn't update the addon again until it is unpinned.
+func (c *Channel) Rollback(ctx context.Context, k8sClient kubernetes.Interface, pruner *Pruner, applier Applier, revision *AddonRevision) error {
+	klog.Infof("rolling back addon %q to revision %d", c.Name, revision.Revision)
+
+	if err := applier.Apply(ctx, revision.Manifest); err != nil {
+		return fmt.Errorf("error applying revision %d of addon %q: %w", revision.Revision, c.Name, err)
+	}
+	if err := pruner.Prune(ctx, revision.Manifest, revision.Prune); err != nil {
+		return fmt.Errorf("error pruning revision %d of addon %q: %w", revision.Revision, c.Name, err)
+	}
+
+	version := revision.Version
+	version.PinnedRevision = revision.Revision
+	if err := c.SetInstalledVersion(ctx, k8sClient, &version); err != nil {
+		return fmt.Errorf("error recording rollback of addon %q: %w", c.Name, err)
+	}
+	return nil
+}
+
+// Unpin removes the pin of the addon, so that it is updated the next time the channel is applied.
+func (c *Channel) Unpin(ctx context.Context, k8sClient kubernetes.Interface) error {
+	version, err := c.GetInstalledVersion(ctx, k8sClient)
+	if err != nil {
+		return err
+	}
+	if version == nil {
+		return fmt.Errorf("addon %q is not installed in namespace %q", c.Name, c.Namespace)
+	}
+	if version.PinnedRevision == 0 {
+		klog.Infof("addon %q is not pinned", c.Name)
+		return nil
+	}
+
+	version.PinnedRevision = 0
+	return c.SetInstalledVersion(ctx, k8sClient, version)
+}
+
+func revisionKey(revision int) string {
+	return "revision-" + strconv.Itoa(revision)
+}
+
+func parseRevisions(secret *v1.Secret) ([]*AddonRevision, error) {
+	var revisions []*AddonRevision
+	for key, data := range secret.Data {
+		if !strings.HasPrefix(key, "revision-") || !strings.HasSuffix(key, ".json") {
+			continue
+		}
+
+		revision := &AddonRevision{}
+		if err := json.Unmarshal(data, revision); err != nil {
+			return nil, fmt.Errorf("error parsing %s of secret %s/%s: %w", key, secret.Namespace, secret.Name, err)
+		}
+
+		manifestKey := revisionKey(revision.Revision) + ".yaml.gz"
+		manifest, err := gunzip(secret.Data[manifestKey])
+		if err != nil {
+			return nil, fmt.Errorf("error reading %s of secret %s/%s: %w", manifestKey, secret.Namespace, secret.Name, err)
+		}
+		revision.Manifest = manifest
+
+		revisions = append(revisions, revision)
+	}
+
+	sort.Slice(revisions, func(i, j int) bool {
+		return revisions[i].Revision < revisions[j].Revision
+	})
+	return revisions, nil
+}
+
+func encodeRevisions(secret *v1.Secret, revisions []*AddonRevision) error {
+	secret.Data = make(map[string][]byte)
+	for _, revision := range revisions {
+		data, err := json.Marshal(revision)
+		if err != nil {
+			return fmt.Errorf("error encoding revision %d: %w", revision.Revision, err)
+		}
+		secret.Data[revisionKey(revision.Revision)+".json"] = data
+
+		var b bytes.Buffer
+		w := gzip.NewWriter(&b)
+		if _, err := w.Write(revision.Manifest); err != nil {
+			return fmt.Errorf("error compressing revision %d: %w", revision.Revision, err)
+		}
+		if err := w.Close(); err != nil {
+			return fmt.Errorf("error compressing revision %d: %w", revision.Revision, err)
+		}
+		secret.Data[revisionKey(revision.Revision)+".yaml.gz"] = b.Bytes()
+	}
+	return nil
+}
+
+func gunzip(data []byte) ([]byte, error) {
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package channels
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakekubernetes "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+type recordingApplier struct {
+	applied [][]byte
+}
+
+func (a *recordingApplier) Apply(ctx context.Context, data []byte) error {
+	a.applied = append(a.applied, data)
+	return nil
+}
+
+func TestAddonHistory(t *testing.T) {
+	ctx := context.Background()
+	k8sClient := fakekubernetes.NewClientset(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kube-system"}})
+
+	channel := &Channel{Namespace: "kube-system", Name: "coredns.addons.k8s.io"}
+	for i := 1; i <= 4; i++ {
+		version := &ChannelVersion{
+			Channel:          fi.PtrTo("bootstrap"),
+			ManifestHash:     fmt.Sprintf("hash-%d", i),
+			SystemGeneration: CurrentSystemGeneration,
+		}
+		manifest := []byte(fmt.Sprintf("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: coredns-%d\n", i))
+		revision, err := channel.RecordRevision(ctx, k8sClient, version, nil, manifest, 3)
+		if err != nil {
+			t.Fatalf("error recording revision: %v", err)
+		}
+		if revision.Revision != i {
+			t.Errorf("expected revision %d, got %d", i, revision.Revision)
+		}
+		if err := channel.SetInstalledVersion(ctx, k8sClient, version); err != nil {
+			t.Fatalf("error setting installed version: %v", err)
+		}
+	}
+
+	revisions, err := channel.ListRevisions(ctx, k8sClient)
+	if err != nil {
+		t.Fatalf("error listing revisions: %v", err)
+	}
+	if len(revisions) != 3 {
+		t.Fatalf("expected 3 revisions, got %d", len(revisions))
+	}
+	for i, revision := range revisions {
+		if revision.Revision != i+2 {
+			t.Errorf("expected revision %d, got %d", i+2, revision.Revision)
+		}
+		if revision.Version.ManifestHash != fmt.Sprintf("hash-%d", i+2) {
+			t.Errorf("unexpected hash %q for revision %d", revision.Version.ManifestHash, revision.Revision)
+		}
+		if !bytes.Contains(revision.Manifest, []byte(fmt.Sprintf("coredns-%d", i+2))) {
+			t.Errorf("unexpected manifest for revision %d: %s", revision.Revision, revision.Manifest)
+		}
+	}
+
+	applier := &recordingApplier{}
+	if err := channel.Rollback(ctx, k8sClient, &Pruner{}, applier, revisions[1]); err != nil {
+		t.Fatalf("error rolling back: %v", err)
+	}
+	if len(applier.applied) != 1 || !bytes.Equal(applier.applied[0], revisions[1].Manifest) {
+		t.Errorf("expected the manifest of revision 3 to be applied, got %q", applier.applied)
+	}
+
+	installed, err := channel.GetInstalledVersion(ctx, k8sClient)
+	if err != nil {
+		t.Fatalf("error getting installed version: %v", err)
+	}
+	if installed.PinnedRevision != 3 || installed.ManifestHash != "hash-3" {
+		t.Errorf("expected version pinned to revision 3, got %v", installed)
+	}
+
+	// A pinned addon is not replaced by the channel
+	latest := &ChannelVersion{Channel: fi.PtrTo("bootstrap"), ManifestHash: "hash-4", SystemGeneration: CurrentSystemGeneration}
+	if latest.replaces(channel.Name, installed) {
+		t.Errorf("expected pinned addon not to be replaced")
+	}
+
+	if err := channel.Unpin(ctx, k8sClient); err != nil {
+		t.Fatalf("error unpinning: %v", err)
+	}
+	installed, err = channel.GetInstalledVersion(ctx, k8sClient)
+	if err != nil {
+		t.Fatalf("error getting installed version: %v", err)
+	}
+	if installed.PinnedRevision != 0 {
+		t.Errorf("expected version to be unpinned, got %v", installed)
+	}
+	if !latest.replaces(channel.Name, installed) {
+		t.Errorf("expected unpinned addon to be replaced")
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"io"
 	"net/url"
 	"os"
+	"strings"
 
 	"github.com/blang/semver/v4"
 	certmanager "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
@@ -113,10 +114,10 @@ func RunApplyChannel(ctx context.Context, f *ChannelsFactory, out io.Writer, opt
 		return fmt.Errorf("cannot build the addon menu from args: %w", err)
 	}
 
-	return applyMenu(ctx, menu, f.VFSContext(), k8sClient, cmClient, dynamicClient, restMapper, options.Yes)
+	return applyMenu(ctx, menu, f.VFSContext(), k8sClient, cmClient, dynamicClient, restMapper, kubernetesVersionInfo.GitVersion, options.Yes)
 }
 
-func applyMenu(ctx context.Context, menu *channels.AddonMenu, vfsContext *vfs.VFSContext, k8sClient kubernetes.Interface, cmClient certmanager.Interface, dynamicClient dynamic.Interface, restMapper *restmapper.DeferredDiscoveryRESTMapper, apply bool) error {
+func applyMenu(ctx context.Context, menu *channels.AddonMenu, vfsContext *vfs.VFSContext, k8sClient kubernetes.Interface, cmClient certmanager.Interface, dynamicClient dynamic.Interface, restMapper *restmapper.DeferredDiscoveryRESTMapper, kubernetesVersion string, apply bool) error {
 	// channelVersions is the list of installed addons in the cluster.
 	// It is keyed by <namespace>:<addon name>.
 	channelVersions, err := getChannelVersions(ctx, k8sClient)
@@ -180,11 +181,36 @@ func applyMenu(ctx context.Context, menu *channels.AddonMenu, vfsContext *vfs.VF
 		RESTMapper: restMapper,
 	}
 
+	helmRenderer := &channels.HelmRenderer{
+		VFSContext:        vfsContext,
+		RESTMapper:        restMapper,
+		KubernetesVersion: kubernetesVersion,
+	}
+
+	healthChecker := &channels.HealthChecker{
+		Client:     dynamicClient,
+		RESTMapper: restMapper,
+	}
+
+	// Addons are applied after the addons they depend on, which are then healthy
+	ordered, err := channels.SortByDependencies(needUpdates)
+	if err != nil {
+		return err
+	}
+
 	var merr error
 
-	for _, needUpdate := range needUpdates {
-		update, err := needUpdate.EnsureUpdated(ctx, vfsContext, k8sClient, cmClient, pruner, applier, channelVersions[needUpdate.GetNamespace()+":"+needUpdate.Name])
+	failed := make(map[string]bool)
+	for _, needUpdate := range ordered {
+		if err := checkDependencies(needUpdate, menu, channelVersions, failed); err != nil {
+			failed[needUpdate.Name] = true
+			merr = multierr.Append(merr, fmt.Errorf("updating %q: %w", needUpdate.Name, err))
+			continue
+		}
+
+		update, err := needUpdate.EnsureUpdated(ctx, vfsContext, k8sClient, cmClient, pruner, applier, helmRenderer, healthChecker, channelVersions[needUpdate.GetNamespace()+":"+needUpdate.Name])
 		if err != nil {
+			failed[needUpdate.Name] = true
 			merr = multierr.Append(merr, fmt.Errorf("updating %q: %w", needUpdate.Name, err))
 		} else if update != nil {
 			fmt.Printf("Updated %q\n", update.Name)
@@ -194,6 +220,31 @@ func applyMenu(ctx context.Context, menu *channels.AddonMenu, vfsContext *vfs.VF
 	return merr
 }
 
+// checkDependencies checks that the dependencies of an addon have been applied.
+// Dependencies from other channels must already be installed in the cluster.
+func checkDependencies(addon *channels.Addon, menu *channels.AddonMenu, channelVersions map[string]*channels.ChannelVersion, failed map[string]bool) error {
+	for _, dependency := range addon.Spec.DependsOn {
+		if failed[dependency] {
+			return fmt.Errorf("dependency %q was not updated", dependency)
+		}
+		if menu.Addons[dependency] != nil {
+			continue
+		}
+
+		installed := false
+		for key := range channelVersions {
+			if strings.HasSuffix(key, ":"+dependency) {
+				installed = true
+				break
+			}
+		}
+		if !installed {
+			return fmt.Errorf("dependency %q is not installed", dependency)
+		}
+	}
+	return nil
+}
+
 func getUpdates(ctx context.Context, menu *channels.AddonMenu, k8sClient kubernetes.Interface, cmClient certmanager.Interface, channelVersions map[string]*channels.ChannelVersion) ([]*channels.AddonUpdate, []*channels.Addon, error) {
 	var updates []*channels.AddonUpdate
 	var needUpdates []*channels.Addon
@@ -77,3 +77,59 @@ func TestGetUpdates(t *testing.T) {
 		t.Errorf("expected update in kube-system, but update applied to %q", needUpdates[0].GetNamespace())
 	}
 }
+
+func TestCheckDependencies(t *testing.T) {
+	menu := channels.NewAddonMenu()
+	menu.Addons = map[string]*channels.Addon{
+		"cert-manager": {
+			Name: "cert-manager",
+			Spec: &api.AddonSpec{Name: fi.PtrTo("cert-manager")},
+		},
+	}
+	channelVersions := map[string]*channels.ChannelVersion{
+		"kube-system:aws-load-balancer-controller.addons.k8s.io": {},
+	}
+
+	grid := []struct {
+		Name      string
+		DependsOn []string
+		Failed    map[string]bool
+		Expected  string
+	}{
+		{
+			Name:      "dependency in channel",
+			DependsOn: []string{"cert-manager"},
+		},
+		{
+			Name:      "dependency installed from another channel",
+			DependsOn: []string{"aws-load-balancer-controller.addons.k8s.io"},
+		},
+		{
+			Name:      "dependency not installed",
+			DependsOn: []string{"karpenter"},
+			Expected:  `dependency "karpenter" is not installed`,
+		},
+		{
+			Name:      "dependency failed",
+			DependsOn: []string{"cert-manager"},
+			Failed:    map[string]bool{"cert-manager": true},
+			Expected:  `dependency "cert-manager" was not updated`,
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.Name, func(t *testing.T) {
+			addon := &channels.Addon{
+				Name: "example",
+				Spec: &api.AddonSpec{Name: fi.PtrTo("example"), DependsOn: g.DependsOn},
+			}
+			err := checkDependencies(addon, menu, channelVersions, g.Failed)
+			if g.Expected == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+			} else if err == nil || err.Error() != g.Expected {
+				t.Errorf("expected error %q, got %v", g.Expected, err)
+			}
+		})
+	}
+}
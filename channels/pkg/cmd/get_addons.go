@@ -37,6 +37,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strconv"
 
 	"github.com/spf13/cobra"
 	v1 "k8s.io/api/core/v1"
@@ -135,7 +136,14 @@ func RunGetAddons(ctx context.Context, f *ChannelsFactory, out io.Writer, option
 			return "?"
 		})
 
-		columns := []string{"NAMESPACE", "NAME", "HASH", "CHANNEL"}
+		t.AddColumn("PINNED", func(r *addonInfo) string {
+			if r.Version == nil || r.Version.PinnedRevision == 0 {
+				return "-"
+			}
+			return strconv.Itoa(r.Version.PinnedRevision)
+		})
+
+		columns := []string{"NAMESPACE", "NAME", "HASH", "CHANNEL", "PINNED"}
 		err := t.Render(info, os.Stdout, columns...)
 		if err != nil {
 			return err
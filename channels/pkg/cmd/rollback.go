@@ -0,0 +1,206 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+
+	"k8s.io/kops/channels/pkg/channels"
+	"k8s.io/kops/util/pkg/tables"
+)
+
+type RollbackAddonOptions struct {
+	Namespace string
+	// ToRevision is the revision to roll back to; by default, the revision before the installed one.
+	ToRevision int
+	// Unpin removes the pin set by a rollback, so the addon is updated the next time its channel is applied.
+	Unpin bool
+	Yes   bool
+}
+
+func NewCmdRollback(f *ChannelsFactory, out io.Writer) *cobra.Command {
+	options := &RollbackAddonOptions{
+		Namespace: "kube-system",
+	}
+
+	cmd := &cobra.Command{
+		Use:   "rollback ADDON",
+		Short: "Re-applies a previously applied manifest of an addon",
+		Long: `Re-applies a previously applied manifest of an addon, and pins the addon to it.
+
+A pinned addon is not updated when its channel is applied, until it is unpinned with --unpin.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunRollbackAddon(cmd.Context(), f, out, options, args[0])
+		},
+	}
+
+	AddRollbackAddonFlags(cmd, options)
+
+	return cmd
+}
+
+// AddRollbackAddonFlags adds the flags of the rollback command, so other commands can expose it.
+func AddRollbackAddonFlags(cmd *cobra.Command, options *RollbackAddonOptions) {
+	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", options.Namespace, "Namespace the addon is installed in")
+	cmd.Flags().IntVar(&options.ToRevision, "to-revision", options.ToRevision, "Revision to roll back to (default: the revision before the installed one)")
+	cmd.Flags().BoolVar(&options.Unpin, "unpin", options.Unpin, "Remove the pin set by a rollback")
+	cmd.Flags().BoolVar(&options.Yes, "yes", options.Yes, "Apply the rollback")
+}
+
+func RunRollbackAddon(ctx context.Context, f *ChannelsFactory, out io.Writer, options *RollbackAddonOptions, name string) error {
+	restConfig, err := f.RESTConfig()
+	if err != nil {
+		return err
+	}
+	httpClient, err := f.HTTPClient()
+	if err != nil {
+		return err
+	}
+
+	k8sClient, err := kubernetes.NewForConfigAndClient(restConfig, httpClient)
+	if err != nil {
+		return fmt.Errorf("building kube client: %w", err)
+	}
+
+	channel := &channels.Channel{
+		Namespace: options.Namespace,
+		Name:      name,
+	}
+
+	if options.Unpin {
+		if !options.Yes {
+			fmt.Fprintf(out, "Must specify --yes to unpin addon %q\n", name)
+			return nil
+		}
+		if err := channel.Unpin(ctx, k8sClient); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "Unpinned addon %q; it will be updated the next time its channel is applied\n", name)
+		return nil
+	}
+
+	installed, err := channel.GetInstalledVersion(ctx, k8sClient)
+	if err != nil {
+		return err
+	}
+	if installed == nil {
+		return fmt.Errorf("addon %q is not installed in namespace %q", name, options.Namespace)
+	}
+
+	revisions, err := channel.ListRevisions(ctx, k8sClient)
+	if err != nil {
+		return err
+	}
+	if len(revisions) == 0 {
+		return fmt.Errorf("no revisions of addon %q were recorded", name)
+	}
+
+	current := channels.FindInstalledRevision(installed, revisions)
+	target, err := findRollbackRevision(options.ToRevision, current, revisions)
+	if err != nil {
+		return err
+	}
+
+	{
+		t := &tables.Table{}
+		t.AddColumn("REVISION", func(r *channels.AddonRevision) string {
+			return strconv.Itoa(r.Revision)
+		})
+		t.AddColumn("APPLIED", func(r *channels.AddonRevision) string {
+			return r.AppliedAt.Format("2006-01-02T15:04:05Z")
+		})
+		t.AddColumn("HASH", func(r *channels.AddonRevision) string {
+			return r.Version.ManifestHash
+		})
+		t.AddColumn("STATUS", func(r *channels.AddonRevision) string {
+			switch {
+			case r == current && installed.PinnedRevision != 0:
+				return "installed (pinned)"
+			case r == current:
+				return "installed"
+			case r == target:
+				return "rollback"
+			}
+			return ""
+		})
+		if err := t.Render(revisions, out, "REVISION", "APPLIED", "HASH", "STATUS"); err != nil {
+			return err
+		}
+	}
+
+	if !options.Yes {
+		fmt.Fprintf(out, "\nMust specify --yes to roll back addon %q to revision %d\n", name, target.Revision)
+		return nil
+	}
+
+	dynamicClient, err := f.DynamicClient()
+	if err != nil {
+		return fmt.Errorf("building dynamic client: %w", err)
+	}
+	restMapper, err := f.RESTMapper()
+	if err != nil {
+		return err
+	}
+
+	pruner := &channels.Pruner{
+		Client:     dynamicClient,
+		RESTMapper: restMapper,
+	}
+	applier := &channels.ClientApplier{
+		Client:     dynamicClient,
+		RESTMapper: restMapper,
+	}
+	if err := channel.Rollback(ctx, k8sClient, pruner, applier, target); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "\nRolled back addon %q to revision %d, and pinned it until it is unpinned with --unpin\n", name, target.Revision)
+	return nil
+}
+
+// findRollbackRevision returns the requested revision, or by default the revision before the installed one.
+func findRollbackRevision(toRevision int, current *channels.AddonRevision, revisions []*channels.AddonRevision) (*channels.AddonRevision, error) {
+	if toRevision != 0 {
+		for _, r := range revisions {
+			if r.Revision == toRevision {
+				return r, nil
+			}
+		}
+		return nil, fmt.Errorf("revision %d was not found; the recorded revisions are %d to %d", toRevision, revisions[0].Revision, revisions[len(revisions)-1].Revision)
+	}
+
+	if current == nil {
+		return nil, fmt.Errorf("the installed version was not recorded; specify the revision with --to-revision")
+	}
+	var previous *channels.AddonRevision
+	for _, r := range revisions {
+		if r.Revision < current.Revision {
+			previous = r
+		}
+	}
+	if previous == nil {
+		return nil, fmt.Errorf("no revision before the installed revision %d was recorded", current.Revision)
+	}
+	return previous, nil
+}
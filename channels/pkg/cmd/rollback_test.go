@@ -0,0 +1,91 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/kops/channels/pkg/channels"
+)
+
+func TestFindRollbackRevision(t *testing.T) {
+	revisions := []*channels.AddonRevision{
+		{Revision: 3, Version: channels.ChannelVersion{ManifestHash: "a"}},
+		{Revision: 4, Version: channels.ChannelVersion{ManifestHash: "b"}},
+		{Revision: 5, Version: channels.ChannelVersion{ManifestHash: "c"}},
+	}
+
+	grid := []struct {
+		Name       string
+		Installed  channels.ChannelVersion
+		ToRevision int
+		Expected   int
+		Error      string
+	}{
+		{
+			Name:      "previous revision",
+			Installed: channels.ChannelVersion{ManifestHash: "c"},
+			Expected:  4,
+		},
+		{
+			Name:      "previous revision of pinned",
+			Installed: channels.ChannelVersion{ManifestHash: "b", PinnedRevision: 4},
+			Expected:  3,
+		},
+		{
+			Name:       "explicit revision",
+			Installed:  channels.ChannelVersion{ManifestHash: "c"},
+			ToRevision: 3,
+			Expected:   3,
+		},
+		{
+			Name:       "unknown revision",
+			Installed:  channels.ChannelVersion{ManifestHash: "c"},
+			ToRevision: 1,
+			Error:      "revision 1 was not found; the recorded revisions are 3 to 5",
+		},
+		{
+			Name:      "oldest revision installed",
+			Installed: channels.ChannelVersion{ManifestHash: "a"},
+			Error:     "no revision before the installed revision 3 was recorded",
+		},
+		{
+			Name:      "installed version not recorded",
+			Installed: channels.ChannelVersion{ManifestHash: "z"},
+			Error:     "the installed version was not recorded",
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.Name, func(t *testing.T) {
+			current := channels.FindInstalledRevision(&g.Installed, revisions)
+			target, err := findRollbackRevision(g.ToRevision, current, revisions)
+			if g.Error != "" {
+				if err == nil || !strings.Contains(err.Error(), g.Error) {
+					t.Errorf("expected error %q, got %v", g.Error, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if target.Revision != g.Expected {
+				t.Errorf("expected revision %d, got %d", g.Expected, target.Revision)
+			}
+		})
+	}
+}
@@ -57,6 +57,7 @@ func NewCmdRoot(f *ChannelsFactory, out io.Writer) *cobra.Command {
 	// create subcommands
 	cmd.AddCommand(NewCmdApply(f, out))
 	cmd.AddCommand(NewCmdGet(f, out))
+	cmd.AddCommand(NewCmdRollback(f, out))
 
 	return cmd
 }
@@ -0,0 +1,250 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/kops/channels/pkg/channels"
+	"k8s.io/kops/cmd/kops-controller/pkg/config"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/applylib/applyset"
+	"k8s.io/kops/pkg/kubemanifest"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	addonDriftObjects = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kops_controller_addon_drift_objects",
+		Help: "The number of objects of the addon that differed from the applied manifest at the latest check.",
+	}, []string{"addon"})
+	addonDriftLastCheck = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kops_controller_addon_drift_last_check_timestamp_seconds",
+		Help: "When the objects of the addon were last checked, in seconds since the epoch.",
+	}, []string{"addon"})
+	addonDriftCorrections = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kops_controller_addon_drift_corrections_total",
+		Help: "The number of times the objects of the addon were re-applied to correct changes.",
+	}, []string{"addon"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(addonDriftObjects, addonDriftLastCheck, addonDriftCorrections)
+}
+
+// AddonDriftReconciler periodically compares the objects of the installed addons with the manifests
+// that were applied for them, as recorded in the addon history.
+// Changes are reported as events and metrics, and are reverted for the addons with the enforce policy.
+type AddonDriftReconciler struct {
+	// k8sClient reads the installed versions and the history of the addons
+	k8sClient kubernetes.Interface
+
+	// dynamicClient and restMapper are used to apply the objects of the addons
+	dynamicClient dynamic.Interface
+	restMapper    meta.RESTMapper
+
+	// recorder records the changes as events on the changed objects
+	recorder record.EventRecorder
+
+	// options configures the policies
+	options *config.AddonDriftOptions
+
+	// log is a logr
+	log logr.Logger
+}
+
+var _ manager.Runnable = &AddonDriftReconciler{}
+
+// NewAddonDriftReconciler is the constructor for an AddonDriftReconciler
+func NewAddonDriftReconciler(mgr manager.Manager, options *config.AddonDriftOptions) (*AddonDriftReconciler, error) {
+	k8sClient, err := kubernetes.NewForConfigAndClient(mgr.GetConfig(), mgr.GetHTTPClient())
+	if err != nil {
+		return nil, fmt.Errorf("error building kube client: %w", err)
+	}
+	dynamicClient, err := dynamic.NewForConfigAndClient(mgr.GetConfig(), mgr.GetHTTPClient())
+	if err != nil {
+		return nil, fmt.Errorf("error building dynamic client: %w", err)
+	}
+
+	return &AddonDriftReconciler{
+		k8sClient:     k8sClient,
+		dynamicClient: dynamicClient,
+		restMapper:    mgr.GetRESTMapper(),
+		recorder:      mgr.GetEventRecorderFor("kops-controller"),
+		options:       options,
+		log:           ctrl.Log.WithName("controllers").WithName("AddonDrift"),
+	}, nil
+}
+
+// Start runs the reconciler until ctx is done; it only runs on the leader.
+func (r *AddonDriftReconciler) Start(ctx context.Context) error {
+	ticker := time.NewTicker(r.options.Interval.Duration)
+	defer ticker.Stop()
+
+	for {
+		if err := r.reconcileAll(ctx); err != nil {
+			r.log.Error(err, "error checking addons for drift")
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// reconcileAll checks the addons recorded on every namespace.
+func (r *AddonDriftReconciler) reconcileAll(ctx context.Context) error {
+	namespaces, err := r.k8sClient.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("error listing namespaces: %w", err)
+	}
+
+	for i := range namespaces.Items {
+		namespace := &namespaces.Items[i]
+
+		versions := channels.FindChannelVersions(namespace)
+		names := make([]string, 0, len(versions))
+		for name := range versions {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			if err := r.reconcile(ctx, namespace, name, versions[name]); err != nil {
+				r.log.Error(err, "error checking addon for drift", "addon", name, "namespace", namespace.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// reconcile compares the objects of one addon with the manifest of its installed revision.
+func (r *AddonDriftReconciler) reconcile(ctx context.Context, namespace *corev1.Namespace, name string, installed *channels.ChannelVersion) error {
+	policy := r.options.PolicyFor(name)
+	if policy == string(kops.AddonDriftPolicyIgnore) {
+		return nil
+	}
+
+	channel := &channels.Channel{Namespace: namespace.Name, Name: name}
+	revisions, err := channel.ListRevisions(ctx, r.k8sClient)
+	if err != nil {
+		return err
+	}
+	revision := channels.FindInstalledRevision(installed, revisions)
+	if revision == nil {
+		// Addons applied before the history was recorded are checked after their next update
+		r.log.V(2).Info("applied manifest of addon was not recorded; skipping", "addon", name)
+		return nil
+	}
+
+	objects, err := kubemanifest.LoadObjectsFrom(revision.Manifest)
+	if err != nil {
+		return fmt.Errorf("error parsing revision %d of addon %q: %w", revision.Revision, name, err)
+	}
+	var applyableObjects []applyset.ApplyableObject
+	for _, object := range objects {
+		if object.IsEmptyObject() {
+			continue
+		}
+		applyableObjects = append(applyableObjects, object)
+	}
+
+	// Like the channels tool, we apply as the kops field manager and take over conflicting fields
+	force := true
+	s, err := applyset.New(applyset.Options{
+		Client:     r.dynamicClient,
+		RESTMapper: r.restMapper,
+		PatchOptions: metav1.PatchOptions{
+			FieldManager: "kops",
+			Force:        &force,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if err := s.SetDesiredObjects(applyableObjects); err != nil {
+		return err
+	}
+
+	drifted, err := s.FindDrift(ctx)
+	if err != nil {
+		return fmt.Errorf("error checking addon %q for drift: %w", name, err)
+	}
+	addonDriftObjects.WithLabelValues(name).Set(float64(len(drifted)))
+	addonDriftLastCheck.WithLabelValues(name).Set(float64(time.Now().Unix()))
+	if len(drifted) == 0 {
+		return nil
+	}
+
+	r.log.Info("objects of addon differ from the applied manifest", "addon", name, "policy", policy, "objects", driftedObjectNames(drifted))
+	for _, d := range drifted {
+		r.recordEvent(namespace, d, corev1.EventTypeWarning, "AddonDriftDetected", "%s of addon %q differs from the applied manifest", d, name)
+	}
+
+	if policy != string(kops.AddonDriftPolicyEnforce) {
+		return nil
+	}
+
+	results, err := s.ApplyOnce(ctx)
+	if err != nil {
+		return fmt.Errorf("error applying addon %q: %w", name, err)
+	}
+	if !results.AllApplied() {
+		return fmt.Errorf("not all objects of addon %q were applied", name)
+	}
+	addonDriftCorrections.WithLabelValues(name).Inc()
+	addonDriftObjects.WithLabelValues(name).Set(0)
+
+	r.log.Info("re-applied addon to correct drift", "addon", name, "revision", revision.Revision)
+	for _, d := range drifted {
+		r.recordEvent(namespace, d, corev1.EventTypeNormal, "AddonDriftCorrected", "%s of addon %q was re-applied from revision %d", d, name, revision.Revision)
+	}
+	return nil
+}
+
+// recordEvent records an event on the changed object, or on the namespace of the addon if the object was deleted.
+func (r *AddonDriftReconciler) recordEvent(namespace *corev1.Namespace, d *applyset.DriftedObject, eventType, reason, messageFmt string, args ...interface{}) {
+	if d.Missing() {
+		r.recorder.Eventf(namespace, eventType, reason, messageFmt+" (the object is missing)", args...)
+		return
+	}
+	r.recorder.Eventf(d.Live, eventType, reason, messageFmt, args...)
+}
+
+func driftedObjectNames(drifted []*applyset.DriftedObject) string {
+	var names []string
+	for _, d := range drifted {
+		names = append(names, d.String())
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,431 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/kops/cmd/kops-controller/pkg/config"
+	"k8s.io/kops/pkg/etcdmanager"
+	"k8s.io/kops/util/pkg/vfs"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	// etcdBackupVerificationPodPrefix is the prefix of the names of the drill pods
+	etcdBackupVerificationPodPrefix = "etcd-backup-verification-"
+	// etcdBackupVerificationTimeout bounds how long a drill pod may run
+	etcdBackupVerificationTimeout = 30 * time.Minute
+	// etcdBackupVerificationPollInterval is how often the verifier checks for due verifications and finished drills
+	etcdBackupVerificationPollInterval = time.Minute
+
+	// annotationEtcdBackup records the name of the backup being verified
+	annotationEtcdBackup = "kops.k8s.io/etcd-backup"
+)
+
+var (
+	etcdBackupVerificationSuccess = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kops_controller_etcd_backup_verification_success",
+		Help: "Whether the latest restore drill of the etcd backups succeeded (1) or failed (0).",
+	}, []string{"etcd_cluster"})
+	etcdBackupVerificationLastRun = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kops_controller_etcd_backup_verification_last_run_timestamp_seconds",
+		Help: "When the latest restore drill of the etcd backups finished, in seconds since the epoch.",
+	}, []string{"etcd_cluster"})
+	etcdBackupVerificationKeys = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kops_controller_etcd_backup_verification_keys",
+		Help: "The number of keys found by the latest restore drill of the etcd backups.",
+	}, []string{"etcd_cluster"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(etcdBackupVerificationSuccess, etcdBackupVerificationLastRun, etcdBackupVerificationKeys)
+}
+
+// EtcdBackupVerifier periodically restores the latest backup of each configured etcd cluster
+// into a throwaway etcd, and records whether the restored data looks complete.
+// The results are recorded in the backup store, where kops etcd health reads them, and as metrics.
+type EtcdBackupVerifier struct {
+	// client is an uncached client, so that we don't watch all pods in the cluster
+	client client.Client
+
+	// vfsContext gives access to the backup stores
+	vfsContext *vfs.VFSContext
+
+	// options configures the drills
+	options *config.EtcdBackupVerificationOptions
+
+	// log is a logr
+	log logr.Logger
+}
+
+var _ manager.Runnable = &EtcdBackupVerifier{}
+
+// NewEtcdBackupVerifier is the constructor for an EtcdBackupVerifier
+func NewEtcdBackupVerifier(mgr manager.Manager, vfsContext *vfs.VFSContext, options *config.EtcdBackupVerificationOptions) (*EtcdBackupVerifier, error) {
+	uncachedClient, err := client.New(mgr.GetConfig(), client.Options{
+		Scheme: mgr.GetScheme(),
+		Mapper: mgr.GetRESTMapper(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error building client: %w", err)
+	}
+
+	return &EtcdBackupVerifier{
+		client:     uncachedClient,
+		vfsContext: vfsContext,
+		options:    options,
+		log:        ctrl.Log.WithName("controllers").WithName("EtcdBackupVerifier"),
+	}, nil
+}
+
+// +kubebuilder:rbac:groups=,resources=pods,namespace=kube-system,verbs=get;list;create;delete
+
+// Start runs the verifier until ctx is done; it only runs on the leader.
+func (v *EtcdBackupVerifier) Start(ctx context.Context) error {
+	ticker := time.NewTicker(etcdBackupVerificationPollInterval)
+	defer ticker.Stop()
+
+	for {
+		for i := range v.options.EtcdClusters {
+			etcdCluster := &v.options.EtcdClusters[i]
+			if err := v.reconcile(ctx, etcdCluster); err != nil {
+				v.log.Error(err, "error verifying etcd backups", "etcdCluster", etcdCluster.Name)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// reconcile collects the result of a finished drill, or starts a drill if one is due.
+func (v *EtcdBackupVerifier) reconcile(ctx context.Context, etcdCluster *config.EtcdBackupVerificationClusterOptions) error {
+	p, err := v.vfsContext.BuildVfsPath(etcdCluster.BackupStore)
+	if err != nil {
+		return fmt.Errorf("error building backup store path: %w", err)
+	}
+	store := etcdmanager.NewBackupStore(p)
+
+	status, err := store.LoadVerificationStatus(ctx)
+	if err != nil {
+		return err
+	}
+	if status == nil {
+		status = &etcdmanager.VerificationStatus{}
+	}
+	if status.LastResult != nil {
+		setEtcdBackupVerificationMetrics(etcdCluster.Name, status.LastResult)
+	}
+
+	pod := &corev1.Pod{}
+	podID := types.NamespacedName{Namespace: v.options.Namespace, Name: etcdBackupVerificationPodName(etcdCluster.Name)}
+	if err := v.client.Get(ctx, podID, pod); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("error getting pod %s: %w", podID, err)
+		}
+		pod = nil
+	}
+
+	if pod != nil {
+		if pod.DeletionTimestamp != nil {
+			return nil
+		}
+		result := etcdBackupVerificationResult(pod)
+		if result == nil {
+			// Still running; activeDeadlineSeconds ends drills that hang
+			return nil
+		}
+		if err := v.record(ctx, store, status, etcdCluster.Name, result); err != nil {
+			return err
+		}
+		if err := v.client.Delete(ctx, pod); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("error deleting pod %s: %w", podID, err)
+		}
+		return nil
+	}
+
+	if status.LastResult != nil && time.Since(status.LastResult.Time.Time) < etcdCluster.Interval.Duration {
+		return nil
+	}
+
+	backups, err := store.ListBackups(ctx)
+	if err != nil {
+		return err
+	}
+	if len(backups) == 0 {
+		return v.record(ctx, store, status, etcdCluster.Name, &etcdmanager.VerificationResult{
+			Time:     metav1.Now(),
+			Problems: []string{"no backups found"},
+		})
+	}
+	latest := backups[len(backups)-1]
+
+	var previousRevision int64
+	if status.LastVerified != nil {
+		previousRevision = status.LastVerified.Revision
+	}
+
+	pod = BuildEtcdBackupVerificationPod(v.options, etcdCluster, latest.Name, previousRevision)
+	v.log.Info("starting etcd backup verification", "etcdCluster", etcdCluster.Name, "backup", latest.Name)
+	if err := v.client.Create(ctx, pod); err != nil {
+		return fmt.Errorf("error creating pod %s: %w", podID, err)
+	}
+	return nil
+}
+
+func (v *EtcdBackupVerifier) record(ctx context.Context, store *etcdmanager.BackupStore, status *etcdmanager.VerificationStatus, name string, result *etcdmanager.VerificationResult) error {
+	if result.Verified() {
+		v.log.Info("etcd backup verified", "etcdCluster", name, "backup", result.Backup, "keys", result.Keys, "revision", result.Revision)
+	} else {
+		v.log.Info("etcd backup verification failed", "etcdCluster", name, "backup", result.Backup, "problems", result.Problems)
+	}
+
+	status.Record(result)
+	setEtcdBackupVerificationMetrics(name, result)
+	return store.WriteVerificationStatus(ctx, status)
+}
+
+func setEtcdBackupVerificationMetrics(name string, result *etcdmanager.VerificationResult) {
+	success := 0.0
+	if result.Verified() {
+		success = 1
+	}
+	etcdBackupVerificationSuccess.WithLabelValues(name).Set(success)
+	etcdBackupVerificationLastRun.WithLabelValues(name).Set(float64(result.Time.Unix()))
+	etcdBackupVerificationKeys.WithLabelValues(name).Set(float64(result.Keys))
+}
+
+// etcdBackupVerificationResult returns the result of a finished drill pod, or nil if the pod is still running.
+func etcdBackupVerificationResult(pod *corev1.Pod) *etcdmanager.VerificationResult {
+	backup := pod.Annotations[annotationEtcdBackup]
+
+	switch pod.Status.Phase {
+	case corev1.PodSucceeded, corev1.PodFailed:
+	default:
+		return nil
+	}
+
+	failed := &etcdmanager.VerificationResult{
+		Backup: backup,
+		Time:   metav1.Now(),
+	}
+
+	var statuses []corev1.ContainerStatus
+	statuses = append(statuses, pod.Status.InitContainerStatuses...)
+	statuses = append(statuses, pod.Status.ContainerStatuses...)
+	for _, status := range statuses {
+		terminated := status.State.Terminated
+		if terminated == nil {
+			continue
+		}
+		if status.Name == "check" && terminated.ExitCode == 0 {
+			result := &etcdmanager.VerificationResult{}
+			if err := json.Unmarshal([]byte(terminated.Message), result); err != nil {
+				failed.Problems = append(failed.Problems, fmt.Sprintf("error parsing result of the check: %v", err))
+				return failed
+			}
+			return result
+		}
+		// The etcd sidecar is stopped once the check finishes
+		if status.Name != "etcd" && terminated.ExitCode != 0 {
+			message := strings.TrimSpace(terminated.Message)
+			if message == "" {
+				message = terminated.Reason
+			}
+			failed.Problems = append(failed.Problems, fmt.Sprintf("%s failed: %s", status.Name, message))
+			return failed
+		}
+	}
+
+	message := pod.Status.Message
+	if message == "" {
+		message = pod.Status.Reason
+	}
+	failed.Problems = append(failed.Problems, fmt.Sprintf("the verification pod did not complete: %s", message))
+	return failed
+}
+
+func etcdBackupVerificationPodName(etcdCluster string) string {
+	return etcdBackupVerificationPodPrefix + etcdCluster
+}
+
+// BuildEtcdBackupVerificationPod builds the pod that restores a backup and checks its contents.
+// The etcd runs as a sidecar, listening on the loopback interface of a control-plane node.
+func BuildEtcdBackupVerificationPod(options *config.EtcdBackupVerificationOptions, etcdCluster *config.EtcdBackupVerificationClusterOptions, backup string, previousRevision int64) *corev1.Pod {
+	const dataDir = "/data"
+	snapshot := dataDir + "/snapshot.db"
+	etcdDataDir := dataDir + "/etcd"
+	clientURL := fmt.Sprintf("http://127.0.0.1:%d", options.ClientPort)
+	peerURL := fmt.Sprintf("http://127.0.0.1:%d", options.PeerPort)
+	memberName := "verification"
+
+	var env []corev1.EnvVar
+	for k, v := range options.Env {
+		env = append(env, corev1.EnvVar{Name: k, Value: v})
+	}
+	sort.Slice(env, func(i, j int) bool { return env[i].Name < env[j].Name })
+
+	restoreCommand := []string{"etcdutl", "snapshot", "restore", snapshot}
+	var restoreEnv []corev1.EnvVar
+	if strings.HasPrefix(etcdCluster.EtcdVersion, "3.4.") {
+		// etcdutl was introduced in etcd 3.5
+		restoreCommand = []string{"etcdctl", "snapshot", "restore", snapshot}
+		restoreEnv = append(restoreEnv, corev1.EnvVar{Name: "ETCDCTL_API", Value: "3"})
+	}
+	restoreCommand = append(restoreCommand,
+		"--data-dir="+etcdDataDir,
+		"--name="+memberName,
+		"--initial-cluster="+memberName+"="+peerURL,
+		"--initial-advertise-peer-urls="+peerURL,
+	)
+
+	checkCommand := []string{
+		"/kops-controller", "check-etcd-backup",
+		"--endpoint=" + clientURL,
+		"--backup=" + backup,
+		"--min-keys=" + strconv.FormatInt(etcdCluster.MinKeys, 10),
+		"--previous-revision=" + strconv.FormatInt(previousRevision, 10),
+	}
+	for _, prefix := range etcdCluster.RequiredPrefixes {
+		checkCommand = append(checkCommand, "--required-prefix="+prefix)
+	}
+
+	dataMount := []corev1.VolumeMount{{Name: "data", MountPath: dataDir}}
+	sidecar := corev1.ContainerRestartPolicyAlways
+	activeDeadlineSeconds := int64(etcdBackupVerificationTimeout.Seconds())
+	runAsUser := int64(10011)
+	runAsNonRoot := true
+
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      etcdBackupVerificationPodName(etcdCluster.Name),
+			Namespace: options.Namespace,
+			Labels: map[string]string{
+				"k8s-app":          "etcd-backup-verification",
+				"kops.k8s.io/etcd": etcdCluster.Name,
+			},
+			Annotations: map[string]string{
+				annotationEtcdBackup: backup,
+			},
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy:         corev1.RestartPolicyNever,
+			ActiveDeadlineSeconds: &activeDeadlineSeconds,
+			// The backup store is accessed with the credentials of the control-plane nodes,
+			// and the etcd only listens on the loopback interface.
+			HostNetwork:       true,
+			DNSPolicy:         corev1.DNSDefault,
+			PriorityClassName: "system-cluster-critical",
+			Affinity: &corev1.Affinity{
+				NodeAffinity: &corev1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+						NodeSelectorTerms: []corev1.NodeSelectorTerm{
+							{MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "node-role.kubernetes.io/control-plane", Operator: corev1.NodeSelectorOpExists}}},
+							{MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "node-role.kubernetes.io/master", Operator: corev1.NodeSelectorOpExists}}},
+						},
+					},
+				},
+			},
+			Tolerations: []corev1.Toleration{
+				{Key: "node-role.kubernetes.io/master", Operator: corev1.TolerationOpExists},
+				{Key: "node-role.kubernetes.io/control-plane", Operator: corev1.TolerationOpExists},
+			},
+			InitContainers: []corev1.Container{
+				{
+					Name:  "fetch",
+					Image: options.Image,
+					Command: []string{
+						"/kops-controller", "fetch-etcd-backup",
+						"--backup-store=" + etcdCluster.BackupStore,
+						"--backup=" + backup,
+						"--out=" + snapshot,
+					},
+					Env:                      env,
+					VolumeMounts:             dataMount,
+					TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+					SecurityContext: &corev1.SecurityContext{
+						RunAsUser:    &runAsUser,
+						RunAsNonRoot: &runAsNonRoot,
+					},
+				},
+				{
+					Name:                     "restore",
+					Image:                    etcdCluster.EtcdImage,
+					Command:                  restoreCommand,
+					Env:                      restoreEnv,
+					VolumeMounts:             dataMount,
+					TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+				},
+				{
+					Name:          "etcd",
+					Image:         etcdCluster.EtcdImage,
+					RestartPolicy: &sidecar,
+					Command: []string{
+						"etcd",
+						"--data-dir=" + etcdDataDir,
+						"--name=" + memberName,
+						"--listen-client-urls=" + clientURL,
+						"--advertise-client-urls=" + clientURL,
+						"--listen-peer-urls=" + peerURL,
+						"--initial-advertise-peer-urls=" + peerURL,
+						"--initial-cluster=" + memberName + "=" + peerURL,
+					},
+					VolumeMounts:             dataMount,
+					TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+				},
+			},
+			Containers: []corev1.Container{
+				{
+					Name:                     "check",
+					Image:                    options.Image,
+					Command:                  checkCommand,
+					TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+					SecurityContext: &corev1.SecurityContext{
+						RunAsUser:    &runAsUser,
+						RunAsNonRoot: &runAsNonRoot,
+					},
+				},
+			},
+			SecurityContext: &corev1.PodSecurityContext{
+				// The fetch container writes the snapshot as the kops-controller user
+				FSGroup: &runAsUser,
+			},
+			Volumes: []corev1.Volume{
+				{Name: "data", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+			},
+		},
+	}
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"k8s.io/kops/pkg/etcdmanager"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+// The restore drills of etcd backups run the kops-controller image with these subcommands,
+// see controllers.EtcdBackupVerifier.
+
+// stringSliceFlag is a repeatable string flag.
+type stringSliceFlag []string
+
+func (f *stringSliceFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *stringSliceFlag) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+// runFetchEtcdBackup downloads a backup from the backup store, and writes the uncompressed snapshot.
+func runFetchEtcdBackup(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("fetch-etcd-backup", flag.ExitOnError)
+	backupStore := flags.String("backup-store", "", "VFS path of the backup store")
+	backup := flags.String("backup", "", "Name of the backup")
+	out := flags.String("out", "", "File to write the etcd snapshot to")
+	flags.Parse(args)
+
+	if *backupStore == "" || *backup == "" || *out == "" {
+		return fmt.Errorf("--backup-store, --backup and --out are required")
+	}
+
+	p, err := vfs.NewVFSContext().BuildVfsPath(*backupStore)
+	if err != nil {
+		return fmt.Errorf("error building backup store path: %w", err)
+	}
+	return etcdmanager.NewBackupStore(p).FetchBackup(ctx, *backup, *out)
+}
+
+// runCheckEtcdBackup checks the contents of an etcd restored from a backup, and writes the result as JSON.
+func runCheckEtcdBackup(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("check-etcd-backup", flag.ExitOnError)
+	endpoint := flags.String("endpoint", "", "Client URL of the restored etcd")
+	backup := flags.String("backup", "", "Name of the restored backup")
+	minKeys := flags.Int64("min-keys", 1, "Minimum number of keys")
+	previousRevision := flags.Int64("previous-revision", 0, "Revision of the previously verified backup")
+	timeout := flags.Duration("timeout", 5*time.Minute, "Maximum time to wait for etcd to serve the restored backup")
+	out := flags.String("out", "/dev/termination-log", "File to write the result to")
+	var requiredPrefixes stringSliceFlag
+	flags.Var(&requiredPrefixes, "required-prefix", "Key prefix that must have at least one key (repeatable)")
+	flags.Parse(args)
+
+	if *endpoint == "" {
+		return fmt.Errorf("--endpoint is required")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, *timeout)
+	defer cancel()
+
+	check := &etcdmanager.VerificationCheck{
+		MinKeys:          *minKeys,
+		RequiredPrefixes: requiredPrefixes,
+		PreviousRevision: *previousRevision,
+	}
+	httpClient := &http.Client{Timeout: 30 * time.Second}
+	result, err := etcdmanager.CheckRestoredEtcd(ctx, httpClient, *endpoint, check, *backup)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("error serializing result: %w", err)
+	}
+	if err := os.WriteFile(*out, data, 0o644); err != nil {
+		return fmt.Errorf("error writing result: %w", err)
+	}
+	return nil
+}
@@ -78,6 +78,23 @@ func main() {
 
 	klog.InitFlags(nil)
 
+	if len(os.Args) > 1 {
+		var run func(context.Context, []string) error
+		switch os.Args[1] {
+		case "fetch-etcd-backup":
+			run = runFetchEtcdBackup
+		case "check-etcd-backup":
+			run = runCheckEtcdBackup
+		}
+		if run != nil {
+			if err := run(ctx, os.Args[2:]); err != nil {
+				fmt.Fprintf(os.Stderr, "%s: %v\n", os.Args[1], err)
+				os.Exit(1)
+			}
+			os.Exit(0)
+		}
+	}
+
 	// Disable metrics by default (avoid port conflicts, also risky because we are host network)
 	metricsAddress := ":0"
 	// flag.StringVar(&metricsAddr, "metrics-addr", metricsAddress, "The address the metric endpoint binds to.")
@@ -105,6 +122,10 @@ func main() {
 		}
 	}
 
+	if opt.MetricsBindAddress != "" {
+		metricsAddress = opt.MetricsBindAddress
+	}
+
 	ctrl.SetLogger(klogr.New())
 
 	scheme, err := buildScheme(&opt)
@@ -250,6 +271,16 @@ func main() {
 		os.Exit(1)
 	}
 
+	if err := addEtcdBackupVerifier(mgr, vfsContext, &opt); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "EtcdBackupVerifier")
+		os.Exit(1)
+	}
+
+	if err := addAddonDriftReconciler(mgr, &opt); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "AddonDriftReconciler")
+		os.Exit(1)
+	}
+
 	// +kubebuilder:scaffold:builder
 
 	if opt.CAPI.IsEnabled() {
@@ -409,6 +440,32 @@ func addGossipController(mgr manager.Manager, opt *config.Options) error {
 	return nil
 }
 
+func addEtcdBackupVerifier(mgr manager.Manager, vfsContext *vfs.VFSContext, opt *config.Options) error {
+	if opt.EtcdBackupVerification == nil {
+		return nil
+	}
+
+	verifier, err := controllers.NewEtcdBackupVerifier(mgr, vfsContext, opt.EtcdBackupVerification)
+	if err != nil {
+		return err
+	}
+
+	return mgr.Add(verifier)
+}
+
+func addAddonDriftReconciler(mgr manager.Manager, opt *config.Options) error {
+	if opt.AddonDrift == nil {
+		return nil
+	}
+
+	reconciler, err := controllers.NewAddonDriftReconciler(mgr, opt.AddonDrift)
+	if err != nil {
+		return err
+	}
+
+	return mgr.Add(reconciler)
+}
+
 // Reconciler is the interface for a standard Reconciler.
 type Reconciler interface {
 	SetupWithManager(mgr manager.Manager) error
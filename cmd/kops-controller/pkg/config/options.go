@@ -17,6 +17,7 @@ limitations under the License.
 package config
 
 import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/kops/pkg/bootstrap/pkibootstrap"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 	"k8s.io/kops/upup/pkg/fi/cloudup/azure"
@@ -43,6 +44,15 @@ type Options struct {
 
 	// CAPI configures Cluster API (CAPI) support.
 	CAPI *CAPIOptions `json:"capi,omitempty"`
+
+	// MetricsBindAddress is the address the metrics endpoint binds to; metrics are not served if empty.
+	MetricsBindAddress string `json:"metricsBindAddress,omitempty"`
+
+	// EtcdBackupVerification configures the periodic verification of etcd backups.
+	EtcdBackupVerification *EtcdBackupVerificationOptions `json:"etcdBackupVerification,omitempty"`
+
+	// AddonDrift configures the detection of changes to the objects of the installed addons.
+	AddonDrift *AddonDriftOptions `json:"addonDrift,omitempty"`
 }
 
 func (o *Options) PopulateDefaults() {
@@ -99,3 +109,54 @@ type DiscoveryOptions struct {
 	// Enabled specifies whether support for discovery population is enabled.
 	Enabled bool `json:"enabled"`
 }
+
+// EtcdBackupVerificationOptions configures the restore drills of etcd backups.
+type EtcdBackupVerificationOptions struct {
+	// Image is the kops-controller image, which fetches the backup and checks the restored etcd.
+	Image string `json:"image"`
+	// Namespace is where the drill pods run.
+	Namespace string `json:"namespace"`
+	// ClientPort and PeerPort are the ports the temporary etcd listens on, on the loopback interface.
+	ClientPort int `json:"clientPort"`
+	PeerPort   int `json:"peerPort"`
+	// Env holds the environment variables needed to read the backup store, e.g. S3 credentials.
+	Env map[string]string `json:"env,omitempty"`
+	// EtcdClusters lists the etcd clusters whose backups are verified.
+	EtcdClusters []EtcdBackupVerificationClusterOptions `json:"etcdClusters"`
+}
+
+// EtcdBackupVerificationClusterOptions configures the verification of the backups of one etcd cluster.
+type EtcdBackupVerificationClusterOptions struct {
+	// Name is the name of the etcd cluster, e.g. main
+	Name string `json:"name"`
+	// BackupStore is the VFS path of the backups
+	BackupStore string `json:"backupStore"`
+	// EtcdImage is the etcd image used to restore the backup
+	EtcdImage string `json:"etcdImage"`
+	// EtcdVersion is the version of etcd in EtcdImage
+	EtcdVersion string `json:"etcdVersion"`
+	// Interval is how often the latest backup is verified
+	Interval metav1.Duration `json:"interval"`
+	// MinKeys is the minimum number of keys the restored backup must hold
+	MinKeys int64 `json:"minKeys"`
+	// RequiredPrefixes are key prefixes that must each have at least one key
+	RequiredPrefixes []string `json:"requiredPrefixes,omitempty"`
+}
+
+// AddonDriftOptions configures the detection of changes to the objects of the installed addons.
+type AddonDriftOptions struct {
+	// Interval is how often the addons are checked
+	Interval metav1.Duration `json:"interval"`
+	// Policy is the policy of the addons that are not listed in Addons: enforce, report or ignore
+	Policy string `json:"policy"`
+	// Addons holds the policy of individual addons, keyed by addon name
+	Addons map[string]string `json:"addons,omitempty"`
+}
+
+// PolicyFor returns the policy of the named addon.
+func (o *AddonDriftOptions) PolicyFor(name string) string {
+	if policy, found := o.Addons[name]; found {
+		return policy
+	}
+	return o.Policy
+}
@@ -49,7 +49,7 @@ func newAddonsClient(basePath vfs.Path, cluster *kops.Cluster) *addonsClient {
 	return r
 }
 
-func (c *addonsClient) Replace(addons kubemanifest.ObjectList) error {
+func (c *addonsClient) Replace(ctx context.Context, addons kubemanifest.ObjectList) error {
 	return fmt.Errorf("server-side addons client does not support Addons::Replace")
 }
 
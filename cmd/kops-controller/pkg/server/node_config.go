@@ -27,6 +27,7 @@ import (
 	"k8s.io/kops/pkg/bootstrap"
 	"k8s.io/kops/pkg/commands"
 	"k8s.io/kops/pkg/nodeidentity/clusterapi"
+	"k8s.io/kops/upup/pkg/fi/utils"
 )
 
 func (s *Server) getNodeConfig(ctx context.Context, req *nodeup.BootstrapRequest, identity *bootstrap.VerifyResult) (*nodeup.NodeConfig, error) {
@@ -91,6 +92,18 @@ func (s *Server) getNodeConfig(ctx context.Context, req *nodeup.BootstrapRequest
 		secretIDs := []string{
 			"dockerconfig",
 		}
+
+		// Nodes also need the secrets of the registries that containerd connects to, and of the file repository
+		nodeupConfig := &nodeup.Config{}
+		if err := utils.YamlUnmarshal([]byte(nodeConfig.NodeupConfig), nodeupConfig); err != nil {
+			return nil, fmt.Errorf("parsing nodeupConfig: %w", err)
+		}
+		if nodeupConfig.ContainerdConfig != nil {
+			secretIDs = append(secretIDs, nodeupConfig.ContainerdConfig.RegistrySecrets()...)
+		}
+		if nodeupConfig.FileRepositorySecret != "" {
+			secretIDs = append(secretIDs, nodeupConfig.FileRepositorySecret)
+		}
 		nodeConfig.NodeSecrets = make(map[string][]byte)
 		for _, id := range secretIDs {
 			secret, err := s.secretStore.FindSecret(id)
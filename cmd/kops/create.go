@@ -225,7 +225,7 @@ func RunCreate(ctx context.Context, f *util.Factory, out io.Writer, c *CreateOpt
 
 		addonsClient := clientset.AddonsFor(cluster)
 
-		if err := addonsClient.Replace(addons); err != nil {
+		if err := addonsClient.Replace(ctx, addons); err != nil {
 			return fmt.Errorf("error writing additional objects: %v", err)
 		}
 	}
@@ -129,6 +129,9 @@ func NewCmdCreateInstanceGroup(f *util.Factory, out io.Writer) *cobra.Command {
 		if r == kopsapi.InstanceGroupRoleAPIServer && !featureflag.APIServerNodes.Enabled() {
 			continue
 		}
+		if r == kopsapi.InstanceGroupRoleEtcd && !featureflag.EtcdNodes.Enabled() {
+			continue
+		}
 		allRoles = append(allRoles, r.ToLowerString())
 	}
 
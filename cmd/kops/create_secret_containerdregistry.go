@@ -0,0 +1,155 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kops/cmd/kops/util"
+	"k8s.io/kops/pkg/apis/nodeup"
+	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	createSecretContainerdRegistryLong = templates.LongDesc(i18n.T(`
+	Create a new containerd registry secret and store it in the state store.
+	The secret holds the CA, client certificate and credentials that containerd uses
+	to connect to a registry host. It is referenced by name from the secret field of
+	the registry hosts in the containerd configuration.`))
+
+	createSecretContainerdRegistryExample = templates.Examples(i18n.T(`
+	# Create a new containerd registry secret.
+	kops create secret containerdregistry registry-example -f /path/to/registry.yaml \
+		--name k8s-cluster.example.com --state s3://my-state-store
+
+	# Create a containerd registry secret with credentials via stdin.
+	printf 'username: puller\npassword: %s\n' "$PASSWORD" | kops create secret containerdregistry registry-example -f - \
+		--name k8s-cluster.example.com --state s3://my-state-store
+
+	# Replace an existing containerd registry secret.
+	kops create secret containerdregistry registry-example -f /path/to/registry.yaml --force \
+		--name k8s-cluster.example.com --state s3://my-state-store
+	`))
+
+	createSecretContainerdRegistryShort = i18n.T(`Create a containerd registry secret.`)
+)
+
+type CreateSecretContainerdRegistryOptions struct {
+	ClusterName  string
+	SecretName   string
+	RegistryPath string
+	Force        bool
+}
+
+func NewCmdCreateSecretContainerdRegistry(f *util.Factory, out io.Writer) *cobra.Command {
+	options := &CreateSecretContainerdRegistryOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "containerdregistry SECRET_NAME -f FILENAME",
+		Short:   createSecretContainerdRegistryShort,
+		Long:    createSecretContainerdRegistryLong,
+		Example: createSecretContainerdRegistryExample,
+		Args: func(cmd *cobra.Command, args []string) error {
+			options.ClusterName = rootCommand.ClusterName(true)
+
+			if options.ClusterName == "" {
+				return fmt.Errorf("--name is required")
+			}
+
+			if len(args) != 1 {
+				return fmt.Errorf("must specify the name of the secret")
+			}
+			options.SecretName = args[0]
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunCreateSecretContainerdRegistry(cmd.Context(), f, out, options)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.RegistryPath, "filename", "f", "", "Path to the registry secret YAML file")
+	cmd.MarkFlagRequired("filename")
+	cmd.RegisterFlagCompletionFunc("filename", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"yaml", "json"}, cobra.ShellCompDirectiveFilterFileExt
+	})
+	cmd.Flags().BoolVar(&options.Force, "force", options.Force, "Force replace the secret if it already exists")
+
+	return cmd
+}
+
+func RunCreateSecretContainerdRegistry(ctx context.Context, f commandutils.Factory, out io.Writer, options *CreateSecretContainerdRegistryOptions) error {
+	cluster, err := GetCluster(ctx, f, options.ClusterName)
+	if err != nil {
+		return err
+	}
+
+	clientset, err := f.KopsClient()
+	if err != nil {
+		return err
+	}
+
+	secretStore, err := clientset.SecretStore(cluster)
+	if err != nil {
+		return err
+	}
+
+	var data []byte
+	if options.RegistryPath == "-" {
+		data, err = ConsumeStdin()
+		if err != nil {
+			return fmt.Errorf("reading registry secret from stdin: %v", err)
+		}
+	} else {
+		data, err = os.ReadFile(options.RegistryPath)
+		if err != nil {
+			return fmt.Errorf("reading registry secret %v: %v", options.RegistryPath, err)
+		}
+	}
+
+	if _, err := nodeup.ParseContainerdRegistrySecret(data); err != nil {
+		return fmt.Errorf("unable to parse %v: %v", options.RegistryPath, err)
+	}
+
+	secret := &fi.Secret{
+		Data: data,
+	}
+
+	if !options.Force {
+		_, created, err := secretStore.GetOrCreateSecret(ctx, options.SecretName, secret)
+		if err != nil {
+			return fmt.Errorf("adding %s secret: %v", options.SecretName, err)
+		}
+		if !created {
+			return fmt.Errorf("failed to create the %s secret as it already exists. Pass the `--force` flag to replace an existing secret", options.SecretName)
+		}
+	} else {
+		_, err := secretStore.ReplaceSecret(options.SecretName, secret)
+		if err != nil {
+			return fmt.Errorf("updating %s secret: %v", options.SecretName, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kops/cmd/kops/util"
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider"
+	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	createSecretDNSProviderLong = templates.LongDesc(i18n.T(`
+	Store the configuration of the DNS provider of a cluster in the state store.
+	The configuration is read from the environment variables of the provider
+	set in spec.externalDns.dnsProvider, and is used by kOps and dns-controller
+	to manage the DNS records of the cluster.`))
+
+	createSecretDNSProviderExample = templates.Examples(i18n.T(`
+	# Store the Cloudflare API token.
+	CLOUDFLARE_API_TOKEN=<token> kops create secret dnsprovider \
+		--name k8s-cluster.example.com --state s3://my-state-store
+
+	# Replace the existing configuration of an RFC2136 DNS server.
+	RFC2136_NAMESERVER=ns1.example.com RFC2136_TSIG_KEYNAME=kops RFC2136_TSIG_SECRET=<secret> \
+		kops create secret dnsprovider --force \
+		--name k8s-cluster.example.com --state s3://my-state-store
+	`))
+
+	createSecretDNSProviderShort = i18n.T(`Store the configuration of the DNS provider.`)
+)
+
+type CreateSecretDNSProviderOptions struct {
+	ClusterName string
+	Force       bool
+}
+
+func NewCmdCreateSecretDNSProvider(f *util.Factory, out io.Writer) *cobra.Command {
+	options := &CreateSecretDNSProviderOptions{}
+
+	cmd := &cobra.Command{
+		Use:               "dnsprovider [CLUSTER]",
+		Short:             createSecretDNSProviderShort,
+		Long:              createSecretDNSProviderLong,
+		Example:           createSecretDNSProviderExample,
+		Args:              rootCommand.clusterNameArgs(&options.ClusterName),
+		ValidArgsFunction: commandutils.CompleteClusterName(f, true, false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunCreateSecretDNSProvider(cmd.Context(), f, out, options)
+		},
+	}
+
+	cmd.Flags().BoolVar(&options.Force, "force", options.Force, "Force replace the secret if it already exists")
+
+	return cmd
+}
+
+func RunCreateSecretDNSProvider(ctx context.Context, f commandutils.Factory, out io.Writer, options *CreateSecretDNSProviderOptions) error {
+	cluster, err := GetCluster(ctx, f, options.ClusterName)
+	if err != nil {
+		return err
+	}
+
+	if cluster.UsesCloudDNSProvider() {
+		return fmt.Errorf("cluster %q uses the DNS service of its cloud provider, which is not configured by a secret", cluster.Name)
+	}
+
+	name := cluster.Spec.ExternalDNS.DNSProvider
+	envVars, err := cloudup.DNSProviderEnvVars(name)
+	if err != nil {
+		return err
+	}
+
+	config := make(map[string]string)
+	for _, k := range envVars {
+		if v := os.Getenv(k); v != "" {
+			config[k] = v
+		}
+	}
+	data, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	if _, err := dnsprovider.GetDnsProvider(name, bytes.NewReader(data)); err != nil {
+		return fmt.Errorf("invalid configuration for the %s DNS provider: %w", name, err)
+	}
+
+	clientset, err := f.KopsClient()
+	if err != nil {
+		return err
+	}
+
+	secretStore, err := clientset.SecretStore(cluster)
+	if err != nil {
+		return err
+	}
+
+	secret := &fi.Secret{
+		Data: data,
+	}
+
+	if !options.Force {
+		_, created, err := secretStore.GetOrCreateSecret(ctx, cloudup.DNSProviderSecretName, secret)
+		if err != nil {
+			return fmt.Errorf("error adding DNS provider secret: %v", err)
+		}
+		if !created {
+			return fmt.Errorf("failed to create the DNS provider secret as it already exists. Pass the `--force` flag to replace an existing secret")
+		}
+	} else {
+		_, err := secretStore.ReplaceSecret(cloudup.DNSProviderSecretName, secret)
+		if err != nil {
+			return fmt.Errorf("updating DNS provider secret: %v", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kops/cmd/kops/util"
+	"k8s.io/kops/pkg/apis/nodeup"
+	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	createSecretFileRepositoryLong = templates.LongDesc(i18n.T(`
+	Create a new file repository secret and store it in the state store.
+	The secret holds the CA, client certificate and credentials that nodes use
+	to download files from the file repository. It is referenced by name from
+	assets.fileRepositoryConfig.secret in the cluster spec.`))
+
+	createSecretFileRepositoryExample = templates.Examples(i18n.T(`
+	# Create a new file repository secret.
+	kops create secret filerepository artifactory -f /path/to/repository.yaml \
+		--name k8s-cluster.example.com --state s3://my-state-store
+
+	# Create a file repository secret with a bearer token via stdin.
+	printf 'token: %s\n' "$TOKEN" | kops create secret filerepository artifactory -f - \
+		--name k8s-cluster.example.com --state s3://my-state-store
+
+	# Replace an existing file repository secret.
+	kops create secret filerepository artifactory -f /path/to/repository.yaml --force \
+		--name k8s-cluster.example.com --state s3://my-state-store
+	`))
+
+	createSecretFileRepositoryShort = i18n.T(`Create a file repository secret.`)
+)
+
+type CreateSecretFileRepositoryOptions struct {
+	ClusterName string
+	SecretName  string
+	SecretPath  string
+	Force       bool
+}
+
+func NewCmdCreateSecretFileRepository(f *util.Factory, out io.Writer) *cobra.Command {
+	options := &CreateSecretFileRepositoryOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "filerepository SECRET_NAME -f FILENAME",
+		Short:   createSecretFileRepositoryShort,
+		Long:    createSecretFileRepositoryLong,
+		Example: createSecretFileRepositoryExample,
+		Args: func(cmd *cobra.Command, args []string) error {
+			options.ClusterName = rootCommand.ClusterName(true)
+
+			if options.ClusterName == "" {
+				return fmt.Errorf("--name is required")
+			}
+
+			if len(args) != 1 {
+				return fmt.Errorf("must specify the name of the secret")
+			}
+			options.SecretName = args[0]
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunCreateSecretFileRepository(cmd.Context(), f, out, options)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.SecretPath, "filename", "f", "", "Path to the file repository secret YAML file")
+	cmd.MarkFlagRequired("filename")
+	cmd.RegisterFlagCompletionFunc("filename", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"yaml", "json"}, cobra.ShellCompDirectiveFilterFileExt
+	})
+	cmd.Flags().BoolVar(&options.Force, "force", options.Force, "Force replace the secret if it already exists")
+
+	return cmd
+}
+
+func RunCreateSecretFileRepository(ctx context.Context, f commandutils.Factory, out io.Writer, options *CreateSecretFileRepositoryOptions) error {
+	cluster, err := GetCluster(ctx, f, options.ClusterName)
+	if err != nil {
+		return err
+	}
+
+	clientset, err := f.KopsClient()
+	if err != nil {
+		return err
+	}
+
+	secretStore, err := clientset.SecretStore(cluster)
+	if err != nil {
+		return err
+	}
+
+	var data []byte
+	if options.SecretPath == "-" {
+		data, err = ConsumeStdin()
+		if err != nil {
+			return fmt.Errorf("reading file repository secret from stdin: %v", err)
+		}
+	} else {
+		data, err = os.ReadFile(options.SecretPath)
+		if err != nil {
+			return fmt.Errorf("reading file repository secret %v: %v", options.SecretPath, err)
+		}
+	}
+
+	if _, err := nodeup.ParseFileRepositorySecret(data); err != nil {
+		return fmt.Errorf("unable to parse %v: %v", options.SecretPath, err)
+	}
+
+	secret := &fi.Secret{
+		Data: data,
+	}
+
+	if !options.Force {
+		_, created, err := secretStore.GetOrCreateSecret(ctx, options.SecretName, secret)
+		if err != nil {
+			return fmt.Errorf("adding %s secret: %v", options.SecretName, err)
+		}
+		if !created {
+			return fmt.Errorf("failed to create the %s secret as it already exists. Pass the `--force` flag to replace an existing secret", options.SecretName)
+		}
+	} else {
+		_, err := secretStore.ReplaceSecret(options.SecretName, secret)
+		if err != nil {
+			return fmt.Errorf("updating %s secret: %v", options.SecretName, err)
+		}
+	}
+
+	return nil
+}
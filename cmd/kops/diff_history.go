@@ -0,0 +1,134 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kops/cmd/kops/util"
+	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kops/pkg/diff"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	diffHistoryLong = templates.LongDesc(i18n.T(`
+	Show the differences between a recorded revision of the cluster configuration
+	and the current configuration, or between two recorded revisions.
+
+	Use ` + "`kops get history`" + ` to list the recorded revisions.`))
+
+	diffHistoryExample = templates.Examples(i18n.T(`
+	# Show what changed since revision 3.
+	kops diff history 3 --name k8s-cluster.example.com
+
+	# Show what changed between revisions 3 and 5.
+	kops diff history 3 5 --name k8s-cluster.example.com`))
+
+	diffHistoryShort = i18n.T(`Show differences between revisions of a cluster configuration.`)
+)
+
+type DiffHistoryOptions struct {
+	ClusterName string
+
+	// From is the older revision to compare
+	From int
+	// To is the newer revision to compare; 0 means the current configuration
+	To int
+}
+
+func NewCmdDiffHistory(f *util.Factory, out io.Writer) *cobra.Command {
+	options := &DiffHistoryOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "history REVISION [REVISION]",
+		Short:   diffHistoryShort,
+		Long:    diffHistoryLong,
+		Example: diffHistoryExample,
+		Args: func(cmd *cobra.Command, args []string) error {
+			options.ClusterName = rootCommand.ClusterName(true)
+			if options.ClusterName == "" {
+				return fmt.Errorf("--name is required")
+			}
+
+			if len(args) == 0 || len(args) > 2 {
+				return fmt.Errorf("must specify one or two revisions")
+			}
+			revisions, err := parseRevisions(args)
+			if err != nil {
+				return err
+			}
+			options.From = revisions[0]
+			if len(revisions) == 2 {
+				options.To = revisions[1]
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunDiffHistory(cmd.Context(), f, out, options)
+		},
+	}
+
+	return cmd
+}
+
+func RunDiffHistory(ctx context.Context, f commandutils.Factory, out io.Writer, options *DiffHistoryOptions) error {
+	history, err := clusterHistory(ctx, f, options.ClusterName)
+	if err != nil {
+		return err
+	}
+
+	from, err := history.Get(ctx, options.From)
+	if err != nil {
+		return err
+	}
+
+	to, err := history.Current(ctx)
+	if options.To != 0 {
+		to, err = history.Get(ctx, options.To)
+	}
+	if err != nil {
+		return err
+	}
+
+	fromText := from.Render()
+	toText := to.Render()
+	if fromText == toText {
+		fmt.Fprintf(out, "No changes\n")
+		return nil
+	}
+
+	_, err = fmt.Fprint(out, diff.FormatDiff(fromText, toText))
+	return err
+}
+
+func parseRevisions(args []string) ([]int, error) {
+	var revisions []int
+	for _, arg := range args {
+		revision, err := strconv.Atoi(arg)
+		if err != nil || revision <= 0 {
+			return nil, fmt.Errorf("invalid revision %q: must be a positive number", arg)
+		}
+		revisions = append(revisions, revision)
+	}
+	return revisions, nil
+}
@@ -27,6 +27,9 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
 	"k8s.io/kops/cmd/kops/util"
 	api "k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/pkg/apis/kops/validation"
@@ -121,22 +124,31 @@ func RunEditCluster(ctx context.Context, f *util.Factory, out io.Writer, options
 	}
 
 	if len(options.Unsets)+len(options.Sets) > 0 {
-		newCluster := oldCluster.DeepCopy()
-		if err := commands.UnsetClusterFields(options.Unsets, newCluster); err != nil {
-			return err
-		}
-		if err := commands.SetClusterFields(options.Sets, newCluster); err != nil {
-			return err
-		}
+		// If the cluster was changed concurrently, re-read it and apply the changes again
+		return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			newCluster := oldCluster.DeepCopy()
+			if err := commands.UnsetClusterFields(options.Unsets, newCluster); err != nil {
+				return err
+			}
+			if err := commands.SetClusterFields(options.Sets, newCluster); err != nil {
+				return err
+			}
 
-		failure, err := updateCluster(ctx, clientset, oldCluster, newCluster, instanceGroups)
-		if err != nil {
-			return err
-		}
-		if failure != "" {
-			return fmt.Errorf("%s", failure)
-		}
-		return nil
+			failure, err := updateCluster(ctx, clientset, oldCluster, newCluster, instanceGroups)
+			if err != nil {
+				if apierrors.IsConflict(err) {
+					klog.Warningf("cluster %q was modified concurrently, retrying", oldCluster.Name)
+					if latest, getErr := clientset.GetCluster(ctx, oldCluster.Name); getErr == nil && latest.FillDefaults() == nil {
+						oldCluster = latest
+					}
+				}
+				return err
+			}
+			if failure != "" {
+				return fmt.Errorf("%s", failure)
+			}
+			return nil
+		})
 	}
 
 	editor := util_editor.NewDefaultEditor(commandutils.EditorEnvs)
@@ -237,6 +249,9 @@ func RunEditCluster(ctx context.Context, f *util.Factory, out io.Writer, options
 
 		failure, err := updateCluster(ctx, clientset, oldCluster, newCluster, instanceGroups)
 		if err != nil {
+			if apierrors.IsConflict(err) {
+				err = fmt.Errorf("cluster %q was modified by someone else while you were editing it; re-run kops edit to apply your changes to the latest version", oldCluster.Name)
+			}
 			return preservedFile(err, file, out)
 		}
 		if failure != "" {
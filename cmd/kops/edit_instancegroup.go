@@ -26,7 +26,9 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
 	"k8s.io/klog/v2"
 	"k8s.io/kops/cmd/kops/util"
 	api "k8s.io/kops/pkg/apis/kops"
@@ -146,22 +148,31 @@ func RunEditInstanceGroup(ctx context.Context, f *util.Factory, out io.Writer, o
 	}
 
 	if len(options.Unsets)+len(options.Sets) > 0 {
-		newGroup := oldGroup.DeepCopy()
-		if err := commands.UnsetInstancegroupFields(options.Unsets, newGroup); err != nil {
-			return err
-		}
-		if err := commands.SetInstancegroupFields(options.Sets, newGroup); err != nil {
-			return err
-		}
+		// If the instance group was changed concurrently, re-read it and apply the changes again
+		return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			newGroup := oldGroup.DeepCopy()
+			if err := commands.UnsetInstancegroupFields(options.Unsets, newGroup); err != nil {
+				return err
+			}
+			if err := commands.SetInstancegroupFields(options.Sets, newGroup); err != nil {
+				return err
+			}
 
-		failure, err := updateInstanceGroup(ctx, clientset, channel, cluster, newGroup)
-		if err != nil {
-			return err
-		}
-		if failure != "" {
-			return fmt.Errorf("%s", failure)
-		}
-		return nil
+			failure, err := updateInstanceGroup(ctx, clientset, channel, cluster, newGroup)
+			if err != nil {
+				if apierrors.IsConflict(err) {
+					klog.Warningf("InstanceGroup %q was modified concurrently, retrying", groupName)
+					if latest, getErr := clientset.InstanceGroupsFor(cluster).Get(ctx, groupName, metav1.GetOptions{}); getErr == nil {
+						oldGroup = latest
+					}
+				}
+				return err
+			}
+			if failure != "" {
+				return fmt.Errorf("%s", failure)
+			}
+			return nil
+		})
 	}
 
 	editor := editor.NewDefaultEditor(commandutils.EditorEnvs)
@@ -262,6 +273,9 @@ func RunEditInstanceGroup(ctx context.Context, f *util.Factory, out io.Writer, o
 
 		failure, err := updateInstanceGroup(ctx, clientset, channel, cluster, newGroup)
 		if err != nil {
+			if apierrors.IsConflict(err) {
+				err = fmt.Errorf("InstanceGroup %q was modified by someone else while you were editing it; re-run kops edit to apply your changes to the latest version", groupName)
+			}
 			return preservedFile(err, file, out)
 		}
 		if failure != "" {
@@ -0,0 +1,89 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kops/cmd/kops/util"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kops/pkg/etcdmanager"
+	"k8s.io/kubectl/pkg/util/i18n"
+)
+
+func NewCmdEtcd(f *util.Factory, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "etcd",
+		Short: i18n.T("Manage the backups and health of the etcd clusters."),
+	}
+
+	// create subcommands
+	cmd.AddCommand(NewCmdEtcdBackup(f, out))
+	cmd.AddCommand(NewCmdEtcdHealth(f, out))
+	cmd.AddCommand(NewCmdEtcdListBackups(f, out))
+	cmd.AddCommand(NewCmdEtcdRestore(f, out))
+
+	return cmd
+}
+
+// etcdClusterBackupStore is the backup store of an etcd cluster.
+type etcdClusterBackupStore struct {
+	EtcdCluster *kops.EtcdClusterSpec
+	Store       *etcdmanager.BackupStore
+}
+
+// etcdBackupStores returns the backup stores of the named etcd clusters of the cluster, or of all its etcd clusters if none are named.
+func etcdBackupStores(ctx context.Context, f commandutils.Factory, clusterName string, etcdClusterNames []string) ([]*etcdClusterBackupStore, error) {
+	clientset, err := f.KopsClient()
+	if err != nil {
+		return nil, err
+	}
+
+	cluster, err := clientset.GetCluster(ctx, clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	configBase, err := clientset.ConfigBaseFor(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	var stores []*etcdClusterBackupStore
+	for i := range cluster.Spec.EtcdClusters {
+		etcdCluster := &cluster.Spec.EtcdClusters[i]
+		if len(etcdClusterNames) != 0 && !slices.Contains(etcdClusterNames, etcdCluster.Name) {
+			continue
+		}
+		store, err := etcdmanager.BackupStoreFor(f.VFSContext(), configBase, etcdCluster)
+		if err != nil {
+			return nil, err
+		}
+		stores = append(stores, &etcdClusterBackupStore{EtcdCluster: etcdCluster, Store: store})
+	}
+	for _, name := range etcdClusterNames {
+		if !slices.ContainsFunc(stores, func(s *etcdClusterBackupStore) bool { return s.EtcdCluster.Name == name }) {
+			return nil, fmt.Errorf("etcd cluster %q not found in cluster %q", name, clusterName)
+		}
+	}
+	return stores, nil
+}
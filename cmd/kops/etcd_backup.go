@@ -0,0 +1,130 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kops/cmd/kops/util"
+	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kops/pkg/etcdmanager"
+	"k8s.io/kops/util/pkg/vfs"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	etcdBackupNowLong = templates.LongDesc(i18n.T(`
+	Ask etcd-manager to back up the etcd clusters without waiting for the backup interval.
+
+	The backup is requested by writing a command to the control directory of the backup store,
+	where etcd-manager also picks up the restore commands written by kops etcd restore.
+	etcd-manager versions that do not support the command still take a backup at the next
+	backup interval, so the wait completes either way; the command is then withdrawn.
+	Without --wait the command stays pending until etcd-manager applies it.`))
+
+	etcdBackupNowExample = templates.Examples(i18n.T(`
+	# Back up all etcd clusters, waiting up to 15 minutes for the backups.
+	kops etcd backup now --name k8s-cluster.example.com
+
+	# Back up the main etcd cluster without waiting.
+	kops etcd backup now --name k8s-cluster.example.com --cluster main --wait 0`))
+
+	etcdBackupNowShort = i18n.T(`Back up the etcd clusters now.`)
+)
+
+type EtcdBackupNowOptions struct {
+	ClusterName string
+	// EtcdClusters restricts the backup to the named etcd clusters
+	EtcdClusters []string
+	// Wait is how long to wait for the backups to appear; zero does not wait
+	Wait time.Duration
+}
+
+func NewCmdEtcdBackup(f *util.Factory, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "backup",
+		Short: i18n.T("Back up the etcd clusters."),
+	}
+
+	// create subcommands
+	cmd.AddCommand(NewCmdEtcdBackupNow(f, out))
+
+	return cmd
+}
+
+func NewCmdEtcdBackupNow(f *util.Factory, out io.Writer) *cobra.Command {
+	options := &EtcdBackupNowOptions{
+		Wait: etcdmanager.DefaultBackupInterval,
+	}
+
+	cmd := &cobra.Command{
+		Use:               "now [CLUSTER]",
+		Short:             etcdBackupNowShort,
+		Long:              etcdBackupNowLong,
+		Example:           etcdBackupNowExample,
+		Args:              rootCommand.clusterNameArgs(&options.ClusterName),
+		ValidArgsFunction: commandutils.CompleteClusterName(f, true, false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunEtcdBackupNow(cmd.Context(), f, out, options)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&options.EtcdClusters, "cluster", options.EtcdClusters, "Names of the etcd clusters, e.g. main or events (default all)")
+	cmd.Flags().DurationVar(&options.Wait, "wait", options.Wait, "Time to wait for the backups to be taken")
+
+	return cmd
+}
+
+func RunEtcdBackupNow(ctx context.Context, f commandutils.Factory, out io.Writer, options *EtcdBackupNowOptions) error {
+	stores, err := etcdBackupStores(ctx, f, options.ClusterName, options.EtcdClusters)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	commands := make(map[string]vfs.Path)
+	for _, s := range stores {
+		p, err := etcdmanager.RequestBackup(ctx, s.Store, now)
+		if err != nil {
+			return err
+		}
+		commands[s.EtcdCluster.Name] = p
+		fmt.Fprintf(out, "Requested backup of etcd cluster %q\n", s.EtcdCluster.Name)
+	}
+
+	if options.Wait == 0 {
+		return nil
+	}
+	for _, s := range stores {
+		backup, err := etcdmanager.WaitForBackup(ctx, s.Store, now, 10*time.Second, options.Wait)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "Backed up etcd cluster %q to %s\n", s.EtcdCluster.Name, backup.Name)
+
+		// The backup may have come from the backup interval, with the command still pending
+		if err := etcdmanager.WithdrawCommand(ctx, s.Store, commands[s.EtcdCluster.Name]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
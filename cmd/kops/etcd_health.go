@@ -0,0 +1,132 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kops/cmd/kops/util"
+	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kops/pkg/etcdmanager"
+	"k8s.io/kops/util/pkg/tables"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	etcdHealthLong = templates.LongDesc(i18n.T(`
+	Check the health of the etcd clusters, from their backup stores.
+
+	etcd-manager only backs up a healthy etcd cluster, so an etcd cluster is reported as
+	unhealthy if its latest backup is older than three backup intervals. Commands that
+	etcd-manager has not applied within that time are also reported.
+
+	If backup verification is enabled for an etcd cluster, the etcd cluster is also reported
+	as unhealthy if the latest restore drill failed, or if no drill has completed within
+	two verification intervals.`))
+
+	etcdHealthExample = templates.Examples(i18n.T(`
+	# Check the health of all etcd clusters.
+	kops etcd health --name k8s-cluster.example.com`))
+
+	etcdHealthShort = i18n.T(`Check the health of the etcd clusters.`)
+)
+
+type EtcdHealthOptions struct {
+	ClusterName string
+	// EtcdClusters restricts the check to the named etcd clusters
+	EtcdClusters []string
+}
+
+func NewCmdEtcdHealth(f *util.Factory, out io.Writer) *cobra.Command {
+	options := &EtcdHealthOptions{}
+
+	cmd := &cobra.Command{
+		Use:               "health [CLUSTER]",
+		Short:             etcdHealthShort,
+		Long:              etcdHealthLong,
+		Example:           etcdHealthExample,
+		Args:              rootCommand.clusterNameArgs(&options.ClusterName),
+		ValidArgsFunction: commandutils.CompleteClusterName(f, true, false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunEtcdHealth(cmd.Context(), f, out, options)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&options.EtcdClusters, "cluster", options.EtcdClusters, "Names of the etcd clusters, e.g. main or events (default all)")
+
+	return cmd
+}
+
+func RunEtcdHealth(ctx context.Context, f commandutils.Factory, out io.Writer, options *EtcdHealthOptions) error {
+	stores, err := etcdBackupStores(ctx, f, options.ClusterName, options.EtcdClusters)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	var results []*etcdmanager.ClusterHealth
+	for _, s := range stores {
+		health, err := etcdmanager.CheckHealth(ctx, s.Store, s.EtcdCluster, now)
+		if err != nil {
+			return err
+		}
+		results = append(results, health)
+	}
+
+	t := &tables.Table{}
+	t.AddColumn("CLUSTER", func(h *etcdmanager.ClusterHealth) string {
+		return h.Name
+	})
+	t.AddColumn("HEALTHY", func(h *etcdmanager.ClusterHealth) string {
+		return strconv.FormatBool(h.Healthy())
+	})
+	t.AddColumn("LATEST BACKUP", func(h *etcdmanager.ClusterHealth) string {
+		if h.LatestBackup == nil {
+			return ""
+		}
+		return h.LatestBackup.Name
+	})
+	t.AddColumn("PENDING COMMANDS", func(h *etcdmanager.ClusterHealth) string {
+		return strconv.Itoa(len(h.PendingCommands))
+	})
+	t.AddColumn("LAST VERIFIED", func(h *etcdmanager.ClusterHealth) string {
+		if h.Verification == nil || h.Verification.LastVerified == nil {
+			return ""
+		}
+		return h.Verification.LastVerified.Backup
+	})
+	t.AddColumn("PROBLEMS", func(h *etcdmanager.ClusterHealth) string {
+		return strings.Join(h.Problems, "; ")
+	})
+	if err := t.Render(results, out, "CLUSTER", "HEALTHY", "LATEST BACKUP", "PENDING COMMANDS", "LAST VERIFIED", "PROBLEMS"); err != nil {
+		return err
+	}
+
+	for _, h := range results {
+		if !h.Healthy() {
+			return fmt.Errorf("etcd cluster %q is not healthy", h.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kops/cmd/kops/util"
+	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kops/util/pkg/tables"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	etcdListBackupsLong = templates.LongDesc(i18n.T(`
+	List the backups taken by etcd-manager for the etcd clusters of a cluster.`))
+
+	etcdListBackupsExample = templates.Examples(i18n.T(`
+	# List the backups of all etcd clusters.
+	kops etcd list-backups --name k8s-cluster.example.com
+
+	# List the backups of the main etcd cluster.
+	kops etcd list-backups --name k8s-cluster.example.com --cluster main`))
+
+	etcdListBackupsShort = i18n.T(`List the backups of the etcd clusters.`)
+)
+
+type EtcdListBackupsOptions struct {
+	ClusterName string
+	// EtcdClusters restricts the listing to the named etcd clusters
+	EtcdClusters []string
+}
+
+func NewCmdEtcdListBackups(f *util.Factory, out io.Writer) *cobra.Command {
+	options := &EtcdListBackupsOptions{}
+
+	cmd := &cobra.Command{
+		Use:               "list-backups [CLUSTER]",
+		Short:             etcdListBackupsShort,
+		Long:              etcdListBackupsLong,
+		Example:           etcdListBackupsExample,
+		Args:              rootCommand.clusterNameArgs(&options.ClusterName),
+		ValidArgsFunction: commandutils.CompleteClusterName(f, true, false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunEtcdListBackups(cmd.Context(), f, out, options)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&options.EtcdClusters, "cluster", options.EtcdClusters, "Names of the etcd clusters, e.g. main or events (default all)")
+
+	return cmd
+}
+
+// etcdBackupRow is a row of the list-backups table.
+type etcdBackupRow struct {
+	EtcdCluster string
+	Name        string
+	Timestamp   time.Time
+	EtcdVersion string
+}
+
+func RunEtcdListBackups(ctx context.Context, f commandutils.Factory, out io.Writer, options *EtcdListBackupsOptions) error {
+	stores, err := etcdBackupStores(ctx, f, options.ClusterName, options.EtcdClusters)
+	if err != nil {
+		return err
+	}
+
+	var rows []*etcdBackupRow
+	for _, s := range stores {
+		backups, err := s.Store.ListBackups(ctx)
+		if err != nil {
+			return err
+		}
+		for _, backup := range backups {
+			rows = append(rows, &etcdBackupRow{
+				EtcdCluster: s.EtcdCluster.Name,
+				Name:        backup.Name,
+				Timestamp:   backup.Time(),
+				EtcdVersion: backup.Info.EtcdVersion,
+			})
+		}
+	}
+
+	t := &tables.Table{}
+	t.AddColumn("CLUSTER", func(r *etcdBackupRow) string {
+		return r.EtcdCluster
+	})
+	t.AddColumn("BACKUP", func(r *etcdBackupRow) string {
+		return r.Name
+	})
+	t.AddColumn("TIMESTAMP", func(r *etcdBackupRow) string {
+		if r.Timestamp.IsZero() {
+			return ""
+		}
+		return r.Timestamp.UTC().Format(time.RFC3339)
+	})
+	t.AddColumn("ETCD VERSION", func(r *etcdBackupRow) string {
+		return r.EtcdVersion
+	})
+	return t.Render(rows, out, "CLUSTER", "BACKUP", "TIMESTAMP", "ETCD VERSION")
+}
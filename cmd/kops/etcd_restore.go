@@ -0,0 +1,137 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kops/cmd/kops/util"
+	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kops/pkg/etcdmanager"
+	"k8s.io/kops/pkg/pretty"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	etcdRestoreLong = pretty.LongDesc(i18n.T(`
+	Restore an etcd cluster from a backup taken by etcd-manager.
+
+	The restore is requested by writing a command to the backup store, which etcd-manager
+	picks up when it next starts. Restart the etcd-manager containers on the control plane
+	nodes (or roll the control plane) for the restore to begin.
+
+	A restore replaces the etcd cluster, and cannot be undone except by restoring again.
+	Resources created after the backup was taken are lost. The Kubernetes API is unavailable
+	while the restore runs.
+
+	Use ` + pretty.Bash("latest") + ` to restore the most recent backup.`))
+
+	etcdRestoreExample = templates.Examples(i18n.T(`
+	# Restore the latest backups of both etcd clusters.
+	kops etcd restore latest --name k8s-cluster.example.com --cluster main --yes
+	kops etcd restore latest --name k8s-cluster.example.com --cluster events --yes
+
+	# Restore a specific backup, and wait for etcd-manager to apply it.
+	kops etcd restore 2026-10-01T11:00:00Z-000001 --name k8s-cluster.example.com --cluster main --yes --wait 30m`))
+
+	etcdRestoreShort = i18n.T(`Restore an etcd cluster from a backup.`)
+)
+
+type EtcdRestoreOptions struct {
+	ClusterName string
+	// EtcdCluster is the name of the etcd cluster to restore
+	EtcdCluster string
+	// Backup is the name of the backup, or latest
+	Backup string
+	// Wait is how long to wait for etcd-manager to apply the restore; zero does not wait
+	Wait time.Duration
+	Yes  bool
+}
+
+func NewCmdEtcdRestore(f *util.Factory, out io.Writer) *cobra.Command {
+	options := &EtcdRestoreOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "restore BACKUP --cluster ETCD_CLUSTER",
+		Short:   etcdRestoreShort,
+		Long:    etcdRestoreLong,
+		Example: etcdRestoreExample,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("must specify the backup to restore, or latest")
+			}
+			options.Backup = args[0]
+
+			options.ClusterName = rootCommand.ClusterName(true)
+			if options.ClusterName == "" {
+				return fmt.Errorf("--name is required")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunEtcdRestore(cmd.Context(), f, out, options)
+		},
+	}
+
+	cmd.Flags().StringVar(&options.EtcdCluster, "cluster", options.EtcdCluster, "Name of the etcd cluster to restore, e.g. main or events")
+	cmd.MarkFlagRequired("cluster")
+	cmd.Flags().DurationVar(&options.Wait, "wait", options.Wait, "Time to wait for etcd-manager to apply the restore")
+	cmd.Flags().BoolVarP(&options.Yes, "yes", "y", options.Yes, "Specify --yes to request the restore")
+
+	return cmd
+}
+
+func RunEtcdRestore(ctx context.Context, f commandutils.Factory, out io.Writer, options *EtcdRestoreOptions) error {
+	stores, err := etcdBackupStores(ctx, f, options.ClusterName, []string{options.EtcdCluster})
+	if err != nil {
+		return err
+	}
+	store := stores[0].Store
+
+	if !options.Yes {
+		backup, err := store.FindBackup(ctx, options.Backup)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "Would restore etcd cluster %q from backup %s, taken %s\n", options.EtcdCluster, backup.Name, backup.Time().UTC().Format(time.RFC3339))
+		fmt.Fprintf(out, "\nMust specify --yes to restore\n")
+		return nil
+	}
+
+	backup, command, err := etcdmanager.RequestRestore(ctx, store, options.Backup, time.Now())
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "Requested restore of etcd cluster %q from backup %s\n", options.EtcdCluster, backup.Name)
+
+	if options.Wait == 0 {
+		fmt.Fprintf(out, "Restart the etcd-manager containers on the control plane nodes for the restore to begin.\n")
+		return nil
+	}
+
+	fmt.Fprintf(out, "Waiting for etcd-manager to apply the restore; restart the etcd-manager containers on the control plane nodes if it does not start.\n")
+	if err := etcdmanager.WaitForCommand(ctx, store, command, 10*time.Second, options.Wait); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "etcd-manager has restored etcd cluster %q from backup %s\n", options.EtcdCluster, backup.Name)
+	return nil
+}
@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"strings"
 
 	"k8s.io/kops/pkg/assets"
 	"k8s.io/kops/pkg/commands/commandutils"
@@ -33,7 +34,9 @@ import (
 
 	"github.com/spf13/cobra"
 	"k8s.io/kops/cmd/kops/util"
+	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup"
+	"k8s.io/kops/upup/pkg/fi/fitasks"
 )
 
 var (
@@ -42,7 +45,11 @@ var (
 	(original) and download (local repository) locations.
 
 	When invoked with the ` + pretty.Bash("--copy") + ` flag, will copy each asset from the
-	canonical to the download location.`))
+	canonical to the download location.
+
+	With ` + pretty.Bash("-o spdx") + ` or ` + pretty.Bash("-o cyclonedx") + `, displays a software bill of materials
+	for the assets and the addon manifests of the cluster, in SPDX 2.3 or CycloneDX 1.5 JSON format.
+	Image digests are resolved from the canonical registries.`))
 
 	getAssetsExample = templates.Examples(i18n.T(`
 	# Display all assets.
@@ -50,11 +57,19 @@ var (
 
 	# Copy assets to the local repositories configured in the cluster spec.
 	kops get assets --copy 
+
+	# Display a software bill of materials in SPDX format.
+	kops get assets -o spdx > sbom.spdx.json
 	`))
 
 	getAssetsShort = i18n.T(`Display assets for cluster.`)
 )
 
+const (
+	OutputSPDX      = "spdx"
+	OutputCycloneDX = "cyclonedx"
+)
+
 type GetAssetsOptions struct {
 	*GetOptions
 	Copy bool
@@ -171,6 +186,15 @@ func RunGetAssets(ctx context.Context, f *util.Factory, out io.Writer, options *
 		if _, err := out.Write(j); err != nil {
 			return fmt.Errorf("error writing to output: %v", err)
 		}
+	case OutputSPDX, OutputCycloneDX:
+		sbom, err := buildSBOM(ctx, updateClusterResults)
+		if err != nil {
+			return err
+		}
+		if options.Output == OutputSPDX {
+			return sbom.WriteSPDX(out)
+		}
+		return sbom.WriteCycloneDX(out)
 	default:
 		return fmt.Errorf("unsupported output format: %q", options.Output)
 	}
@@ -178,6 +202,27 @@ func RunGetAssets(ctx context.Context, f *util.Factory, out io.Writer, options *
 	return nil
 }
 
+// buildSBOM creates a software bill of materials for the assets and addon manifests of the cluster.
+func buildSBOM(ctx context.Context, results *UpdateClusterResults) (*assets.SBOM, error) {
+	var manifests []*assets.SBOMManifest
+	for _, task := range results.TaskMap {
+		managedFile, ok := task.(*fitasks.ManagedFile)
+		if !ok || !strings.HasPrefix(fi.ValueOf(managedFile.Location), "addons/") {
+			continue
+		}
+		contents, err := fi.ResourceAsBytes(managedFile.Contents)
+		if err != nil {
+			return nil, fmt.Errorf("error reading manifest %q: %w", fi.ValueOf(managedFile.Location), err)
+		}
+		manifests = append(manifests, &assets.SBOMManifest{
+			Location: fi.ValueOf(managedFile.Location),
+			Contents: contents,
+		})
+	}
+
+	return assets.BuildSBOM(ctx, results.Cluster.ObjectMeta.Name, results.ImageAssets, results.FileAssets, manifests, assets.ResolveImageDigest)
+}
+
 func imageOutputTable(images []*Image, out io.Writer) error {
 	fmt.Println("")
 	t := &tables.Table{}
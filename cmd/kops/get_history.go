@@ -0,0 +1,165 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kops/cmd/kops/util"
+	"k8s.io/kops/pkg/client/simple/vfsclientset"
+	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kops/util/pkg/tables"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+	"sigs.k8s.io/yaml"
+)
+
+var (
+	getHistoryLong = templates.LongDesc(i18n.T(`
+	Display the recorded revisions of the cluster configuration.
+
+	A revision is recorded in the state store every time the cluster, an instance group
+	or the cluster addons are changed.`))
+
+	getHistoryExample = templates.Examples(i18n.T(`
+	# List the revisions of the cluster configuration.
+	kops get history --name k8s-cluster.example.com
+
+	# Show the revision metadata as YAML.
+	kops get history --name k8s-cluster.example.com -o yaml`))
+
+	getHistoryShort = i18n.T(`Get the revision history of a cluster configuration.`)
+)
+
+type GetHistoryOptions struct {
+	*GetOptions
+}
+
+func NewCmdGetHistory(f *util.Factory, out io.Writer, getOptions *GetOptions) *cobra.Command {
+	options := GetHistoryOptions{
+		GetOptions: getOptions,
+	}
+	cmd := &cobra.Command{
+		Use:     "history",
+		Aliases: []string{"revisions"},
+		Short:   getHistoryShort,
+		Long:    getHistoryLong,
+		Example: getHistoryExample,
+		Args: func(cmd *cobra.Command, args []string) error {
+			options.ClusterName = rootCommand.ClusterName(true)
+			if options.ClusterName == "" {
+				return fmt.Errorf("--name is required")
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunGetHistory(cmd.Context(), f, out, &options)
+		},
+	}
+
+	return cmd
+}
+
+func RunGetHistory(ctx context.Context, f commandutils.Factory, out io.Writer, options *GetHistoryOptions) error {
+	history, err := clusterHistory(ctx, f, options.ClusterName)
+	if err != nil {
+		return err
+	}
+
+	items, err := history.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(items) == 0 {
+		return fmt.Errorf("no history found")
+	}
+	switch options.Output {
+
+	case OutputTable:
+		t := &tables.Table{}
+		t.AddColumn("REVISION", func(i *vfsclientset.HistoryRevision) string {
+			return strconv.Itoa(i.Revision)
+		})
+		t.AddColumn("TIMESTAMP", func(i *vfsclientset.HistoryRevision) string {
+			return i.Timestamp.Format(time.RFC3339)
+		})
+		t.AddColumn("AUTHOR", func(i *vfsclientset.HistoryRevision) string {
+			return i.Author
+		})
+		t.AddColumn("KOPS VERSION", func(i *vfsclientset.HistoryRevision) string {
+			return i.KopsVersion
+		})
+		t.AddColumn("REASON", func(i *vfsclientset.HistoryRevision) string {
+			return i.Reason
+		})
+		t.AddColumn("COMMAND", func(i *vfsclientset.HistoryRevision) string {
+			return strings.Join(i.CommandLine, " ")
+		})
+		return t.Render(items, out, "REVISION", "TIMESTAMP", "AUTHOR", "KOPS VERSION", "REASON", "COMMAND")
+
+	case OutputYaml:
+		y, err := yaml.Marshal(items)
+		if err != nil {
+			return fmt.Errorf("unable to marshal YAML: %v", err)
+		}
+		if _, err := out.Write(y); err != nil {
+			return fmt.Errorf("error writing to output: %v", err)
+		}
+
+	case OutputJSON:
+		j, err := json.Marshal(items)
+		if err != nil {
+			return fmt.Errorf("unable to marshal JSON: %v", err)
+		}
+		if _, err := out.Write(j); err != nil {
+			return fmt.Errorf("error writing to output: %v", err)
+		}
+
+	default:
+		return fmt.Errorf("unknown output format: %q", options.Output)
+	}
+	return nil
+}
+
+// clusterHistory returns the configuration history for the named cluster.
+func clusterHistory(ctx context.Context, f commandutils.Factory, clusterName string) (*vfsclientset.History, error) {
+	clientset, err := f.KopsClient()
+	if err != nil {
+		return nil, err
+	}
+
+	cluster, err := clientset.GetCluster(ctx, clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	configBase, err := clientset.ConfigBaseFor(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	return vfsclientset.NewHistory(configBase, cluster), nil
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+	channelscmd "k8s.io/kops/channels/pkg/cmd"
+	"k8s.io/kops/pkg/pretty"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	rollbackAddonLong = pretty.LongDesc(i18n.T(`
+	Re-apply a previously applied manifest of an addon, in the cluster of the current kubectl context.
+
+	The last manifests applied for each addon are kept in the cluster. The addon is pinned to the restored
+	revision, so that ` + pretty.Bash("kops update cluster") + ` and the control plane don't update it again
+	until it is unpinned with ` + pretty.Bash("--unpin") + `.
+	Without ` + pretty.Bash("--yes") + ` the recorded revisions are only displayed.`))
+
+	rollbackAddonExample = templates.Examples(i18n.T(`
+	# Display the recorded revisions of CoreDNS.
+	kops rollback addon coredns.addons.k8s.io
+
+	# Restore the revision of CoreDNS before the installed one.
+	kops rollback addon coredns.addons.k8s.io --yes
+
+	# Let the next update of the cluster update CoreDNS again.
+	kops rollback addon coredns.addons.k8s.io --unpin --yes`))
+
+	rollbackAddonShort = i18n.T(`Re-apply a previously applied manifest of an addon.`)
+)
+
+func NewCmdRollbackAddon(out io.Writer) *cobra.Command {
+	options := &channelscmd.RollbackAddonOptions{
+		Namespace: "kube-system",
+	}
+
+	f := channelscmd.NewChannelsFactory()
+
+	cmd := &cobra.Command{
+		Use:     "addon ADDON",
+		Short:   rollbackAddonShort,
+		Long:    rollbackAddonLong,
+		Example: rollbackAddonExample,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return channelscmd.RunRollbackAddon(cmd.Context(), f, out, options, args[0])
+		},
+	}
+
+	channelscmd.AddRollbackAddonFlags(cmd, options)
+
+	return cmd
+}
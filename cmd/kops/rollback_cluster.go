@@ -0,0 +1,134 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kops/cmd/kops/util"
+	"k8s.io/kops/pkg/client/simple/vfsclientset"
+	"k8s.io/kops/pkg/commands"
+	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kops/pkg/diff"
+	"k8s.io/kops/pkg/pretty"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	rollbackClusterLong = pretty.LongDesc(i18n.T(`
+	Restore the cluster, its instance groups and its addons to a recorded revision.
+
+	The restored configuration is validated and written to the state store as a new revision.
+	Without ` + pretty.Bash("--yes") + ` the changes are only displayed.
+
+	kops rollback does not update the cloud resources; to apply the changes use ` + pretty.Bash("kops update cluster") + `.`))
+
+	rollbackClusterExample = templates.Examples(i18n.T(`
+	# Preview restoring revision 3.
+	kops rollback cluster --name k8s-cluster.example.com --to 3
+
+	# Restore revision 3.
+	kops rollback cluster --name k8s-cluster.example.com --to 3 --yes`))
+
+	rollbackClusterShort = i18n.T(`Restore a previous revision of the cluster configuration.`)
+)
+
+type RollbackClusterOptions struct {
+	ClusterName string
+	// To is the revision to restore
+	To  int
+	Yes bool
+}
+
+func NewCmdRollbackCluster(f *util.Factory, out io.Writer) *cobra.Command {
+	options := &RollbackClusterOptions{}
+
+	cmd := &cobra.Command{
+		Use:               "cluster [CLUSTER] --to REVISION",
+		Short:             rollbackClusterShort,
+		Long:              rollbackClusterLong,
+		Example:           rollbackClusterExample,
+		Args:              rootCommand.clusterNameArgs(&options.ClusterName),
+		ValidArgsFunction: commandutils.CompleteClusterName(f, true, false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunRollbackCluster(cmd.Context(), f, out, options)
+		},
+	}
+
+	cmd.Flags().IntVar(&options.To, "to", options.To, "Revision to restore, as listed by kops get history")
+	cmd.MarkFlagRequired("to")
+	cmd.Flags().BoolVarP(&options.Yes, "yes", "y", options.Yes, "Specify --yes to restore the revision")
+
+	return cmd
+}
+
+func RunRollbackCluster(ctx context.Context, f commandutils.Factory, out io.Writer, options *RollbackClusterOptions) error {
+	if options.To <= 0 {
+		return fmt.Errorf("--to must be a positive revision number")
+	}
+
+	clientset, err := f.KopsClient()
+	if err != nil {
+		return err
+	}
+
+	cluster, err := clientset.GetCluster(ctx, options.ClusterName)
+	if err != nil {
+		return err
+	}
+
+	configBase, err := clientset.ConfigBaseFor(cluster)
+	if err != nil {
+		return err
+	}
+	history := vfsclientset.NewHistory(configBase, cluster)
+
+	snapshot, err := history.Get(ctx, options.To)
+	if err != nil {
+		return err
+	}
+	current, err := history.Current(ctx)
+	if err != nil {
+		return err
+	}
+
+	currentText := current.Render()
+	snapshotText := snapshot.Render()
+	if currentText == snapshotText {
+		fmt.Fprintf(out, "The cluster configuration already matches revision %d\n", options.To)
+		return nil
+	}
+
+	if !options.Yes {
+		fmt.Fprintf(out, "Restoring revision %d (recorded %s by %s) would make these changes:\n\n", snapshot.Revision, snapshot.Timestamp, snapshot.Author)
+		fmt.Fprint(out, diff.FormatDiff(currentText, snapshotText))
+		fmt.Fprintf(out, "\nMust specify --yes to restore revision %d\n", options.To)
+		return nil
+	}
+
+	if err := commands.RollbackCluster(ctx, clientset, cluster, snapshot); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "Restored revision %d of cluster %q\n", options.To, options.ClusterName)
+	fmt.Fprintf(out, "To apply the changes to the cloud resources, run: kops update cluster --name %s --yes\n", options.ClusterName)
+	return nil
+}
@@ -25,6 +25,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -164,8 +165,10 @@ func NewCmdRoot(f *util.Factory, out io.Writer) *cobra.Command {
 	// create subcommands
 	cmd.AddCommand(NewCmdCreate(f, out))
 	cmd.AddCommand(NewCmdDelete(f, out))
+	cmd.AddCommand(NewCmdDiff(f, out))
 	cmd.AddCommand(NewCmdDistrust(f, out))
 	cmd.AddCommand(NewCmdEdit(f, out))
+	cmd.AddCommand(NewCmdEtcd(f, out))
 	cmd.AddCommand(NewCmdExport(f, out))
 	cmd.AddCommand(NewCmdGenCLIDocs(f, out))
 	cmd.AddCommand(NewCmdGet(f, out))
@@ -173,7 +176,9 @@ func NewCmdRoot(f *util.Factory, out io.Writer) *cobra.Command {
 	cmd.AddCommand(NewCmdPromote(f, out))
 	cmd.AddCommand(NewCmdReconcile(f, out))
 	cmd.AddCommand(NewCmdReplace(f, out))
+	cmd.AddCommand(NewCmdRollback(f, out))
 	cmd.AddCommand(NewCmdRollingUpdate(f, out))
+	cmd.AddCommand(NewCmdScale(f, out))
 	cmd.AddCommand(NewCmdToolbox(f, out))
 	cmd.AddCommand(NewCmdTrust(f, out))
 	cmd.AddCommand(NewCmdUpdate(f, out))
@@ -218,6 +223,18 @@ func initConfig() {
 
 	// Tolerate multiple slashes at end
 	rootCommand.RegistryPath = strings.TrimSuffix(rootCommand.RegistryPath, "/")
+
+	// The on-disk state store cache is opt-in, configured from the environment or the config file
+	viper.BindEnv("KOPS_STATE_CACHE_DIR")
+	viper.BindEnv("KOPS_STATE_CACHE_TTL")
+	viper.SetDefault("KOPS_STATE_CACHE_TTL", time.Minute)
+	viper.BindEnv("KOPS_STATE_CACHE_TREE_TTL")
+	viper.SetDefault("KOPS_STATE_CACHE_TREE_TTL", 30*time.Second)
+	viper.BindEnv("KOPS_STATE_CACHE_SECRETS")
+	rootCommand.StateCacheDir = viper.GetString("KOPS_STATE_CACHE_DIR")
+	rootCommand.StateCacheTTL = viper.GetDuration("KOPS_STATE_CACHE_TTL")
+	rootCommand.StateCacheTreeTTL = viper.GetDuration("KOPS_STATE_CACHE_TREE_TTL")
+	rootCommand.StateCacheSecrets = viper.GetBool("KOPS_STATE_CACHE_SECRETS")
 }
 
 func (c *RootCmd) AddCommand(cmd *cobra.Command) {
@@ -0,0 +1,409 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kops/cmd/kops/util"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/commands"
+	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kops/pkg/etcdmanager"
+	"k8s.io/kops/util/pkg/vfs"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	scaleControlPlaneLong = templates.LongDesc(i18n.T(`
+	Change the number of control-plane nodes, and the number of members of each etcd cluster.
+
+	When adding control-plane nodes, new control-plane instance groups are created as copies of an
+	existing one, in the zones with the fewest control-plane nodes (or in the zones given with --zones),
+	and an etcd member is added for each. The cluster is then updated, the control plane is rolled,
+	and the command waits for etcd-manager to report the new number of members before validating the cluster.
+
+	When removing control-plane nodes, etcd-manager is first asked to reduce the number of members of
+	each etcd cluster, and the command waits for the next periodic etcd-manager backup to report the new
+	number of members. Only then are the etcd members removed from the cluster spec and the control-plane
+	instance groups deleted, in reverse name order. The cluster is then updated, the control plane is
+	rolled, and the cluster is validated. --etcd-timeout must be longer than the etcd-manager backup interval.
+
+	Scaling down to fewer than half of the current control-plane nodes (for example from 3 to 1)
+	loses etcd quorum. This is only done with --allow-restore: the command waits for the next periodic
+	etcd-manager backup of each etcd cluster, and restores from that backup once the remaining
+	control-plane nodes are running. Changes made to the cluster after the backup are lost.`))
+
+	scaleControlPlaneExample = templates.Examples(i18n.T(`
+	# Preview adding control-plane nodes to reach three.
+	kops scale control-plane --name k8s-cluster.example.com --replicas 3
+
+	# Add the control-plane nodes.
+	kops scale control-plane --name k8s-cluster.example.com --replicas 3 --yes
+
+	# Place the new control-plane nodes in specific zones.
+	kops scale control-plane --name k8s-cluster.example.com --replicas 5 --zones us-east-1d,us-east-1e --yes`))
+
+	scaleControlPlaneShort = i18n.T(`Change the number of control-plane nodes.`)
+)
+
+type ScaleControlPlaneOptions struct {
+	ClusterName string
+	// Replicas is the target number of control-plane nodes
+	Replicas int
+	// Zones are the zones for new control-plane nodes
+	Zones []string
+	// AllowRestore allows scaling down in a way that loses etcd quorum, restoring etcd from a backup
+	AllowRestore bool
+	// ValidationTimeout is the maximum time to wait for the cluster to validate after each step
+	ValidationTimeout time.Duration
+	// EtcdTimeout is the maximum time to wait for etcd-manager to change the number of members
+	EtcdTimeout time.Duration
+	Yes         bool
+}
+
+func (o *ScaleControlPlaneOptions) InitDefaults() {
+	o.ValidationTimeout = 15 * time.Minute
+	o.EtcdTimeout = 30 * time.Minute
+}
+
+func NewCmdScaleControlPlane(f *util.Factory, out io.Writer) *cobra.Command {
+	options := &ScaleControlPlaneOptions{}
+	options.InitDefaults()
+
+	cmd := &cobra.Command{
+		Use:               "control-plane [CLUSTER]",
+		Short:             scaleControlPlaneShort,
+		Long:              scaleControlPlaneLong,
+		Example:           scaleControlPlaneExample,
+		Args:              rootCommand.clusterNameArgs(&options.ClusterName),
+		ValidArgsFunction: commandutils.CompleteClusterName(f, true, false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunScaleControlPlane(cmd.Context(), f, out, options)
+		},
+	}
+
+	cmd.Flags().IntVar(&options.Replicas, "replicas", options.Replicas, "Number of control-plane nodes; must be odd")
+	cmd.MarkFlagRequired("replicas")
+	cmd.Flags().StringSliceVar(&options.Zones, "zones", options.Zones, "Zones for the new control-plane nodes (defaults to the zones with the fewest control-plane nodes)")
+	cmd.Flags().BoolVar(&options.AllowRestore, "allow-restore", options.AllowRestore, "Allow scaling down in a way that loses etcd quorum, by restoring etcd from a backup")
+	cmd.Flags().DurationVar(&options.ValidationTimeout, "validation-timeout", options.ValidationTimeout, "Maximum time to wait for the cluster to validate")
+	cmd.Flags().DurationVar(&options.EtcdTimeout, "etcd-timeout", options.EtcdTimeout, "Maximum time to wait for etcd-manager to change the number of etcd members")
+	cmd.Flags().BoolVarP(&options.Yes, "yes", "y", options.Yes, "Specify --yes to scale the control plane")
+
+	return cmd
+}
+
+func RunScaleControlPlane(ctx context.Context, f *util.Factory, out io.Writer, options *ScaleControlPlaneOptions) error {
+	clientset, err := f.KopsClient()
+	if err != nil {
+		return err
+	}
+
+	cluster, err := GetCluster(ctx, f, options.ClusterName)
+	if err != nil {
+		return err
+	}
+
+	instanceGroups, err := commands.ReadAllInstanceGroups(ctx, clientset, cluster)
+	if err != nil {
+		return err
+	}
+
+	plan, err := commands.PlanControlPlaneScale(cluster, instanceGroups, options.Replicas, options.Zones)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "Scaling the control plane from %d to %d nodes\n", plan.Current, plan.Replicas)
+	for _, ig := range plan.AddInstanceGroups {
+		fmt.Fprintf(out, "  create instance group %q in subnets %s\n", ig.Name, strings.Join(ig.Spec.Subnets, ","))
+	}
+	for _, ig := range plan.RemoveInstanceGroups {
+		fmt.Fprintf(out, "  delete instance group %q\n", ig.Name)
+	}
+	for _, etcdCluster := range plan.Cluster.Spec.EtcdClusters {
+		var members []string
+		for _, member := range etcdCluster.Members {
+			members = append(members, member.Name)
+		}
+		fmt.Fprintf(out, "  etcd cluster %q members: %s\n", etcdCluster.Name, strings.Join(members, ","))
+	}
+	for _, warning := range plan.Warnings {
+		fmt.Fprintf(out, "Warning: %s\n", warning)
+	}
+	if !plan.ScaleUp() && !plan.KeepsQuorum() {
+		fmt.Fprintf(out, "Warning: removing %d of %d control-plane nodes loses etcd quorum; etcd will be restored from a backup taken first\n", len(plan.RemoveInstanceGroups), plan.Current)
+		if !options.AllowRestore {
+			return fmt.Errorf("scaling from %d to %d control-plane nodes loses etcd quorum; specify --allow-restore to scale through a backup and restore", plan.Current, plan.Replicas)
+		}
+	}
+
+	if !options.Yes {
+		fmt.Fprintf(out, "\nMust specify --yes to scale the control plane\n")
+		return nil
+	}
+
+	stores, err := etcdBackupStores(ctx, f, options.ClusterName, nil)
+	if err != nil {
+		return err
+	}
+	// We learn the number of etcd members from the periodic backups of etcd-manager
+	for _, s := range stores {
+		if backupInterval := etcdmanager.BackupInterval(s.EtcdCluster); options.EtcdTimeout <= backupInterval {
+			return fmt.Errorf("--etcd-timeout must be longer than the backup interval (%s) of etcd cluster %q", backupInterval, s.EtcdCluster.Name)
+		}
+	}
+
+	if plan.ScaleUp() {
+		return scaleUpControlPlane(ctx, f, out, options, plan, instanceGroups, stores)
+	}
+	return scaleDownControlPlane(ctx, f, out, options, plan, instanceGroups, stores)
+}
+
+func scaleUpControlPlane(ctx context.Context, f *util.Factory, out io.Writer, options *ScaleControlPlaneOptions, plan *commands.ControlPlaneScalePlan, instanceGroups []*kops.InstanceGroup, stores []*etcdClusterBackupStore) error {
+	clientset, err := f.KopsClient()
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "Adding control-plane instance groups and etcd members\n")
+	{
+		allInstanceGroups := append(instanceGroups, plan.AddInstanceGroups...)
+		for _, ig := range plan.AddInstanceGroups {
+			if _, err := clientset.InstanceGroupsFor(plan.Cluster).Create(ctx, ig, metav1.CreateOptions{}); err != nil {
+				return fmt.Errorf("error creating instance group %q: %w", ig.Name, err)
+			}
+		}
+		if err := commands.UpdateCluster(ctx, clientset, plan.Cluster, allInstanceGroups); err != nil {
+			// Don't leave control-plane instance groups without etcd members behind
+			for _, ig := range plan.AddInstanceGroups {
+				if err := clientset.InstanceGroupsFor(plan.Cluster).Delete(ctx, ig.Name, metav1.DeleteOptions{}); err != nil {
+					fmt.Fprintf(out, "Warning: error removing instance group %q: %v\n", ig.Name, err)
+				}
+			}
+			return err
+		}
+	}
+
+	startTime := time.Now()
+	if err := scaleControlPlaneUpdateCluster(ctx, f, out, options); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "Waiting for the new control-plane nodes\n")
+	if err := scaleControlPlaneValidate(ctx, f, out, options, true); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "Performing rolling-update for control plane\n")
+	if err := scaleControlPlaneRollingUpdate(ctx, f, out, options, false); err != nil {
+		return err
+	}
+
+	if err := scaleControlPlaneWaitForEtcd(ctx, out, options, stores, startTime); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "Validating cluster\n")
+	if err := scaleControlPlaneValidate(ctx, f, out, options, false); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "\nThe control plane now has %d nodes\n", plan.Replicas)
+	return nil
+}
+
+func scaleDownControlPlane(ctx context.Context, f *util.Factory, out io.Writer, options *ScaleControlPlaneOptions, plan *commands.ControlPlaneScalePlan, instanceGroups []*kops.InstanceGroup, stores []*etcdClusterBackupStore) error {
+	clientset, err := f.KopsClient()
+	if err != nil {
+		return err
+	}
+
+	restore := !plan.KeepsQuorum()
+	if restore {
+		// etcd-manager cannot be asked for a backup, so we wait for its next periodic backup
+		fmt.Fprintf(out, "Waiting for etcd-manager to back up the etcd clusters before losing quorum\n")
+		since := time.Now()
+		for _, s := range stores {
+			backup, err := etcdmanager.WaitForBackup(ctx, s.Store, since, 10*time.Second, options.EtcdTimeout)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "  etcd cluster %q backed up to %s\n", s.EtcdCluster.Name, backup.Name)
+		}
+	}
+
+	startTime := time.Now()
+	if !restore {
+		// The members are removed while their control-plane nodes are still running, so etcd keeps quorum.
+		// kops update cluster would reject control-plane instance groups without etcd members,
+		// so we write the reduced etcd cluster specs for etcd-manager directly.
+		fmt.Fprintf(out, "Removing etcd members\n")
+		for _, s := range stores {
+			spec, err := s.Store.LoadClusterSpec(ctx)
+			if err != nil {
+				return err
+			}
+			if spec == nil {
+				return fmt.Errorf("etcd cluster spec not found in %s", s.Store.Path())
+			}
+			spec.MemberCount = int32(plan.Replicas)
+			if err := s.Store.WriteClusterSpec(ctx, spec); err != nil {
+				return err
+			}
+		}
+		if err := scaleControlPlaneWaitForEtcd(ctx, out, options, stores, startTime); err != nil {
+			return err
+		}
+	}
+
+	if err := commands.UpdateCluster(ctx, clientset, plan.Cluster, plan.RemainingInstanceGroups(instanceGroups)); err != nil {
+		return err
+	}
+
+	for _, ig := range plan.RemoveInstanceGroups {
+		fmt.Fprintf(out, "Deleting control-plane instance group %q\n", ig.Name)
+		opt := &DeleteInstanceGroupOptions{
+			Yes:         true,
+			ClusterName: options.ClusterName,
+			GroupName:   ig.Name,
+		}
+		if err := RunDeleteInstanceGroup(ctx, f, out, opt); err != nil {
+			return err
+		}
+	}
+
+	if err := scaleControlPlaneUpdateCluster(ctx, f, out, options); err != nil {
+		return err
+	}
+
+	if restore {
+		fmt.Fprintf(out, "Restoring etcd clusters from backup\n")
+		var restoreCommands []vfs.Path
+		for _, s := range stores {
+			backup, p, err := etcdmanager.RequestRestore(ctx, s.Store, "latest", time.Now())
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "  requested restore of etcd cluster %q from backup %s\n", s.EtcdCluster.Name, backup.Name)
+			restoreCommands = append(restoreCommands, p)
+		}
+
+		// etcd-manager picks up restore commands when it restarts; the API is not available to validate the cluster
+		fmt.Fprintf(out, "Restarting the remaining control-plane nodes\n")
+		if err := scaleControlPlaneRollingUpdate(ctx, f, out, options, true); err != nil {
+			return err
+		}
+		for i, s := range stores {
+			if err := etcdmanager.WaitForCommand(ctx, s.Store, restoreCommands[i], 10*time.Second, options.EtcdTimeout); err != nil {
+				return err
+			}
+		}
+		if err := scaleControlPlaneWaitForEtcd(ctx, out, options, stores, startTime); err != nil {
+			return err
+		}
+	} else {
+		fmt.Fprintf(out, "Performing rolling-update for control plane\n")
+		if err := scaleControlPlaneRollingUpdate(ctx, f, out, options, false); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(out, "Validating cluster\n")
+	if err := scaleControlPlaneValidate(ctx, f, out, options, false); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "\nThe control plane now has %d nodes\n", plan.Replicas)
+	return nil
+}
+
+// scaleControlPlaneUpdateCluster applies the changes to the cloud, including the etcd cluster specs read by etcd-manager.
+func scaleControlPlaneUpdateCluster(ctx context.Context, f *util.Factory, out io.Writer, options *ScaleControlPlaneOptions) error {
+	fmt.Fprintf(out, "Updating cluster\n")
+	opt := &CoreUpdateClusterOptions{}
+	opt.InitDefaults()
+	opt.ClusterName = options.ClusterName
+	opt.Yes = true
+	_, err := RunCoreUpdateCluster(ctx, f, out, opt)
+	return err
+}
+
+func scaleControlPlaneValidate(ctx context.Context, f *util.Factory, out io.Writer, options *ScaleControlPlaneOptions, controlPlaneOnly bool) error {
+	opt := &ValidateClusterOptions{}
+	opt.InitDefaults()
+	opt.ClusterName = options.ClusterName
+	opt.wait = options.ValidationTimeout
+	if controlPlaneOnly {
+		opt.filterInstanceGroups = func(ig *kops.InstanceGroup) bool {
+			return ig.Spec.Role == kops.InstanceGroupRoleAPIServer || ig.Spec.Role == kops.InstanceGroupRoleControlPlane
+		}
+		opt.filterPodsForValidation = func(pod *v1.Pod) bool {
+			return false
+		}
+	}
+
+	result, err := RunValidateCluster(ctx, f, out, opt)
+	if err != nil {
+		return fmt.Errorf("validating cluster: %w", err)
+	}
+	if len(result.Failures) != 0 {
+		return fmt.Errorf("cluster did not validate within %s", options.ValidationTimeout)
+	}
+	return nil
+}
+
+// scaleControlPlaneRollingUpdate rolls the control-plane nodes; force restarts them even without changes,
+// without validating the cluster.
+func scaleControlPlaneRollingUpdate(ctx context.Context, f *util.Factory, out io.Writer, options *ScaleControlPlaneOptions, force bool) error {
+	opt := &RollingUpdateOptions{}
+	opt.InitDefaults()
+	opt.ClusterName = options.ClusterName
+	opt.InstanceGroupRoles = []string{
+		string(kops.InstanceGroupRoleAPIServer),
+		string(kops.InstanceGroupRoleControlPlane),
+	}
+	opt.ValidationTimeout = options.ValidationTimeout
+	opt.Yes = true
+	if force {
+		opt.Force = true
+		opt.CloudOnly = true
+	}
+	return RunRollingUpdateCluster(ctx, f, out, opt)
+}
+
+// scaleControlPlaneWaitForEtcd waits for etcd-manager to report the new number of members of every etcd cluster.
+func scaleControlPlaneWaitForEtcd(ctx context.Context, out io.Writer, options *ScaleControlPlaneOptions, stores []*etcdClusterBackupStore, since time.Time) error {
+	fmt.Fprintf(out, "Waiting for etcd-manager to change the number of etcd members\n")
+	for _, s := range stores {
+		backup, err := etcdmanager.WaitForMemberCount(ctx, s.Store, int32(options.Replicas), since, 30*time.Second, options.EtcdTimeout)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "  etcd cluster %q has %d members (backup %s)\n", s.EtcdCluster.Name, options.Replicas, backup.Name)
+	}
+	return nil
+}
@@ -0,0 +1,227 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kops/cmd/kops/util"
+	"k8s.io/kops/pkg/assets"
+	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kops/upup/pkg/fi/cloudup"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	toolboxBundleCreateLong = templates.LongDesc(i18n.T(`
+	Download every image and file asset used by a cluster into a single tar archive,
+	for installing clusters that cannot reach the internet.
+
+	Images are stored as an OCI image layout, and files with their hashes. The archive
+	holds a SHA256SUMS file listing the checksum of every file in it, which is verified by
+	kops toolbox bundle push.
+
+	The SHA-256 of the archive is printed once it is written. Record it separately from
+	the archive: kops toolbox bundle push requires it, so that a bundle that was replaced
+	in transit, along with its SHA256SUMS file, is rejected.`))
+
+	toolboxBundleCreateExample = templates.Examples(i18n.T(`
+	# Create a bundle of the assets of a cluster
+	kops toolbox bundle create --name k8s-cluster.example.com --out kops-bundle.tar
+	`))
+
+	toolboxBundleCreateShort = i18n.T(`Create a bundle of the assets of a cluster`)
+
+	toolboxBundlePushLong = templates.LongDesc(i18n.T(`
+	Verify the SHA-256 and the checksums of a bundle created by kops toolbox bundle create,
+	and push its images to a container registry and its files to a file repository.
+
+	The SHA-256 of the archive, printed by kops toolbox bundle create, is required.
+
+	By default, the assets are pushed to the locations configured in the cluster spec
+	when the bundle was created. The registry and the file repository are mapped as
+	spec.assets.containerRegistry and spec.assets.fileRepository are.`))
+
+	toolboxBundlePushExample = templates.Examples(i18n.T(`
+	# Push the assets of a bundle
+	kops toolbox bundle push kops-bundle.tar --sha256 SHA256_OF_THE_BUNDLE \
+		--registry registry.example.com --file-repository s3://example-assets
+	`))
+
+	toolboxBundlePushShort = i18n.T(`Push the assets of a bundle`)
+)
+
+// ToolboxBundleCreateOptions holds the options of kops toolbox bundle create.
+type ToolboxBundleCreateOptions struct {
+	ClusterName string
+	// Out is the path of the archive.
+	Out string
+	// WorkDir is where the archive is staged; a temporary directory by default.
+	WorkDir string
+}
+
+// ToolboxBundlePushOptions holds the options of kops toolbox bundle push.
+type ToolboxBundlePushOptions struct {
+	assets.PushBundleOptions
+	// WorkDir is where the archive is extracted; a temporary directory by default.
+	WorkDir string
+}
+
+func NewCmdToolboxBundle(f *util.Factory, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bundle",
+		Short: i18n.T(`Create and push bundles of assets for air-gapped installs`),
+	}
+
+	cmd.AddCommand(NewCmdToolboxBundleCreate(f, out))
+	cmd.AddCommand(NewCmdToolboxBundlePush(f, out))
+
+	return cmd
+}
+
+func NewCmdToolboxBundleCreate(f *util.Factory, out io.Writer) *cobra.Command {
+	options := &ToolboxBundleCreateOptions{}
+
+	cmd := &cobra.Command{
+		Use:               "create [CLUSTER]",
+		Short:             toolboxBundleCreateShort,
+		Long:              toolboxBundleCreateLong,
+		Example:           toolboxBundleCreateExample,
+		Args:              rootCommand.clusterNameArgs(&options.ClusterName),
+		ValidArgsFunction: commandutils.CompleteClusterName(f, true, false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunToolboxBundleCreate(cmd.Context(), f, out, options)
+		},
+	}
+
+	cmd.Flags().StringVar(&options.Out, "out", options.Out, "Path of the bundle to write")
+	cmd.Flags().StringVar(&options.WorkDir, "work-dir", options.WorkDir, "Directory to stage the bundle in (default: a temporary directory)")
+	cmd.MarkFlagRequired("out")
+
+	return cmd
+}
+
+func NewCmdToolboxBundlePush(f *util.Factory, out io.Writer) *cobra.Command {
+	options := &ToolboxBundlePushOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "push BUNDLE",
+		Short:   toolboxBundlePushShort,
+		Long:    toolboxBundlePushLong,
+		Example: toolboxBundlePushExample,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunToolboxBundlePush(cmd.Context(), f, out, options, args[0])
+		},
+	}
+
+	cmd.Flags().StringVar(&options.ContainerRegistry, "registry", options.ContainerRegistry, "Container registry to push the images to")
+	cmd.Flags().StringVar(&options.FileRepository, "file-repository", options.FileRepository, "File repository to push the files to")
+	cmd.Flags().StringVar(&options.SHA256, "sha256", options.SHA256, "SHA-256 of the bundle, as printed by kops toolbox bundle create")
+	cmd.Flags().StringVar(&options.WorkDir, "work-dir", options.WorkDir, "Directory to extract the bundle in (default: a temporary directory)")
+	cmd.MarkFlagRequired("sha256")
+
+	return cmd
+}
+
+func RunToolboxBundleCreate(ctx context.Context, f *util.Factory, out io.Writer, options *ToolboxBundleCreateOptions) error {
+	updateClusterResults, err := RunUpdateCluster(ctx, f, out, &UpdateClusterOptions{
+		CoreUpdateClusterOptions: CoreUpdateClusterOptions{
+			Target:      cloudup.TargetDryRun,
+			GetAssets:   true,
+			ClusterName: options.ClusterName,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	workDir, cleanup, err := bundleWorkDir(options.WorkDir)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	w, err := os.Create(options.Out)
+	if err != nil {
+		return fmt.Errorf("error creating %q: %w", options.Out, err)
+	}
+	defer w.Close()
+
+	manifest, err := assets.CreateBundle(ctx, f.VFSContext(), updateClusterResults.ImageAssets, updateClusterResults.FileAssets, workDir, w)
+	if err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("error writing %q: %w", options.Out, err)
+	}
+
+	fmt.Fprintf(out, "Wrote %d images and %d files to %s\n", len(manifest.Images), len(manifest.Files), options.Out)
+	fmt.Fprintf(out, "SHA-256: %s\n", manifest.ArchiveSHA256)
+	return nil
+}
+
+func RunToolboxBundlePush(ctx context.Context, f *util.Factory, out io.Writer, options *ToolboxBundlePushOptions, bundle string) error {
+	r, err := os.Open(bundle)
+	if err != nil {
+		return fmt.Errorf("error opening bundle: %w", err)
+	}
+	defer r.Close()
+
+	workDir, cleanup, err := bundleWorkDir(options.WorkDir)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	manifest, err := assets.PushBundle(ctx, f.VFSContext(), r, workDir, &options.PushBundleOptions)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "Pushed %d images and %d files from %s\n", len(manifest.Images), len(manifest.Files), bundle)
+	return nil
+}
+
+// bundleWorkDir returns the directory to stage a bundle in, which must be empty, and a function to clean it up.
+func bundleWorkDir(workDir string) (string, func(), error) {
+	if workDir == "" {
+		dir, err := os.MkdirTemp("", "kops-bundle")
+		if err != nil {
+			return "", nil, fmt.Errorf("error creating temporary directory: %w", err)
+		}
+		return dir, func() { os.RemoveAll(dir) }, nil
+	}
+
+	entries, err := os.ReadDir(workDir)
+	if err != nil && !os.IsNotExist(err) {
+		return "", nil, fmt.Errorf("error reading %q: %w", workDir, err)
+	}
+	if len(entries) != 0 {
+		return "", nil, fmt.Errorf("work directory %q is not empty", workDir)
+	}
+	if err := os.MkdirAll(workDir, 0o755); err != nil {
+		return "", nil, err
+	}
+	// A directory given by the user is kept, so it can be inspected
+	return workDir, func() {}, nil
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/kops/pkg/commands"
+	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kops/pkg/kubeconfig"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	toolboxGossipStatusLong = templates.LongDesc(i18n.T(`
+	Display the gossip membership and the replicated DNS records of a gossip cluster.
+
+	The status is read from protokube on every control plane node, through the API server proxy.
+	The command reports the nodes that see different sets of members (split-brain), the members
+	that have not been seen alive recently, and the records that are missing or outdated on some nodes,
+	and fails if it found any.`))
+
+	toolboxGossipStatusExample = templates.Examples(i18n.T(`
+	# Display the gossip status of a cluster
+	kops toolbox gossip-status --name k8s-cluster.k8s.local
+
+	# Display the full status of each node
+	kops toolbox gossip-status --name k8s-cluster.k8s.local -o json
+	`))
+
+	toolboxGossipStatusShort = i18n.T(`Display the gossip status of a cluster`)
+)
+
+type ToolboxGossipStatusOptions struct {
+	commands.ToolboxGossipStatusOptions
+	kubeconfig.CreateKubecfgOptions
+
+	ClusterName string
+}
+
+func NewCmdToolboxGossipStatus(f commandutils.Factory, out io.Writer) *cobra.Command {
+	options := &ToolboxGossipStatusOptions{}
+	options.Output = "table"
+	options.StaleAfter = 2 * time.Minute
+
+	cmd := &cobra.Command{
+		Use:               "gossip-status [CLUSTER]",
+		Short:             toolboxGossipStatusShort,
+		Long:              toolboxGossipStatusLong,
+		Example:           toolboxGossipStatusExample,
+		Args:              rootCommand.clusterNameArgs(&options.ClusterName),
+		ValidArgsFunction: commandutils.CompleteClusterName(f, true, false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunToolboxGossipStatus(cmd.Context(), f, out, options)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.Output, "output", "o", options.Output, "Output format. One of table or json")
+	cmd.RegisterFlagCompletionFunc("output", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"table", "json"}, cobra.ShellCompDirectiveNoFileComp
+	})
+	cmd.Flags().DurationVar(&options.StaleAfter, "stale-after", options.StaleAfter, "Report the members not seen alive for longer than this duration")
+	options.CreateKubecfgOptions.AddCommonFlags(cmd.Flags())
+
+	return cmd
+}
+
+func RunToolboxGossipStatus(ctx context.Context, f commandutils.Factory, out io.Writer, options *ToolboxGossipStatusOptions) error {
+	clientset, err := f.KopsClient()
+	if err != nil {
+		return err
+	}
+
+	cluster, err := clientset.GetCluster(ctx, options.ClusterName)
+	if err != nil {
+		return err
+	}
+	if cluster == nil {
+		return fmt.Errorf("cluster not found %q", options.ClusterName)
+	}
+	if !cluster.UsesLegacyGossip() {
+		return fmt.Errorf("cluster %q does not use gossip DNS", cluster.Name)
+	}
+
+	restConfig, err := f.RESTConfig(ctx, cluster, options.CreateKubecfgOptions)
+	if err != nil {
+		return err
+	}
+	k8sClient, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return fmt.Errorf("building kubernetes client: %w", err)
+	}
+
+	return commands.RunToolboxGossipStatus(ctx, out, k8sClient, &options.ToolboxGossipStatusOptions)
+}
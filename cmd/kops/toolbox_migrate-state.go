@@ -0,0 +1,138 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kops/cmd/kops/util"
+	"k8s.io/kops/pkg/commands"
+	"k8s.io/kops/util/pkg/vfs"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	toolboxMigrateStateLong = templates.LongDesc(i18n.T(`
+	Copy clusters from one state store to another.
+
+	The cluster, its instance groups, addons, keysets, secrets and SSH public keys are
+	read and written through the kOps API rather than copied as files, and are verified
+	after they have been written. ConfigStore paths that point into the old state store
+	are rewritten to point into the new one.
+
+	The configuration history and the etcd backups of each cluster are copied as files,
+	and etcd backup stores that point into the old state store are rewritten as well.
+
+	The old state store is not modified. After migrating, run kops update cluster against
+	the new state store so that the nodes are configured to read from it.
+
+	Both state stores use the S3_* environment variables by default. To migrate between
+	S3 endpoints or accounts, configure either side with --from-s3-endpoint, --from-s3-region
+	and --from-aws-profile, or the --to- equivalents; the credentials for a custom endpoint are
+	read from FROM_S3_ACCESS_KEY_ID and FROM_S3_SECRET_ACCESS_KEY, or TO_S3_ACCESS_KEY_ID and
+	TO_S3_SECRET_ACCESS_KEY.`))
+
+	toolboxMigrateStateExample = templates.Examples(i18n.T(`
+	# Show what would be copied
+	kops toolbox migrate-state --from s3://old-state-store --to gs://new-state-store
+
+	# Copy a single cluster
+	kops toolbox migrate-state --from s3://old-state-store --to s3://new-state-store --name k8s-cluster.example.com --yes
+
+	# Copy from a custom S3 endpoint to AWS S3
+	export FROM_S3_ACCESS_KEY_ID=... FROM_S3_SECRET_ACCESS_KEY=...
+	kops toolbox migrate-state --from s3://old-state-store --from-s3-endpoint https://minio.example.com \
+	  --to s3://new-state-store --to-aws-profile production --yes
+	`))
+
+	toolboxMigrateStateShort = i18n.T(`Copy clusters between state stores`)
+)
+
+// migrateStateS3Options configures how one side of the migration reaches S3.
+type migrateStateS3Options struct {
+	Endpoint string
+	Region   string
+	Profile  string
+}
+
+// vfsContext returns the VFS context for one side of the migration.
+// If none of the S3 flags are set, the S3_* environment variables are used as usual;
+// otherwise they are ignored, and the credentials for a custom endpoint are read from the variables with the given prefix.
+func (o *migrateStateS3Options) vfsContext(envPrefix string) *vfs.VFSContext {
+	if o.Endpoint == "" && o.Region == "" && o.Profile == "" {
+		return vfs.Context
+	}
+	return vfs.Context.WithS3Config(&vfs.S3Config{
+		Endpoint:        o.Endpoint,
+		Region:          o.Region,
+		AccessKeyID:     os.Getenv(envPrefix + "S3_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv(envPrefix + "S3_SECRET_ACCESS_KEY"),
+		Profile:         o.Profile,
+	})
+}
+
+func NewCmdToolboxMigrateState(out io.Writer) *cobra.Command {
+	options := &commands.ToolboxMigrateStateOptions{}
+	var fromS3, toS3 migrateStateS3Options
+
+	cmd := &cobra.Command{
+		Use:     "migrate-state",
+		Short:   toolboxMigrateStateShort,
+		Long:    toolboxMigrateStateLong,
+		Example: toolboxMigrateStateExample,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Only an explicit --name limits the migration; we don't fall back to the kubectl context
+			if rootCommand.clusterName != "" {
+				options.ClusterNames = []string{rootCommand.clusterName}
+			}
+			return RunToolboxMigrateState(cmd.Context(), out, fromS3.vfsContext("FROM_"), toS3.vfsContext("TO_"), options)
+		},
+	}
+
+	cmd.Flags().StringVar(&options.From, "from", options.From, "State store to copy from")
+	cmd.Flags().StringVar(&options.To, "to", options.To, "State store to copy to")
+	cmd.Flags().StringVar(&fromS3.Endpoint, "from-s3-endpoint", fromS3.Endpoint, "Custom S3 endpoint of the state store to copy from")
+	cmd.Flags().StringVar(&fromS3.Region, "from-s3-region", fromS3.Region, "Region of the custom S3 endpoint of the state store to copy from")
+	cmd.Flags().StringVar(&fromS3.Profile, "from-aws-profile", fromS3.Profile, "AWS profile with the credentials for the state store to copy from")
+	cmd.Flags().StringVar(&toS3.Endpoint, "to-s3-endpoint", toS3.Endpoint, "Custom S3 endpoint of the state store to copy to")
+	cmd.Flags().StringVar(&toS3.Region, "to-s3-region", toS3.Region, "Region of the custom S3 endpoint of the state store to copy to")
+	cmd.Flags().StringVar(&toS3.Profile, "to-aws-profile", toS3.Profile, "AWS profile with the credentials for the state store to copy to")
+	cmd.Flags().BoolVarP(&options.Yes, "yes", "y", options.Yes, "Specify --yes to copy the state")
+	cmd.MarkFlagRequired("from")
+	cmd.MarkFlagRequired("to")
+
+	return cmd
+}
+
+func RunToolboxMigrateState(ctx context.Context, out io.Writer, fromVFSContext, toVFSContext *vfs.VFSContext, options *commands.ToolboxMigrateStateOptions) error {
+	from, err := util.NewFactory(&util.FactoryOptions{RegistryPath: options.From, VFSContext: fromVFSContext}).KopsClient()
+	if err != nil {
+		return fmt.Errorf("error opening state store %q: %w", options.From, err)
+	}
+	to, err := util.NewFactory(&util.FactoryOptions{RegistryPath: options.To, VFSContext: toVFSContext}).KopsClient()
+	if err != nil {
+		return fmt.Errorf("error opening state store %q: %w", options.To, err)
+	}
+
+	return commands.RunToolboxMigrateState(ctx, out, from, to, options)
+}
@@ -23,6 +23,7 @@ import (
 	"net/url"
 	"strings"
 	"sync"
+	"time"
 
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
@@ -32,6 +33,7 @@ import (
 	"k8s.io/klog/v2"
 
 	gceacls "k8s.io/kops/pkg/acls/gce"
+	webdavacls "k8s.io/kops/pkg/acls/webdav"
 	"k8s.io/kops/pkg/apis/kops"
 	kopsclient "k8s.io/kops/pkg/client/clientset_generated/clientset"
 	"k8s.io/kops/pkg/client/simple"
@@ -44,6 +46,18 @@ import (
 
 type FactoryOptions struct {
 	RegistryPath string
+
+	// StateCacheDir enables the on-disk cache of the state store, if set
+	StateCacheDir string
+	// StateCacheTTL is how long cached files are used without revalidating them
+	StateCacheTTL time.Duration
+	// StateCacheTreeTTL is how long cached directory listings are used
+	StateCacheTreeTTL time.Duration
+	// StateCacheSecrets allows the cache to store private keys and secrets on disk
+	StateCacheSecrets bool
+
+	// VFSContext is used to reach the state store; the global vfs.Context is used if nil
+	VFSContext *vfs.VFSContext
 }
 
 type Factory struct {
@@ -71,14 +85,16 @@ type clusterInfo struct {
 
 func NewFactory(options *FactoryOptions) *Factory {
 	gceacls.Register()
+	webdavacls.Register()
 
 	if options == nil {
 		options = &FactoryOptions{}
 	}
 
 	return &Factory{
-		options:  options,
-		clusters: make(map[string]*clusterInfo),
+		options:    options,
+		vfsContext: options.VFSContext,
+		clusters:   make(map[string]*clusterInfo),
 	}
 }
 
@@ -145,7 +161,13 @@ func (f *Factory) KopsClient() (simple.Clientset, error) {
 				return nil, field.Invalid(field.NewPath("State Store"), registryPath, INVALID_STATE_ERROR)
 			}
 
-			f.clientset = vfsclientset.NewVFSClientset(f.VFSContext(), basePath)
+			if f.options.StateCacheDir != "" {
+				klog.V(2).Infof("caching state store in %s", f.options.StateCacheDir)
+				cache := vfs.NewDiskCache(f.options.StateCacheDir, f.options.StateCacheTTL, f.options.StateCacheTreeTTL, f.options.StateCacheSecrets)
+				f.clientset = vfsclientset.NewCachedVFSClientset(f.VFSContext(), basePath, cache)
+			} else {
+				f.clientset = vfsclientset.NewVFSClientset(f.VFSContext(), basePath)
+			}
 		}
 		if strings.HasPrefix(registryPath, "file://") {
 			klog.Warning("The local filesystem state store is not functional for running clusters")
@@ -17,17 +17,20 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
 	"os"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/spf13/pflag"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
 	_ "k8s.io/component-base/metrics/prometheus/restclient" // for client metric registration
 	"k8s.io/klog/v2"
 
@@ -35,9 +38,11 @@ import (
 	"k8s.io/kops/dns-controller/pkg/watchers"
 	"k8s.io/kops/dnsprovider/pkg/dnsprovider"
 	"k8s.io/kops/dnsprovider/pkg/dnsprovider/providers/aws/route53"
+	_ "k8s.io/kops/dnsprovider/pkg/dnsprovider/providers/cloudflare"
 	_ "k8s.io/kops/dnsprovider/pkg/dnsprovider/providers/do"
 	_ "k8s.io/kops/dnsprovider/pkg/dnsprovider/providers/google/clouddns"
 	_ "k8s.io/kops/dnsprovider/pkg/dnsprovider/providers/openstack/designate"
+	_ "k8s.io/kops/dnsprovider/pkg/dnsprovider/providers/rfc2136"
 	_ "k8s.io/kops/dnsprovider/pkg/dnsprovider/providers/scaleway"
 	"k8s.io/kops/pkg/wellknownports"
 	"k8s.io/kops/protokube/pkg/gossip"
@@ -45,6 +50,7 @@ import (
 	gossipdnsprovider "k8s.io/kops/protokube/pkg/gossip/dns/provider"
 	_ "k8s.io/kops/protokube/pkg/gossip/memberlist"
 	_ "k8s.io/kops/protokube/pkg/gossip/mesh"
+	gatewayclient "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned"
 )
 
 var (
@@ -57,7 +63,12 @@ func main() {
 	var dnsServer, dnsProviderID, gossipListen, gossipSecret, watchNamespace, metricsListen, gossipProtocol, gossipSecretSecondary, gossipListenSecondary, gossipProtocolSecondary string
 	var gossipSeeds, gossipSeedsSecondary, zones []string
 	var internalIpv4, internalIpv6 bool
-	var watchIngress bool
+	var watchIngress, watchGateway bool
+	var txtOwnerID, txtPrefix, clusterName string
+	var txtAdoptUnowned bool
+	var dryRun bool
+	var kubeconfig string
+	var planTimeout time.Duration
 	var updateInterval int
 
 	// Be sure to get the glog flags
@@ -66,9 +77,10 @@ func main() {
 
 	flag.StringVar(&dnsServer, "dns-server", "", "DNS Server")
 	flags.BoolVar(&watchIngress, "watch-ingress", true, "Configure hostnames found in ingress resources")
+	flags.BoolVar(&watchGateway, "watch-gateway", false, "Configure hostnames found in Gateway API gateway and httproute resources")
 	flags.StringSliceVar(&gossipSeeds, "gossip-seed", gossipSeeds, "If set, will enable gossip zones and seed using the provided addresses")
 	flags.StringSliceVarP(&zones, "zone", "z", []string{}, "Configure permitted zones and their mappings")
-	flags.StringVar(&dnsProviderID, "dns", "aws-route53", "DNS provider we should use (aws-route53, google-clouddns, digitalocean, gossip, openstack-designate, scaleway)")
+	flags.StringVar(&dnsProviderID, "dns", "aws-route53", "DNS provider we should use (aws-route53, google-clouddns, digitalocean, gossip, openstack-designate, scaleway, cloudflare, rfc2136)")
 	flag.StringVar(&gossipProtocol, "gossip-protocol", "mesh", "mesh/memberlist")
 	flags.StringVar(&gossipListen, "gossip-listen", fmt.Sprintf("0.0.0.0:%d", wellknownports.DNSControllerGossipWeaveMesh), "The address on which to listen if gossip is enabled")
 	flags.StringVar(&gossipSecret, "gossip-secret", gossipSecret, "Secret to use to secure gossip")
@@ -78,10 +90,17 @@ func main() {
 	flags.StringSliceVar(&gossipSeedsSecondary, "gossip-seed-secondary", gossipSeedsSecondary, "If set, will enable gossip zones and seed using the provided addresses")
 	flags.BoolVar(&internalIpv4, "internal-ipv4", internalIpv4, "Internal network has IPv4")
 	flags.BoolVar(&internalIpv6, "internal-ipv6", internalIpv6, "Internal network has IPv6")
-	flags.StringVar(&watchNamespace, "watch-namespace", "", "Limits the functionality for pods, services and ingress to specific namespace, by default all")
+	flags.StringVar(&watchNamespace, "watch-namespace", "", "Limits the functionality for pods, services, ingress and gateways to specific namespace, by default all")
 	flag.IntVar(&route53.MaxBatchSize, "route53-batch-size", route53.MaxBatchSize, "Maximum number of operations performed per changeset batch")
 	flag.StringVar(&metricsListen, "metrics-listen", "", "The address on which to listen for Prometheus metrics.")
 	flags.IntVar(&updateInterval, "update-interval", 5, "Configure interval at which to update DNS records.")
+	flags.StringVar(&txtOwnerID, "txt-owner-id", "", "If set, records the ownership of DNS records in TXT records with this owner ID, and doesn't modify records of other owners")
+	flags.StringVar(&txtPrefix, "txt-prefix", dns.DefaultOwnershipPrefix, "Prefix of the names of the TXT ownership records")
+	flags.BoolVar(&txtAdoptUnowned, "txt-adopt-unowned", false, "Take ownership of existing DNS records without TXT ownership record")
+	flags.StringVar(&clusterName, "cluster-name", "", "Name of the cluster, recorded in the TXT ownership records")
+	flags.BoolVar(&dryRun, "dry-run", false, "Log the DNS changes instead of applying them")
+	flags.StringVar(&kubeconfig, "kubeconfig", "", "Path to a kubeconfig file, by default the in-cluster configuration is used")
+	flags.DurationVar(&planTimeout, "plan-timeout", 5*time.Minute, "Maximum time to wait for the watchers to be ready in plan mode")
 
 	// Trick to avoid 'logging before flag.Parse' warning
 	flag.CommandLine.Parse([]string{})
@@ -90,6 +109,16 @@ func main() {
 	flags.AddGoFlagSet(flag.CommandLine)
 	flags.Parse(os.Args)
 
+	// "dns-controller plan" prints the DNS changes once, without applying them
+	plan := false
+	if args := flags.Args(); len(args) > 1 {
+		if len(args) != 2 || args[1] != "plan" {
+			klog.Errorf("unexpected arguments %q, the only command is \"plan\"", args[1:])
+			os.Exit(1)
+		}
+		plan = true
+	}
+
 	var internalRecordTypes []dns.RecordType
 	if internalIpv4 {
 		internalRecordTypes = append(internalRecordTypes, dns.RecordTypeA)
@@ -115,7 +144,12 @@ func main() {
 		os.Exit(1)
 	}
 
-	config, err := rest.InClusterConfig()
+	var config *rest.Config
+	if kubeconfig != "" {
+		config, err = clientcmd.BuildConfigFromFlags("", kubeconfig)
+	} else {
+		config, err = rest.InClusterConfig()
+	}
 	if err != nil {
 		klog.Errorf("error building client configuration: %v", err)
 		os.Exit(1)
@@ -126,6 +160,11 @@ func main() {
 		klog.Fatalf("error building REST client: %v", err)
 	}
 
+	gatewayClient, err := gatewayclient.NewForConfig(config)
+	if err != nil {
+		klog.Fatalf("error building gateway REST client: %v", err)
+	}
+
 	var dnsProviders []dnsprovider.Interface
 	if dnsProviderID != "gossip" {
 		var file io.Reader
@@ -195,24 +234,50 @@ func main() {
 		dnsProviders = append(dnsProviders, dnsProvider)
 	}
 
-	dnsController, err := dns.NewDNSController(dnsProviders, zoneRules, updateInterval)
+	var registry *dns.OwnershipRegistry
+	if txtOwnerID != "" {
+		registry = &dns.OwnershipRegistry{
+			OwnerID:      txtOwnerID,
+			ClusterName:  clusterName,
+			Prefix:       txtPrefix,
+			AdoptUnowned: txtAdoptUnowned,
+		}
+	}
+
+	dnsController, err := dns.NewDNSController(dnsProviders, zoneRules, registry, updateInterval, dryRun || plan)
 	if err != nil {
 		klog.Errorf("Error building DNS controller: %v", err)
 		os.Exit(1)
 	}
 
 	// @step: initialize the watchers
-	if err := initializeWatchers(client, dnsController, watchNamespace, watchIngress, internalRecordTypes); err != nil {
+	if err := initializeWatchers(client, gatewayClient, dnsController, watchNamespace, watchIngress, watchGateway, internalRecordTypes); err != nil {
 		klog.Errorf("%s", err)
 		os.Exit(1)
 	}
 
+	if plan {
+		ctx, cancel := context.WithTimeout(context.Background(), planTimeout)
+		defer cancel()
+
+		changes, err := dnsController.Plan(ctx)
+		if err != nil {
+			klog.Errorf("error planning DNS changes: %v", err)
+			os.Exit(1)
+		}
+		if err := changes.Write(os.Stdout); err != nil {
+			klog.Errorf("error writing DNS changes: %v", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	// start and wait on the dns controller
 	dnsController.Run()
 }
 
 // initializeWatchers is responsible for creating the watchers
-func initializeWatchers(client kubernetes.Interface, dnsctl *dns.DNSController, namespace string, watchIngress bool, internalRecordTypes []dns.RecordType) error {
+func initializeWatchers(client kubernetes.Interface, gatewayClient gatewayclient.Interface, dnsctl *dns.DNSController, namespace string, watchIngress bool, watchGateway bool, internalRecordTypes []dns.RecordType) error {
 	klog.V(1).Infof("initializing the watch controllers, namespace: %q", namespace)
 
 	nodeController, err := watchers.NewNodeController(client, dnsctl, internalRecordTypes)
@@ -240,6 +305,16 @@ func initializeWatchers(client kubernetes.Interface, dnsctl *dns.DNSController,
 		klog.Infof("Ingress controller disabled")
 	}
 
+	var gatewayController *watchers.GatewayController
+	if watchGateway {
+		gatewayController, err = watchers.NewGatewayController(gatewayClient, dnsctl, namespace)
+		if err != nil {
+			return fmt.Errorf("failed to initialize the gateway controller, error: %v", err)
+		}
+	} else {
+		klog.Infof("Gateway controller disabled")
+	}
+
 	go nodeController.Run()
 	go podController.Run()
 	go serviceController.Run()
@@ -248,5 +323,9 @@ func initializeWatchers(client kubernetes.Interface, dnsctl *dns.DNSController,
 		go ingressController.Run()
 	}
 
+	if watchGateway {
+		go gatewayController.Run()
+	}
+
 	return nil
 }
@@ -47,6 +47,12 @@ type DNSController struct {
 
 	dnsCache *dnsCache
 
+	// registry records the ownership of the records, if set
+	registry *OwnershipRegistry
+
+	// dryRun logs the changes instead of applying them
+	dryRun bool
+
 	// mutex protects the following mutable state
 	mutex sync.Mutex
 	// scopes is a map for each top-level grouping
@@ -87,8 +93,9 @@ type DNSControllerScope struct {
 // DNSControllerScope is a Scope
 var _ Scope = &DNSControllerScope{}
 
-// NewDNSController creates a DnsController
-func NewDNSController(dnsProviders []dnsprovider.Interface, zoneRules *ZoneRules, updateInterval int) (*DNSController, error) {
+// NewDNSController creates a DnsController.
+// If registry is nil, the controller assumes it owns every record matching the desired records.
+func NewDNSController(dnsProviders []dnsprovider.Interface, zoneRules *ZoneRules, registry *OwnershipRegistry, updateInterval int, dryRun bool) (*DNSController, error) {
 	dnsCache, err := newDNSCache(dnsProviders)
 	if err != nil {
 		return nil, fmt.Errorf("error initializing DNS cache: %v", err)
@@ -98,6 +105,8 @@ func NewDNSController(dnsProviders []dnsprovider.Interface, zoneRules *ZoneRules
 		scopes:         make(map[string]*DNSControllerScope),
 		zoneRules:      zoneRules,
 		dnsCache:       dnsCache,
+		registry:       registry,
+		dryRun:         dryRun,
 		updateInterval: time.Duration(updateInterval) * time.Second,
 	}
 
@@ -147,7 +156,8 @@ type snapshot struct {
 	records      []Record
 	aliasTargets map[string][]Record
 
-	recordValues map[recordKey][]string
+	recordValues   map[recordKey][]string
+	recordPolicies map[recordKey]dnsprovider.RoutingPolicy
 }
 
 func (c *DNSController) snapshotIfChangedAndReady() *snapshot {
@@ -165,19 +175,14 @@ func (c *DNSController) snapshotIfChangedAndReady() *snapshot {
 		return nil
 	}
 
-	recordCount := 0
+	var records []Record
 	for _, scope := range c.scopes {
+		scope.mutex.Lock()
 		if !scope.Ready {
+			scope.mutex.Unlock()
 			klog.Infof("scope not yet ready: %s", scope.ScopeName)
 			return nil
 		}
-		for _, scopeRecords := range scope.Records {
-			recordCount += len(scopeRecords)
-		}
-	}
-
-	records := make([]Record, 0, recordCount)
-	for _, scope := range c.scopes {
 		for _, scopeRecords := range scope.Records {
 			for i := range scopeRecords {
 				r := &scopeRecords[i]
@@ -188,6 +193,7 @@ func (c *DNSController) snapshotIfChangedAndReady() *snapshot {
 				}
 			}
 		}
+		scope.mutex.Unlock()
 	}
 
 	s.records = records
@@ -199,6 +205,8 @@ func (c *DNSController) snapshotIfChangedAndReady() *snapshot {
 type recordKey struct {
 	RecordType RecordType
 	FQDN       string
+	// SetIdentifier distinguishes the record sets with the same name and type but a routing policy
+	SetIdentifier string
 }
 
 func (c *DNSController) runOnce() error {
@@ -210,10 +218,93 @@ func (c *DNSController) runOnce() error {
 		return nil
 	}
 
+	op, errors, err := c.buildChanges(snapshot, c.dryRun)
+	if err != nil {
+		return err
+	}
+
+	for key, changeset := range op.changesets {
+		if changeset.IsEmpty() {
+			continue
+		}
+
+		if recording, ok := changeset.(*recordingChangeset); ok {
+			for _, change := range recording.changes {
+				klog.Infof("Dry run: would %s", change.String())
+			}
+			continue
+		}
+
+		klog.V(2).Infof("Applying DNS changeset for zone %s", key)
+		if err := changeset.Apply(ctx); err != nil {
+			klog.Warningf("error applying DNS changeset for zone %s: %v", key, err)
+			errors = append(errors, fmt.Errorf("error applying DNS changeset for zone %s: %v", key, err))
+		}
+	}
+
+	if len(errors) != 0 {
+		return errors[0]
+	}
+
+	// Success!  Store the snapshot as our new baseline
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.lastSuccessfulSnapshot = snapshot
+	return nil
+}
+
+// Plan waits until all the scopes are ready, and returns the changes that would be applied to the DNS zones.
+// As the controller has not applied any snapshot yet, no record sets are deleted.
+func (c *DNSController) Plan(ctx context.Context) (*Plan, error) {
+	var snapshot *snapshot
+	for {
+		snapshot = c.snapshotIfChangedAndReady()
+		if snapshot != nil {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("timed out waiting for the scopes to be ready: %w", ctx.Err())
+		case <-time.After(time.Second):
+		}
+	}
+
+	op, errors, err := c.buildChanges(snapshot, true)
+	if err != nil {
+		return nil, err
+	}
+	if len(errors) != 0 {
+		return nil, errors[0]
+	}
+
+	plan := &Plan{}
+	for _, changeset := range op.changesets {
+		plan.Changes = append(plan.Changes, changeset.(*recordingChangeset).changes...)
+	}
+	return plan, nil
+}
+
+// buildChanges computes the changes from the last applied snapshot to the given snapshot.
+// It returns the errors for individual records separately, so that one bad apple doesn't block every other request.
+// If dryRun is set, the changes are recorded instead of being applied.
+func (c *DNSController) buildChanges(snapshot *snapshot, dryRun bool) (*dnsOp, []error, error) {
 	newValueMap := make(map[recordKey][]string)
+	newPolicyMap := make(map[recordKey]dnsprovider.RoutingPolicy)
 	{
+		addPolicy := func(key recordKey, r *Record) {
+			if key.SetIdentifier == "" {
+				return
+			}
+			if existing, found := newPolicyMap[key]; found && existing != r.Routing {
+				klog.Warningf("Found conflicting routing policies for %s, using %+v", key, existing)
+				return
+			}
+			newPolicyMap[key] = r.Routing
+		}
+
 		// Resolve and build map
-		for _, r := range snapshot.records {
+		for i := range snapshot.records {
+			r := &snapshot.records[i]
 			if r.RecordType == RecordTypeAlias {
 				aliasRecords := snapshot.aliasTargets[r.Value]
 				if len(aliasRecords) == 0 {
@@ -221,19 +312,23 @@ func (c *DNSController) runOnce() error {
 				}
 				for _, aliasRecord := range aliasRecords {
 					key := recordKey{
-						RecordType: aliasRecord.RecordType,
-						FQDN:       r.FQDN,
+						RecordType:    aliasRecord.RecordType,
+						FQDN:          r.FQDN,
+						SetIdentifier: r.Routing.SetIdentifier,
 					}
 					// TODO: Support chains: alias of alias (etc)
 					newValueMap[key] = append(newValueMap[key], aliasRecord.Value)
+					addPolicy(key, r)
 				}
 				continue
 			} else {
 				key := recordKey{
-					RecordType: r.RecordType,
-					FQDN:       r.FQDN,
+					RecordType:    r.RecordType,
+					FQDN:          r.FQDN,
+					SetIdentifier: r.Routing.SetIdentifier,
 				}
 				newValueMap[key] = append(newValueMap[key], r.Value)
+				addPolicy(key, r)
 				continue
 			}
 		}
@@ -244,17 +339,21 @@ func (c *DNSController) runOnce() error {
 			newValueMap[k] = values
 		}
 		snapshot.recordValues = newValueMap
+		snapshot.recordPolicies = newPolicyMap
 	}
 
 	var oldValueMap map[recordKey][]string
+	var oldPolicyMap map[recordKey]dnsprovider.RoutingPolicy
 	if c.lastSuccessfulSnapshot != nil {
 		oldValueMap = c.lastSuccessfulSnapshot.recordValues
+		oldPolicyMap = c.lastSuccessfulSnapshot.recordPolicies
 	}
 
-	op, err := newDNSOp(c.zoneRules, c.dnsCache)
+	op, err := newDNSOp(c.zoneRules, c.dnsCache, c.registry)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
+	op.dryRun = dryRun
 
 	// Store a list of all the errors, so that one bad apple doesn't block every other request
 	var errors []error
@@ -262,11 +361,12 @@ func (c *DNSController) runOnce() error {
 	// Check each hostname for changes and apply them
 	for k, newValues := range newValueMap {
 		if c.StopRequested() {
-			return fmt.Errorf("stop requested")
+			return nil, nil, fmt.Errorf("stop requested")
 		}
 		oldValues := oldValueMap[k]
+		policy := newPolicyMap[k]
 
-		if util.StringSlicesEqual(newValues, oldValues) {
+		if util.StringSlicesEqual(newValues, oldValues) && policy == oldPolicyMap[k] {
 			klog.V(4).Infof("no change to records for %s", k)
 			continue
 		}
@@ -293,7 +393,7 @@ func (c *DNSController) runOnce() error {
 			dedup = append(dedup, s)
 		}
 
-		err := op.updateRecords(k, dedup, int64(ttl.Seconds()))
+		err := op.updateRecords(k, policy, dedup, int64(ttl.Seconds()))
 		if err != nil {
 			klog.Infof("error updating records for %s: %v", k, err)
 			errors = append(errors, err)
@@ -303,7 +403,7 @@ func (c *DNSController) runOnce() error {
 	// Look for deleted hostnames
 	for k := range oldValueMap {
 		if c.StopRequested() {
-			return fmt.Errorf("stop requested")
+			return nil, nil, fmt.Errorf("stop requested")
 		}
 
 		newValues := newValueMap[k]
@@ -316,44 +416,26 @@ func (c *DNSController) runOnce() error {
 		}
 	}
 
-	for key, changeset := range op.changesets {
-		if changeset.IsEmpty() {
-			continue
-		}
-
-		klog.V(2).Infof("Applying DNS changeset for zone %s", key)
-		if err := changeset.Apply(ctx); err != nil {
-			klog.Warningf("error applying DNS changeset for zone %s: %v", key, err)
-			errors = append(errors, fmt.Errorf("error applying DNS changeset for zone %s: %v", key, err))
-		}
-	}
-
-	if len(errors) != 0 {
-		return errors[0]
-	}
-
-	// Success!  Store the snapshot as our new baseline
-	c.mutex.Lock()
-	defer c.mutex.Unlock()
-	c.lastSuccessfulSnapshot = snapshot
-	return nil
+	return op, errors, nil
 }
 
 func (c *DNSController) RemoveRecordsImmediate(records []Record) error {
 	ctx := context.TODO()
 
-	op, err := newDNSOp(c.zoneRules, c.dnsCache)
+	op, err := newDNSOp(c.zoneRules, c.dnsCache, c.registry)
 	if err != nil {
 		return err
 	}
+	op.dryRun = c.dryRun
 
 	// Store a list of all the errors, so that one bad apple doesn't block every other request
 	var errors []error
 
 	for _, r := range records {
 		k := recordKey{
-			RecordType: r.RecordType,
-			FQDN:       r.FQDN,
+			RecordType:    r.RecordType,
+			FQDN:          r.FQDN,
+			SetIdentifier: r.Routing.SetIdentifier,
 		}
 
 		err := op.deleteRecords(k)
@@ -381,13 +463,17 @@ func (c *DNSController) RemoveRecordsImmediate(records []Record) error {
 // dnsOp manages a single dns change; we cache results and state for the duration of the operation
 type dnsOp struct {
 	dnsCache     *dnsCache
+	registry     *OwnershipRegistry
 	zones        map[string]dnsprovider.Zone
 	recordsCache map[string][]dnsprovider.ResourceRecordSet
 
 	changesets map[string]dnsprovider.ResourceRecordChangeset
+
+	// dryRun records the changes instead of applying them
+	dryRun bool
 }
 
-func newDNSOp(zoneRules *ZoneRules, dnsCache *dnsCache) (*dnsOp, error) {
+func newDNSOp(zoneRules *ZoneRules, dnsCache *dnsCache, registry *OwnershipRegistry) (*dnsOp, error) {
 	zones, err := dnsCache.ListZones(zoneListCacheValidity)
 	if err != nil {
 		return nil, fmt.Errorf("error querying for zones: %v", err)
@@ -424,6 +510,7 @@ func newDNSOp(zoneRules *ZoneRules, dnsCache *dnsCache) (*dnsOp, error) {
 
 	o := &dnsOp{
 		dnsCache:     dnsCache,
+		registry:     registry,
 		zones:        zoneMap,
 		changesets:   make(map[string]dnsprovider.ResourceRecordChangeset),
 		recordsCache: make(map[string][]dnsprovider.ResourceRecordSet),
@@ -462,7 +549,15 @@ func (o *dnsOp) getChangeset(zone dnsprovider.Zone) (dnsprovider.ResourceRecordC
 		if !ok {
 			return nil, fmt.Errorf("zone does not support resource records %q", zone.Name())
 		}
-		changeset = rrsProvider.StartChangeset()
+		if o.dryRun {
+			existing, err := o.listRecords(zone)
+			if err != nil {
+				return nil, err
+			}
+			changeset = newRecordingChangeset(zone, rrsProvider, existing)
+		} else {
+			changeset = rrsProvider.StartChangeset()
+		}
 		o.changesets[key] = changeset
 	}
 
@@ -515,6 +610,7 @@ func (o *dnsOp) deleteRecords(k recordKey) error {
 		return err
 	}
 
+	var matches []dnsprovider.ResourceRecordSet
 	for _, rr := range rrs {
 		rrName := EnsureDotSuffix(rr.Name())
 		if rrName != fqdn {
@@ -525,8 +621,36 @@ func (o *dnsOp) deleteRecords(k recordKey) error {
 			klog.V(8).Infof("Skipping delete of record %q (type %s != %s)", rrName, rr.Type(), k.RecordType)
 			continue
 		}
+		if setIdentifier(rr) != k.SetIdentifier {
+			klog.V(8).Infof("Skipping delete of record %q (set identifier %q != %q)", rrName, setIdentifier(rr), k.SetIdentifier)
+			continue
+		}
+		matches = append(matches, rr)
+	}
+
+	if o.registry != nil {
+		// Retrying would not help, so we don't return errors for records we don't own
+		ownershipRecord, owner := o.registry.findOwnershipRecord(rrs, k)
+		if owner != "" && owner != o.registry.OwnerID {
+			klog.Warningf("Not deleting records for %s, as they are owned by %q", k, owner)
+			return nil
+		}
+		if owner == "" {
+			for _, rr := range matches {
+				if !o.registry.canAdopt(rr) {
+					klog.Warningf("Not deleting records for %s, as they have no ownership record", k)
+					return nil
+				}
+			}
+		}
+		if ownershipRecord != nil {
+			klog.V(2).Infof("Deleting ownership record %s", ownershipRecord.Name())
+			cs.Remove(ownershipRecord)
+		}
+	}
 
-		klog.V(2).Infof("Deleting resource record %s %s", rrName, rr.Type())
+	for _, rr := range matches {
+		klog.V(2).Infof("Deleting resource record %s %s", rr.Name(), rr.Type())
 		cs.Remove(rr)
 	}
 
@@ -537,7 +661,16 @@ func FixWildcards(s string) string {
 	return strings.Replace(s, "\\052", "*", 1)
 }
 
-func (o *dnsOp) updateRecords(k recordKey, newRecords []string, ttl int64) error {
+// setIdentifier returns the set identifier of the routing policy of a record set, or "" if it has none
+func setIdentifier(rr dnsprovider.ResourceRecordSet) string {
+	if policy := dnsprovider.GetRoutingPolicy(rr); policy != nil {
+		return policy.SetIdentifier
+	}
+	return ""
+}
+
+// updateRecords upserts the record set for k; policy is only used for keys with a set identifier
+func (o *dnsOp) updateRecords(k recordKey, policy dnsprovider.RoutingPolicy, newRecords []string, ttl int64) error {
 	fqdn := EnsureDotSuffix(k.FQDN)
 
 	zone := o.findZone(fqdn)
@@ -551,6 +684,14 @@ func (o *dnsOp) updateRecords(k recordKey, newRecords []string, ttl int64) error
 		return fmt.Errorf("zone does not support resource records %q", zone.Name())
 	}
 
+	var routingProvider dnsprovider.RoutingPolicyResourceRecordSets
+	if k.SetIdentifier != "" {
+		routingProvider, ok = rrsProvider.(dnsprovider.RoutingPolicyResourceRecordSets)
+		if !ok {
+			return fmt.Errorf("zone %q does not support routing policies, needed for %s", zone.Name(), k)
+		}
+	}
+
 	var existing dnsprovider.ResourceRecordSet
 
 	// when DNS provider is aws-route53 or google-clouddns
@@ -569,6 +710,10 @@ func (o *dnsOp) updateRecords(k recordKey, newRecords []string, ttl int64) error
 			klog.V(8).Infof("Skipping record %q (type %s != %s)", rrName, rr.Type(), k.RecordType)
 			continue
 		}
+		if setIdentifier(rr) != k.SetIdentifier {
+			klog.V(8).Infof("Skipping record %q (set identifier %q != %q)", rrName, setIdentifier(rr), k.SetIdentifier)
+			continue
+		}
 
 		if existing != nil {
 			klog.Warningf("Found multiple matching records: %v and %v", existing, rr)
@@ -578,13 +723,38 @@ func (o *dnsOp) updateRecords(k recordKey, newRecords []string, ttl int64) error
 		existing = rr
 	}
 
+	if o.registry != nil {
+		ownershipRecord, owner := o.registry.findOwnershipRecord(rrs, k)
+		if owner != "" && owner != o.registry.OwnerID {
+			return fmt.Errorf("not updating records for %s, as they are owned by %q", k, owner)
+		}
+		if owner == "" && existing != nil && !o.registry.canAdopt(existing) {
+			return fmt.Errorf("not updating records for %s, as they have no ownership record", k)
+		}
+
+		value := o.registry.recordValue()
+		if ownershipRecord == nil || len(ownershipRecord.Rrdatas()) != 1 || ownershipRecord.Rrdatas()[0] != value {
+			cs, err := o.getChangeset(zone)
+			if err != nil {
+				return err
+			}
+			klog.V(2).Infof("Adding ownership record for %s to batch", k)
+			cs.Upsert(rrsProvider.New(o.registry.recordName(k), []string{value}, ownershipRecordTTL, rrstype.TXT))
+		}
+	}
+
 	cs, err := o.getChangeset(zone)
 	if err != nil {
 		return err
 	}
 
 	klog.V(2).Infof("Adding DNS changes to batch %s %s", k, newRecords)
-	rr := rrsProvider.New(fqdn, newRecords, ttl, rrstype.RrsType(k.RecordType))
+	var rr dnsprovider.ResourceRecordSet
+	if routingProvider != nil {
+		rr = routingProvider.NewWithRoutingPolicy(fqdn, newRecords, ttl, rrstype.RrsType(k.RecordType), policy)
+	} else {
+		rr = rrsProvider.New(fqdn, newRecords, ttl, rrstype.RrsType(k.RecordType))
+	}
 	cs.Upsert(rr)
 
 	return nil
@@ -0,0 +1,117 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dns
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider"
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider/rrstype"
+)
+
+// dumpZoneWithRoutingPolicies is dumpZone, adding the routing policies of the record sets
+func dumpZoneWithRoutingPolicies(t *testing.T, rrsets dnsprovider.ResourceRecordSets) []string {
+	records, err := rrsets.List()
+	if err != nil {
+		t.Fatalf("error listing records: %v", err)
+	}
+	var lines []string
+	for _, rr := range records {
+		line := rr.Name() + " " + string(rr.Type()) + " " + strings.Join(rr.Rrdatas(), ",")
+		if policy := dnsprovider.GetRoutingPolicy(rr); policy != nil {
+			line += fmt.Sprintf(" %+v", *policy)
+		}
+		lines = append(lines, line)
+	}
+	sort.Strings(lines)
+	return lines
+}
+
+func TestRoutingPolicies(t *testing.T) {
+	provider, rrsets := newTestZone(t,
+		// Not managed by dns-controller, left alone
+		testRecord{"app.example.com.", []string{"192.0.2.1"}, rrstype.A},
+	)
+
+	zoneRules, err := ParseZoneRules(nil)
+	if err != nil {
+		t.Fatalf("error parsing zone rules: %v", err)
+	}
+	registry := &OwnershipRegistry{OwnerID: "test", Prefix: DefaultOwnershipPrefix}
+	c, err := NewDNSController([]dnsprovider.Interface{provider}, zoneRules, registry, 1, false)
+	if err != nil {
+		t.Fatalf("error building DNS controller: %v", err)
+	}
+	scope, err := c.CreateScope("test")
+	if err != nil {
+		t.Fatalf("error creating scope: %v", err)
+	}
+
+	blue := dnsprovider.RoutingPolicy{SetIdentifier: "blue", Weight: 90}
+	green := dnsprovider.RoutingPolicy{SetIdentifier: "green", Weight: 10}
+	primary := dnsprovider.RoutingPolicy{SetIdentifier: "primary", Failover: dnsprovider.FailoverPrimary, HealthCheckID: "hc-1"}
+	scope.Replace("blue", []Record{
+		{RecordType: RecordTypeA, FQDN: "app.example.com.", Value: "10.0.0.1", Routing: blue},
+	})
+	scope.Replace("green", []Record{
+		{RecordType: RecordTypeA, FQDN: "app.example.com.", Value: "10.0.0.2", Routing: green},
+	})
+	scope.Replace("primary", []Record{
+		{RecordType: RecordTypeCNAME, FQDN: "api.example.com.", Value: "lb.example.net", Routing: primary},
+	})
+	scope.MarkReady()
+
+	if err := c.runOnce(); err != nil {
+		t.Fatalf("runOnce: %v", err)
+	}
+	const ownership = `"heritage=dns-controller,dns-controller/owner=test"`
+	expected := []string{
+		"_dns-controller.a-blue.app.example.com. TXT " + ownership,
+		"_dns-controller.a-green.app.example.com. TXT " + ownership,
+		"_dns-controller.cname-primary.api.example.com. TXT " + ownership,
+		"api.example.com. CNAME lb.example.net {SetIdentifier:primary Weight:0 Failover:PRIMARY HealthCheckID:hc-1}",
+		"app.example.com. A 10.0.0.1 {SetIdentifier:blue Weight:90 Failover: HealthCheckID:}",
+		"app.example.com. A 10.0.0.2 {SetIdentifier:green Weight:10 Failover: HealthCheckID:}",
+		"app.example.com. A 192.0.2.1",
+	}
+	if actual := dumpZoneWithRoutingPolicies(t, rrsets); strings.Join(actual, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("unexpected records\nexpected:\n%s\nactual:\n%s", strings.Join(expected, "\n"), strings.Join(actual, "\n"))
+	}
+
+	// Changing only the weight updates the record set, and removing a record set leaves the others alone
+	green.Weight = 50
+	scope.Replace("green", []Record{
+		{RecordType: RecordTypeA, FQDN: "app.example.com.", Value: "10.0.0.2", Routing: green},
+	})
+	scope.Replace("blue", nil)
+	if err := c.runOnce(); err != nil {
+		t.Fatalf("runOnce: %v", err)
+	}
+	expected = []string{
+		"_dns-controller.a-green.app.example.com. TXT " + ownership,
+		"_dns-controller.cname-primary.api.example.com. TXT " + ownership,
+		"api.example.com. CNAME lb.example.net {SetIdentifier:primary Weight:0 Failover:PRIMARY HealthCheckID:hc-1}",
+		"app.example.com. A 10.0.0.2 {SetIdentifier:green Weight:50 Failover: HealthCheckID:}",
+		"app.example.com. A 192.0.2.1",
+	}
+	if actual := dumpZoneWithRoutingPolicies(t, rrsets); strings.Join(actual, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("unexpected records\nexpected:\n%s\nactual:\n%s", strings.Join(expected, "\n"), strings.Join(actual, "\n"))
+	}
+}
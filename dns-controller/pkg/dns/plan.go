@@ -0,0 +1,196 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dns
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider"
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider/rrstype"
+)
+
+// PlanAction is the kind of change made to a record set
+type PlanAction string
+
+const (
+	PlanActionCreate PlanAction = "create"
+	PlanActionUpdate PlanAction = "update"
+	PlanActionDelete PlanAction = "delete"
+)
+
+// PlannedChange is a change to a record set that dns-controller would apply
+type PlannedChange struct {
+	Zone   string
+	Action PlanAction
+	Name   string
+	Type   rrstype.RrsType
+	TTL    int64
+	Values []string
+	// OldValues are the values of the record set before an update
+	OldValues []string
+}
+
+func (c *PlannedChange) String() string {
+	s := fmt.Sprintf("%s %s %s %d %s", c.Action, c.Type, c.Name, c.TTL, strings.Join(c.Values, ","))
+	if c.Action == PlanActionUpdate {
+		s += " (was " + strings.Join(c.OldValues, ",") + ")"
+	}
+	return s
+}
+
+// Plan is the list of changes dns-controller would apply to the DNS zones
+type Plan struct {
+	Changes []PlannedChange
+}
+
+// Write prints the changes of the plan, grouped by zone
+func (p *Plan) Write(w io.Writer) error {
+	if len(p.Changes) == 0 {
+		_, err := fmt.Fprintf(w, "No changes\n")
+		return err
+	}
+
+	changes := append([]PlannedChange{}, p.Changes...)
+	sort.SliceStable(changes, func(i, j int) bool {
+		if changes[i].Zone != changes[j].Zone {
+			return changes[i].Zone < changes[j].Zone
+		}
+		if changes[i].Name != changes[j].Name {
+			return changes[i].Name < changes[j].Name
+		}
+		return changes[i].Type < changes[j].Type
+	})
+
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	zone := ""
+	for _, change := range changes {
+		if change.Zone != zone {
+			if zone != "" {
+				fmt.Fprintf(tw, "\n")
+			}
+			zone = change.Zone
+			fmt.Fprintf(tw, "Zone %s\n", zone)
+			fmt.Fprintf(tw, "  ACTION\tTYPE\tNAME\tTTL\tVALUES\n")
+		}
+		values := strings.Join(change.Values, ",")
+		if change.Action == PlanActionUpdate {
+			values += " (was " + strings.Join(change.OldValues, ",") + ")"
+		}
+		fmt.Fprintf(tw, "  %s\t%s\t%s\t%d\t%s\n", change.Action, change.Type, change.Name, change.TTL, values)
+	}
+	return tw.Flush()
+}
+
+// recordingChangeset records the changes made to a zone instead of applying them
+type recordingChangeset struct {
+	zone     string
+	rrsets   dnsprovider.ResourceRecordSets
+	existing []dnsprovider.ResourceRecordSet
+
+	changes []PlannedChange
+}
+
+var _ dnsprovider.ResourceRecordChangeset = &recordingChangeset{}
+
+func newRecordingChangeset(zone dnsprovider.Zone, rrsets dnsprovider.ResourceRecordSets, existing []dnsprovider.ResourceRecordSet) *recordingChangeset {
+	return &recordingChangeset{
+		zone:     EnsureDotSuffix(zone.Name()),
+		rrsets:   rrsets,
+		existing: existing,
+	}
+}
+
+func (c *recordingChangeset) record(action PlanAction, rr dnsprovider.ResourceRecordSet, oldValues []string) {
+	values := append([]string{}, rr.Rrdatas()...)
+	sort.Strings(values)
+	c.changes = append(c.changes, PlannedChange{
+		Zone:      c.zone,
+		Action:    action,
+		Name:      EnsureDotSuffix(rr.Name()),
+		Type:      rr.Type(),
+		TTL:       rr.Ttl(),
+		Values:    values,
+		OldValues: oldValues,
+	})
+}
+
+// findExisting returns the existing record set with the name, type and set identifier of rr
+func (c *recordingChangeset) findExisting(rr dnsprovider.ResourceRecordSet) dnsprovider.ResourceRecordSet {
+	name := EnsureDotSuffix(rr.Name())
+	for _, existing := range c.existing {
+		if existing.Type() == rr.Type() && strings.EqualFold(EnsureDotSuffix(FixWildcards(existing.Name())), name) && setIdentifier(existing) == setIdentifier(rr) {
+			return existing
+		}
+	}
+	return nil
+}
+
+func (c *recordingChangeset) Add(rr dnsprovider.ResourceRecordSet) dnsprovider.ResourceRecordChangeset {
+	c.record(PlanActionCreate, rr, nil)
+	return c
+}
+
+func (c *recordingChangeset) Remove(rr dnsprovider.ResourceRecordSet) dnsprovider.ResourceRecordChangeset {
+	c.record(PlanActionDelete, rr, nil)
+	return c
+}
+
+func (c *recordingChangeset) Upsert(rr dnsprovider.ResourceRecordSet) dnsprovider.ResourceRecordChangeset {
+	existing := c.findExisting(rr)
+	if existing == nil {
+		c.record(PlanActionCreate, rr, nil)
+		return c
+	}
+
+	oldValues := append([]string{}, existing.Rrdatas()...)
+	sort.Strings(oldValues)
+	newValues := append([]string{}, rr.Rrdatas()...)
+	sort.Strings(newValues)
+	if existing.Ttl() == rr.Ttl() && strings.Join(oldValues, "\n") == strings.Join(newValues, "\n") && routingPolicyEqual(existing, rr) {
+		// The record set is already up to date
+		return c
+	}
+	c.record(PlanActionUpdate, rr, oldValues)
+	return c
+}
+
+// routingPolicyEqual returns true if both record sets have the same routing policy, or none
+func routingPolicyEqual(l, r dnsprovider.ResourceRecordSet) bool {
+	lp, rp := dnsprovider.GetRoutingPolicy(l), dnsprovider.GetRoutingPolicy(r)
+	if lp == nil || rp == nil {
+		return lp == rp
+	}
+	return *lp == *rp
+}
+
+// Apply does not apply the changes, they are only recorded
+func (c *recordingChangeset) Apply(ctx context.Context) error {
+	return nil
+}
+
+func (c *recordingChangeset) IsEmpty() bool {
+	return len(c.changes) == 0
+}
+
+func (c *recordingChangeset) ResourceRecordSets() dnsprovider.ResourceRecordSets {
+	return c.rrsets
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dns
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider"
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider/rrstype"
+)
+
+func TestPlan(t *testing.T) {
+	provider, rrsets := newTestZone(t,
+		testRecord{"api.example.com.", []string{"192.0.2.1"}, rrstype.A},
+		testRecord{"unchanged.example.com.", []string{"192.0.2.2"}, rrstype.A},
+	)
+	before := dumpZone(t, rrsets)
+
+	zoneRules, err := ParseZoneRules(nil)
+	if err != nil {
+		t.Fatalf("error parsing zone rules: %v", err)
+	}
+	c, err := NewDNSController([]dnsprovider.Interface{provider}, zoneRules, nil, 1, true)
+	if err != nil {
+		t.Fatalf("error building DNS controller: %v", err)
+	}
+
+	scope, err := c.CreateScope("test")
+	if err != nil {
+		t.Fatalf("error creating scope: %v", err)
+	}
+	scope.Replace("api", []Record{
+		{RecordType: RecordTypeA, FQDN: "api.example.com.", Value: "10.0.0.2"},
+		{RecordType: RecordTypeA, FQDN: "api.example.com.", Value: "10.0.0.1"},
+	})
+	scope.Replace("unchanged", []Record{
+		{RecordType: RecordTypeA, FQDN: "unchanged.example.com.", Value: "192.0.2.2"},
+	})
+	scope.Replace("new", []Record{
+		{RecordType: RecordTypeCNAME, FQDN: "new.example.com.", Value: "lb.example.net"},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		scope.MarkReady()
+	}()
+
+	plan, err := c.Plan(ctx)
+	if err != nil {
+		t.Fatalf("Plan: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := plan.Write(&out); err != nil {
+		t.Fatalf("error writing plan: %v", err)
+	}
+	expected := strings.Join([]string{
+		"Zone example.com.",
+		"  ACTION  TYPE   NAME              TTL  VALUES",
+		"  update  A      api.example.com.  60   10.0.0.1,10.0.0.2 (was 192.0.2.1)",
+		"  create  CNAME  new.example.com.  60   lb.example.net",
+		"",
+	}, "\n")
+	if out.String() != expected {
+		t.Errorf("unexpected plan\nexpected:\n%s\nactual:\n%s", expected, out.String())
+	}
+
+	// Dry runs don't change the zone
+	if err := c.runOnce(); err != nil {
+		t.Fatalf("runOnce: %v", err)
+	}
+	if after := dumpZone(t, rrsets); strings.Join(after, "\n") != strings.Join(before, "\n") {
+		t.Errorf("zone was changed by a dry run\nbefore:\n%s\nafter:\n%s", strings.Join(before, "\n"), strings.Join(after, "\n"))
+	}
+}
+
+func TestPlanNoChanges(t *testing.T) {
+	var out bytes.Buffer
+	if err := (&Plan{}).Write(&out); err != nil {
+		t.Fatalf("error writing plan: %v", err)
+	}
+	if out.String() != "No changes\n" {
+		t.Errorf("unexpected plan %q", out.String())
+	}
+}
@@ -16,6 +16,12 @@ limitations under the License.
 
 package dns
 
+import (
+	"strconv"
+
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider"
+)
+
 type RecordType string
 
 const (
@@ -39,6 +45,10 @@ type Record struct {
 	// but will be used as an expansion for Records with type=RecordTypeAlias,
 	// where the referring record has Value = our FQDN
 	AliasTarget bool
+
+	// Routing is the routing policy of the record, for weighted or failover record sets.
+	// Records with the same name and type but a different Routing.SetIdentifier are published as separate record sets.
+	Routing dnsprovider.RoutingPolicy
 }
 
 // AliasForNodesInRole returns the alias for nodes in the given role
@@ -53,6 +63,18 @@ func (r *Record) String() string {
 		s += ",AliasTarget"
 	}
 
+	if r.Routing.SetIdentifier != "" {
+		s += ",SetIdentifier=" + r.Routing.SetIdentifier
+		if r.Routing.Failover != "" {
+			s += ",Failover=" + r.Routing.Failover
+		} else {
+			s += ",Weight=" + strconv.FormatInt(r.Routing.Weight, 10)
+		}
+		if r.Routing.HealthCheckID != "" {
+			s += ",HealthCheckID=" + r.Routing.HealthCheckID
+		}
+	}
+
 	s += "]"
 
 	return s
@@ -0,0 +1,132 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dns
+
+import (
+	"strings"
+
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider"
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider/rrstype"
+)
+
+const (
+	// DefaultOwnershipPrefix is the default prefix of the names of the ownership records
+	DefaultOwnershipPrefix = "_dns-controller."
+
+	ownershipHeritage   = "heritage=dns-controller"
+	ownershipOwnerKey   = "dns-controller/owner"
+	ownershipClusterKey = "dns-controller/cluster"
+	ownershipRecordTTL  = 300
+
+	// The placeholder values of the records created by kOps before dns-controller is running
+	placeholderIPv4 = "203.0.113.123"
+	placeholderIPv6 = "fd00:dead:add::"
+)
+
+// OwnershipRegistry records the owner of each record set in a TXT record, like the TXT registry of external-dns.
+// Record sets owned by another owner, or by nobody, are neither updated nor deleted, so that clusters and tools
+// sharing a zone do not overwrite each other's records.
+type OwnershipRegistry struct {
+	// OwnerID identifies the records owned by this dns-controller
+	OwnerID string
+	// ClusterName is the name of the cluster, recorded for information
+	ClusterName string
+	// Prefix is prepended to the names of the ownership records, as a TXT record can't share its name with a CNAME
+	Prefix string
+	// AdoptUnowned allows taking ownership of record sets without ownership record,
+	// for enabling the registry on clusters whose records were created without it.
+	AdoptUnowned bool
+}
+
+// recordName returns the name of the ownership record of a record set,
+// e.g. _dns-controller.a.api.example.com, or _dns-controller.a-blue.api.example.com for the record set with set identifier blue
+func (r *OwnershipRegistry) recordName(k recordKey) string {
+	fqdn := EnsureDotSuffix(strings.ToLower(k.FQDN))
+	if strings.HasPrefix(fqdn, "*.") {
+		fqdn = "_wildcard" + fqdn[1:]
+	}
+	recordType := strings.ToLower(string(k.RecordType))
+	if k.SetIdentifier != "" {
+		// Each record set with a routing policy has its own owner
+		recordType += "-" + sanitizeLabel(k.SetIdentifier)
+	}
+	return r.Prefix + recordType + "." + fqdn
+}
+
+// sanitizeLabel replaces the characters not allowed in a DNS label
+func sanitizeLabel(s string) string {
+	return strings.Map(func(c rune) rune {
+		if (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') || c == '-' {
+			return c
+		}
+		return '-'
+	}, strings.ToLower(s))
+}
+
+// recordValue returns the value of the ownership records of this owner
+func (r *OwnershipRegistry) recordValue() string {
+	value := ownershipHeritage + "," + ownershipOwnerKey + "=" + r.OwnerID
+	if r.ClusterName != "" {
+		value += "," + ownershipClusterKey + "=" + r.ClusterName
+	}
+	return "\"" + value + "\""
+}
+
+// findOwnershipRecord returns the ownership record of a record set, and its owner
+func (r *OwnershipRegistry) findOwnershipRecord(rrs []dnsprovider.ResourceRecordSet, k recordKey) (dnsprovider.ResourceRecordSet, string) {
+	name := r.recordName(k)
+	for _, rr := range rrs {
+		if rr.Type() != rrstype.TXT || !strings.EqualFold(EnsureDotSuffix(rr.Name()), name) {
+			continue
+		}
+		for _, value := range rr.Rrdatas() {
+			if owner := parseOwner(value); owner != "" {
+				return rr, owner
+			}
+		}
+		return rr, ""
+	}
+	return nil, ""
+}
+
+// parseOwner returns the owner from the value of an ownership record, or "" if it is not one
+func parseOwner(value string) string {
+	value = strings.Trim(value, "\"")
+	fields := strings.Split(value, ",")
+	if len(fields) == 0 || fields[0] != ownershipHeritage {
+		return ""
+	}
+	for _, field := range fields[1:] {
+		if owner, found := strings.CutPrefix(field, ownershipOwnerKey+"="); found {
+			return owner
+		}
+	}
+	return ""
+}
+
+// canAdopt returns true if this owner can take ownership of an existing record set without ownership record
+func (r *OwnershipRegistry) canAdopt(rr dnsprovider.ResourceRecordSet) bool {
+	if r.AdoptUnowned {
+		return true
+	}
+	for _, value := range rr.Rrdatas() {
+		if value != placeholderIPv4 && value != placeholderIPv6 {
+			return false
+		}
+	}
+	return true
+}
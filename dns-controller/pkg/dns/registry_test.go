@@ -0,0 +1,179 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dns
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider"
+	awsroute53 "k8s.io/kops/dnsprovider/pkg/dnsprovider/providers/aws/route53"
+	route53testing "k8s.io/kops/dnsprovider/pkg/dnsprovider/providers/aws/route53/stubs"
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider/rrstype"
+)
+
+// newTestZone returns a DNS provider with the zone example.com, containing the given records
+func newTestZone(t *testing.T, records ...dnsprovider.ResourceRecordSet) (dnsprovider.Interface, dnsprovider.ResourceRecordSets) {
+	service := route53testing.NewRoute53APIStub()
+	if _, err := service.CreateHostedZone(context.TODO(), &route53.CreateHostedZoneInput{
+		CallerReference: aws.String("Nonce"),
+		Name:            aws.String("example.com."),
+	}); err != nil {
+		t.Fatalf("error creating zone: %v", err)
+	}
+	provider := awsroute53.New(service)
+
+	zonesProvider, _ := provider.Zones()
+	zones, err := zonesProvider.List()
+	if err != nil {
+		t.Fatalf("error listing zones: %v", err)
+	}
+	rrsets, _ := zones[0].ResourceRecordSets()
+
+	if len(records) != 0 {
+		changeset := rrsets.StartChangeset()
+		for _, rr := range records {
+			changeset.Add(rrsets.New(rr.Name(), rr.Rrdatas(), rr.Ttl(), rr.Type()))
+		}
+		if err := changeset.Apply(context.TODO()); err != nil {
+			t.Fatalf("error adding records: %v", err)
+		}
+	}
+
+	return provider, rrsets
+}
+
+// testRecord is a ResourceRecordSet for building the test zone
+type testRecord struct {
+	name    string
+	rrdatas []string
+	rrsType rrstype.RrsType
+}
+
+var _ dnsprovider.ResourceRecordSet = testRecord{}
+
+func (r testRecord) Name() string                             { return r.name }
+func (r testRecord) Rrdatas() []string                        { return r.rrdatas }
+func (r testRecord) Ttl() int64                               { return 60 }
+func (r testRecord) Type() rrstype.RrsType                    { return r.rrsType }
+func (r testRecord) Equal(dnsprovider.ResourceRecordSet) bool { return false }
+
+func dumpZone(t *testing.T, rrsets dnsprovider.ResourceRecordSets) []string {
+	records, err := rrsets.List()
+	if err != nil {
+		t.Fatalf("error listing records: %v", err)
+	}
+	var lines []string
+	for _, rr := range records {
+		lines = append(lines, rr.Name()+" "+string(rr.Type())+" "+strings.Join(rr.Rrdatas(), ","))
+	}
+	sort.Strings(lines)
+	return lines
+}
+
+func TestOwnershipRegistry(t *testing.T) {
+	const ownership = `"heritage=dns-controller,dns-controller/owner=cluster-a,dns-controller/cluster=a.example.com"`
+
+	provider, rrsets := newTestZone(t,
+		// Created by kOps before dns-controller is running
+		testRecord{"api.internal.a.example.com.", []string{placeholderIPv4}, rrstype.A},
+		// Owned by another cluster
+		testRecord{"api.internal.b.example.com.", []string{"192.0.2.20"}, rrstype.A},
+		testRecord{"_dns-controller.a.api.internal.b.example.com.", []string{`"heritage=dns-controller,dns-controller/owner=cluster-b"`}, rrstype.TXT},
+		// Created by someone else
+		testRecord{"www.example.com.", []string{"192.0.2.30"}, rrstype.A},
+		// Owned by us
+		testRecord{"old.a.example.com.", []string{"192.0.2.40"}, rrstype.A},
+		testRecord{"_dns-controller.a.old.a.example.com.", []string{ownership}, rrstype.TXT},
+	)
+
+	zoneRules, err := ParseZoneRules(nil)
+	if err != nil {
+		t.Fatalf("error parsing zone rules: %v", err)
+	}
+	dnsCache, err := newDNSCache([]dnsprovider.Interface{provider})
+	if err != nil {
+		t.Fatalf("error building DNS cache: %v", err)
+	}
+	registry := &OwnershipRegistry{
+		OwnerID:     "cluster-a",
+		ClusterName: "a.example.com",
+		Prefix:      DefaultOwnershipPrefix,
+	}
+	op, err := newDNSOp(zoneRules, dnsCache, registry)
+	if err != nil {
+		t.Fatalf("error building DNS op: %v", err)
+	}
+
+	if err := op.updateRecords(recordKey{RecordTypeA, "api.internal.a.example.com.", ""}, dnsprovider.RoutingPolicy{}, []string{"10.0.0.1"}, 60); err != nil {
+		t.Errorf("unexpected error updating placeholder record: %v", err)
+	}
+	if err := op.updateRecords(recordKey{RecordTypeA, "new.a.example.com.", ""}, dnsprovider.RoutingPolicy{}, []string{"10.0.0.2"}, 60); err != nil {
+		t.Errorf("unexpected error creating record: %v", err)
+	}
+	if err := op.updateRecords(recordKey{RecordTypeA, "api.internal.b.example.com.", ""}, dnsprovider.RoutingPolicy{}, []string{"10.0.0.3"}, 60); err == nil || !strings.Contains(err.Error(), `owned by "cluster-b"`) {
+		t.Errorf("expected error updating record of another owner, got %v", err)
+	}
+	if err := op.updateRecords(recordKey{RecordTypeA, "www.example.com.", ""}, dnsprovider.RoutingPolicy{}, []string{"10.0.0.4"}, 60); err == nil || !strings.Contains(err.Error(), "no ownership record") {
+		t.Errorf("expected error updating unowned record, got %v", err)
+	}
+	for _, fqdn := range []string{"old.a.example.com.", "api.internal.b.example.com.", "www.example.com."} {
+		if err := op.deleteRecords(recordKey{RecordTypeA, fqdn, ""}); err != nil {
+			t.Errorf("unexpected error deleting %s: %v", fqdn, err)
+		}
+	}
+
+	for _, changeset := range op.changesets {
+		if err := changeset.Apply(context.TODO()); err != nil {
+			t.Fatalf("error applying changeset: %v", err)
+		}
+	}
+
+	expected := []string{
+		"_dns-controller.a.api.internal.a.example.com. TXT " + ownership,
+		`_dns-controller.a.api.internal.b.example.com. TXT "heritage=dns-controller,dns-controller/owner=cluster-b"`,
+		"_dns-controller.a.new.a.example.com. TXT " + ownership,
+		"api.internal.a.example.com. A 10.0.0.1",
+		"api.internal.b.example.com. A 192.0.2.20",
+		"new.a.example.com. A 10.0.0.2",
+		"www.example.com. A 192.0.2.30",
+	}
+	if actual := dumpZone(t, rrsets); strings.Join(actual, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("unexpected records\nexpected:\n%s\nactual:\n%s", strings.Join(expected, "\n"), strings.Join(actual, "\n"))
+	}
+}
+
+func TestOwnershipRecordName(t *testing.T) {
+	registry := &OwnershipRegistry{Prefix: DefaultOwnershipPrefix}
+	cases := []struct {
+		key      recordKey
+		expected string
+	}{
+		{recordKey{RecordTypeA, "API.example.com", ""}, "_dns-controller.a.api.example.com."},
+		{recordKey{RecordTypeCNAME, "www.example.com.", ""}, "_dns-controller.cname.www.example.com."},
+		{recordKey{RecordTypeAAAA, "*.apps.example.com.", ""}, "_dns-controller.aaaa._wildcard.apps.example.com."},
+	}
+	for _, c := range cases {
+		if actual := registry.recordName(c.key); actual != c.expected {
+			t.Errorf("recordName(%v) expected %q, but got %q", c.key, c.expected, actual)
+		}
+	}
+}
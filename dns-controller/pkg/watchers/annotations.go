@@ -16,6 +16,16 @@ limitations under the License.
 
 package watchers
 
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"k8s.io/klog/v2"
+	"k8s.io/kops/dns-controller/pkg/dns"
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider"
+)
+
 const (
 	// AnnotationNameDNSExternal is used to set up a DNS name for accessing the resource from outside the cluster
 	// For a service of Type=LoadBalancer, it would map to the external LB hostname or IP
@@ -25,3 +35,73 @@ const (
 	// This is only supported on Pods currently, and maps to the Internal address
 	AnnotationNameDNSInternal = "dns.alpha.kubernetes.io/internal"
 )
+
+const (
+	// AnnotationNameDNSSetIdentifier distinguishes the records of this resource from the records with the same names
+	// published for other resources, and is required for weighted and failover records
+	AnnotationNameDNSSetIdentifier = "dns.alpha.kubernetes.io/set-identifier"
+
+	// AnnotationNameDNSWeight is the relative weight of the records of this resource, for weighted routing
+	AnnotationNameDNSWeight = "dns.alpha.kubernetes.io/weight"
+
+	// AnnotationNameDNSFailover is the failover role of the records of this resource, primary or secondary
+	AnnotationNameDNSFailover = "dns.alpha.kubernetes.io/failover"
+
+	// AnnotationNameDNSHealthCheckID is the ID of an existing health check in the DNS service, associated with the records of this resource
+	AnnotationNameDNSHealthCheckID = "dns.alpha.kubernetes.io/health-check-id"
+)
+
+// parseRoutingPolicy returns the routing policy set by the annotations of a resource, or an empty policy if none is set
+func parseRoutingPolicy(annotations map[string]string) (dnsprovider.RoutingPolicy, error) {
+	policy := dnsprovider.RoutingPolicy{
+		SetIdentifier: strings.TrimSpace(annotations[AnnotationNameDNSSetIdentifier]),
+		HealthCheckID: strings.TrimSpace(annotations[AnnotationNameDNSHealthCheckID]),
+	}
+	weight := strings.TrimSpace(annotations[AnnotationNameDNSWeight])
+	failover := strings.TrimSpace(annotations[AnnotationNameDNSFailover])
+
+	if policy.SetIdentifier == "" {
+		if weight != "" || failover != "" || policy.HealthCheckID != "" {
+			return dnsprovider.RoutingPolicy{}, fmt.Errorf("annotation %s is required with %s, %s and %s", AnnotationNameDNSSetIdentifier, AnnotationNameDNSWeight, AnnotationNameDNSFailover, AnnotationNameDNSHealthCheckID)
+		}
+		return policy, nil
+	}
+
+	switch {
+	case weight != "" && failover != "":
+		return dnsprovider.RoutingPolicy{}, fmt.Errorf("annotations %s and %s are mutually exclusive", AnnotationNameDNSWeight, AnnotationNameDNSFailover)
+	case weight != "":
+		n, err := strconv.ParseInt(weight, 10, 64)
+		if err != nil || n < 0 || n > 255 {
+			return dnsprovider.RoutingPolicy{}, fmt.Errorf("annotation %s must be an integer between 0 and 255, got %q", AnnotationNameDNSWeight, weight)
+		}
+		policy.Weight = n
+	case failover != "":
+		switch strings.ToLower(failover) {
+		case "primary":
+			policy.Failover = dnsprovider.FailoverPrimary
+		case "secondary":
+			policy.Failover = dnsprovider.FailoverSecondary
+		default:
+			return dnsprovider.RoutingPolicy{}, fmt.Errorf("annotation %s must be primary or secondary, got %q", AnnotationNameDNSFailover, failover)
+		}
+	default:
+		return dnsprovider.RoutingPolicy{}, fmt.Errorf("annotation %s requires %s or %s", AnnotationNameDNSSetIdentifier, AnnotationNameDNSWeight, AnnotationNameDNSFailover)
+	}
+	return policy, nil
+}
+
+// withRoutingPolicy sets the routing policy from the annotations on the records of a resource.
+// If the annotations are invalid no records are returned, so that we don't publish records without their policy.
+func withRoutingPolicy(records []dns.Record, annotations map[string]string, description string) []dns.Record {
+	policy, err := parseRoutingPolicy(annotations)
+	if err != nil {
+		// TODO: Emit event so that users are informed of this
+		klog.Warningf("Not publishing records for %s: %v", description, err)
+		return nil
+	}
+	for i := range records {
+		records[i].Routing = policy
+	}
+	return records
+}
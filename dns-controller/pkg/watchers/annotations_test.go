@@ -0,0 +1,84 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package watchers
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider"
+)
+
+func TestParseRoutingPolicy(t *testing.T) {
+	cases := []struct {
+		annotations map[string]string
+		expected    dnsprovider.RoutingPolicy
+		err         string
+	}{
+		{
+			annotations: map[string]string{AnnotationNameDNSExternal: "app.example.com"},
+		},
+		{
+			annotations: map[string]string{AnnotationNameDNSSetIdentifier: "blue", AnnotationNameDNSWeight: "90"},
+			expected:    dnsprovider.RoutingPolicy{SetIdentifier: "blue", Weight: 90},
+		},
+		{
+			annotations: map[string]string{AnnotationNameDNSSetIdentifier: "main", AnnotationNameDNSFailover: "Primary", AnnotationNameDNSHealthCheckID: "hc-1"},
+			expected:    dnsprovider.RoutingPolicy{SetIdentifier: "main", Failover: dnsprovider.FailoverPrimary, HealthCheckID: "hc-1"},
+		},
+		{
+			annotations: map[string]string{AnnotationNameDNSSetIdentifier: "backup", AnnotationNameDNSFailover: "secondary"},
+			expected:    dnsprovider.RoutingPolicy{SetIdentifier: "backup", Failover: dnsprovider.FailoverSecondary},
+		},
+		{
+			annotations: map[string]string{AnnotationNameDNSWeight: "90"},
+			err:         "is required",
+		},
+		{
+			annotations: map[string]string{AnnotationNameDNSSetIdentifier: "blue"},
+			err:         "requires",
+		},
+		{
+			annotations: map[string]string{AnnotationNameDNSSetIdentifier: "blue", AnnotationNameDNSWeight: "90", AnnotationNameDNSFailover: "primary"},
+			err:         "mutually exclusive",
+		},
+		{
+			annotations: map[string]string{AnnotationNameDNSSetIdentifier: "blue", AnnotationNameDNSWeight: "256"},
+			err:         "between 0 and 255",
+		},
+		{
+			annotations: map[string]string{AnnotationNameDNSSetIdentifier: "blue", AnnotationNameDNSFailover: "tertiary"},
+			err:         "primary or secondary",
+		},
+	}
+	for _, c := range cases {
+		actual, err := parseRoutingPolicy(c.annotations)
+		if c.err != "" {
+			if err == nil || !strings.Contains(err.Error(), c.err) {
+				t.Errorf("parseRoutingPolicy(%v) expected error containing %q, got %v", c.annotations, c.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseRoutingPolicy(%v) unexpected error: %v", c.annotations, err)
+			continue
+		}
+		if actual != c.expected {
+			t.Errorf("parseRoutingPolicy(%v) expected %+v, got %+v", c.annotations, c.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,354 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package watchers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/dns-controller/pkg/dns"
+	"k8s.io/kops/dns-controller/pkg/util"
+	"k8s.io/kops/upup/pkg/fi/utils"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayclient "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned"
+)
+
+// GatewayController watches for Gateway and HTTPRoute objects, and publishes their hostnames
+// pointing at the addresses of the Gateways
+type GatewayController struct {
+	util.Stoppable
+	client    gatewayclient.Interface
+	namespace string
+	scope     dns.Scope
+
+	// mutex protects the fields below
+	mutex          sync.Mutex
+	gateways       map[string]*gatewayv1.Gateway
+	httpRoutes     map[string]*gatewayv1.HTTPRoute
+	gatewaysListed bool
+	routesListed   bool
+	ready          bool
+}
+
+// NewGatewayController creates a GatewayController
+func NewGatewayController(client gatewayclient.Interface, dns dns.Context, namespace string) (*GatewayController, error) {
+	scope, err := dns.CreateScope("gateway")
+	if err != nil {
+		return nil, fmt.Errorf("error building dns scope: %v", err)
+	}
+	c := &GatewayController{
+		client:     client,
+		namespace:  namespace,
+		scope:      scope,
+		gateways:   make(map[string]*gatewayv1.Gateway),
+		httpRoutes: make(map[string]*gatewayv1.HTTPRoute),
+	}
+
+	return c, nil
+}
+
+// Run starts the GatewayController.
+func (c *GatewayController) Run() {
+	klog.Infof("starting gateway controller")
+
+	stopCh := c.StopChannel()
+	go c.runGatewayWatcher(stopCh)
+	go c.runHTTPRouteWatcher(stopCh)
+
+	<-stopCh
+	klog.Infof("shutting down gateway controller")
+}
+
+func (c *GatewayController) runGatewayWatcher(stopCh <-chan struct{}) {
+	runOnce := func() (bool, error) {
+		ctx := context.TODO()
+
+		var listOpts metav1.ListOptions
+		gatewayList, err := c.client.GatewayV1().Gateways(c.namespace).List(ctx, listOpts)
+		if err != nil {
+			return false, fmt.Errorf("error listing gateways: %v", err)
+		}
+		gateways := make(map[string]*gatewayv1.Gateway)
+		for i := range gatewayList.Items {
+			gateway := &gatewayList.Items[i]
+			klog.V(4).Infof("found gateway: %v", gateway.Name)
+			gateways[gateway.Namespace+"/"+gateway.Name] = gateway
+		}
+
+		c.mutex.Lock()
+		c.gateways = gateways
+		c.gatewaysListed = true
+		c.updateRecords()
+		c.mutex.Unlock()
+
+		listOpts.Watch = true
+		listOpts.ResourceVersion = gatewayList.ResourceVersion
+		watcher, err := c.client.GatewayV1().Gateways(c.namespace).Watch(ctx, listOpts)
+		if err != nil {
+			return false, fmt.Errorf("error watching gateways: %v", err)
+		}
+		ch := watcher.ResultChan()
+		for {
+			select {
+			case <-stopCh:
+				klog.Infof("Got stop signal")
+				return true, nil
+			case event, ok := <-ch:
+				if !ok {
+					klog.Infof("gateway watch channel closed")
+					return false, nil
+				}
+
+				gateway := event.Object.(*gatewayv1.Gateway)
+				klog.V(4).Infof("gateway changed: %s %v", event.Type, gateway.Name)
+
+				c.mutex.Lock()
+				switch event.Type {
+				case watch.Added, watch.Modified:
+					c.gateways[gateway.Namespace+"/"+gateway.Name] = gateway
+
+				case watch.Deleted:
+					delete(c.gateways, gateway.Namespace+"/"+gateway.Name)
+
+				default:
+					klog.Warningf("Unknown event type: %v", event.Type)
+				}
+				c.updateRecords()
+				c.mutex.Unlock()
+			}
+		}
+	}
+
+	for {
+		stop, err := runOnce()
+		if stop {
+			return
+		}
+
+		if err != nil {
+			klog.Warningf("Unexpected error in event watch, will retry: %v", err)
+			time.Sleep(10 * time.Second)
+		}
+	}
+}
+
+func (c *GatewayController) runHTTPRouteWatcher(stopCh <-chan struct{}) {
+	runOnce := func() (bool, error) {
+		ctx := context.TODO()
+
+		var listOpts metav1.ListOptions
+		routeList, err := c.client.GatewayV1().HTTPRoutes(c.namespace).List(ctx, listOpts)
+		if err != nil {
+			return false, fmt.Errorf("error listing httproutes: %v", err)
+		}
+		routes := make(map[string]*gatewayv1.HTTPRoute)
+		for i := range routeList.Items {
+			route := &routeList.Items[i]
+			klog.V(4).Infof("found httproute: %v", route.Name)
+			routes[route.Namespace+"/"+route.Name] = route
+		}
+
+		c.mutex.Lock()
+		c.httpRoutes = routes
+		c.routesListed = true
+		c.updateRecords()
+		c.mutex.Unlock()
+
+		listOpts.Watch = true
+		listOpts.ResourceVersion = routeList.ResourceVersion
+		watcher, err := c.client.GatewayV1().HTTPRoutes(c.namespace).Watch(ctx, listOpts)
+		if err != nil {
+			return false, fmt.Errorf("error watching httproutes: %v", err)
+		}
+		ch := watcher.ResultChan()
+		for {
+			select {
+			case <-stopCh:
+				klog.Infof("Got stop signal")
+				return true, nil
+			case event, ok := <-ch:
+				if !ok {
+					klog.Infof("httproute watch channel closed")
+					return false, nil
+				}
+
+				route := event.Object.(*gatewayv1.HTTPRoute)
+				klog.V(4).Infof("httproute changed: %s %v", event.Type, route.Name)
+
+				c.mutex.Lock()
+				switch event.Type {
+				case watch.Added, watch.Modified:
+					c.httpRoutes[route.Namespace+"/"+route.Name] = route
+
+				case watch.Deleted:
+					delete(c.httpRoutes, route.Namespace+"/"+route.Name)
+
+				default:
+					klog.Warningf("Unknown event type: %v", event.Type)
+				}
+				c.updateRecords()
+				c.mutex.Unlock()
+			}
+		}
+	}
+
+	for {
+		stop, err := runOnce()
+		if stop {
+			return
+		}
+
+		if err != nil {
+			klog.Warningf("Unexpected error in event watch, will retry: %v", err)
+			time.Sleep(10 * time.Second)
+		}
+	}
+}
+
+// updateRecords applies the records of all the gateways and routes, as the records of a route depend on its gateways.
+// It must be called with the mutex held.
+func (c *GatewayController) updateRecords() {
+	if !c.gatewaysListed || !c.routesListed {
+		// Wait for both lists, so we don't remove the records of routes whose gateways are not yet known
+		return
+	}
+
+	desired := make(map[string][]dns.Record)
+	for key, gateway := range c.gateways {
+		var hostnames []string
+		for _, listener := range gateway.Spec.Listeners {
+			if listener.Hostname != nil && *listener.Hostname != "" {
+				hostnames = append(hostnames, string(*listener.Hostname))
+			}
+		}
+		records := buildGatewayRecords(hostnames, gatewayAddresses(gateway))
+		desired["gateway/"+key] = withRoutingPolicy(records, gateway.Annotations, "gateway "+key)
+	}
+
+	for key, route := range c.httpRoutes {
+		var hostnames []string
+		for _, hostname := range route.Spec.Hostnames {
+			hostnames = append(hostnames, string(hostname))
+		}
+
+		var addresses []dns.Record
+		for _, parent := range route.Status.Parents {
+			if !meta.IsStatusConditionTrue(parent.Conditions, string(gatewayv1.RouteConditionAccepted)) {
+				continue
+			}
+			ref := parent.ParentRef
+			if ref.Group != nil && *ref.Group != gatewayv1.GroupName {
+				continue
+			}
+			if ref.Kind != nil && *ref.Kind != "Gateway" {
+				continue
+			}
+			namespace := route.Namespace
+			if ref.Namespace != nil {
+				namespace = string(*ref.Namespace)
+			}
+			gateway := c.gateways[namespace+"/"+string(ref.Name)]
+			if gateway == nil {
+				klog.V(4).Infof("gateway %s/%s of httproute %s not found", namespace, ref.Name, key)
+				continue
+			}
+			addresses = append(addresses, gatewayAddresses(gateway)...)
+		}
+		records := buildGatewayRecords(hostnames, addresses)
+		desired["httproute/"+key] = withRoutingPolicy(records, route.Annotations, "httproute "+key)
+	}
+
+	for _, key := range c.scope.AllKeys() {
+		if _, found := desired[key]; !found {
+			// The gateway or route previously existed, but no longer exists; delete it from the scope
+			klog.V(2).Infof("removing gateway record not found in list: %s", key)
+			c.scope.Replace(key, nil)
+		}
+	}
+	for key, records := range desired {
+		c.scope.Replace(key, records)
+	}
+	if !c.ready {
+		c.scope.MarkReady()
+		c.ready = true
+	}
+}
+
+// gatewayAddresses returns the records for the addresses of a gateway, without FQDN
+func gatewayAddresses(gateway *gatewayv1.Gateway) []dns.Record {
+	var records []dns.Record
+	for _, address := range gateway.Status.Addresses {
+		addressType := gatewayv1.IPAddressType
+		if address.Type != nil {
+			addressType = *address.Type
+		}
+		switch addressType {
+		case gatewayv1.IPAddressType:
+			var recordType dns.RecordType = dns.RecordTypeA
+			if utils.IsIPv6IP(address.Value) {
+				recordType = dns.RecordTypeAAAA
+			}
+			records = append(records, dns.Record{
+				RecordType: recordType,
+				Value:      address.Value,
+			})
+		case gatewayv1.HostnameAddressType:
+			records = append(records, dns.Record{
+				RecordType: dns.RecordTypeCNAME,
+				Value:      address.Value,
+			})
+		default:
+			klog.V(4).Infof("ignoring address %q of unsupported type %q of gateway %s/%s", address.Value, addressType, gateway.Namespace, gateway.Name)
+		}
+	}
+	return records
+}
+
+// buildGatewayRecords returns the records for each hostname pointing at each address, sorted and without duplicates
+func buildGatewayRecords(hostnames []string, addresses []dns.Record) []dns.Record {
+	seen := make(map[dns.Record]bool)
+	var records []dns.Record
+	for _, hostname := range hostnames {
+		fqdn := dns.EnsureDotSuffix(hostname)
+		for _, address := range addresses {
+			r := address
+			r.FQDN = fqdn
+			if seen[r] {
+				continue
+			}
+			seen[r] = true
+			records = append(records, r)
+		}
+	}
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].FQDN != records[j].FQDN {
+			return records[i].FQDN < records[j].FQDN
+		}
+		if records[i].RecordType != records[j].RecordType {
+			return records[i].RecordType < records[j].RecordType
+		}
+		return records[i].Value < records[j].Value
+	})
+	return records
+}
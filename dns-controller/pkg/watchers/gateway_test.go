@@ -0,0 +1,159 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package watchers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kops/dns-controller/pkg/dns"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/pkg/client/clientset/versioned/fake"
+)
+
+func TestGatewayController(t *testing.T) {
+	ctx := context.Background()
+
+	hostnameAddress := gatewayv1.HostnameAddressType
+	listenerHostname := gatewayv1.Hostname("gateway.foo.com")
+	otherNamespace := gatewayv1.Namespace("infra")
+
+	accepted := []metav1.Condition{
+		{Type: string(gatewayv1.RouteConditionAccepted), Status: metav1.ConditionTrue},
+	}
+
+	// NewClientset does not support the gateway-api types
+	client := fake.NewSimpleClientset()
+
+	publicGateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "public", Namespace: "default"},
+		Spec: gatewayv1.GatewaySpec{
+			Listeners: []gatewayv1.Listener{
+				{Name: "http", Hostname: &listenerHostname},
+				{Name: "any"},
+			},
+		},
+		Status: gatewayv1.GatewayStatus{
+			Addresses: []gatewayv1.GatewayStatusAddress{
+				{Value: "54.100.0.1"},
+				{Value: "2001:db8::1"},
+			},
+		},
+	}
+	if _, err := client.GatewayV1().Gateways(publicGateway.Namespace).Create(ctx, publicGateway, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("unexpected error creating gateway: %v", err)
+	}
+
+	sharedGateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "shared", Namespace: "infra"},
+		Status: gatewayv1.GatewayStatus{
+			Addresses: []gatewayv1.GatewayStatusAddress{
+				{Type: &hostnameAddress, Value: "lb.example.com"},
+			},
+		},
+	}
+	if _, err := client.GatewayV1().Gateways(sharedGateway.Namespace).Create(ctx, sharedGateway, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("unexpected error creating gateway: %v", err)
+	}
+
+	appRoute := &gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+		Spec: gatewayv1.HTTPRouteSpec{
+			Hostnames: []gatewayv1.Hostname{"app.foo.com", "www.foo.com"},
+		},
+		Status: gatewayv1.HTTPRouteStatus{
+			RouteStatus: gatewayv1.RouteStatus{
+				Parents: []gatewayv1.RouteParentStatus{
+					{ParentRef: gatewayv1.ParentReference{Name: "public"}, Conditions: accepted},
+					{ParentRef: gatewayv1.ParentReference{Name: "shared", Namespace: &otherNamespace}, Conditions: accepted},
+				},
+			},
+		},
+	}
+	if _, err := client.GatewayV1().HTTPRoutes(appRoute.Namespace).Create(ctx, appRoute, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("unexpected error creating httproute: %v", err)
+	}
+
+	rejectedRoute := &gatewayv1.HTTPRoute{
+		// Routes not accepted by their gateway are not published
+		ObjectMeta: metav1.ObjectMeta{Name: "rejected", Namespace: "default"},
+		Spec: gatewayv1.HTTPRouteSpec{
+			Hostnames: []gatewayv1.Hostname{"rejected.foo.com"},
+		},
+		Status: gatewayv1.HTTPRouteStatus{
+			RouteStatus: gatewayv1.RouteStatus{
+				Parents: []gatewayv1.RouteParentStatus{
+					{ParentRef: gatewayv1.ParentReference{Name: "public"}},
+				},
+			},
+		},
+	}
+	if _, err := client.GatewayV1().HTTPRoutes(rejectedRoute.Namespace).Create(ctx, rejectedRoute, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("unexpected error creating httproute: %v", err)
+	}
+
+	ch := make(chan struct{})
+	scope := &fakeScope{
+		readyCh: ch,
+		records: make(map[string][]dns.Record),
+	}
+
+	dnsctx := &fakeDNSContext{
+		scope: scope,
+	}
+
+	c, err := NewGatewayController(client, dnsctx, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	go c.Run()
+
+	select {
+	case <-ch:
+	case <-time.After(time.Second):
+		t.Fatalf("update was not marked as complete")
+	}
+
+	c.Stop()
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	want := map[string][]dns.Record{
+		"gateway/default/public": {
+			{RecordType: "A", FQDN: "gateway.foo.com.", Value: "54.100.0.1"},
+			{RecordType: "AAAA", FQDN: "gateway.foo.com.", Value: "2001:db8::1"},
+		},
+		"gateway/infra/shared": nil,
+		"httproute/default/app": {
+			{RecordType: "A", FQDN: "app.foo.com.", Value: "54.100.0.1"},
+			{RecordType: "AAAA", FQDN: "app.foo.com.", Value: "2001:db8::1"},
+			{RecordType: "CNAME", FQDN: "app.foo.com.", Value: "lb.example.com"},
+			{RecordType: "A", FQDN: "www.foo.com.", Value: "54.100.0.1"},
+			{RecordType: "AAAA", FQDN: "www.foo.com.", Value: "2001:db8::1"},
+			{RecordType: "CNAME", FQDN: "www.foo.com.", Value: "lb.example.com"},
+		},
+		"httproute/default/rejected": nil,
+	}
+	if diff := cmp.Diff(scope.records, want); diff != "" {
+		t.Fatalf("generated records did not match expected; diff=%s", diff)
+	}
+}
@@ -181,6 +181,7 @@ func (c *IngressController) updateIngressRecords(ingress *v1.Ingress) string {
 	}
 
 	key := ingress.Namespace + "/" + ingress.Name
+	records = withRoutingPolicy(records, ingress.Annotations, "ingress "+key)
 	c.scope.Replace(key, records)
 	return key
 }
@@ -221,6 +221,7 @@ func (c *ServiceController) updateServiceRecords(service *v1.Service) string {
 	}
 
 	key := service.Namespace + "/" + service.Name
+	records = withRoutingPolicy(records, service.Annotations, "service "+key)
 	c.scope.Replace(key, records)
 	return key
 }
@@ -99,6 +99,48 @@ type ResourceRecordSet interface {
 	Type() rrstype.RrsType
 }
 
+const (
+	// FailoverPrimary is the failover role of the record set answering while it is healthy
+	FailoverPrimary = "PRIMARY"
+	// FailoverSecondary is the failover role of the record set answering while the primary is unhealthy
+	FailoverSecondary = "SECONDARY"
+)
+
+// RoutingPolicy selects which of the record sets with the same name and type answers a query,
+// for DNS services supporting weighted or failover routing.
+type RoutingPolicy struct {
+	// SetIdentifier distinguishes the record sets with the same name and type
+	SetIdentifier string
+	// Weight is the relative weight of a weighted record set, used if Failover is not set
+	Weight int64
+	// Failover is FailoverPrimary or FailoverSecondary for failover record sets
+	Failover string
+	// HealthCheckID is the ID of the health check of the record set in the DNS service
+	HealthCheckID string
+}
+
+// RoutingPolicyResourceRecordSets is implemented by the ResourceRecordSets of DNS services supporting routing policies.
+// It requires each set identifier to be a separate record set, so it is not implemented for Google Cloud DNS,
+// where the weighted and failover targets of a name are items of one record set.
+type RoutingPolicyResourceRecordSets interface {
+	// NewWithRoutingPolicy allocates a new ResourceRecordSet with a routing policy, as per New
+	NewWithRoutingPolicy(name string, rrdatas []string, ttl int64, rrstype rrstype.RrsType, policy RoutingPolicy) ResourceRecordSet
+}
+
+// RoutingPolicyResourceRecordSet is implemented by the ResourceRecordSet of DNS services supporting routing policies
+type RoutingPolicyResourceRecordSet interface {
+	// RoutingPolicy returns the routing policy of the record set, or nil if it has none
+	RoutingPolicy() *RoutingPolicy
+}
+
+// GetRoutingPolicy returns the routing policy of a record set, or nil if it has none
+func GetRoutingPolicy(rrs ResourceRecordSet) *RoutingPolicy {
+	if r, ok := rrs.(RoutingPolicyResourceRecordSet); ok {
+		return r.RoutingPolicy()
+	}
+	return nil
+}
+
 /*
 ResourceRecordSetsEquivalent compares two ResourceRecordSets for semantic equivalence.
 
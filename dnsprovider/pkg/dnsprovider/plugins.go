@@ -17,6 +17,7 @@ limitations under the License.
 package dnsprovider
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
@@ -62,6 +63,21 @@ func GetDnsProvider(name string, config io.Reader) (Interface, error) {
 	return f(config)
 }
 
+// ConfigLookup returns a function that looks up the settings of a DNS provider configured by variables.
+// The variables are read from config, a JSON object of names and values, or from the environment if config is nil.
+func ConfigLookup(config io.Reader) (func(string) string, error) {
+	if config == nil {
+		return os.Getenv, nil
+	}
+	values := make(map[string]string)
+	if err := json.NewDecoder(config).Decode(&values); err != nil {
+		return nil, fmt.Errorf("error parsing DNS provider configuration: %w", err)
+	}
+	return func(k string) string {
+		return values[k]
+	}, nil
+}
+
 // Returns a list of registered dns providers.
 func RegisteredDnsProviders() []string {
 	registeredProviders := make([]string, len(providers))
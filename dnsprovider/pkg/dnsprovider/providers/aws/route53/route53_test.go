@@ -21,6 +21,7 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"reflect"
 	"testing"
 
 	"k8s.io/kops/dnsprovider/pkg/dnsprovider"
@@ -302,3 +303,36 @@ func TestContract(t *testing.T) {
 
 	tests.TestContract(t, sets)
 }
+
+/* TestResourceRecordSetsRoutingPolicy verifies that record sets with the same name and type but a different set identifier can coexist */
+func TestResourceRecordSetsRoutingPolicy(t *testing.T) {
+	ctx := context.Background()
+
+	zone := firstZone(t)
+	sets := rrs(t, zone).(dnsprovider.RoutingPolicyResourceRecordSets)
+	blue := sets.NewWithRoutingPolicy("weighted."+zone.Name(), []string{"10.10.10.1"}, 60, rrstype.A, dnsprovider.RoutingPolicy{SetIdentifier: "blue", Weight: 90})
+	green := sets.NewWithRoutingPolicy("weighted."+zone.Name(), []string{"10.10.10.2"}, 60, rrstype.A, dnsprovider.RoutingPolicy{SetIdentifier: "green", Weight: 10, HealthCheckID: "abcdef"})
+	if err := rrs(t, zone).StartChangeset().Add(blue).Add(green).Apply(ctx); err != nil {
+		t.Fatalf("Failed to add recordsets: %v", err)
+	}
+	defer rrs(t, zone).StartChangeset().Remove(blue).Remove(green).Apply(ctx)
+
+	policies := make(map[string]dnsprovider.RoutingPolicy)
+	for _, record := range listRrsOrFail(t, rrs(t, zone)) {
+		if record.Name() != blue.Name() {
+			continue
+		}
+		policy := dnsprovider.GetRoutingPolicy(record)
+		if policy == nil {
+			t.Fatalf("record set %v has no routing policy", record)
+		}
+		policies[policy.SetIdentifier] = *policy
+	}
+	expected := map[string]dnsprovider.RoutingPolicy{
+		"blue":  {SetIdentifier: "blue", Weight: 90},
+		"green": {SetIdentifier: "green", Weight: 10, HealthCheckID: "abcdef"},
+	}
+	if !reflect.DeepEqual(policies, expected) {
+		t.Errorf("unexpected routing policies %v, expected %v", policies, expected)
+	}
+}
@@ -72,9 +72,19 @@ func buildChange(action route53types.ChangeAction, rrs dnsprovider.ResourceRecor
 		}
 		change.ResourceRecordSet.ResourceRecords = append(change.ResourceRecordSet.ResourceRecords, rr)
 	}
+	setRoutingPolicy(change.ResourceRecordSet, dnsprovider.GetRoutingPolicy(rrs))
 	return change
 }
 
+// changeKey returns the key identifying a record set in a changeset
+func changeKey(rrs dnsprovider.ResourceRecordSet) string {
+	key := string(rrs.Type()) + "::" + rrs.Name()
+	if policy := dnsprovider.GetRoutingPolicy(rrs); policy != nil {
+		key += "::" + policy.SetIdentifier
+	}
+	return key
+}
+
 func (c *ResourceRecordChangeset) Apply(ctx context.Context) error {
 	// Empty changesets should be a relatively quick no-op
 	if c.IsEmpty() {
@@ -85,17 +95,17 @@ func (c *ResourceRecordChangeset) Apply(ctx context.Context) error {
 
 	removals := make(map[string]route53types.Change)
 	for _, removal := range c.removals {
-		removals[string(removal.Type())+"::"+removal.Name()] = buildChange(route53types.ChangeActionDelete, removal)
+		removals[changeKey(removal)] = buildChange(route53types.ChangeActionDelete, removal)
 	}
 
 	additions := make(map[string]route53types.Change)
 	for _, addition := range c.additions {
-		additions[string(addition.Type())+"::"+addition.Name()] = buildChange(route53types.ChangeActionCreate, addition)
+		additions[changeKey(addition)] = buildChange(route53types.ChangeActionCreate, addition)
 	}
 
 	upserts := make(map[string]route53types.Change)
 	for _, upsert := range c.upserts {
-		upserts[string(upsert.Type())+"::"+upsert.Name()] = buildChange(route53types.ChangeActionUpsert, upsert)
+		upserts[changeKey(upsert)] = buildChange(route53types.ChangeActionUpsert, upsert)
 	}
 
 	doneKeys := make(map[string]bool)
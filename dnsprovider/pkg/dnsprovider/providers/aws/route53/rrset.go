@@ -26,6 +26,7 @@ import (
 
 // Compile time check for interface adherence
 var _ dnsprovider.ResourceRecordSet = ResourceRecordSet{}
+var _ dnsprovider.RoutingPolicyResourceRecordSet = ResourceRecordSet{}
 
 type ResourceRecordSet struct {
 	impl   *route53types.ResourceRecordSet
@@ -53,6 +54,20 @@ func (rrset ResourceRecordSet) Type() rrstype.RrsType {
 	return rrstype.RrsType(rrset.impl.Type)
 }
 
+// RoutingPolicy returns the routing policy of the record set, or nil if it has none
+func (rrset ResourceRecordSet) RoutingPolicy() *dnsprovider.RoutingPolicy {
+	if rrset.impl.SetIdentifier == nil {
+		return nil
+	}
+	policy := &dnsprovider.RoutingPolicy{
+		SetIdentifier: aws.ToString(rrset.impl.SetIdentifier),
+		Weight:        aws.ToInt64(rrset.impl.Weight),
+		Failover:      string(rrset.impl.Failover),
+		HealthCheckID: aws.ToString(rrset.impl.HealthCheckId),
+	}
+	return policy
+}
+
 // Route53ResourceRecordSet returns the route53 ResourceRecordSet object for the ResourceRecordSet
 // This is a "back door" that allows for limited access to the ResourceRecordSet,
 // without having to requery it, so that we can expose AWS specific functionality.
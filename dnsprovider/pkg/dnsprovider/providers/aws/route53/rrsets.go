@@ -29,6 +29,7 @@ import (
 
 // Compile time check for interface adherence
 var _ dnsprovider.ResourceRecordSets = ResourceRecordSets{}
+var _ dnsprovider.RoutingPolicyResourceRecordSets = ResourceRecordSets{}
 
 type ResourceRecordSets struct {
 	zone *Zone
@@ -105,6 +106,28 @@ func (r ResourceRecordSets) New(name string, rrdatas []string, ttl int64, rrstyp
 	}
 }
 
+func (r ResourceRecordSets) NewWithRoutingPolicy(name string, rrdatas []string, ttl int64, rrstype rrstype.RrsType, policy dnsprovider.RoutingPolicy) dnsprovider.ResourceRecordSet {
+	rrset := r.New(name, rrdatas, ttl, rrstype).(ResourceRecordSet)
+	setRoutingPolicy(rrset.impl, &policy)
+	return rrset
+}
+
+// setRoutingPolicy sets the routing policy fields of a route53 ResourceRecordSet
+func setRoutingPolicy(rrs *route53types.ResourceRecordSet, policy *dnsprovider.RoutingPolicy) {
+	if policy == nil || policy.SetIdentifier == "" {
+		return
+	}
+	rrs.SetIdentifier = aws.String(policy.SetIdentifier)
+	if policy.Failover != "" {
+		rrs.Failover = route53types.ResourceRecordSetFailover(policy.Failover)
+	} else {
+		rrs.Weight = aws.Int64(policy.Weight)
+	}
+	if policy.HealthCheckID != "" {
+		rrs.HealthCheckId = aws.String(policy.HealthCheckID)
+	}
+}
+
 // Zone returns the parent zone
 func (rrset ResourceRecordSets) Zone() dnsprovider.Zone {
 	return rrset.zone
@@ -76,7 +76,7 @@ func (r *Route53APIStub) ChangeResourceRecordSets(ctx context.Context, input *ro
 	}
 
 	for _, change := range input.ChangeBatch.Changes {
-		key := *change.ResourceRecordSet.Name + "::" + string(change.ResourceRecordSet.Type)
+		key := *change.ResourceRecordSet.Name + "::" + string(change.ResourceRecordSet.Type) + "::" + aws.ToString(change.ResourceRecordSet.SetIdentifier)
 		switch change.Action {
 		case route53types.ChangeActionCreate:
 			if _, found := recordSets[key]; found {
@@ -89,7 +89,7 @@ func (r *Route53APIStub) ChangeResourceRecordSets(ctx context.Context, input *ro
 			}
 			delete(recordSets, key)
 		case route53types.ChangeActionUpsert:
-			// TODO - not used yet
+			recordSets[key] = []route53types.ResourceRecordSet{*change.ResourceRecordSet}
 		}
 	}
 	r.recordSets[*input.HostedZoneId] = recordSets
@@ -0,0 +1,533 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudflare
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider"
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider/rrstype"
+)
+
+var _ dnsprovider.Interface = &Interface{}
+
+const (
+	ProviderName = "cloudflare"
+
+	// DefaultEndpoint is the base URL of the Cloudflare API
+	DefaultEndpoint = "https://api.cloudflare.com/client/v4"
+
+	// pageSize is the number of results requested per page
+	pageSize = 100
+)
+
+// EnvVars are the environment variables configuring the provider
+var EnvVars = []string{"CLOUDFLARE_API_TOKEN", "CLOUDFLARE_ACCOUNT_ID"}
+
+func init() {
+	dnsprovider.RegisterDNSProvider(ProviderName, func(config io.Reader) (dnsprovider.Interface, error) {
+		getenv, err := dnsprovider.ConfigLookup(config)
+		if err != nil {
+			return nil, err
+		}
+		token := getenv("CLOUDFLARE_API_TOKEN")
+		if token == "" {
+			return nil, errors.New("CLOUDFLARE_API_TOKEN is required")
+		}
+
+		client := &Client{
+			HTTPClient: &http.Client{Timeout: 30 * time.Second},
+			Endpoint:   DefaultEndpoint,
+			Token:      token,
+			AccountID:  getenv("CLOUDFLARE_ACCOUNT_ID"),
+		}
+		return NewProvider(client), nil
+	})
+}
+
+// Client is a minimal client for the zones and DNS records of the Cloudflare API
+type Client struct {
+	HTTPClient *http.Client
+	// Endpoint is the base URL of the API
+	Endpoint string
+	// Token is the API token, which needs the Zone:Read and DNS:Edit permissions
+	Token string
+	// AccountID is the account in which zones are created; only needed to create zones
+	AccountID string
+}
+
+// apiResponse is the envelope of all Cloudflare API responses
+type apiResponse struct {
+	Success    bool            `json:"success"`
+	Errors     []apiError      `json:"errors"`
+	Result     json.RawMessage `json:"result"`
+	ResultInfo *resultInfo     `json:"result_info,omitempty"`
+}
+
+type apiError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type resultInfo struct {
+	Page       int `json:"page"`
+	TotalPages int `json:"total_pages"`
+}
+
+// cloudflareZone is a zone in the Cloudflare API
+type cloudflareZone struct {
+	ID      string          `json:"id,omitempty"`
+	Name    string          `json:"name"`
+	Account *cloudflareName `json:"account,omitempty"`
+}
+
+type cloudflareName struct {
+	ID string `json:"id"`
+}
+
+// cloudflareRecord is a DNS record in the Cloudflare API
+type cloudflareRecord struct {
+	ID      string `json:"id,omitempty"`
+	Type    string `json:"type"`
+	Name    string `json:"name"`
+	Content string `json:"content"`
+	TTL     int64  `json:"ttl"`
+	Proxied bool   `json:"proxied"`
+}
+
+// do sends a request to the API, and decodes the result into out if not nil
+func (c *Client) do(ctx context.Context, method string, path string, query url.Values, body any, out any) (*resultInfo, error) {
+	u := c.Endpoint + path
+	if len(query) != 0 {
+		u += "?" + query.Encode()
+	}
+
+	var bodyReader io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("error encoding request: %w", err)
+		}
+		bodyReader = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, bodyReader)
+	if err != nil {
+		return nil, fmt.Errorf("error building request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.Token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error calling %s %s: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	response := &apiResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(response); err != nil {
+		return nil, fmt.Errorf("error decoding response to %s %s (status %d): %w", method, path, resp.StatusCode, err)
+	}
+	if !response.Success || resp.StatusCode >= 300 {
+		var messages []string
+		for _, e := range response.Errors {
+			messages = append(messages, fmt.Sprintf("%d: %s", e.Code, e.Message))
+		}
+		return nil, fmt.Errorf("%s %s failed with status %d: %s", method, path, resp.StatusCode, strings.Join(messages, "; "))
+	}
+
+	if out != nil {
+		if err := json.Unmarshal(response.Result, out); err != nil {
+			return nil, fmt.Errorf("error decoding result of %s %s: %w", method, path, err)
+		}
+	}
+	return response.ResultInfo, nil
+}
+
+// listZones returns all the zones the token has access to
+func (c *Client) listZones(ctx context.Context) ([]cloudflareZone, error) {
+	var zones []cloudflareZone
+	for page := 1; ; page++ {
+		var results []cloudflareZone
+		query := url.Values{"page": {strconv.Itoa(page)}, "per_page": {strconv.Itoa(pageSize)}}
+		info, err := c.do(ctx, http.MethodGet, "/zones", query, nil, &results)
+		if err != nil {
+			return nil, fmt.Errorf("error listing zones: %w", err)
+		}
+		zones = append(zones, results...)
+		if info == nil || page >= info.TotalPages {
+			return zones, nil
+		}
+	}
+}
+
+// listRecords returns all the records of a zone
+func (c *Client) listRecords(ctx context.Context, zoneID string) ([]cloudflareRecord, error) {
+	var records []cloudflareRecord
+	for page := 1; ; page++ {
+		var results []cloudflareRecord
+		query := url.Values{"page": {strconv.Itoa(page)}, "per_page": {strconv.Itoa(pageSize)}}
+		info, err := c.do(ctx, http.MethodGet, "/zones/"+zoneID+"/dns_records", query, nil, &results)
+		if err != nil {
+			return nil, fmt.Errorf("error listing records: %w", err)
+		}
+		records = append(records, results...)
+		if info == nil || page >= info.TotalPages {
+			return records, nil
+		}
+	}
+}
+
+// Interface implements dnsprovider.Interface
+type Interface struct {
+	client *Client
+}
+
+// NewProvider returns an implementation of dnsprovider.Interface
+func NewProvider(client *Client) *Interface {
+	return &Interface{client: client}
+}
+
+// Zones returns an implementation of dnsprovider.Zones
+func (d *Interface) Zones() (dnsprovider.Zones, bool) {
+	return &zones{client: d.client}, true
+}
+
+// zones implements dnsprovider.Zones
+type zones struct {
+	client *Client
+}
+
+// List returns the zones the API token has access to
+func (z *zones) List() ([]dnsprovider.Zone, error) {
+	results, err := z.client.listZones(context.TODO())
+	if err != nil {
+		return nil, err
+	}
+
+	var zones []dnsprovider.Zone
+	for _, result := range results {
+		zones = append(zones, &zone{id: result.ID, name: result.Name, client: z.client})
+	}
+	return zones, nil
+}
+
+// Add creates a zone in the configured account
+func (z *zones) Add(newZone dnsprovider.Zone) (dnsprovider.Zone, error) {
+	if z.client.AccountID == "" {
+		return nil, fmt.Errorf("CLOUDFLARE_ACCOUNT_ID is required to create zone %q", newZone.Name())
+	}
+
+	request := &cloudflareZone{
+		Name:    strings.TrimSuffix(newZone.Name(), "."),
+		Account: &cloudflareName{ID: z.client.AccountID},
+	}
+	result := &cloudflareZone{}
+	if _, err := z.client.do(context.TODO(), http.MethodPost, "/zones", nil, request, result); err != nil {
+		return nil, fmt.Errorf("error creating zone %q: %w", newZone.Name(), err)
+	}
+	return &zone{id: result.ID, name: result.Name, client: z.client}, nil
+}
+
+// Remove deletes a zone
+func (z *zones) Remove(zone dnsprovider.Zone) error {
+	if _, err := z.client.do(context.TODO(), http.MethodDelete, "/zones/"+zone.ID(), nil, nil, nil); err != nil {
+		return fmt.Errorf("error deleting zone %q: %w", zone.Name(), err)
+	}
+	return nil
+}
+
+// New returns a new implementation of dnsprovider.Zone, which can be passed to Add
+func (z *zones) New(name string) (dnsprovider.Zone, error) {
+	return &zone{name: strings.TrimSuffix(name, "."), client: z.client}, nil
+}
+
+// zone implements dnsprovider.Zone
+type zone struct {
+	id     string
+	name   string
+	client *Client
+}
+
+// Name returns the name of the zone, e.g. example.com
+func (z *zone) Name() string {
+	return z.name
+}
+
+// ID returns the Cloudflare identifier of the zone
+func (z *zone) ID() string {
+	return z.id
+}
+
+// ResourceRecordSets returns an implementation of dnsprovider.ResourceRecordSets
+func (z *zone) ResourceRecordSets() (dnsprovider.ResourceRecordSets, bool) {
+	return &resourceRecordSets{zone: z}, true
+}
+
+// resourceRecordSets implements dnsprovider.ResourceRecordSets
+type resourceRecordSets struct {
+	zone *zone
+}
+
+// List returns the records of the zone, grouped by name and type
+func (r *resourceRecordSets) List() ([]dnsprovider.ResourceRecordSet, error) {
+	records, err := r.zone.client.listRecords(context.TODO(), r.zone.id)
+	if err != nil {
+		return nil, err
+	}
+
+	var rrsets []dnsprovider.ResourceRecordSet
+	byKey := make(map[string]*resourceRecordSet)
+	for _, record := range records {
+		key := record.Name + "::" + record.Type
+		rrset := byKey[key]
+		if rrset == nil {
+			rrset = &resourceRecordSet{
+				name:       record.Name,
+				ttl:        record.TTL,
+				recordType: rrstype.RrsType(record.Type),
+			}
+			byKey[key] = rrset
+			rrsets = append(rrsets, rrset)
+		}
+		rrset.data = append(rrset.data, record.Content)
+	}
+	return rrsets, nil
+}
+
+// Get returns the record sets with the name
+func (r *resourceRecordSets) Get(name string) ([]dnsprovider.ResourceRecordSet, error) {
+	rrsets, err := r.List()
+	if err != nil {
+		return nil, err
+	}
+
+	name = normalizeName(name)
+	var matches []dnsprovider.ResourceRecordSet
+	for _, rrset := range rrsets {
+		if rrset.Name() == name {
+			matches = append(matches, rrset)
+		}
+	}
+	return matches, nil
+}
+
+// New returns an implementation of dnsprovider.ResourceRecordSet
+func (r *resourceRecordSets) New(name string, rrdatas []string, ttl int64, rrstype rrstype.RrsType) dnsprovider.ResourceRecordSet {
+	return &resourceRecordSet{
+		name:       normalizeName(name),
+		data:       rrdatas,
+		ttl:        ttl,
+		recordType: rrstype,
+	}
+}
+
+// StartChangeset returns an implementation of dnsprovider.ResourceRecordChangeset
+func (r *resourceRecordSets) StartChangeset() dnsprovider.ResourceRecordChangeset {
+	return &resourceRecordChangeset{rrsets: r}
+}
+
+// Zone returns the parent zone
+func (r *resourceRecordSets) Zone() dnsprovider.Zone {
+	return r.zone
+}
+
+// resourceRecordSet implements dnsprovider.ResourceRecordSet
+type resourceRecordSet struct {
+	name       string
+	data       []string
+	ttl        int64
+	recordType rrstype.RrsType
+}
+
+// Name returns the name of the record set; Cloudflare names have no trailing dot
+func (r *resourceRecordSet) Name() string {
+	return r.name
+}
+
+// Rrdatas returns the content of the records
+func (r *resourceRecordSet) Rrdatas() []string {
+	return r.data
+}
+
+// Ttl returns the time-to-live of the record set, 1 means automatic
+func (r *resourceRecordSet) Ttl() int64 {
+	return r.ttl
+}
+
+// Type returns the type of the record set
+func (r *resourceRecordSet) Type() rrstype.RrsType {
+	return r.recordType
+}
+
+// resourceRecordChangeset implements dnsprovider.ResourceRecordChangeset
+type resourceRecordChangeset struct {
+	rrsets *resourceRecordSets
+
+	additions []dnsprovider.ResourceRecordSet
+	removals  []dnsprovider.ResourceRecordSet
+	upserts   []dnsprovider.ResourceRecordSet
+}
+
+// Add adds the creation of the record set to the changeset
+func (c *resourceRecordChangeset) Add(rrset dnsprovider.ResourceRecordSet) dnsprovider.ResourceRecordChangeset {
+	c.additions = append(c.additions, rrset)
+	return c
+}
+
+// Remove adds the removal of the record set to the changeset
+func (c *resourceRecordChangeset) Remove(rrset dnsprovider.ResourceRecordSet) dnsprovider.ResourceRecordChangeset {
+	c.removals = append(c.removals, rrset)
+	return c
+}
+
+// Upsert adds the replacement of the record set to the changeset
+func (c *resourceRecordChangeset) Upsert(rrset dnsprovider.ResourceRecordSet) dnsprovider.ResourceRecordChangeset {
+	c.upserts = append(c.upserts, rrset)
+	return c
+}
+
+// Apply applies the changes one record at a time, as the Cloudflare API has no record sets.
+// Upserts create the new records before deleting the old ones, so the name keeps resolving.
+func (c *resourceRecordChangeset) Apply(ctx context.Context) error {
+	if c.IsEmpty() {
+		klog.V(4).Info("record change set is empty")
+		return nil
+	}
+
+	client := c.rrsets.zone.client
+	zoneID := c.rrsets.zone.id
+
+	existing, err := client.listRecords(ctx, zoneID)
+	if err != nil {
+		return err
+	}
+
+	for _, rrset := range c.removals {
+		for _, record := range matchingRecords(existing, rrset) {
+			if !slices.Contains(rrset.Rrdatas(), record.Content) {
+				continue
+			}
+			if err := c.deleteRecord(ctx, record); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, rrset := range c.upserts {
+		old := matchingRecords(existing, rrset)
+		if err := c.createRecords(ctx, rrset, old); err != nil {
+			return err
+		}
+		for _, record := range old {
+			if slices.Contains(rrset.Rrdatas(), record.Content) && record.TTL == rrset.Ttl() {
+				continue
+			}
+			if err := c.deleteRecord(ctx, record); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, rrset := range c.additions {
+		if err := c.createRecords(ctx, rrset, nil); err != nil {
+			return err
+		}
+	}
+
+	klog.V(2).Infof("applied changes to zone %q", c.rrsets.zone.name)
+	return nil
+}
+
+// createRecords creates a record for each of the data of the record set, skipping identical records in existing
+func (c *resourceRecordChangeset) createRecords(ctx context.Context, rrset dnsprovider.ResourceRecordSet, existing []cloudflareRecord) error {
+	zone := c.rrsets.zone
+	for _, data := range rrset.Rrdatas() {
+		found := false
+		for _, record := range existing {
+			if record.Content == data && record.TTL == rrset.Ttl() {
+				found = true
+			}
+		}
+		if found {
+			continue
+		}
+
+		record := &cloudflareRecord{
+			Type:    string(rrset.Type()),
+			Name:    normalizeName(rrset.Name()),
+			Content: data,
+			TTL:     rrset.Ttl(),
+		}
+		klog.V(2).Infof("creating %s record %s: %s", record.Type, record.Name, record.Content)
+		if _, err := zone.client.do(ctx, http.MethodPost, "/zones/"+zone.id+"/dns_records", nil, record, nil); err != nil {
+			return fmt.Errorf("error creating %s record %q: %w", record.Type, record.Name, err)
+		}
+	}
+	return nil
+}
+
+func (c *resourceRecordChangeset) deleteRecord(ctx context.Context, record cloudflareRecord) error {
+	zone := c.rrsets.zone
+	klog.V(2).Infof("deleting %s record %s: %s", record.Type, record.Name, record.Content)
+	if _, err := zone.client.do(ctx, http.MethodDelete, "/zones/"+zone.id+"/dns_records/"+record.ID, nil, nil, nil); err != nil {
+		return fmt.Errorf("error deleting %s record %q: %w", record.Type, record.Name, err)
+	}
+	return nil
+}
+
+// IsEmpty returns true if there are no changes
+func (c *resourceRecordChangeset) IsEmpty() bool {
+	return len(c.additions) == 0 && len(c.removals) == 0 && len(c.upserts) == 0
+}
+
+// ResourceRecordSets returns the parent ResourceRecordSets
+func (c *resourceRecordChangeset) ResourceRecordSets() dnsprovider.ResourceRecordSets {
+	return c.rrsets
+}
+
+// matchingRecords returns the records with the name and type of the record set
+func matchingRecords(records []cloudflareRecord, rrset dnsprovider.ResourceRecordSet) []cloudflareRecord {
+	name := normalizeName(rrset.Name())
+	var matches []cloudflareRecord
+	for _, record := range records {
+		if strings.EqualFold(record.Name, name) && record.Type == string(rrset.Type()) {
+			matches = append(matches, record)
+		}
+	}
+	return matches
+}
+
+// normalizeName returns the name as used by Cloudflare, in lower case without the trailing dot
+func normalizeName(name string) string {
+	return strings.ToLower(strings.TrimSuffix(name, "."))
+}
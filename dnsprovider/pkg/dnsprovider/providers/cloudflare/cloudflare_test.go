@@ -0,0 +1,328 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudflare
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider"
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider/rrstype"
+)
+
+const testToken = "test-token"
+
+// fakeCloudflare implements the zones and DNS records endpoints of the Cloudflare API
+type fakeCloudflare struct {
+	t *testing.T
+
+	mutex   sync.Mutex
+	zones   []cloudflareZone
+	records map[string][]cloudflareRecord
+	nextID  int
+	// pageSize overrides the requested page size, to test pagination
+	pageSize int
+}
+
+func newFakeCloudflare(t *testing.T) (*fakeCloudflare, *Interface) {
+	f := &fakeCloudflare{
+		t:        t,
+		records:  make(map[string][]cloudflareRecord),
+		pageSize: 2,
+	}
+	server := httptest.NewServer(f)
+	t.Cleanup(server.Close)
+
+	provider := NewProvider(&Client{
+		HTTPClient: server.Client(),
+		Endpoint:   server.URL + "/client/v4",
+		Token:      testToken,
+		AccountID:  "account-1",
+	})
+	return f, provider
+}
+
+func (f *fakeCloudflare) newID() string {
+	f.nextID++
+	return fmt.Sprintf("id-%d", f.nextID)
+}
+
+func (f *fakeCloudflare) addZone(name string) string {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	id := f.newID()
+	f.zones = append(f.zones, cloudflareZone{ID: id, Name: name})
+	return id
+}
+
+func (f *fakeCloudflare) addRecord(zoneID string, recordType string, name string, content string, ttl int64) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.records[zoneID] = append(f.records[zoneID], cloudflareRecord{ID: f.newID(), Type: recordType, Name: name, Content: content, TTL: ttl})
+}
+
+// dump returns the records of a zone, sorted
+func (f *fakeCloudflare) dump(zoneID string) []string {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	var lines []string
+	for _, record := range f.records[zoneID] {
+		lines = append(lines, fmt.Sprintf("%s %d %s %s", record.Name, record.TTL, record.Type, record.Content))
+	}
+	sort.Strings(lines)
+	return lines
+}
+
+func (f *fakeCloudflare) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if r.Header.Get("Authorization") != "Bearer "+testToken {
+		f.reply(w, http.StatusForbidden, nil, nil, &apiError{Code: 9109, Message: "Invalid access token"})
+		return
+	}
+
+	path := strings.Split(strings.TrimPrefix(r.URL.Path, "/client/v4/"), "/")
+	switch {
+	case len(path) == 1 && path[0] == "zones" && r.Method == http.MethodGet:
+		replyPage(f, w, r, f.zones)
+
+	case len(path) == 1 && path[0] == "zones" && r.Method == http.MethodPost:
+		request := &cloudflareZone{}
+		if err := json.NewDecoder(r.Body).Decode(request); err != nil {
+			f.t.Errorf("error decoding request: %v", err)
+		}
+		if request.Account == nil || request.Account.ID != "account-1" {
+			f.reply(w, http.StatusBadRequest, nil, nil, &apiError{Code: 1000, Message: "account required"})
+			return
+		}
+		zone := cloudflareZone{ID: f.newID(), Name: request.Name}
+		f.zones = append(f.zones, zone)
+		f.reply(w, http.StatusOK, zone, nil, nil)
+
+	case len(path) == 2 && path[0] == "zones" && r.Method == http.MethodDelete:
+		var zones []cloudflareZone
+		for _, zone := range f.zones {
+			if zone.ID != path[1] {
+				zones = append(zones, zone)
+			}
+		}
+		f.zones = zones
+		f.reply(w, http.StatusOK, map[string]string{"id": path[1]}, nil, nil)
+
+	case len(path) == 3 && path[2] == "dns_records" && r.Method == http.MethodGet:
+		replyPage(f, w, r, f.records[path[1]])
+
+	case len(path) == 3 && path[2] == "dns_records" && r.Method == http.MethodPost:
+		record := cloudflareRecord{}
+		if err := json.NewDecoder(r.Body).Decode(&record); err != nil {
+			f.t.Errorf("error decoding request: %v", err)
+		}
+		record.ID = f.newID()
+		f.records[path[1]] = append(f.records[path[1]], record)
+		f.reply(w, http.StatusOK, record, nil, nil)
+
+	case len(path) == 4 && path[2] == "dns_records" && r.Method == http.MethodDelete:
+		var records []cloudflareRecord
+		for _, record := range f.records[path[1]] {
+			if record.ID != path[3] {
+				records = append(records, record)
+			}
+		}
+		f.records[path[1]] = records
+		f.reply(w, http.StatusOK, map[string]string{"id": path[3]}, nil, nil)
+
+	default:
+		f.reply(w, http.StatusNotFound, nil, nil, &apiError{Code: 7003, Message: "No route for that URI"})
+	}
+}
+
+// replyPage replies with the requested page of items
+func replyPage[T any](f *fakeCloudflare, w http.ResponseWriter, r *http.Request, items []T) {
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if page == 0 {
+		page = 1
+	}
+	totalPages := (len(items) + f.pageSize - 1) / f.pageSize
+	start := min((page-1)*f.pageSize, len(items))
+	end := min(start+f.pageSize, len(items))
+	f.reply(w, http.StatusOK, items[start:end], &resultInfo{Page: page, TotalPages: totalPages}, nil)
+}
+
+func (f *fakeCloudflare) reply(w http.ResponseWriter, status int, result any, info *resultInfo, apiErr *apiError) {
+	response := map[string]any{
+		"success": apiErr == nil,
+		"errors":  []any{},
+		"result":  result,
+	}
+	if apiErr != nil {
+		response["errors"] = []any{apiErr}
+	}
+	if info != nil {
+		response["result_info"] = info
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(response)
+}
+
+func findZone(t *testing.T, provider dnsprovider.Interface, name string) dnsprovider.Zone {
+	zonesProvider, _ := provider.Zones()
+	zones, err := zonesProvider.List()
+	if err != nil {
+		t.Fatalf("error listing zones: %v", err)
+	}
+	for _, zone := range zones {
+		if zone.Name() == name {
+			return zone
+		}
+	}
+	t.Fatalf("zone %q not found in %v", name, zones)
+	return nil
+}
+
+func TestZones(t *testing.T) {
+	f, provider := newFakeCloudflare(t)
+	f.addZone("example.com")
+	f.addZone("example.org")
+	id := f.addZone("example.net")
+
+	if zone := findZone(t, provider, "example.net"); zone.ID() != id {
+		t.Errorf("expected zone ID %q, got %q", id, zone.ID())
+	}
+
+	zonesProvider, _ := provider.Zones()
+	newZone, err := zonesProvider.New("k8s.example.com.")
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	zone, err := zonesProvider.Add(newZone)
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if zone.Name() != "k8s.example.com" || zone.ID() == "" {
+		t.Errorf("unexpected zone %q with ID %q", zone.Name(), zone.ID())
+	}
+
+	if err := zonesProvider.Remove(zone); err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+	zones, err := zonesProvider.List()
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(zones) != 3 {
+		t.Errorf("expected 3 zones after removal, got %d", len(zones))
+	}
+}
+
+func TestListRecords(t *testing.T) {
+	f, provider := newFakeCloudflare(t)
+	zoneID := f.addZone("example.com")
+	f.addRecord(zoneID, "A", "api.example.com", "192.0.2.1", 60)
+	f.addRecord(zoneID, "NS", "example.com", "ns1.example.net", 86400)
+	f.addRecord(zoneID, "A", "api.example.com", "192.0.2.2", 60)
+	f.addRecord(zoneID, "AAAA", "api.example.com", "2001:db8::1", 60)
+
+	rrsets, _ := findZone(t, provider, "example.com").ResourceRecordSets()
+	records, err := rrsets.List()
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+
+	var actual []string
+	for _, rrset := range records {
+		actual = append(actual, fmt.Sprintf("%s %d %s %s", rrset.Name(), rrset.Ttl(), rrset.Type(), strings.Join(rrset.Rrdatas(), ",")))
+	}
+	expected := []string{
+		"api.example.com 60 A 192.0.2.1,192.0.2.2",
+		"example.com 86400 NS ns1.example.net",
+		"api.example.com 60 AAAA 2001:db8::1",
+	}
+	if strings.Join(actual, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("unexpected records\nexpected:\n%s\nactual:\n%s", strings.Join(expected, "\n"), strings.Join(actual, "\n"))
+	}
+
+	matches, err := rrsets.Get("API.example.com.")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if len(matches) != 2 {
+		t.Errorf("expected 2 record sets for api.example.com, got %d", len(matches))
+	}
+}
+
+func TestApply(t *testing.T) {
+	ctx := context.TODO()
+
+	f, provider := newFakeCloudflare(t)
+	zoneID := f.addZone("example.com")
+	f.addRecord(zoneID, "A", "api.example.com", "192.0.2.1", 60)
+	f.addRecord(zoneID, "A", "api.example.com", "192.0.2.2", 60)
+	f.addRecord(zoneID, "A", "api.internal.example.com", "10.0.0.1", 60)
+	f.addRecord(zoneID, "A", "api.internal.example.com", "10.0.0.2", 60)
+	f.addRecord(zoneID, "AAAA", "api.internal.example.com", "2001:db8::1", 60)
+
+	rrsets, _ := findZone(t, provider, "example.com").ResourceRecordSets()
+
+	changeset := rrsets.StartChangeset()
+	if !changeset.IsEmpty() {
+		t.Errorf("expected empty changeset")
+	}
+	if err := changeset.Apply(ctx); err != nil {
+		t.Fatalf("error applying empty changeset: %v", err)
+	}
+
+	changeset.Remove(rrsets.New("api.example.com.", []string{"192.0.2.1", "192.0.2.2"}, 60, rrstype.A))
+	changeset.Upsert(rrsets.New("api.internal.example.com.", []string{"10.0.0.2", "10.0.0.3"}, 60, rrstype.A))
+	changeset.Add(rrsets.New("kops-controller.internal.example.com.", []string{"10.0.0.3"}, 60, rrstype.A))
+	if err := changeset.Apply(ctx); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+
+	expected := []string{
+		"api.internal.example.com 60 A 10.0.0.2",
+		"api.internal.example.com 60 A 10.0.0.3",
+		"api.internal.example.com 60 AAAA 2001:db8::1",
+		"kops-controller.internal.example.com 60 A 10.0.0.3",
+	}
+	if actual := f.dump(zoneID); strings.Join(actual, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("unexpected records\nexpected:\n%s\nactual:\n%s", strings.Join(expected, "\n"), strings.Join(actual, "\n"))
+	}
+}
+
+func TestAPIErrors(t *testing.T) {
+	_, provider := newFakeCloudflare(t)
+	provider.client.Token = "wrong"
+
+	zonesProvider, _ := provider.Zones()
+	_, err := zonesProvider.List()
+	if err == nil || !strings.Contains(err.Error(), "9109: Invalid access token") {
+		t.Errorf("expected access token error, got %v", err)
+	}
+}
@@ -0,0 +1,493 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rfc2136
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+	"k8s.io/klog/v2"
+
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider"
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider/rrstype"
+)
+
+var _ dnsprovider.Interface = &Interface{}
+
+const (
+	ProviderName = "rfc2136"
+
+	// tsigFudge is the permitted clock skew for TSIG signatures, in seconds
+	tsigFudge = 300
+)
+
+// EnvVars are the environment variables configuring the provider
+var EnvVars = []string{"RFC2136_NAMESERVER", "RFC2136_ZONES", "RFC2136_TSIG_KEYNAME", "RFC2136_TSIG_SECRET", "RFC2136_TSIG_ALGORITHM", "RFC2136_TIMEOUT"}
+
+func init() {
+	dnsprovider.RegisterDNSProvider(ProviderName, func(config io.Reader) (dnsprovider.Interface, error) {
+		getenv, err := dnsprovider.ConfigLookup(config)
+		if err != nil {
+			return nil, err
+		}
+		options, err := optionsFrom(getenv)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewProvider(options)
+	})
+}
+
+// Options configures the connection to the DNS server.
+type Options struct {
+	// Server is the address of the DNS server accepting dynamic updates, as host or host:port
+	Server string
+	// Zones are the zones managed on the server; RFC2136 has no way to list them
+	Zones []string
+	// TSIGKeyName is the name of the TSIG key used to sign requests; requests are not signed if empty
+	TSIGKeyName string
+	// TSIGSecret is the base64 encoded TSIG secret
+	TSIGSecret string
+	// TSIGAlgorithm is the TSIG algorithm, e.g. hmac-sha256
+	TSIGAlgorithm string
+	// Timeout is the timeout of each request to the DNS server
+	Timeout time.Duration
+}
+
+// optionsFrom reads the Options from the RFC2136_* variables.
+func optionsFrom(getenv func(string) string) (*Options, error) {
+	options := &Options{
+		Server:        getenv("RFC2136_NAMESERVER"),
+		TSIGKeyName:   getenv("RFC2136_TSIG_KEYNAME"),
+		TSIGSecret:    getenv("RFC2136_TSIG_SECRET"),
+		TSIGAlgorithm: getenv("RFC2136_TSIG_ALGORITHM"),
+	}
+	if options.Server == "" {
+		return nil, errors.New("RFC2136_NAMESERVER is required")
+	}
+	for _, zone := range strings.Split(getenv("RFC2136_ZONES"), ",") {
+		if zone = strings.TrimSpace(zone); zone != "" {
+			options.Zones = append(options.Zones, zone)
+		}
+	}
+	if len(options.Zones) == 0 {
+		return nil, errors.New("RFC2136_ZONES is required")
+	}
+	if s := getenv("RFC2136_TIMEOUT"); s != "" {
+		timeout, err := time.ParseDuration(s)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing RFC2136_TIMEOUT %q: %w", s, err)
+		}
+		options.Timeout = timeout
+	}
+	return options, nil
+}
+
+// Interface implements dnsprovider.Interface
+type Interface struct {
+	server  string
+	zones   []string
+	timeout time.Duration
+
+	tsigKeyName   string
+	tsigSecret    string
+	tsigAlgorithm string
+}
+
+// NewProvider returns an implementation of dnsprovider.Interface
+func NewProvider(options *Options) (*Interface, error) {
+	server := options.Server
+	if _, _, err := net.SplitHostPort(server); err != nil {
+		server = net.JoinHostPort(server, "53")
+	}
+
+	d := &Interface{
+		server:  server,
+		timeout: options.Timeout,
+	}
+	if d.timeout == 0 {
+		d.timeout = 30 * time.Second
+	}
+	for _, zone := range options.Zones {
+		d.zones = append(d.zones, dns.Fqdn(zone))
+	}
+
+	if options.TSIGKeyName != "" {
+		if options.TSIGSecret == "" {
+			return nil, fmt.Errorf("TSIG secret is required for TSIG key %q", options.TSIGKeyName)
+		}
+		d.tsigKeyName = dns.Fqdn(options.TSIGKeyName)
+		d.tsigSecret = options.TSIGSecret
+		d.tsigAlgorithm = dns.HmacSHA256
+		if options.TSIGAlgorithm != "" {
+			d.tsigAlgorithm = dns.Fqdn(strings.ToLower(options.TSIGAlgorithm))
+		}
+		switch d.tsigAlgorithm {
+		case dns.HmacSHA1, dns.HmacSHA224, dns.HmacSHA256, dns.HmacSHA384, dns.HmacSHA512:
+		default:
+			return nil, fmt.Errorf("unsupported TSIG algorithm %q", options.TSIGAlgorithm)
+		}
+	}
+
+	return d, nil
+}
+
+// Zones returns an implementation of dnsprovider.Zones
+func (d *Interface) Zones() (dnsprovider.Zones, bool) {
+	return &zones{dns: d}, true
+}
+
+// sign adds the TSIG signature to the message, if a TSIG key is configured
+func (d *Interface) sign(m *dns.Msg) {
+	if d.tsigKeyName != "" {
+		m.SetTsig(d.tsigKeyName, d.tsigAlgorithm, tsigFudge, time.Now().Unix())
+	}
+}
+
+func (d *Interface) tsigSecrets() map[string]string {
+	if d.tsigKeyName == "" {
+		return nil
+	}
+	return map[string]string{d.tsigKeyName: d.tsigSecret}
+}
+
+// exchange sends the message over TCP, and checks the response code
+func (d *Interface) exchange(ctx context.Context, m *dns.Msg) (*dns.Msg, error) {
+	d.sign(m)
+
+	client := &dns.Client{
+		Net:        "tcp",
+		Timeout:    d.timeout,
+		TsigSecret: d.tsigSecrets(),
+	}
+	response, _, err := client.ExchangeContext(ctx, m, d.server)
+	if err != nil {
+		return nil, fmt.Errorf("error sending request to %s: %w", d.server, err)
+	}
+	if response.Rcode != dns.RcodeSuccess {
+		return nil, fmt.Errorf("request to %s failed: %s", d.server, dns.RcodeToString[response.Rcode])
+	}
+	return response, nil
+}
+
+// transfer returns all the records of a zone, using a zone transfer (AXFR)
+func (d *Interface) transfer(zoneName string) ([]dns.RR, error) {
+	m := new(dns.Msg)
+	m.SetAxfr(zoneName)
+	d.sign(m)
+
+	t := &dns.Transfer{
+		DialTimeout:  d.timeout,
+		ReadTimeout:  d.timeout,
+		WriteTimeout: d.timeout,
+		TsigSecret:   d.tsigSecrets(),
+	}
+	envelopes, err := t.In(m, d.server)
+	if err != nil {
+		return nil, fmt.Errorf("error starting zone transfer of %q from %s: %w", zoneName, d.server, err)
+	}
+
+	var records []dns.RR
+	for envelope := range envelopes {
+		if envelope.Error != nil {
+			return nil, fmt.Errorf("error during zone transfer of %q from %s: %w", zoneName, d.server, envelope.Error)
+		}
+		records = append(records, envelope.RR...)
+	}
+	return records, nil
+}
+
+// zones implements dnsprovider.Zones
+type zones struct {
+	dns *Interface
+}
+
+// List returns the configured zones
+func (z *zones) List() ([]dnsprovider.Zone, error) {
+	var zones []dnsprovider.Zone
+	for _, name := range z.dns.zones {
+		zones = append(zones, &zone{name: name, dns: z.dns})
+	}
+	return zones, nil
+}
+
+// Add is not supported; zones must be created on the DNS server
+func (z *zones) Add(newZone dnsprovider.Zone) (dnsprovider.Zone, error) {
+	return nil, fmt.Errorf("creating zone %q is not supported by the %s DNS provider", newZone.Name(), ProviderName)
+}
+
+// Remove is not supported; zones must be deleted on the DNS server
+func (z *zones) Remove(zone dnsprovider.Zone) error {
+	return fmt.Errorf("deleting zone %q is not supported by the %s DNS provider", zone.Name(), ProviderName)
+}
+
+// New returns a new implementation of dnsprovider.Zone
+func (z *zones) New(name string) (dnsprovider.Zone, error) {
+	return &zone{name: dns.Fqdn(name), dns: z.dns}, nil
+}
+
+// zone implements dnsprovider.Zone
+type zone struct {
+	name string
+	dns  *Interface
+}
+
+// Name returns the name of the zone
+func (z *zone) Name() string {
+	return z.name
+}
+
+// ID returns the name of the zone, zones have no other identifier
+func (z *zone) ID() string {
+	return z.name
+}
+
+// ResourceRecordSets returns an implementation of dnsprovider.ResourceRecordSets
+func (z *zone) ResourceRecordSets() (dnsprovider.ResourceRecordSets, bool) {
+	return &resourceRecordSets{zone: z}, true
+}
+
+// resourceRecordSets implements dnsprovider.ResourceRecordSets
+type resourceRecordSets struct {
+	zone *zone
+}
+
+// List returns the records of the zone, grouped by name and type
+func (r *resourceRecordSets) List() ([]dnsprovider.ResourceRecordSet, error) {
+	records, err := r.zone.dns.transfer(r.zone.name)
+	if err != nil {
+		return nil, err
+	}
+
+	var rrsets []dnsprovider.ResourceRecordSet
+	byKey := make(map[string]*resourceRecordSet)
+	for _, record := range records {
+		header := record.Header()
+		name := strings.ToLower(header.Name)
+		recordType := rrstype.RrsType(dns.TypeToString[header.Rrtype])
+		data := rrdata(record)
+
+		key := name + "::" + string(recordType)
+		rrset := byKey[key]
+		if rrset == nil {
+			rrset = &resourceRecordSet{
+				name:       name,
+				ttl:        int64(header.Ttl),
+				recordType: recordType,
+			}
+			byKey[key] = rrset
+			rrsets = append(rrsets, rrset)
+		}
+		// The SOA record is sent at the start and the end of a zone transfer
+		if !slices.Contains(rrset.data, data) {
+			rrset.data = append(rrset.data, data)
+		}
+	}
+
+	return rrsets, nil
+}
+
+// Get returns the record sets with the name
+func (r *resourceRecordSets) Get(name string) ([]dnsprovider.ResourceRecordSet, error) {
+	rrsets, err := r.List()
+	if err != nil {
+		return nil, err
+	}
+
+	name = strings.ToLower(dns.Fqdn(name))
+	var matches []dnsprovider.ResourceRecordSet
+	for _, rrset := range rrsets {
+		if rrset.Name() == name {
+			matches = append(matches, rrset)
+		}
+	}
+	return matches, nil
+}
+
+// New returns an implementation of dnsprovider.ResourceRecordSet
+func (r *resourceRecordSets) New(name string, rrdatas []string, ttl int64, rrstype rrstype.RrsType) dnsprovider.ResourceRecordSet {
+	return &resourceRecordSet{
+		name:       dns.Fqdn(name),
+		data:       rrdatas,
+		ttl:        ttl,
+		recordType: rrstype,
+	}
+}
+
+// StartChangeset returns an implementation of dnsprovider.ResourceRecordChangeset
+func (r *resourceRecordSets) StartChangeset() dnsprovider.ResourceRecordChangeset {
+	return &resourceRecordChangeset{rrsets: r}
+}
+
+// Zone returns the parent zone
+func (r *resourceRecordSets) Zone() dnsprovider.Zone {
+	return r.zone
+}
+
+// resourceRecordSet implements dnsprovider.ResourceRecordSet
+type resourceRecordSet struct {
+	name       string
+	data       []string
+	ttl        int64
+	recordType rrstype.RrsType
+}
+
+// Name returns the fully qualified name of the record set
+func (r *resourceRecordSet) Name() string {
+	return r.name
+}
+
+// Rrdatas returns the data of the records, in zone file format
+func (r *resourceRecordSet) Rrdatas() []string {
+	return r.data
+}
+
+// Ttl returns the time-to-live of the record set
+func (r *resourceRecordSet) Ttl() int64 {
+	return r.ttl
+}
+
+// Type returns the type of the record set
+func (r *resourceRecordSet) Type() rrstype.RrsType {
+	return r.recordType
+}
+
+// records returns the DNS records of the record set
+func (r *resourceRecordSet) records() ([]dns.RR, error) {
+	var records []dns.RR
+	for _, data := range r.data {
+		record, err := dns.NewRR(fmt.Sprintf("%s %d IN %s %s", r.name, r.ttl, r.recordType, data))
+		if err != nil {
+			return nil, fmt.Errorf("error parsing %s record %q for %q: %w", r.recordType, data, r.name, err)
+		}
+		if record == nil {
+			return nil, fmt.Errorf("empty %s record for %q", r.recordType, r.name)
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// resourceRecordChangeset implements dnsprovider.ResourceRecordChangeset
+type resourceRecordChangeset struct {
+	rrsets *resourceRecordSets
+
+	additions []dnsprovider.ResourceRecordSet
+	removals  []dnsprovider.ResourceRecordSet
+	upserts   []dnsprovider.ResourceRecordSet
+}
+
+// Add adds the creation of the record set to the changeset
+func (c *resourceRecordChangeset) Add(rrset dnsprovider.ResourceRecordSet) dnsprovider.ResourceRecordChangeset {
+	c.additions = append(c.additions, rrset)
+	return c
+}
+
+// Remove adds the removal of the record set to the changeset
+func (c *resourceRecordChangeset) Remove(rrset dnsprovider.ResourceRecordSet) dnsprovider.ResourceRecordChangeset {
+	c.removals = append(c.removals, rrset)
+	return c
+}
+
+// Upsert adds the replacement of the record set to the changeset
+func (c *resourceRecordChangeset) Upsert(rrset dnsprovider.ResourceRecordSet) dnsprovider.ResourceRecordChangeset {
+	c.upserts = append(c.upserts, rrset)
+	return c
+}
+
+// Apply sends the changes to the DNS server as a single dynamic update,
+// which the server applies atomically.
+func (c *resourceRecordChangeset) Apply(ctx context.Context) error {
+	if c.IsEmpty() {
+		klog.V(4).Info("record change set is empty")
+		return nil
+	}
+
+	zone := c.rrsets.zone
+	m := new(dns.Msg)
+	m.SetUpdate(zone.name)
+
+	for _, rrset := range c.removals {
+		records, err := toRecords(rrset)
+		if err != nil {
+			return err
+		}
+		m.Remove(records)
+	}
+	for _, rrset := range c.upserts {
+		records, err := toRecords(rrset)
+		if err != nil {
+			return err
+		}
+		if len(records) == 0 {
+			continue
+		}
+		// Delete the existing records with the name and type, then add the new records
+		m.RemoveRRset(records[:1])
+		m.Insert(records)
+	}
+	for _, rrset := range c.additions {
+		records, err := toRecords(rrset)
+		if err != nil {
+			return err
+		}
+		m.Insert(records)
+	}
+
+	klog.V(2).Infof("applying %d changes to zone %q", len(m.Ns), zone.name)
+	if _, err := zone.dns.exchange(ctx, m); err != nil {
+		return fmt.Errorf("error updating zone %q: %w", zone.name, err)
+	}
+	return nil
+}
+
+// IsEmpty returns true if there are no changes
+func (c *resourceRecordChangeset) IsEmpty() bool {
+	return len(c.additions) == 0 && len(c.removals) == 0 && len(c.upserts) == 0
+}
+
+// ResourceRecordSets returns the parent ResourceRecordSets
+func (c *resourceRecordChangeset) ResourceRecordSets() dnsprovider.ResourceRecordSets {
+	return c.rrsets
+}
+
+// toRecords returns the DNS records of a record set
+func toRecords(rrset dnsprovider.ResourceRecordSet) ([]dns.RR, error) {
+	r, ok := rrset.(*resourceRecordSet)
+	if !ok {
+		r = &resourceRecordSet{
+			name:       dns.Fqdn(rrset.Name()),
+			data:       rrset.Rrdatas(),
+			ttl:        rrset.Ttl(),
+			recordType: rrset.Type(),
+		}
+	}
+	return r.records()
+}
+
+// rrdata returns the data of a record in zone file format, e.g. the address of an A record
+func rrdata(record dns.RR) string {
+	return strings.TrimPrefix(record.String(), record.Header().String())
+}
@@ -0,0 +1,358 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rfc2136
+
+import (
+	"context"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/miekg/dns"
+
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider"
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider/rrstype"
+)
+
+const (
+	testZone       = "example.com."
+	testKeyName    = "kops."
+	testKeySecret  = "c2VjcmV0LXRzaWcta2V5LWZvci10ZXN0aW5n"
+	testAlgorithm  = "hmac-sha256"
+	testSOARecord  = "example.com. 3600 IN SOA ns1.example.com. admin.example.com. 1 7200 3600 1209600 3600"
+	testNSRecord   = "example.com. 3600 IN NS ns1.example.com."
+	testAPIRecord  = "api.example.com. 60 IN A 192.0.2.1"
+	testAPIRecord2 = "api.example.com. 60 IN A 192.0.2.2"
+)
+
+// fakeServer is an in-process DNS server for a single zone, accepting
+// TSIG signed zone transfers and dynamic updates.
+type fakeServer struct {
+	t *testing.T
+
+	mutex   sync.Mutex
+	records []dns.RR
+	updates int
+
+	server *dns.Server
+}
+
+func newFakeServer(t *testing.T, records ...string) *fakeServer {
+	s := &fakeServer{t: t}
+	for _, record := range records {
+		rr, err := dns.NewRR(record)
+		if err != nil {
+			t.Fatalf("error parsing record %q: %v", record, err)
+		}
+		s.records = append(s.records, rr)
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("error listening: %v", err)
+	}
+	started := make(chan struct{})
+	s.server = &dns.Server{
+		Listener:          listener,
+		Handler:           s,
+		TsigSecret:        map[string]string{testKeyName: testKeySecret},
+		NotifyStartedFunc: func() { close(started) },
+		// The default rejects dynamic updates
+		MsgAcceptFunc: func(dh dns.Header) dns.MsgAcceptAction {
+			return dns.MsgAccept
+		},
+	}
+	go func() {
+		if err := s.server.ActivateAndServe(); err != nil {
+			t.Errorf("error serving DNS: %v", err)
+		}
+	}()
+	<-started
+	t.Cleanup(func() { s.server.Shutdown() })
+
+	return s
+}
+
+func (s *fakeServer) Addr() string {
+	return s.server.Listener.Addr().String()
+}
+
+func (s *fakeServer) ServeDNS(w dns.ResponseWriter, r *dns.Msg) {
+	m := new(dns.Msg)
+	m.SetReply(r)
+
+	if r.IsTsig() == nil || w.TsigStatus() != nil {
+		m.Rcode = dns.RcodeNotAuth
+		w.WriteMsg(m)
+		return
+	}
+	tsig := r.IsTsig()
+	m.SetTsig(tsig.Hdr.Name, tsig.Algorithm, 300, int64(tsig.TimeSigned))
+
+	if len(r.Question) != 1 || r.Question[0].Name != testZone {
+		m.Rcode = dns.RcodeNotZone
+		w.WriteMsg(m)
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	switch {
+	case r.Opcode == dns.OpcodeQuery && r.Question[0].Qtype == dns.TypeAXFR:
+		records := append([]dns.RR{}, s.records...)
+		records = append(records, s.records[0])
+		ch := make(chan *dns.Envelope, 1)
+		tr := new(dns.Transfer)
+		go func() {
+			ch <- &dns.Envelope{RR: records}
+			close(ch)
+		}()
+		if err := tr.Out(w, r, ch); err != nil {
+			s.t.Errorf("error sending zone transfer: %v", err)
+		}
+		return
+
+	case r.Opcode == dns.OpcodeUpdate:
+		s.updates++
+		for _, rr := range r.Ns {
+			s.apply(rr)
+		}
+	default:
+		m.Rcode = dns.RcodeNotImplemented
+	}
+	w.WriteMsg(m)
+}
+
+// apply applies an update record, as described in RFC 2136 section 3.4.2
+func (s *fakeServer) apply(update dns.RR) {
+	header := update.Header()
+	var records []dns.RR
+	switch header.Class {
+	case dns.ClassANY:
+		// Delete an RRset
+		for _, rr := range s.records {
+			if !strings.EqualFold(rr.Header().Name, header.Name) || rr.Header().Rrtype != header.Rrtype {
+				records = append(records, rr)
+			}
+		}
+	case dns.ClassNONE:
+		// Delete an RR from an RRset
+		for _, rr := range s.records {
+			if !strings.EqualFold(rr.Header().Name, header.Name) || rrdata(rr) != rrdata(update) || rr.Header().Rrtype != header.Rrtype {
+				records = append(records, rr)
+			}
+		}
+	default:
+		// Add to an RRset
+		records = s.records
+		exists := false
+		for _, rr := range s.records {
+			if dns.IsDuplicate(rr, update) {
+				exists = true
+			}
+		}
+		if !exists {
+			records = append(records, update)
+		}
+	}
+	s.records = records
+}
+
+// zoneFile returns the records of the zone, sorted
+func (s *fakeServer) zoneFile() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var lines []string
+	for _, rr := range s.records {
+		lines = append(lines, strings.ReplaceAll(rr.String(), "\t", " "))
+	}
+	sort.Strings(lines)
+	return lines
+}
+
+func newTestProvider(t *testing.T, s *fakeServer) dnsprovider.ResourceRecordSets {
+	provider, err := NewProvider(&Options{
+		Server:        s.Addr(),
+		Zones:         []string{"example.com"},
+		TSIGKeyName:   "kops",
+		TSIGSecret:    testKeySecret,
+		TSIGAlgorithm: testAlgorithm,
+	})
+	if err != nil {
+		t.Fatalf("NewProvider: %v", err)
+	}
+
+	zonesProvider, _ := provider.Zones()
+	zones, err := zonesProvider.List()
+	if err != nil {
+		t.Fatalf("error listing zones: %v", err)
+	}
+	if len(zones) != 1 || zones[0].Name() != testZone || zones[0].ID() != testZone {
+		t.Fatalf("unexpected zones %v", zones)
+	}
+	rrsets, _ := zones[0].ResourceRecordSets()
+	return rrsets
+}
+
+func TestList(t *testing.T) {
+	s := newFakeServer(t, testSOARecord, testNSRecord, testAPIRecord, testAPIRecord2)
+	rrsets := newTestProvider(t, s)
+
+	records, err := rrsets.List()
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+
+	var actual []string
+	for _, rrset := range records {
+		actual = append(actual, rrset.Name()+" "+string(rrset.Type())+" "+strings.Join(rrset.Rrdatas(), ","))
+	}
+	expected := []string{
+		"example.com. SOA ns1.example.com. admin.example.com. 1 7200 3600 1209600 3600",
+		"example.com. NS ns1.example.com.",
+		"api.example.com. A 192.0.2.1,192.0.2.2",
+	}
+	if strings.Join(actual, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("unexpected records\nexpected:\n%s\nactual:\n%s", strings.Join(expected, "\n"), strings.Join(actual, "\n"))
+	}
+
+	matches, err := rrsets.Get("API.example.com")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if len(matches) != 1 || matches[0].Ttl() != 60 || matches[0].Type() != rrstype.A {
+		t.Errorf("unexpected records for api.example.com: %v", matches)
+	}
+}
+
+func TestApply(t *testing.T) {
+	ctx := context.TODO()
+
+	s := newFakeServer(t, testSOARecord, testNSRecord, testAPIRecord, testAPIRecord2)
+	rrsets := newTestProvider(t, s)
+
+	records, err := rrsets.Get("api.example.com.")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+
+	changeset := rrsets.StartChangeset()
+	if !changeset.IsEmpty() {
+		t.Errorf("expected empty changeset")
+	}
+	if err := changeset.Apply(ctx); err != nil {
+		t.Fatalf("error applying empty changeset: %v", err)
+	}
+	if s.updates != 0 {
+		t.Errorf("expected no updates for an empty changeset")
+	}
+
+	changeset.Remove(records[0])
+	changeset.Upsert(rrsets.New("api.internal.example.com.", []string{"10.0.0.1", "10.0.0.2"}, 60, rrstype.A))
+	changeset.Add(rrsets.New("_owner.example.com.", []string{`"heritage=kops"`}, 300, rrstype.TXT))
+	if err := changeset.Apply(ctx); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+
+	// Upserts replace the existing records
+	changeset = rrsets.StartChangeset()
+	changeset.Upsert(rrsets.New("api.internal.example.com.", []string{"10.0.0.3"}, 30, rrstype.A))
+	if err := changeset.Apply(ctx); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+
+	expected := []string{
+		`_owner.example.com. 300 IN TXT "heritage=kops"`,
+		"api.internal.example.com. 30 IN A 10.0.0.3",
+		testSOARecord,
+		testNSRecord,
+	}
+	sort.Strings(expected)
+	if actual := s.zoneFile(); strings.Join(actual, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("unexpected zone\nexpected:\n%s\nactual:\n%s", strings.Join(expected, "\n"), strings.Join(actual, "\n"))
+	}
+	if s.updates != 2 {
+		t.Errorf("expected 2 updates, got %d", s.updates)
+	}
+}
+
+func TestUnsignedRequestsRejected(t *testing.T) {
+	s := newFakeServer(t, testSOARecord)
+
+	provider, err := NewProvider(&Options{
+		Server: s.Addr(),
+		Zones:  []string{"example.com"},
+	})
+	if err != nil {
+		t.Fatalf("NewProvider: %v", err)
+	}
+	zonesProvider, _ := provider.Zones()
+	zone, _ := zonesProvider.New("example.com")
+	rrsets, _ := zone.ResourceRecordSets()
+
+	changeset := rrsets.StartChangeset()
+	changeset.Add(rrsets.New("api.example.com.", []string{"192.0.2.1"}, 60, rrstype.A))
+	err = changeset.Apply(context.TODO())
+	if err == nil || !strings.Contains(err.Error(), "NOTAUTH") {
+		t.Errorf("expected NOTAUTH error, got %v", err)
+	}
+}
+
+func TestNewProvider(t *testing.T) {
+	grid := []struct {
+		options  Options
+		server   string
+		expected string
+	}{
+		{
+			options: Options{Server: "ns1.example.com"},
+			server:  "ns1.example.com:53",
+		},
+		{
+			options: Options{Server: "192.0.2.53:5353"},
+			server:  "192.0.2.53:5353",
+		},
+		{
+			options:  Options{Server: "ns1.example.com", TSIGKeyName: "kops"},
+			expected: `TSIG secret is required for TSIG key "kops"`,
+		},
+		{
+			options:  Options{Server: "ns1.example.com", TSIGKeyName: "kops", TSIGSecret: testKeySecret, TSIGAlgorithm: "hmac-md4"},
+			expected: `unsupported TSIG algorithm "hmac-md4"`,
+		},
+	}
+	for _, g := range grid {
+		provider, err := NewProvider(&g.options)
+		if g.expected != "" {
+			if err == nil || err.Error() != g.expected {
+				t.Errorf("expected error %q, got %v", g.expected, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("NewProvider: %v", err)
+			continue
+		}
+		if provider.server != g.server {
+			t.Errorf("expected server %q, got %q", g.server, provider.server)
+		}
+	}
+}
@@ -98,6 +98,11 @@ func (i *Installation) buildEnvFile() *nodetasks.InstallFile {
 	}
 
 	// Pass in required credentials when using user-defined swift endpoint
+	if os.Getenv("WEBDAV_USERNAME") != "" {
+		envVars["WEBDAV_USERNAME"] = os.Getenv("WEBDAV_USERNAME")
+		envVars["WEBDAV_PASSWORD"] = os.Getenv("WEBDAV_PASSWORD")
+	}
+
 	if os.Getenv("OS_AUTH_URL") != "" {
 		for _, envVar := range []string{
 			"OS_TENANT_ID", "OS_TENANT_NAME", "OS_PROJECT_ID", "OS_PROJECT_NAME",
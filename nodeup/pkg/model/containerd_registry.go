@@ -0,0 +1,191 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"encoding/base64"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/apis/nodeup"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/nodeup/nodetasks"
+)
+
+// containerdRegistryConfigPath is the directory holding the hosts.toml files of the registries.
+const containerdRegistryConfigPath = "/etc/containerd/certs.d"
+
+// defaultRegistryHostCapabilities are the capabilities of the hosts that don't list any.
+var defaultRegistryHostCapabilities = []string{"pull", "resolve"}
+
+// usesRegistryHosts returns true if the registries are configured with hosts.toml files.
+func usesRegistryHosts(containerd *kops.ContainerdConfig) bool {
+	return containerd != nil && len(containerd.RegistryHosts) != 0
+}
+
+// registryHosts returns the registry hosts, with the registry mirrors converted to hosts.
+func registryHosts(containerd *kops.ContainerdConfig) []kops.ContainerdRegistryHostsConfig {
+	registries := append([]kops.ContainerdRegistryHostsConfig{}, containerd.RegistryHosts...)
+
+	var names []string
+	for name := range containerd.RegistryMirrors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		registry := kops.ContainerdRegistryHostsConfig{Registry: registryHostsName(name)}
+		for _, endpoint := range containerd.RegistryMirrors[name] {
+			registry.Hosts = append(registry.Hosts, kops.ContainerdRegistryHost{URL: endpoint})
+		}
+		registries = append(registries, registry)
+	}
+
+	return registries
+}
+
+// registryHostsName returns the name of the hosts.toml directory of a registry mirror.
+// The * mirror of config.toml is the _default directory.
+func registryHostsName(mirror string) string {
+	if mirror == "*" {
+		return "_default"
+	}
+	return mirror
+}
+
+// buildRegistryHosts writes the hosts.toml files of the registries, with the files they refer to.
+func (b *ContainerdBuilder) buildRegistryHosts(c *fi.NodeupModelBuilderContext) error {
+	containerd := b.NodeupConfig.ContainerdConfig
+	if !usesRegistryHosts(containerd) {
+		return nil
+	}
+
+	for _, registry := range registryHosts(containerd) {
+		dir := filepath.Join(containerdRegistryConfigPath, registry.Registry)
+
+		var hostsTOML strings.Builder
+		if registry.Server != nil {
+			if registry.Server.URL != "" {
+				fmt.Fprintf(&hostsTOML, "server = %s\n", tomlString(registry.Server.URL))
+			}
+			if err := b.writeRegistryHost(c, &hostsTOML, dir, "server", "", registry.Server.Capabilities, registry.Server); err != nil {
+				return fmt.Errorf("configuring server of registry %q: %w", registry.Registry, err)
+			}
+		}
+		for i, host := range registry.Hosts {
+			capabilities := host.Capabilities
+			if len(capabilities) == 0 {
+				capabilities = defaultRegistryHostCapabilities
+			}
+			table := "host." + tomlString(host.URL)
+			fmt.Fprintf(&hostsTOML, "\n[%s]\n", table)
+			if err := b.writeRegistryHost(c, &hostsTOML, dir, "host-"+strconv.Itoa(i), table, capabilities, &host); err != nil {
+				return fmt.Errorf("configuring host %q of registry %q: %w", host.URL, registry.Registry, err)
+			}
+		}
+
+		// The file may hold credentials, so it is only readable by root
+		c.AddTask(&nodetasks.File{
+			Path:     filepath.Join(dir, "hosts.toml"),
+			Contents: fi.NewStringResource(strings.TrimPrefix(hostsTOML.String(), "\n")),
+			Type:     nodetasks.FileType_File,
+			Mode:     s("0600"),
+		})
+	}
+
+	return nil
+}
+
+// writeRegistryHost writes the settings of a host in the current table of a hosts.toml file.
+// The CA and client certificate of the host are written as files named after prefix.
+func (b *ContainerdBuilder) writeRegistryHost(c *fi.NodeupModelBuilderContext, w *strings.Builder, dir string, prefix string, table string, capabilities []string, host *kops.ContainerdRegistryHost) error {
+	if len(capabilities) != 0 {
+		var quoted []string
+		for _, capability := range capabilities {
+			quoted = append(quoted, tomlString(capability))
+		}
+		fmt.Fprintf(w, "capabilities = [%s]\n", strings.Join(quoted, ", "))
+	}
+	if host.SkipVerify {
+		fmt.Fprintf(w, "skip_verify = true\n")
+	}
+	if host.OverridePath {
+		fmt.Fprintf(w, "override_path = true\n")
+	}
+	if host.Secret == "" {
+		return nil
+	}
+
+	if b.SecretStore == nil {
+		return fmt.Errorf("secret %q is required, but there is no secret store", host.Secret)
+	}
+	kopsSecret, err := b.SecretStore.Secret(host.Secret)
+	if err != nil {
+		return fmt.Errorf("loading secret %q: %w", host.Secret, err)
+	}
+	secret, err := nodeup.ParseContainerdRegistrySecret(kopsSecret.Data)
+	if err != nil {
+		return fmt.Errorf("secret %q: %w", host.Secret, err)
+	}
+
+	if secret.CA != "" {
+		caPath := filepath.Join(dir, prefix+"-ca.crt")
+		c.AddTask(&nodetasks.File{
+			Path:     caPath,
+			Contents: fi.NewStringResource(secret.CA),
+			Type:     nodetasks.FileType_File,
+			Mode:     s("0644"),
+		})
+		fmt.Fprintf(w, "ca = %s\n", tomlString(caPath))
+	}
+	if secret.Certificate != "" {
+		certPath := filepath.Join(dir, prefix+".crt")
+		keyPath := filepath.Join(dir, prefix+".key")
+		c.AddTask(&nodetasks.File{
+			Path:     certPath,
+			Contents: fi.NewStringResource(secret.Certificate),
+			Type:     nodetasks.FileType_File,
+			Mode:     s("0644"),
+		})
+		c.AddTask(&nodetasks.File{
+			Path:     keyPath,
+			Contents: fi.NewStringResource(secret.Key),
+			Type:     nodetasks.FileType_File,
+			Mode:     s("0600"),
+		})
+		fmt.Fprintf(w, "client = [[%s, %s]]\n", tomlString(certPath), tomlString(keyPath))
+	}
+	if secret.Username != "" {
+		auth := base64.StdEncoding.EncodeToString([]byte(secret.Username + ":" + secret.Password))
+		headerTable := "header"
+		if table != "" {
+			headerTable = table + ".header"
+		}
+		fmt.Fprintf(w, "\n[%s]\n", headerTable)
+		fmt.Fprintf(w, "Authorization = [%s]\n", tomlString("Basic "+auth))
+	}
+
+	return nil
+}
+
+// tomlString quotes s as a TOML basic string.
+func tomlString(s string) string {
+	return strconv.Quote(s)
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/pelletier/go-toml"
 	"k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/pkg/apis/nodeup"
+	"k8s.io/kops/pkg/configserver"
 	"k8s.io/kops/pkg/diff"
 	"k8s.io/kops/pkg/flagbuilder"
 	"k8s.io/kops/pkg/testutils"
@@ -48,6 +49,10 @@ func TestContainerdBuilder_Complex(t *testing.T) {
 	runContainerdBuilderTest(t, "complex", distributions.DistributionUbuntu2004)
 }
 
+func TestContainerdBuilder_RegistryHosts(t *testing.T) {
+	runContainerdBuilderTest(t, "registryhosts", distributions.DistributionUbuntu2004)
+}
+
 func TestContainerdBuilder_BuildFlags(t *testing.T) {
 	grid := []struct {
 		config   kops.ContainerdConfig
@@ -148,8 +153,12 @@ func runContainerdBuilderTest(t *testing.T, key string, distro distributions.Dis
 	}
 
 	nodeUpModelContext.Distribution = distro
+	nodeUpModelContext.SecretStore = configserver.NewSecretStore(map[string][]byte{
+		"registry-example": []byte("ca: |\n  -----BEGIN CERTIFICATE-----\n  example-ca\n  -----END CERTIFICATE-----\nusername: puller\npassword: secret\n"),
+		"registry-backup":  []byte("certificate: client-certificate\nkey: client-key\n"),
+	})
 
-	nodeUpModelContext.Assets = fi.NewAssetStore("")
+	nodeUpModelContext.Assets = fi.NewAssetStore("", nil)
 	nodeUpModelContext.Assets.AddForTest("containerd", "bin/containerd", "testing containerd content")
 	nodeUpModelContext.Assets.AddForTest("containerd-shim", "bin/containerd-shim", "testing containerd content")
 	nodeUpModelContext.Assets.AddForTest("containerd-shim-runc-v1", "bin/containerd-shim-runc-v1", "testing containerd content")
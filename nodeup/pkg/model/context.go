@@ -66,6 +66,9 @@ type NodeupModelContext struct {
 	// HasAPIServer is true if the InstanceGroup has a role of master or apiserver (pupulated by Init)
 	HasAPIServer bool
 
+	// HasEtcd is true if the InstanceGroup has a role of master or etcd (populated by Init)
+	HasEtcd bool
+
 	// usesLegacyGossip is true if the cluster runs (legacy) Gossip DNS.
 	usesLegacyGossip bool
 
@@ -108,6 +111,10 @@ func (c *NodeupModelContext) Init() error {
 		c.HasAPIServer = true
 	}
 
+	if role == kops.InstanceGroupRoleControlPlane || role == kops.InstanceGroupRoleEtcd {
+		c.HasEtcd = true
+	}
+
 	c.usesNoneDNS = c.NodeupConfig.UsesNoneDNS
 	c.usesLegacyGossip = c.NodeupConfig.UsesLegacyGossip
 	c.discoveryService = c.NodeupConfig.DiscoveryService
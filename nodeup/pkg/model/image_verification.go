@@ -0,0 +1,94 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/kops/pkg/imageverification"
+	"k8s.io/kops/pkg/kubemanifest"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/nodeup/nodetasks"
+)
+
+// ImageVerificationBuilder verifies the images of the static pods before their manifests are written,
+// and pins the images of the manifests to the verified digests.
+// It must run after the builders that add static pod manifests.
+type ImageVerificationBuilder struct {
+	*NodeupModelContext
+}
+
+var _ fi.NodeupModelBuilder = &ImageVerificationBuilder{}
+
+func (b *ImageVerificationBuilder) Build(c *fi.NodeupModelBuilderContext) error {
+	if b.NodeupConfig.ImageVerification == nil {
+		return nil
+	}
+
+	verifier, err := imageverification.NewVerifier(b.NodeupConfig.ImageVerification, b.NodeupConfig.ImageVerificationMirrors)
+	if err != nil {
+		return fmt.Errorf("building image verifier: %w", err)
+	}
+
+	tasks := make(map[string]*nodetasks.VerifyImage)
+	var files []*nodetasks.File
+	for _, task := range c.Tasks {
+		file, ok := task.(*nodetasks.File)
+		if ok && strings.HasPrefix(file.Path, nodetasks.StaticPodManifestDir) && file.Contents != nil {
+			files = append(files, file)
+		}
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+
+	for _, file := range files {
+		data, err := fi.ResourceAsBytes(file.Contents)
+		if err != nil {
+			return fmt.Errorf("reading static pod manifest %s: %w", file.Path, err)
+		}
+		objects, err := kubemanifest.LoadObjectsFrom(data)
+		if err != nil {
+			return fmt.Errorf("parsing static pod manifest %s: %w", file.Path, err)
+		}
+		images := make(map[string]*nodetasks.VerifyImage)
+		for _, obj := range objects {
+			err := obj.RemapImages(func(image string) string {
+				if tasks[image] == nil {
+					tasks[image] = &nodetasks.VerifyImage{
+						Name:     image,
+						Verifier: verifier,
+					}
+					c.AddTask(tasks[image])
+				}
+				images[image] = tasks[image]
+				return image
+			})
+			if err != nil {
+				return fmt.Errorf("reading images of static pod manifest %s: %w", file.Path, err)
+			}
+		}
+
+		// The manifest is written once its images are verified, referring to the verified digests
+		file.Contents = &nodetasks.VerifiedManifest{
+			Manifest: data,
+			Images:   images,
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/apis/nodeup"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/nodeup/nodetasks"
+)
+
+func TestImageVerificationBuilder(t *testing.T) {
+	manifest := `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: kube-apiserver
+  namespace: kube-system
+spec:
+  initContainers:
+  - name: init
+    image: registry.k8s.io/init:v1
+  containers:
+  - name: kube-apiserver
+    image: registry.k8s.io/kube-apiserver:v1.34.0
+  - name: healthcheck
+    image: registry.k8s.io/kops/kube-apiserver-healthcheck:1.35.0
+`
+	b := &ImageVerificationBuilder{
+		NodeupModelContext: &NodeupModelContext{
+			NodeupConfig: &nodeup.Config{
+				ImageVerification: &kops.ImageVerificationSpec{
+					Action: kops.ImageVerificationActionWarn,
+				},
+			},
+		},
+	}
+	ctx := &fi.NodeupModelBuilderContext{
+		Tasks: map[string]fi.NodeupTask{},
+	}
+	ctx.AddTask(&nodetasks.File{
+		Path:     "/etc/kubernetes/manifests/kube-apiserver.manifest",
+		Contents: fi.NewStringResource(manifest),
+		Type:     nodetasks.FileType_File,
+	})
+	ctx.AddTask(&nodetasks.File{
+		Path:     "/etc/kubernetes/kube-proxy.kubeconfig",
+		Contents: fi.NewStringResource("image: registry.k8s.io/not-an-image:v1"),
+		Type:     nodetasks.FileType_File,
+	})
+	if err := b.Build(ctx); err != nil {
+		t.Fatalf("unexpected error from Build(): %v", err)
+	}
+
+	var actual []string
+	var verifyTasks []fi.NodeupTask
+	for _, v := range ctx.Tasks {
+		if task, ok := v.(*nodetasks.VerifyImage); ok {
+			actual = append(actual, task.Name)
+			verifyTasks = append(verifyTasks, task)
+		}
+	}
+	sort.Strings(actual)
+	expected := []string{
+		"registry.k8s.io/init:v1",
+		"registry.k8s.io/kops/kube-apiserver-healthcheck:1.35.0",
+		"registry.k8s.io/kube-apiserver:v1.34.0",
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected images %v, got %v", expected, actual)
+	}
+
+	manifestTask := ctx.Tasks["File//etc/kubernetes/manifests/kube-apiserver.manifest"].(*nodetasks.File)
+	if _, ok := manifestTask.Contents.(*nodetasks.VerifiedManifest); !ok {
+		t.Errorf("expected manifest to refer to the verified images, got %T", manifestTask.Contents)
+	}
+	deps := manifestTask.GetDependencies(ctx.Tasks)
+	for _, task := range verifyTasks {
+		found := false
+		for _, dep := range deps {
+			if dep == task {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("expected manifest to depend on %v", task)
+		}
+	}
+}
+
+func TestImageVerificationBuilderDisabled(t *testing.T) {
+	b := &ImageVerificationBuilder{
+		NodeupModelContext: &NodeupModelContext{
+			NodeupConfig: &nodeup.Config{},
+		},
+	}
+	ctx := &fi.NodeupModelBuilderContext{
+		Tasks: map[string]fi.NodeupTask{},
+	}
+	ctx.AddTask(&nodetasks.File{
+		Path:     "/etc/kubernetes/manifests/kube-apiserver.manifest",
+		Contents: fi.NewStringResource("apiVersion: v1\nkind: Pod\n"),
+		Type:     nodetasks.FileType_File,
+	})
+	if err := b.Build(ctx); err != nil {
+		t.Fatalf("unexpected error from Build(): %v", err)
+	}
+	if len(ctx.Tasks) != 1 {
+		t.Errorf("expected no tasks to be added, got %v", ctx.Tasks)
+	}
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package model
 
 import (
+	"os"
 	"path/filepath"
 
 	"k8s.io/kops/pkg/wellknownusers"
@@ -106,5 +107,17 @@ func (b *KopsControllerBuilder) Build(c *fi.NodeupModelBuilderContext) error {
 		Owner:    s(wellknownusers.KopsControllerName),
 	})
 
+	// kops-controller reads the state store; on a WebDAV server it uses the same credentials as nodeup,
+	// passing the password through a file rather than the pod spec
+	if os.Getenv("WEBDAV_USERNAME") != "" {
+		c.AddTask(&nodetasks.File{
+			Path:     filepath.Join(pkiDir, "webdav-password"),
+			Contents: fi.NewStringResource(os.Getenv("WEBDAV_PASSWORD")),
+			Type:     nodetasks.FileType_File,
+			Mode:     s("0600"),
+			Owner:    s(wellknownusers.KopsControllerName),
+		})
+	}
+
 	return nil
 }
@@ -25,7 +25,7 @@ import (
 
 func TestKubectlBuilder(t *testing.T) {
 	RunGoldenTest(t, "tests/golden/minimal", "kubectl", func(nodeupModelContext *NodeupModelContext, target *fi.NodeupModelBuilderContext) error {
-		nodeupModelContext.Assets = fi.NewAssetStore("")
+		nodeupModelContext.Assets = fi.NewAssetStore("", nil)
 		nodeupModelContext.Assets.AddForTest("kubectl", "/path/to/kubectl/asset", "testing kubectl content")
 		// NodeUp looks for the default user and group on the machine running the tests.
 		// Flatcar is unlikely to be used for such task, so tests results should be consistent.
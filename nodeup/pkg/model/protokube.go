@@ -21,6 +21,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 
 	"k8s.io/klog/v2"
@@ -28,6 +29,7 @@ import (
 	"k8s.io/kops/pkg/flagbuilder"
 	"k8s.io/kops/pkg/rbac"
 	"k8s.io/kops/pkg/systemd"
+	"k8s.io/kops/pkg/wellknownports"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/scaleway"
 	"k8s.io/kops/upup/pkg/fi/nodeup/nodetasks"
@@ -94,6 +96,12 @@ func (t *ProtokubeBuilder) Build(c *fi.NodeupModelBuilderContext) error {
 		})
 	}
 
+	if t.IsMaster && t.UsesLegacyGossip() {
+		if err := t.writeGossipStatusCertificate(c); err != nil {
+			return err
+		}
+	}
+
 	envFile, err := t.buildEnvFile()
 	if err != nil {
 		return err
@@ -109,6 +117,20 @@ func (t *ProtokubeBuilder) Build(c *fi.NodeupModelBuilderContext) error {
 	return nil
 }
 
+// writeGossipStatusCertificate issues the serving certificate of the gossip status, which the API server reaches through the node proxy
+func (t *ProtokubeBuilder) writeGossipStatusCertificate(c *fi.NodeupModelBuilderContext) error {
+	issueCert := &nodetasks.IssueCert{
+		Name:      "protokube-gossip-status",
+		Signer:    fi.CertificateIDCA,
+		KeypairID: t.NodeupConfig.KeypairIDs[fi.CertificateIDCA],
+		Type:      "server",
+		Subject:   nodetasks.PKIXName{CommonName: "protokube"},
+	}
+	c.AddTask(issueCert)
+	// The kubelet-api client certificate of the API server is issued by the same CA
+	return issueCert.AddFileTasks(c, filepath.Join(t.PathSrvKubernetes(), "protokube"), "server", "ca", nil)
+}
+
 // buildSystemdService generates the manifest for the protokube service
 func (t *ProtokubeBuilder) buildSystemdService() (*nodetasks.Service, error) {
 	protokubeFlags, err := t.ProtokubeFlags()
@@ -171,6 +193,17 @@ type ProtokubeFlags struct {
 	GossipProtocolSecondary *string `json:"gossip-protocol-secondary" flag:"gossip-protocol-secondary" flag-include-empty:"true"`
 	GossipListenSecondary   *string `json:"gossip-listen-secondary" flag:"gossip-listen-secondary"`
 	GossipSecretSecondary   *string `json:"gossip-secret-secondary" flag:"gossip-secret-secondary"`
+
+	// GossipStatusListen is the address on which the gossip status is served, for kops toolbox gossip-status
+	GossipStatusListen *string `json:"gossip-status-listen,omitempty" flag:"gossip-status-listen"`
+	// GossipStatusTLSCertFile and GossipStatusTLSKeyFile are the serving certificate and key of the gossip status
+	GossipStatusTLSCertFile *string `json:"gossip-status-tls-cert-file,omitempty" flag:"gossip-status-tls-cert-file"`
+	GossipStatusTLSKeyFile  *string `json:"gossip-status-tls-key-file,omitempty" flag:"gossip-status-tls-key-file"`
+	// GossipStatusClientCAFile is the CA of the client certificate the API server uses to reach the nodes
+	GossipStatusClientCAFile *string `json:"gossip-status-client-ca-file,omitempty" flag:"gossip-status-client-ca-file"`
+
+	// EtcdNodeHosts are the hostnames of the etcd clusters on etcd nodes, with the instance groups of their members
+	EtcdNodeHosts []string `json:"etcdNodeHosts,omitempty" flag:"etcd-node-hosts,repeat"`
 }
 
 // ProtokubeFlags is responsible for building the command line flags for protokube
@@ -215,6 +248,15 @@ func (t *ProtokubeBuilder) ProtokubeFlags() (*ProtokubeFlags, error) {
 			}
 		}
 
+		if t.IsMaster {
+			// Only the port is set, so protokube serves on the internal IP of the node, to the API server only
+			pathSrvProtokube := filepath.Join(t.PathSrvKubernetes(), "protokube")
+			f.GossipStatusListen = fi.PtrTo(fmt.Sprintf(":%d", wellknownports.ProtokubeGossipStatus))
+			f.GossipStatusTLSCertFile = fi.PtrTo(filepath.Join(pathSrvProtokube, "server.crt"))
+			f.GossipStatusTLSKeyFile = fi.PtrTo(filepath.Join(pathSrvProtokube, "server.key"))
+			f.GossipStatusClientCAFile = fi.PtrTo(filepath.Join(pathSrvProtokube, "ca.crt"))
+		}
+
 		// @TODO: This is hacky, but we want it so that we can have a different internal & external name
 		internalSuffix := t.APIInternalName()
 		internalSuffix = strings.TrimPrefix(internalSuffix, "api.")
@@ -225,6 +267,13 @@ func (t *ProtokubeBuilder) ProtokubeFlags() (*ProtokubeFlags, error) {
 		f.DNSInternalSuffix = fi.PtrTo(".internal." + t.NodeupConfig.ClusterName)
 	}
 
+	if t.IsMaster && t.NodeupConfig.APIServerConfig != nil {
+		for hostname, instanceGroups := range t.NodeupConfig.APIServerConfig.RemoteEtcdHosts {
+			f.EtcdNodeHosts = append(f.EtcdNodeHosts, hostname+"="+strings.Join(instanceGroups, ","))
+		}
+		sort.Strings(f.EtcdNodeHosts)
+	}
+
 	f.BootstrapMasterNodeLabels = true
 
 	nodeName, err := t.NodeName()
@@ -258,6 +307,11 @@ func (t *ProtokubeBuilder) buildEnvFile() (*nodetasks.File, error) {
 		envVars["S3_SECRET_ACCESS_KEY"] = os.Getenv("S3_SECRET_ACCESS_KEY")
 	}
 
+	if os.Getenv("WEBDAV_USERNAME") != "" {
+		envVars["WEBDAV_USERNAME"] = os.Getenv("WEBDAV_USERNAME")
+		envVars["WEBDAV_PASSWORD"] = os.Getenv("WEBDAV_PASSWORD")
+	}
+
 	if os.Getenv("OS_AUTH_URL") != "" {
 		for _, envVar := range []string{
 			"OS_TENANT_ID", "OS_TENANT_NAME", "OS_PROJECT_ID", "OS_PROJECT_NAME",
@@ -35,6 +35,9 @@ func GetACL(ctx context.Context, p vfs.Path, cluster *kops.Cluster) (vfs.ACL, er
 	strategiesMutex.Lock()
 	defer strategiesMutex.Unlock()
 
+	// The ACL depends on the backend, not on any caching in front of it
+	p = vfs.Unwrap(p)
+
 	for k, strategy := range strategies {
 		acl, err := strategy.GetACL(ctx, p, cluster)
 		if err != nil {
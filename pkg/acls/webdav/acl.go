@@ -0,0 +1,67 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webdav
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/acls"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+// webdavACLStrategy is the ACLStrategy for files written to a WebDAV server
+type webdavACLStrategy struct{}
+
+var _ acls.ACLStrategy = &webdavACLStrategy{}
+
+// GetACL returns the ACL to use if this is a WebDAV path.
+// The principals in KOPS_STATE_WEBDAV_READ_PRINCIPALS are granted read access;
+// otherwise the server's own permissions apply.
+func (s *webdavACLStrategy) GetACL(ctx context.Context, p vfs.Path, cluster *kops.Cluster) (vfs.ACL, error) {
+	if _, ok := p.(*vfs.WebDAVPath); !ok {
+		return nil, nil
+	}
+
+	principals := readPrincipals(os.Getenv("KOPS_STATE_WEBDAV_READ_PRINCIPALS"))
+	if len(principals) == 0 {
+		return nil, nil
+	}
+	klog.V(8).Infof("granting read on %s to %v", p, principals)
+
+	return &vfs.WebDAVACL{
+		ReadPrincipals: principals,
+	}, nil
+}
+
+// readPrincipals parses a comma-separated list of principal hrefs.
+func readPrincipals(s string) []string {
+	var principals []string
+	for _, principal := range strings.Split(s, ",") {
+		if principal = strings.TrimSpace(principal); principal != "" {
+			principals = append(principals, principal)
+		}
+	}
+	return principals
+}
+
+func Register() {
+	acls.RegisterPlugin("k8s.io/kops/acl/webdav", &webdavACLStrategy{})
+}
@@ -60,6 +60,11 @@ type ClusterSpec struct {
 	Channel string `json:"channel,omitempty"`
 	// Additional addons that should be installed on the cluster
 	Addons []AddonSpec `json:"addons,omitempty"`
+	// AddonDriftDetection enables kops-controller to periodically re-apply the installed addons,
+	// and to report or correct changes made to their objects.
+	AddonDriftDetection *AddonDriftDetectionSpec `json:"addonDriftDetection,omitempty"`
+	// AddonOverrides holds patches that are applied to the manifests of the addons.
+	AddonOverrides []AddonOverrideSpec `json:"addonOverrides,omitempty"`
 	// ConfigStore configures the stores that nodes use to get their configuration.
 	ConfigStore ConfigStoreSpec `json:"configStore"`
 	// CloudProvider configures the cloud provider to use.
@@ -345,6 +350,61 @@ type AddonSpec struct {
 	Manifest string `json:"manifest,omitempty"`
 }
 
+// AddonDriftDetectionSpec configures the detection of changes to the objects of the installed addons.
+type AddonDriftDetectionSpec struct {
+	// Interval is how often the addons are checked. The default is 10 minutes.
+	Interval *metav1.Duration `json:"interval,omitempty"`
+	// Policy is the policy of the addons that are not listed in Addons. The default is report.
+	Policy AddonDriftPolicy `json:"policy,omitempty"`
+	// Addons holds the policy of individual addons, keyed by addon name, e.g. coredns.addons.k8s.io.
+	Addons map[string]AddonDriftPolicy `json:"addons,omitempty"`
+}
+
+// AddonDriftPolicy is what kops-controller does when the objects of an addon have changed.
+type AddonDriftPolicy string
+
+// AddonOverrideSpec holds the patches of an addon.
+type AddonOverrideSpec struct {
+	// Name is the name of the addon, e.g. coredns.addons.k8s.io.
+	Name string `json:"name"`
+	// Patches are applied in order to the objects of the addon.
+	Patches []AddonPatchSpec `json:"patches,omitempty"`
+}
+
+// AddonPatchSpec is a patch of an object of an addon.
+type AddonPatchSpec struct {
+	// Target selects the object to patch.
+	Target AddonPatchTarget `json:"target"`
+	// Patch is a strategic merge patch, in YAML or JSON.
+	// Objects of kinds that are not built into Kubernetes are patched with a JSON merge patch.
+	Patch string `json:"patch,omitempty"`
+	// JSONPatch is a JSON patch (RFC 6902), in YAML or JSON.
+	JSONPatch string `json:"jsonPatch,omitempty"`
+}
+
+// AddonPatchTarget selects an object of an addon.
+type AddonPatchTarget struct {
+	// Group is the API group of the object, empty for the core group.
+	Group string `json:"group,omitempty"`
+	// Version is the API version of the object; any version matches if empty.
+	Version string `json:"version,omitempty"`
+	// Kind is the kind of the object.
+	Kind string `json:"kind"`
+	// Namespace is the namespace of the object; any namespace matches if empty.
+	Namespace string `json:"namespace,omitempty"`
+	// Name is the name of the object.
+	Name string `json:"name"`
+}
+
+const (
+	// AddonDriftPolicyEnforce re-applies the objects of the addon, and reports the changes.
+	AddonDriftPolicyEnforce AddonDriftPolicy = "enforce"
+	// AddonDriftPolicyReport only reports the changes.
+	AddonDriftPolicyReport AddonDriftPolicy = "report"
+	// AddonDriftPolicyIgnore doesn't check the addon.
+	AddonDriftPolicyIgnore AddonDriftPolicy = "ignore"
+)
+
 // FileAssetSpec defines the structure for a file asset
 type FileAssetSpec struct {
 	// Name is a shortened reference to the asset
@@ -369,8 +429,73 @@ type AssetsSpec struct {
 	FileRepository *string `json:"fileRepository,omitempty"`
 	// ContainerProxy is a url for a pull-through proxy of a container registry.
 	ContainerProxy *string `json:"containerProxy,omitempty"`
+	// ImageVerification configures the verification of the signatures of the images, when they are copied
+	// with kops get assets --copy and when nodes run them as static pods.
+	ImageVerification *ImageVerificationSpec `json:"imageVerification,omitempty"`
+	// FileRepositoryConfig configures how nodes download files from the FileRepository.
+	FileRepositoryConfig *FileRepositoryConfig `json:"fileRepositoryConfig,omitempty"`
+}
+
+// FileRepositoryConfig configures how nodes download files from the file repository.
+type FileRepositoryConfig struct {
+	// Secret is the name of the secret holding the CA, client certificate and credentials used to download
+	// from the file repository, created with kops create secret filerepository.
+	Secret string `json:"secret,omitempty"`
+	// FallbackToCanonical makes nodes download files from their canonical location when the file repository fails.
+	// Files are verified against their hashes wherever they are downloaded from.
+	FallbackToCanonical bool `json:"fallbackToCanonical,omitempty"`
+}
+
+// ImageVerificationSpec configures the verification of the signatures of container images.
+type ImageVerificationSpec struct {
+	// Action is what is done with an image that fails verification: enforce (the default) refuses the image, warn only logs it.
+	Action ImageVerificationAction `json:"action,omitempty"`
+	// Policies are the trusted signers of the images. An image is verified by the first policy that matches it,
+	// and fails verification if no policy matches it.
+	Policies []ImageVerificationPolicy `json:"policies,omitempty"`
+}
+
+// ImageVerificationAction is what is done with an image that fails verification.
+type ImageVerificationAction string
+
+// ImageVerificationPolicy holds the trusted signers of a set of images.
+type ImageVerificationPolicy struct {
+	// Images are the names of the images the policy applies to, as they are pulled by the nodes.
+	// A trailing * matches any suffix. The policy applies to all images if empty.
+	Images []string `json:"images,omitempty"`
+	// PublicKeys are PEM encoded public keys. A signature made with any of them verifies the image.
+	PublicKeys []string `json:"publicKeys,omitempty"`
+	// Keyless verifies signatures made with short-lived certificates issued to an identity.
+	Keyless *KeylessImageVerification `json:"keyless,omitempty"`
+}
+
+// KeylessImageVerification holds the trusted identities of keyless signatures.
+type KeylessImageVerification struct {
+	// Roots are PEM encoded certificates of the authorities that issue the signing certificates.
+	Roots []string `json:"roots,omitempty"`
+	// Identities are the identities that are trusted to sign the images.
+	Identities []KeylessIdentity `json:"identities,omitempty"`
+	// TransparencyLogPublicKeys are PEM encoded public keys of the transparency logs, such as Rekor, that are trusted
+	// to record when a signature was made. A signature is only trusted if one of these logs has recorded it
+	// while its certificate was valid.
+	TransparencyLogPublicKeys []string `json:"transparencyLogPublicKeys,omitempty"`
+}
+
+// KeylessIdentity is an identity of a keyless signature.
+type KeylessIdentity struct {
+	// Issuer is the OIDC issuer that authenticated the signer, e.g. https://accounts.google.com.
+	Issuer string `json:"issuer"`
+	// Subject is the email address or URI of the signer.
+	Subject string `json:"subject"`
 }
 
+const (
+	// ImageVerificationActionEnforce refuses images that fail verification.
+	ImageVerificationActionEnforce ImageVerificationAction = "enforce"
+	// ImageVerificationActionWarn logs images that fail verification.
+	ImageVerificationActionWarn ImageVerificationAction = "warn"
+)
+
 // IAMSpec adds control over the IAM security policies applied to resources
 type IAMSpec struct {
 	Legacy                 bool    `json:"legacy"`
@@ -653,10 +778,23 @@ type ExternalDNSConfig struct {
 	WatchIngress *bool `json:"watchIngress,omitempty"`
 	// WatchNamespace is namespace to watch, defaults to all (use to control whom can creates dns entries)
 	WatchNamespace string `json:"watchNamespace,omitempty"`
+	// WatchGateway indicates you want the dns-controller to watch and create dns entries for Gateway API
+	// gateway and httproute resources. The Gateway API CRDs must be installed.
+	WatchGateway *bool `json:"watchGateway,omitempty"`
 	// Provider determines which implementation of ExternalDNS to use.
 	// 'dns-controller' will use kOps DNS Controller.
 	// 'external-dns' will use kubernetes-sigs/external-dns.
 	Provider ExternalDNSProvider `json:"provider,omitempty"`
+	// DNSProvider is the DNS service in which dns-controller and kOps manage the DNS records,
+	// instead of the DNS service of the cloud provider: 'cloudflare' or 'rfc2136'.
+	// The credentials are read from the environment when updating the cluster.
+	DNSProvider string `json:"dnsProvider,omitempty"`
+	// TXTOwnerID enables the ownership registry of dns-controller: the records it manages are tagged with
+	// TXT records containing this owner ID, and records owned by others are neither updated nor deleted.
+	TXTOwnerID string `json:"txtOwnerID,omitempty"`
+	// TXTAdoptUnowned lets dns-controller take ownership of existing records without ownership record,
+	// for enabling the ownership registry on an existing cluster.
+	TXTAdoptUnowned bool `json:"txtAdoptUnowned,omitempty"`
 }
 
 // EtcdProviderType describes etcd cluster provisioning types (Standalone, Manager)
@@ -699,6 +837,19 @@ type EtcdBackupSpec struct {
 	BackupStore string `json:"backupStore,omitempty"`
 	// Image is the etcd backup manager image to use.  Setting this will create a sidecar container in the etcd pod with the specified image.
 	Image string `json:"image,omitempty"`
+	// Verification enables periodic restore drills of the latest backup by kops-controller.
+	Verification *EtcdBackupVerificationSpec `json:"verification,omitempty"`
+}
+
+// EtcdBackupVerificationSpec configures the periodic verification of etcd backups.
+// The latest backup is restored into a temporary single-member etcd, and checked.
+type EtcdBackupVerificationSpec struct {
+	// Interval is how often the latest backup is verified. The default is 24 hours.
+	Interval *metav1.Duration `json:"interval,omitempty"`
+	// RequiredPrefixes are key prefixes that must each have at least one key in the restored backup.
+	RequiredPrefixes []string `json:"requiredPrefixes,omitempty"`
+	// MinKeys is the minimum number of keys the restored backup must hold. The default is 1.
+	MinKeys *int64 `json:"minKeys,omitempty"`
 }
 
 // EtcdManagerSpec describes how we configure the etcd manager
@@ -924,6 +1075,11 @@ func (c *Cluster) PublishesDNSRecords() bool {
 	return true
 }
 
+// UsesCloudDNSProvider returns true if the DNS records are managed in the DNS service of the cloud provider.
+func (c *Cluster) UsesCloudDNSProvider() bool {
+	return c.Spec.ExternalDNS == nil || c.Spec.ExternalDNS.DNSProvider == ""
+}
+
 func (c *Cluster) UsesLegacyGossip() bool {
 	if c.UsesNoneDNS() || !dns.IsGossipClusterName(c.Name) {
 		return false
@@ -41,7 +41,11 @@ type ContainerdConfig struct {
 	// Packages overrides the URL and hash for the packages.
 	Packages *PackagesConfig `json:"packages,omitempty"`
 	// RegistryMirrors is list of image registries
+	// RegistryHosts should be used instead, as it also supports CAs, client certificates and credentials.
 	RegistryMirrors map[string][]string `json:"registryMirrors,omitempty"`
+	// RegistryHosts configures how containerd connects to registries, in /etc/containerd/certs.d/<registry>/hosts.toml.
+	// When set, RegistryMirrors are written as hosts.toml files too, instead of in the deprecated mirrors section of config.toml.
+	RegistryHosts []ContainerdRegistryHostsConfig `json:"registryHosts,omitempty"`
 	// Root directory for persistent data (default "/var/lib/containerd").
 	Root *string `json:"root,omitempty" flag:"root"`
 	// SkipInstall prevents kOps from installing and modifying containerd in any way (default "false").
@@ -68,6 +72,33 @@ type ContainerdConfig struct {
 	SandboxImage *string `json:"sandboxImage,omitempty"`
 }
 
+// ContainerdRegistryHostsConfig configures how containerd connects to a registry.
+type ContainerdRegistryHostsConfig struct {
+	// Registry is the name of the registry, e.g. docker.io or registry.example.com:5000.
+	// _default configures the registries that are not listed.
+	Registry string `json:"registry"`
+	// Server configures the registry itself. The default URL is https://<registry>.
+	Server *ContainerdRegistryHost `json:"server,omitempty"`
+	// Hosts are tried in order before the server, e.g. mirrors or pull-through caches.
+	Hosts []ContainerdRegistryHost `json:"hosts,omitempty"`
+}
+
+// ContainerdRegistryHost configures a host serving a registry.
+type ContainerdRegistryHost struct {
+	// URL of the host, e.g. https://mirror.example.com:5000.
+	URL string `json:"url,omitempty"`
+	// Capabilities are the operations the host is used for: pull, resolve and push.
+	// The default is pull and resolve for hosts, and all for the server.
+	Capabilities []string `json:"capabilities,omitempty"`
+	// SkipVerify skips the verification of the certificate of the host.
+	SkipVerify bool `json:"skipVerify,omitempty"`
+	// OverridePath is true if the URL includes the path of the API, e.g. https://mirror.example.com/v2/registry.
+	OverridePath bool `json:"overridePath,omitempty"`
+	// Secret is the name of the secret holding the CA, client certificate and credentials of the host,
+	// created with kops create secret containerdregistry.
+	Secret string `json:"secret,omitempty"`
+}
+
 type NRIConfig struct {
 	// Enable NRI support in containerd
 	Enabled *bool `json:"enabled,omitempty"`
@@ -103,3 +134,19 @@ type Runc struct {
 	// Packages overrides the URL and hash for the packages.
 	Packages *PackagesConfig `json:"packages,omitempty"`
 }
+
+// RegistrySecrets returns the names of the secrets used by the registry hosts.
+func (c *ContainerdConfig) RegistrySecrets() []string {
+	var secrets []string
+	for _, registry := range c.RegistryHosts {
+		if registry.Server != nil && registry.Server.Secret != "" {
+			secrets = append(secrets, registry.Server.Secret)
+		}
+		for _, host := range registry.Hosts {
+			if host.Secret != "" {
+				secrets = append(secrets, host.Secret)
+			}
+		}
+	}
+	return secrets
+}
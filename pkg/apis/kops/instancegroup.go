@@ -63,12 +63,15 @@ const (
 	InstanceGroupRoleBastion InstanceGroupRole = "Bastion"
 	// InstanceGroupRoleAPIServer is an API server role.
 	InstanceGroupRoleAPIServer InstanceGroupRole = "APIServer"
+	// InstanceGroupRoleEtcd is an etcd role.
+	InstanceGroupRoleEtcd InstanceGroupRole = "Etcd"
 )
 
 // AllInstanceGroupRoles is a slice of all valid InstanceGroupRole values
 var AllInstanceGroupRoles = []InstanceGroupRole{
 	InstanceGroupRoleControlPlane,
 	InstanceGroupRoleAPIServer,
+	InstanceGroupRoleEtcd,
 	InstanceGroupRoleNode,
 	InstanceGroupRoleBastion,
 }
@@ -359,6 +362,16 @@ func (g *InstanceGroup) IsAPIServerOnly() bool {
 	}
 }
 
+// IsEtcdOnly checks if instanceGroup runs only etcd
+func (g *InstanceGroup) IsEtcdOnly() bool {
+	switch g.Spec.Role {
+	case InstanceGroupRoleEtcd:
+		return true
+	default:
+		return false
+	}
+}
+
 // hasAPIServer checks if instanceGroup runs an API Server
 func (g *InstanceGroup) HasAPIServer() bool {
 	return g.IsControlPlane() || g.IsAPIServerOnly()
@@ -56,7 +56,7 @@ func CreateClusterConfig(ctx context.Context, clientset simple.Clientset, cluste
 	{
 		addonsClient := clientset.AddonsFor(cluster)
 
-		if err := addonsClient.Replace(addons); err != nil {
+		if err := addonsClient.Replace(ctx, addons); err != nil {
 			return fmt.Errorf("error writing updated addon configuration: %v", err)
 		}
 	}
@@ -94,6 +94,46 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*AddonDriftDetectionSpec)(nil), (*kops.AddonDriftDetectionSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_AddonDriftDetectionSpec_To_kops_AddonDriftDetectionSpec(a.(*AddonDriftDetectionSpec), b.(*kops.AddonDriftDetectionSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.AddonDriftDetectionSpec)(nil), (*AddonDriftDetectionSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_AddonDriftDetectionSpec_To_v1alpha2_AddonDriftDetectionSpec(a.(*kops.AddonDriftDetectionSpec), b.(*AddonDriftDetectionSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*AddonOverrideSpec)(nil), (*kops.AddonOverrideSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_AddonOverrideSpec_To_kops_AddonOverrideSpec(a.(*AddonOverrideSpec), b.(*kops.AddonOverrideSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.AddonOverrideSpec)(nil), (*AddonOverrideSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_AddonOverrideSpec_To_v1alpha2_AddonOverrideSpec(a.(*kops.AddonOverrideSpec), b.(*AddonOverrideSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*AddonPatchSpec)(nil), (*kops.AddonPatchSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_AddonPatchSpec_To_kops_AddonPatchSpec(a.(*AddonPatchSpec), b.(*kops.AddonPatchSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.AddonPatchSpec)(nil), (*AddonPatchSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_AddonPatchSpec_To_v1alpha2_AddonPatchSpec(a.(*kops.AddonPatchSpec), b.(*AddonPatchSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*AddonPatchTarget)(nil), (*kops.AddonPatchTarget)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_AddonPatchTarget_To_kops_AddonPatchTarget(a.(*AddonPatchTarget), b.(*kops.AddonPatchTarget), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.AddonPatchTarget)(nil), (*AddonPatchTarget)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_AddonPatchTarget_To_v1alpha2_AddonPatchTarget(a.(*kops.AddonPatchTarget), b.(*AddonPatchTarget), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*AddonSpec)(nil), (*kops.AddonSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_AddonSpec_To_kops_AddonSpec(a.(*AddonSpec), b.(*kops.AddonSpec), scope)
 	}); err != nil {
@@ -314,6 +354,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ContainerdRegistryHost)(nil), (*kops.ContainerdRegistryHost)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_ContainerdRegistryHost_To_kops_ContainerdRegistryHost(a.(*ContainerdRegistryHost), b.(*kops.ContainerdRegistryHost), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.ContainerdRegistryHost)(nil), (*ContainerdRegistryHost)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_ContainerdRegistryHost_To_v1alpha2_ContainerdRegistryHost(a.(*kops.ContainerdRegistryHost), b.(*ContainerdRegistryHost), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ContainerdRegistryHostsConfig)(nil), (*kops.ContainerdRegistryHostsConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_ContainerdRegistryHostsConfig_To_kops_ContainerdRegistryHostsConfig(a.(*ContainerdRegistryHostsConfig), b.(*kops.ContainerdRegistryHostsConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.ContainerdRegistryHostsConfig)(nil), (*ContainerdRegistryHostsConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_ContainerdRegistryHostsConfig_To_v1alpha2_ContainerdRegistryHostsConfig(a.(*kops.ContainerdRegistryHostsConfig), b.(*ContainerdRegistryHostsConfig), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*DCGMExporterConfig)(nil), (*kops.DCGMExporterConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_DCGMExporterConfig_To_kops_DCGMExporterConfig(a.(*DCGMExporterConfig), b.(*kops.DCGMExporterConfig), scope)
 	}); err != nil {
@@ -414,6 +474,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*EtcdBackupVerificationSpec)(nil), (*kops.EtcdBackupVerificationSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_EtcdBackupVerificationSpec_To_kops_EtcdBackupVerificationSpec(a.(*EtcdBackupVerificationSpec), b.(*kops.EtcdBackupVerificationSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.EtcdBackupVerificationSpec)(nil), (*EtcdBackupVerificationSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_EtcdBackupVerificationSpec_To_v1alpha2_EtcdBackupVerificationSpec(a.(*kops.EtcdBackupVerificationSpec), b.(*EtcdBackupVerificationSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*EtcdClusterSpec)(nil), (*kops.EtcdClusterSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_EtcdClusterSpec_To_kops_EtcdClusterSpec(a.(*EtcdClusterSpec), b.(*kops.EtcdClusterSpec), scope)
 	}); err != nil {
@@ -474,6 +544,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*FileRepositoryConfig)(nil), (*kops.FileRepositoryConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_FileRepositoryConfig_To_kops_FileRepositoryConfig(a.(*FileRepositoryConfig), b.(*kops.FileRepositoryConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.FileRepositoryConfig)(nil), (*FileRepositoryConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_FileRepositoryConfig_To_v1alpha2_FileRepositoryConfig(a.(*kops.FileRepositoryConfig), b.(*FileRepositoryConfig), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*FlannelNetworkingSpec)(nil), (*kops.FlannelNetworkingSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_FlannelNetworkingSpec_To_kops_FlannelNetworkingSpec(a.(*FlannelNetworkingSpec), b.(*kops.FlannelNetworkingSpec), scope)
 	}); err != nil {
@@ -584,6 +664,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ImageVerificationPolicy)(nil), (*kops.ImageVerificationPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_ImageVerificationPolicy_To_kops_ImageVerificationPolicy(a.(*ImageVerificationPolicy), b.(*kops.ImageVerificationPolicy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.ImageVerificationPolicy)(nil), (*ImageVerificationPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_ImageVerificationPolicy_To_v1alpha2_ImageVerificationPolicy(a.(*kops.ImageVerificationPolicy), b.(*ImageVerificationPolicy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ImageVerificationSpec)(nil), (*kops.ImageVerificationSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_ImageVerificationSpec_To_kops_ImageVerificationSpec(a.(*ImageVerificationSpec), b.(*kops.ImageVerificationSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.ImageVerificationSpec)(nil), (*ImageVerificationSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_ImageVerificationSpec_To_v1alpha2_ImageVerificationSpec(a.(*kops.ImageVerificationSpec), b.(*ImageVerificationSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*InstanceGroup)(nil), (*kops.InstanceGroup)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_InstanceGroup_To_kops_InstanceGroup(a.(*InstanceGroup), b.(*kops.InstanceGroup), scope)
 	}); err != nil {
@@ -634,6 +734,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*KeylessIdentity)(nil), (*kops.KeylessIdentity)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_KeylessIdentity_To_kops_KeylessIdentity(a.(*KeylessIdentity), b.(*kops.KeylessIdentity), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.KeylessIdentity)(nil), (*KeylessIdentity)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_KeylessIdentity_To_v1alpha2_KeylessIdentity(a.(*kops.KeylessIdentity), b.(*KeylessIdentity), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*KeylessImageVerification)(nil), (*kops.KeylessImageVerification)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_KeylessImageVerification_To_kops_KeylessImageVerification(a.(*KeylessImageVerification), b.(*kops.KeylessImageVerification), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.KeylessImageVerification)(nil), (*KeylessImageVerification)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_KeylessImageVerification_To_v1alpha2_KeylessImageVerification(a.(*kops.KeylessImageVerification), b.(*KeylessImageVerification), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*Keyset)(nil), (*kops.Keyset)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_Keyset_To_kops_Keyset(a.(*Keyset), b.(*kops.Keyset), scope)
 	}); err != nil {
@@ -1533,6 +1653,144 @@ func Convert_kops_AccessLogSpec_To_v1alpha2_AccessLogSpec(in *kops.AccessLogSpec
 	return autoConvert_kops_AccessLogSpec_To_v1alpha2_AccessLogSpec(in, out, s)
 }
 
+func autoConvert_v1alpha2_AddonDriftDetectionSpec_To_kops_AddonDriftDetectionSpec(in *AddonDriftDetectionSpec, out *kops.AddonDriftDetectionSpec, s conversion.Scope) error {
+	out.Interval = in.Interval
+	out.Policy = kops.AddonDriftPolicy(in.Policy)
+	if in.Addons != nil {
+		in, out := &in.Addons, &out.Addons
+		*out = make(map[string]kops.AddonDriftPolicy, len(*in))
+		for key, val := range *in {
+			(*out)[key] = kops.AddonDriftPolicy(val)
+		}
+	} else {
+		out.Addons = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha2_AddonDriftDetectionSpec_To_kops_AddonDriftDetectionSpec is an autogenerated conversion function.
+func Convert_v1alpha2_AddonDriftDetectionSpec_To_kops_AddonDriftDetectionSpec(in *AddonDriftDetectionSpec, out *kops.AddonDriftDetectionSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_AddonDriftDetectionSpec_To_kops_AddonDriftDetectionSpec(in, out, s)
+}
+
+func autoConvert_kops_AddonDriftDetectionSpec_To_v1alpha2_AddonDriftDetectionSpec(in *kops.AddonDriftDetectionSpec, out *AddonDriftDetectionSpec, s conversion.Scope) error {
+	out.Interval = in.Interval
+	out.Policy = AddonDriftPolicy(in.Policy)
+	if in.Addons != nil {
+		in, out := &in.Addons, &out.Addons
+		*out = make(map[string]AddonDriftPolicy, len(*in))
+		for key, val := range *in {
+			(*out)[key] = AddonDriftPolicy(val)
+		}
+	} else {
+		out.Addons = nil
+	}
+	return nil
+}
+
+// Convert_kops_AddonDriftDetectionSpec_To_v1alpha2_AddonDriftDetectionSpec is an autogenerated conversion function.
+func Convert_kops_AddonDriftDetectionSpec_To_v1alpha2_AddonDriftDetectionSpec(in *kops.AddonDriftDetectionSpec, out *AddonDriftDetectionSpec, s conversion.Scope) error {
+	return autoConvert_kops_AddonDriftDetectionSpec_To_v1alpha2_AddonDriftDetectionSpec(in, out, s)
+}
+
+func autoConvert_v1alpha2_AddonOverrideSpec_To_kops_AddonOverrideSpec(in *AddonOverrideSpec, out *kops.AddonOverrideSpec, s conversion.Scope) error {
+	out.Name = in.Name
+	if in.Patches != nil {
+		in, out := &in.Patches, &out.Patches
+		*out = make([]kops.AddonPatchSpec, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha2_AddonPatchSpec_To_kops_AddonPatchSpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Patches = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha2_AddonOverrideSpec_To_kops_AddonOverrideSpec is an autogenerated conversion function.
+func Convert_v1alpha2_AddonOverrideSpec_To_kops_AddonOverrideSpec(in *AddonOverrideSpec, out *kops.AddonOverrideSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_AddonOverrideSpec_To_kops_AddonOverrideSpec(in, out, s)
+}
+
+func autoConvert_kops_AddonOverrideSpec_To_v1alpha2_AddonOverrideSpec(in *kops.AddonOverrideSpec, out *AddonOverrideSpec, s conversion.Scope) error {
+	out.Name = in.Name
+	if in.Patches != nil {
+		in, out := &in.Patches, &out.Patches
+		*out = make([]AddonPatchSpec, len(*in))
+		for i := range *in {
+			if err := Convert_kops_AddonPatchSpec_To_v1alpha2_AddonPatchSpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Patches = nil
+	}
+	return nil
+}
+
+// Convert_kops_AddonOverrideSpec_To_v1alpha2_AddonOverrideSpec is an autogenerated conversion function.
+func Convert_kops_AddonOverrideSpec_To_v1alpha2_AddonOverrideSpec(in *kops.AddonOverrideSpec, out *AddonOverrideSpec, s conversion.Scope) error {
+	return autoConvert_kops_AddonOverrideSpec_To_v1alpha2_AddonOverrideSpec(in, out, s)
+}
+
+func autoConvert_v1alpha2_AddonPatchSpec_To_kops_AddonPatchSpec(in *AddonPatchSpec, out *kops.AddonPatchSpec, s conversion.Scope) error {
+	if err := Convert_v1alpha2_AddonPatchTarget_To_kops_AddonPatchTarget(&in.Target, &out.Target, s); err != nil {
+		return err
+	}
+	out.Patch = in.Patch
+	out.JSONPatch = in.JSONPatch
+	return nil
+}
+
+// Convert_v1alpha2_AddonPatchSpec_To_kops_AddonPatchSpec is an autogenerated conversion function.
+func Convert_v1alpha2_AddonPatchSpec_To_kops_AddonPatchSpec(in *AddonPatchSpec, out *kops.AddonPatchSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_AddonPatchSpec_To_kops_AddonPatchSpec(in, out, s)
+}
+
+func autoConvert_kops_AddonPatchSpec_To_v1alpha2_AddonPatchSpec(in *kops.AddonPatchSpec, out *AddonPatchSpec, s conversion.Scope) error {
+	if err := Convert_kops_AddonPatchTarget_To_v1alpha2_AddonPatchTarget(&in.Target, &out.Target, s); err != nil {
+		return err
+	}
+	out.Patch = in.Patch
+	out.JSONPatch = in.JSONPatch
+	return nil
+}
+
+// Convert_kops_AddonPatchSpec_To_v1alpha2_AddonPatchSpec is an autogenerated conversion function.
+func Convert_kops_AddonPatchSpec_To_v1alpha2_AddonPatchSpec(in *kops.AddonPatchSpec, out *AddonPatchSpec, s conversion.Scope) error {
+	return autoConvert_kops_AddonPatchSpec_To_v1alpha2_AddonPatchSpec(in, out, s)
+}
+
+func autoConvert_v1alpha2_AddonPatchTarget_To_kops_AddonPatchTarget(in *AddonPatchTarget, out *kops.AddonPatchTarget, s conversion.Scope) error {
+	out.Group = in.Group
+	out.Version = in.Version
+	out.Kind = in.Kind
+	out.Namespace = in.Namespace
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_v1alpha2_AddonPatchTarget_To_kops_AddonPatchTarget is an autogenerated conversion function.
+func Convert_v1alpha2_AddonPatchTarget_To_kops_AddonPatchTarget(in *AddonPatchTarget, out *kops.AddonPatchTarget, s conversion.Scope) error {
+	return autoConvert_v1alpha2_AddonPatchTarget_To_kops_AddonPatchTarget(in, out, s)
+}
+
+func autoConvert_kops_AddonPatchTarget_To_v1alpha2_AddonPatchTarget(in *kops.AddonPatchTarget, out *AddonPatchTarget, s conversion.Scope) error {
+	out.Group = in.Group
+	out.Version = in.Version
+	out.Kind = in.Kind
+	out.Namespace = in.Namespace
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_kops_AddonPatchTarget_To_v1alpha2_AddonPatchTarget is an autogenerated conversion function.
+func Convert_kops_AddonPatchTarget_To_v1alpha2_AddonPatchTarget(in *kops.AddonPatchTarget, out *AddonPatchTarget, s conversion.Scope) error {
+	return autoConvert_kops_AddonPatchTarget_To_v1alpha2_AddonPatchTarget(in, out, s)
+}
+
 func autoConvert_v1alpha2_AddonSpec_To_kops_AddonSpec(in *AddonSpec, out *kops.AddonSpec, s conversion.Scope) error {
 	out.Manifest = in.Manifest
 	return nil
@@ -1621,6 +1879,24 @@ func autoConvert_v1alpha2_AssetsSpec_To_kops_AssetsSpec(in *AssetsSpec, out *kop
 	out.ContainerRegistry = in.ContainerRegistry
 	out.FileRepository = in.FileRepository
 	out.ContainerProxy = in.ContainerProxy
+	if in.ImageVerification != nil {
+		in, out := &in.ImageVerification, &out.ImageVerification
+		*out = new(kops.ImageVerificationSpec)
+		if err := Convert_v1alpha2_ImageVerificationSpec_To_kops_ImageVerificationSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ImageVerification = nil
+	}
+	if in.FileRepositoryConfig != nil {
+		in, out := &in.FileRepositoryConfig, &out.FileRepositoryConfig
+		*out = new(kops.FileRepositoryConfig)
+		if err := Convert_v1alpha2_FileRepositoryConfig_To_kops_FileRepositoryConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.FileRepositoryConfig = nil
+	}
 	return nil
 }
 
@@ -1633,6 +1909,24 @@ func autoConvert_kops_AssetsSpec_To_v1alpha2_AssetsSpec(in *kops.AssetsSpec, out
 	out.ContainerRegistry = in.ContainerRegistry
 	out.FileRepository = in.FileRepository
 	out.ContainerProxy = in.ContainerProxy
+	if in.ImageVerification != nil {
+		in, out := &in.ImageVerification, &out.ImageVerification
+		*out = new(ImageVerificationSpec)
+		if err := Convert_kops_ImageVerificationSpec_To_v1alpha2_ImageVerificationSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ImageVerification = nil
+	}
+	if in.FileRepositoryConfig != nil {
+		in, out := &in.FileRepositoryConfig, &out.FileRepositoryConfig
+		*out = new(FileRepositoryConfig)
+		if err := Convert_kops_FileRepositoryConfig_To_v1alpha2_FileRepositoryConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.FileRepositoryConfig = nil
+	}
 	return nil
 }
 
@@ -2543,6 +2837,26 @@ func autoConvert_v1alpha2_ClusterSpec_To_kops_ClusterSpec(in *ClusterSpec, out *
 		out.Addons = nil
 	}
 	out.ConfigStore = in.ConfigStore
+	if in.AddonDriftDetection != nil {
+		in, out := &in.AddonDriftDetection, &out.AddonDriftDetection
+		*out = new(kops.AddonDriftDetectionSpec)
+		if err := Convert_v1alpha2_AddonDriftDetectionSpec_To_kops_AddonDriftDetectionSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AddonDriftDetection = nil
+	}
+	if in.AddonOverrides != nil {
+		in, out := &in.AddonOverrides, &out.AddonOverrides
+		*out = make([]kops.AddonOverrideSpec, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha2_AddonOverrideSpec_To_kops_AddonOverrideSpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.AddonOverrides = nil
+	}
 	// INFO: in.ConfigBase opted out of conversion generation
 	out.CloudProvider = in.CloudProvider
 	// INFO: in.LegacyCloudProvider opted out of conversion generation
@@ -2902,6 +3216,26 @@ func autoConvert_kops_ClusterSpec_To_v1alpha2_ClusterSpec(in *kops.ClusterSpec,
 	} else {
 		out.Addons = nil
 	}
+	if in.AddonDriftDetection != nil {
+		in, out := &in.AddonDriftDetection, &out.AddonDriftDetection
+		*out = new(AddonDriftDetectionSpec)
+		if err := Convert_kops_AddonDriftDetectionSpec_To_v1alpha2_AddonDriftDetectionSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AddonDriftDetection = nil
+	}
+	if in.AddonOverrides != nil {
+		in, out := &in.AddonOverrides, &out.AddonOverrides
+		*out = make([]AddonOverrideSpec, len(*in))
+		for i := range *in {
+			if err := Convert_kops_AddonOverrideSpec_To_v1alpha2_AddonOverrideSpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.AddonOverrides = nil
+	}
 	out.ConfigStore = in.ConfigStore
 	out.CloudProvider = in.CloudProvider
 	if in.GossipConfig != nil {
@@ -3295,6 +3629,17 @@ func autoConvert_v1alpha2_ContainerdConfig_To_kops_ContainerdConfig(in *Containe
 		out.Packages = nil
 	}
 	out.RegistryMirrors = in.RegistryMirrors
+	if in.RegistryHosts != nil {
+		in, out := &in.RegistryHosts, &out.RegistryHosts
+		*out = make([]kops.ContainerdRegistryHostsConfig, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha2_ContainerdRegistryHostsConfig_To_kops_ContainerdRegistryHostsConfig(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.RegistryHosts = nil
+	}
 	out.Root = in.Root
 	out.SkipInstall = in.SkipInstall
 	out.State = in.State
@@ -3354,6 +3699,17 @@ func autoConvert_kops_ContainerdConfig_To_v1alpha2_ContainerdConfig(in *kops.Con
 		out.Packages = nil
 	}
 	out.RegistryMirrors = in.RegistryMirrors
+	if in.RegistryHosts != nil {
+		in, out := &in.RegistryHosts, &out.RegistryHosts
+		*out = make([]ContainerdRegistryHostsConfig, len(*in))
+		for i := range *in {
+			if err := Convert_kops_ContainerdRegistryHostsConfig_To_v1alpha2_ContainerdRegistryHostsConfig(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.RegistryHosts = nil
+	}
 	out.Root = in.Root
 	out.SkipInstall = in.SkipInstall
 	out.State = in.State
@@ -3398,6 +3754,94 @@ func Convert_kops_ContainerdConfig_To_v1alpha2_ContainerdConfig(in *kops.Contain
 	return autoConvert_kops_ContainerdConfig_To_v1alpha2_ContainerdConfig(in, out, s)
 }
 
+func autoConvert_v1alpha2_ContainerdRegistryHost_To_kops_ContainerdRegistryHost(in *ContainerdRegistryHost, out *kops.ContainerdRegistryHost, s conversion.Scope) error {
+	out.URL = in.URL
+	out.Capabilities = in.Capabilities
+	out.SkipVerify = in.SkipVerify
+	out.OverridePath = in.OverridePath
+	out.Secret = in.Secret
+	return nil
+}
+
+// Convert_v1alpha2_ContainerdRegistryHost_To_kops_ContainerdRegistryHost is an autogenerated conversion function.
+func Convert_v1alpha2_ContainerdRegistryHost_To_kops_ContainerdRegistryHost(in *ContainerdRegistryHost, out *kops.ContainerdRegistryHost, s conversion.Scope) error {
+	return autoConvert_v1alpha2_ContainerdRegistryHost_To_kops_ContainerdRegistryHost(in, out, s)
+}
+
+func autoConvert_kops_ContainerdRegistryHost_To_v1alpha2_ContainerdRegistryHost(in *kops.ContainerdRegistryHost, out *ContainerdRegistryHost, s conversion.Scope) error {
+	out.URL = in.URL
+	out.Capabilities = in.Capabilities
+	out.SkipVerify = in.SkipVerify
+	out.OverridePath = in.OverridePath
+	out.Secret = in.Secret
+	return nil
+}
+
+// Convert_kops_ContainerdRegistryHost_To_v1alpha2_ContainerdRegistryHost is an autogenerated conversion function.
+func Convert_kops_ContainerdRegistryHost_To_v1alpha2_ContainerdRegistryHost(in *kops.ContainerdRegistryHost, out *ContainerdRegistryHost, s conversion.Scope) error {
+	return autoConvert_kops_ContainerdRegistryHost_To_v1alpha2_ContainerdRegistryHost(in, out, s)
+}
+
+func autoConvert_v1alpha2_ContainerdRegistryHostsConfig_To_kops_ContainerdRegistryHostsConfig(in *ContainerdRegistryHostsConfig, out *kops.ContainerdRegistryHostsConfig, s conversion.Scope) error {
+	out.Registry = in.Registry
+	if in.Server != nil {
+		in, out := &in.Server, &out.Server
+		*out = new(kops.ContainerdRegistryHost)
+		if err := Convert_v1alpha2_ContainerdRegistryHost_To_kops_ContainerdRegistryHost(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Server = nil
+	}
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]kops.ContainerdRegistryHost, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha2_ContainerdRegistryHost_To_kops_ContainerdRegistryHost(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Hosts = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha2_ContainerdRegistryHostsConfig_To_kops_ContainerdRegistryHostsConfig is an autogenerated conversion function.
+func Convert_v1alpha2_ContainerdRegistryHostsConfig_To_kops_ContainerdRegistryHostsConfig(in *ContainerdRegistryHostsConfig, out *kops.ContainerdRegistryHostsConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha2_ContainerdRegistryHostsConfig_To_kops_ContainerdRegistryHostsConfig(in, out, s)
+}
+
+func autoConvert_kops_ContainerdRegistryHostsConfig_To_v1alpha2_ContainerdRegistryHostsConfig(in *kops.ContainerdRegistryHostsConfig, out *ContainerdRegistryHostsConfig, s conversion.Scope) error {
+	out.Registry = in.Registry
+	if in.Server != nil {
+		in, out := &in.Server, &out.Server
+		*out = new(ContainerdRegistryHost)
+		if err := Convert_kops_ContainerdRegistryHost_To_v1alpha2_ContainerdRegistryHost(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Server = nil
+	}
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]ContainerdRegistryHost, len(*in))
+		for i := range *in {
+			if err := Convert_kops_ContainerdRegistryHost_To_v1alpha2_ContainerdRegistryHost(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Hosts = nil
+	}
+	return nil
+}
+
+// Convert_kops_ContainerdRegistryHostsConfig_To_v1alpha2_ContainerdRegistryHostsConfig is an autogenerated conversion function.
+func Convert_kops_ContainerdRegistryHostsConfig_To_v1alpha2_ContainerdRegistryHostsConfig(in *kops.ContainerdRegistryHostsConfig, out *ContainerdRegistryHostsConfig, s conversion.Scope) error {
+	return autoConvert_kops_ContainerdRegistryHostsConfig_To_v1alpha2_ContainerdRegistryHostsConfig(in, out, s)
+}
+
 func autoConvert_v1alpha2_DCGMExporterConfig_To_kops_DCGMExporterConfig(in *DCGMExporterConfig, out *kops.DCGMExporterConfig, s conversion.Scope) error {
 	out.Enabled = in.Enabled
 	return nil
@@ -3713,6 +4157,15 @@ func Convert_kops_EnvVar_To_v1alpha2_EnvVar(in *kops.EnvVar, out *EnvVar, s conv
 func autoConvert_v1alpha2_EtcdBackupSpec_To_kops_EtcdBackupSpec(in *EtcdBackupSpec, out *kops.EtcdBackupSpec, s conversion.Scope) error {
 	out.BackupStore = in.BackupStore
 	out.Image = in.Image
+	if in.Verification != nil {
+		in, out := &in.Verification, &out.Verification
+		*out = new(kops.EtcdBackupVerificationSpec)
+		if err := Convert_v1alpha2_EtcdBackupVerificationSpec_To_kops_EtcdBackupVerificationSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Verification = nil
+	}
 	return nil
 }
 
@@ -3724,6 +4177,15 @@ func Convert_v1alpha2_EtcdBackupSpec_To_kops_EtcdBackupSpec(in *EtcdBackupSpec,
 func autoConvert_kops_EtcdBackupSpec_To_v1alpha2_EtcdBackupSpec(in *kops.EtcdBackupSpec, out *EtcdBackupSpec, s conversion.Scope) error {
 	out.BackupStore = in.BackupStore
 	out.Image = in.Image
+	if in.Verification != nil {
+		in, out := &in.Verification, &out.Verification
+		*out = new(EtcdBackupVerificationSpec)
+		if err := Convert_kops_EtcdBackupVerificationSpec_To_v1alpha2_EtcdBackupVerificationSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Verification = nil
+	}
 	return nil
 }
 
@@ -3732,6 +4194,30 @@ func Convert_kops_EtcdBackupSpec_To_v1alpha2_EtcdBackupSpec(in *kops.EtcdBackupS
 	return autoConvert_kops_EtcdBackupSpec_To_v1alpha2_EtcdBackupSpec(in, out, s)
 }
 
+func autoConvert_v1alpha2_EtcdBackupVerificationSpec_To_kops_EtcdBackupVerificationSpec(in *EtcdBackupVerificationSpec, out *kops.EtcdBackupVerificationSpec, s conversion.Scope) error {
+	out.Interval = in.Interval
+	out.RequiredPrefixes = in.RequiredPrefixes
+	out.MinKeys = in.MinKeys
+	return nil
+}
+
+// Convert_v1alpha2_EtcdBackupVerificationSpec_To_kops_EtcdBackupVerificationSpec is an autogenerated conversion function.
+func Convert_v1alpha2_EtcdBackupVerificationSpec_To_kops_EtcdBackupVerificationSpec(in *EtcdBackupVerificationSpec, out *kops.EtcdBackupVerificationSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_EtcdBackupVerificationSpec_To_kops_EtcdBackupVerificationSpec(in, out, s)
+}
+
+func autoConvert_kops_EtcdBackupVerificationSpec_To_v1alpha2_EtcdBackupVerificationSpec(in *kops.EtcdBackupVerificationSpec, out *EtcdBackupVerificationSpec, s conversion.Scope) error {
+	out.Interval = in.Interval
+	out.RequiredPrefixes = in.RequiredPrefixes
+	out.MinKeys = in.MinKeys
+	return nil
+}
+
+// Convert_kops_EtcdBackupVerificationSpec_To_v1alpha2_EtcdBackupVerificationSpec is an autogenerated conversion function.
+func Convert_kops_EtcdBackupVerificationSpec_To_v1alpha2_EtcdBackupVerificationSpec(in *kops.EtcdBackupVerificationSpec, out *EtcdBackupVerificationSpec, s conversion.Scope) error {
+	return autoConvert_kops_EtcdBackupVerificationSpec_To_v1alpha2_EtcdBackupVerificationSpec(in, out, s)
+}
+
 func autoConvert_v1alpha2_EtcdClusterSpec_To_kops_EtcdClusterSpec(in *EtcdClusterSpec, out *kops.EtcdClusterSpec, s conversion.Scope) error {
 	out.Name = in.Name
 	out.Provider = kops.EtcdProviderType(in.Provider)
@@ -3940,14 +4426,22 @@ func autoConvert_v1alpha2_ExternalDNSConfig_To_kops_ExternalDNSConfig(in *Extern
 	// INFO: in.Disable opted out of conversion generation
 	out.WatchIngress = in.WatchIngress
 	out.WatchNamespace = in.WatchNamespace
+	out.WatchGateway = in.WatchGateway
 	out.Provider = kops.ExternalDNSProvider(in.Provider)
+	out.DNSProvider = in.DNSProvider
+	out.TXTOwnerID = in.TXTOwnerID
+	out.TXTAdoptUnowned = in.TXTAdoptUnowned
 	return nil
 }
 
 func autoConvert_kops_ExternalDNSConfig_To_v1alpha2_ExternalDNSConfig(in *kops.ExternalDNSConfig, out *ExternalDNSConfig, s conversion.Scope) error {
 	out.WatchIngress = in.WatchIngress
 	out.WatchNamespace = in.WatchNamespace
+	out.WatchGateway = in.WatchGateway
 	out.Provider = ExternalDNSProvider(in.Provider)
+	out.DNSProvider = in.DNSProvider
+	out.TXTOwnerID = in.TXTOwnerID
+	out.TXTAdoptUnowned = in.TXTAdoptUnowned
 	return nil
 }
 
@@ -4015,6 +4509,28 @@ func Convert_kops_FileAssetSpec_To_v1alpha2_FileAssetSpec(in *kops.FileAssetSpec
 	return autoConvert_kops_FileAssetSpec_To_v1alpha2_FileAssetSpec(in, out, s)
 }
 
+func autoConvert_v1alpha2_FileRepositoryConfig_To_kops_FileRepositoryConfig(in *FileRepositoryConfig, out *kops.FileRepositoryConfig, s conversion.Scope) error {
+	out.Secret = in.Secret
+	out.FallbackToCanonical = in.FallbackToCanonical
+	return nil
+}
+
+// Convert_v1alpha2_FileRepositoryConfig_To_kops_FileRepositoryConfig is an autogenerated conversion function.
+func Convert_v1alpha2_FileRepositoryConfig_To_kops_FileRepositoryConfig(in *FileRepositoryConfig, out *kops.FileRepositoryConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha2_FileRepositoryConfig_To_kops_FileRepositoryConfig(in, out, s)
+}
+
+func autoConvert_kops_FileRepositoryConfig_To_v1alpha2_FileRepositoryConfig(in *kops.FileRepositoryConfig, out *FileRepositoryConfig, s conversion.Scope) error {
+	out.Secret = in.Secret
+	out.FallbackToCanonical = in.FallbackToCanonical
+	return nil
+}
+
+// Convert_kops_FileRepositoryConfig_To_v1alpha2_FileRepositoryConfig is an autogenerated conversion function.
+func Convert_kops_FileRepositoryConfig_To_v1alpha2_FileRepositoryConfig(in *kops.FileRepositoryConfig, out *FileRepositoryConfig, s conversion.Scope) error {
+	return autoConvert_kops_FileRepositoryConfig_To_v1alpha2_FileRepositoryConfig(in, out, s)
+}
+
 func autoConvert_v1alpha2_FlannelNetworkingSpec_To_kops_FlannelNetworkingSpec(in *FlannelNetworkingSpec, out *kops.FlannelNetworkingSpec, s conversion.Scope) error {
 	out.Backend = in.Backend
 	// INFO: in.DisableTxChecksumOffloading opted out of conversion generation
@@ -4382,6 +4898,88 @@ func Convert_kops_IAMSpec_To_v1alpha2_IAMSpec(in *kops.IAMSpec, out *IAMSpec, s
 	return autoConvert_kops_IAMSpec_To_v1alpha2_IAMSpec(in, out, s)
 }
 
+func autoConvert_v1alpha2_ImageVerificationPolicy_To_kops_ImageVerificationPolicy(in *ImageVerificationPolicy, out *kops.ImageVerificationPolicy, s conversion.Scope) error {
+	out.Images = in.Images
+	out.PublicKeys = in.PublicKeys
+	if in.Keyless != nil {
+		in, out := &in.Keyless, &out.Keyless
+		*out = new(kops.KeylessImageVerification)
+		if err := Convert_v1alpha2_KeylessImageVerification_To_kops_KeylessImageVerification(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Keyless = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha2_ImageVerificationPolicy_To_kops_ImageVerificationPolicy is an autogenerated conversion function.
+func Convert_v1alpha2_ImageVerificationPolicy_To_kops_ImageVerificationPolicy(in *ImageVerificationPolicy, out *kops.ImageVerificationPolicy, s conversion.Scope) error {
+	return autoConvert_v1alpha2_ImageVerificationPolicy_To_kops_ImageVerificationPolicy(in, out, s)
+}
+
+func autoConvert_kops_ImageVerificationPolicy_To_v1alpha2_ImageVerificationPolicy(in *kops.ImageVerificationPolicy, out *ImageVerificationPolicy, s conversion.Scope) error {
+	out.Images = in.Images
+	out.PublicKeys = in.PublicKeys
+	if in.Keyless != nil {
+		in, out := &in.Keyless, &out.Keyless
+		*out = new(KeylessImageVerification)
+		if err := Convert_kops_KeylessImageVerification_To_v1alpha2_KeylessImageVerification(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Keyless = nil
+	}
+	return nil
+}
+
+// Convert_kops_ImageVerificationPolicy_To_v1alpha2_ImageVerificationPolicy is an autogenerated conversion function.
+func Convert_kops_ImageVerificationPolicy_To_v1alpha2_ImageVerificationPolicy(in *kops.ImageVerificationPolicy, out *ImageVerificationPolicy, s conversion.Scope) error {
+	return autoConvert_kops_ImageVerificationPolicy_To_v1alpha2_ImageVerificationPolicy(in, out, s)
+}
+
+func autoConvert_v1alpha2_ImageVerificationSpec_To_kops_ImageVerificationSpec(in *ImageVerificationSpec, out *kops.ImageVerificationSpec, s conversion.Scope) error {
+	out.Action = kops.ImageVerificationAction(in.Action)
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = make([]kops.ImageVerificationPolicy, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha2_ImageVerificationPolicy_To_kops_ImageVerificationPolicy(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Policies = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha2_ImageVerificationSpec_To_kops_ImageVerificationSpec is an autogenerated conversion function.
+func Convert_v1alpha2_ImageVerificationSpec_To_kops_ImageVerificationSpec(in *ImageVerificationSpec, out *kops.ImageVerificationSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_ImageVerificationSpec_To_kops_ImageVerificationSpec(in, out, s)
+}
+
+func autoConvert_kops_ImageVerificationSpec_To_v1alpha2_ImageVerificationSpec(in *kops.ImageVerificationSpec, out *ImageVerificationSpec, s conversion.Scope) error {
+	out.Action = ImageVerificationAction(in.Action)
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = make([]ImageVerificationPolicy, len(*in))
+		for i := range *in {
+			if err := Convert_kops_ImageVerificationPolicy_To_v1alpha2_ImageVerificationPolicy(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Policies = nil
+	}
+	return nil
+}
+
+// Convert_kops_ImageVerificationSpec_To_v1alpha2_ImageVerificationSpec is an autogenerated conversion function.
+func Convert_kops_ImageVerificationSpec_To_v1alpha2_ImageVerificationSpec(in *kops.ImageVerificationSpec, out *ImageVerificationSpec, s conversion.Scope) error {
+	return autoConvert_kops_ImageVerificationSpec_To_v1alpha2_ImageVerificationSpec(in, out, s)
+}
+
 func autoConvert_v1alpha2_InstanceGroup_To_kops_InstanceGroup(in *InstanceGroup, out *kops.InstanceGroup, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1alpha2_InstanceGroupSpec_To_kops_InstanceGroupSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -4920,6 +5518,72 @@ func Convert_kops_KarpenterConfig_To_v1alpha2_KarpenterConfig(in *kops.Karpenter
 	return autoConvert_kops_KarpenterConfig_To_v1alpha2_KarpenterConfig(in, out, s)
 }
 
+func autoConvert_v1alpha2_KeylessIdentity_To_kops_KeylessIdentity(in *KeylessIdentity, out *kops.KeylessIdentity, s conversion.Scope) error {
+	out.Issuer = in.Issuer
+	out.Subject = in.Subject
+	return nil
+}
+
+// Convert_v1alpha2_KeylessIdentity_To_kops_KeylessIdentity is an autogenerated conversion function.
+func Convert_v1alpha2_KeylessIdentity_To_kops_KeylessIdentity(in *KeylessIdentity, out *kops.KeylessIdentity, s conversion.Scope) error {
+	return autoConvert_v1alpha2_KeylessIdentity_To_kops_KeylessIdentity(in, out, s)
+}
+
+func autoConvert_kops_KeylessIdentity_To_v1alpha2_KeylessIdentity(in *kops.KeylessIdentity, out *KeylessIdentity, s conversion.Scope) error {
+	out.Issuer = in.Issuer
+	out.Subject = in.Subject
+	return nil
+}
+
+// Convert_kops_KeylessIdentity_To_v1alpha2_KeylessIdentity is an autogenerated conversion function.
+func Convert_kops_KeylessIdentity_To_v1alpha2_KeylessIdentity(in *kops.KeylessIdentity, out *KeylessIdentity, s conversion.Scope) error {
+	return autoConvert_kops_KeylessIdentity_To_v1alpha2_KeylessIdentity(in, out, s)
+}
+
+func autoConvert_v1alpha2_KeylessImageVerification_To_kops_KeylessImageVerification(in *KeylessImageVerification, out *kops.KeylessImageVerification, s conversion.Scope) error {
+	out.Roots = in.Roots
+	if in.Identities != nil {
+		in, out := &in.Identities, &out.Identities
+		*out = make([]kops.KeylessIdentity, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha2_KeylessIdentity_To_kops_KeylessIdentity(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Identities = nil
+	}
+	out.TransparencyLogPublicKeys = in.TransparencyLogPublicKeys
+	return nil
+}
+
+// Convert_v1alpha2_KeylessImageVerification_To_kops_KeylessImageVerification is an autogenerated conversion function.
+func Convert_v1alpha2_KeylessImageVerification_To_kops_KeylessImageVerification(in *KeylessImageVerification, out *kops.KeylessImageVerification, s conversion.Scope) error {
+	return autoConvert_v1alpha2_KeylessImageVerification_To_kops_KeylessImageVerification(in, out, s)
+}
+
+func autoConvert_kops_KeylessImageVerification_To_v1alpha2_KeylessImageVerification(in *kops.KeylessImageVerification, out *KeylessImageVerification, s conversion.Scope) error {
+	out.Roots = in.Roots
+	if in.Identities != nil {
+		in, out := &in.Identities, &out.Identities
+		*out = make([]KeylessIdentity, len(*in))
+		for i := range *in {
+			if err := Convert_kops_KeylessIdentity_To_v1alpha2_KeylessIdentity(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Identities = nil
+	}
+	out.TransparencyLogPublicKeys = in.TransparencyLogPublicKeys
+	return nil
+}
+
+// Convert_kops_KeylessImageVerification_To_v1alpha2_KeylessImageVerification is an autogenerated conversion function.
+func Convert_kops_KeylessImageVerification_To_v1alpha2_KeylessImageVerification(in *kops.KeylessImageVerification, out *KeylessImageVerification, s conversion.Scope) error {
+	return autoConvert_kops_KeylessImageVerification_To_v1alpha2_KeylessImageVerification(in, out, s)
+}
+
 func autoConvert_v1alpha2_Keyset_To_kops_Keyset(in *Keyset, out *kops.Keyset, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1alpha2_KeysetSpec_To_kops_KeysetSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -29,6 +29,7 @@ import (
 
 	"k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/pkg/apis/kops/util"
+	"k8s.io/kops/pkg/featureflag"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 	"k8s.io/kops/upup/pkg/fi/cloudup/gce"
@@ -55,6 +56,16 @@ func ValidateInstanceGroup(g *kops.InstanceGroup, cloud fi.Cloud, strict bool) f
 		if fi.ValueOf(g.Spec.MaxSize) > 1 {
 			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "maxSize"), fi.ValueOf(g.Spec.MaxSize), "controlPlane InstanceGroup must have maxSize set to 1, add more InstanceGroups instead"))
 		}
+	case kops.InstanceGroupRoleEtcd:
+		if len(g.Spec.Subnets) == 0 {
+			allErrs = append(allErrs, field.Required(field.NewPath("spec", "subnets"), "etcd InstanceGroup must specify at least one Subnet"))
+		}
+		if fi.ValueOf(g.Spec.MinSize) > 1 {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "minSize"), fi.ValueOf(g.Spec.MinSize), "etcd InstanceGroup must have minSize set to 1"))
+		}
+		if fi.ValueOf(g.Spec.MaxSize) > 1 {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "maxSize"), fi.ValueOf(g.Spec.MaxSize), "etcd InstanceGroup must have maxSize set to 1, add more InstanceGroups instead"))
+		}
 	case kops.InstanceGroupRoleNode:
 	case kops.InstanceGroupRoleBastion:
 	case kops.InstanceGroupRoleAPIServer:
@@ -152,7 +163,7 @@ func ValidateInstanceGroup(g *kops.InstanceGroup, cloud fi.Cloud, strict bool) f
 	}
 
 	if g.Spec.RollingUpdate != nil {
-		allErrs = append(allErrs, validateRollingUpdate(g.Spec.RollingUpdate, field.NewPath("spec", "rollingUpdate"), g.Spec.Role == kops.InstanceGroupRoleControlPlane)...)
+		allErrs = append(allErrs, validateRollingUpdate(g.Spec.RollingUpdate, field.NewPath("spec", "rollingUpdate"), g.Spec.Role == kops.InstanceGroupRoleControlPlane || g.Spec.Role == kops.InstanceGroupRoleEtcd)...)
 	}
 
 	if g.Spec.NodeLabels != nil {
@@ -247,6 +258,10 @@ func CrossValidateInstanceGroup(g *kops.InstanceGroup, cluster *kops.Cluster, cl
 		}
 	}
 
+	if g.Spec.Role == kops.InstanceGroupRoleEtcd {
+		allErrs = append(allErrs, ValidateEtcdInstanceGroup(g, cluster)...)
+	}
+
 	// Check that instance groups are defined in subnets that are defined in the cluster
 	{
 		clusterSubnets := make(map[string]*kops.ClusterSubnetSpec)
@@ -297,6 +312,10 @@ func CrossValidateInstanceGroup(g *kops.InstanceGroup, cluster *kops.Cluster, cl
 
 func ValidateControlPlaneInstanceGroup(g *kops.InstanceGroup, cluster *kops.Cluster) field.ErrorList {
 	allErrs := field.ErrorList{}
+	if featureflag.EtcdNodes.Enabled() {
+		// The etcd members may run on etcd instance groups; DeepValidate checks the placement of the members
+		return allErrs
+	}
 	for _, etcd := range cluster.Spec.EtcdClusters {
 		hasEtcd := false
 		for _, m := range etcd.Members {
@@ -312,6 +331,30 @@ func ValidateControlPlaneInstanceGroup(g *kops.InstanceGroup, cluster *kops.Clus
 	return allErrs
 }
 
+// ValidateEtcdInstanceGroup validates an instance group with role Etcd against the cluster.
+func ValidateEtcdInstanceGroup(g *kops.InstanceGroup, cluster *kops.Cluster) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if cluster.GetCloudProvider() != kops.CloudProviderAWS {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "role"), "Etcd role only supported on AWS"))
+	}
+	if cluster.UsesNoneDNS() || cluster.UsesLegacyGossip() {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "role"), "Etcd role requires a DNS zone (topology.dns.type=Public or Private)"))
+	}
+
+	hasEtcd := false
+	for _, etcd := range cluster.Spec.EtcdClusters {
+		for _, m := range etcd.Members {
+			if fi.ValueOf(m.InstanceGroup) == g.ObjectMeta.Name {
+				hasEtcd = true
+			}
+		}
+	}
+	if !hasEtcd {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "metadata", "name"), fmt.Sprintf("InstanceGroup %q with role Etcd must have a member in an etcd cluster", g.ObjectMeta.Name)))
+	}
+	return allErrs
+}
+
 var validUserDataTypes = []string{
 	"text/x-include-once-url",
 	"text/x-include-url",
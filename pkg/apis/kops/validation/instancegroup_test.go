@@ -24,6 +24,7 @@ import (
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/featureflag"
 	"k8s.io/kops/upup/pkg/fi"
 )
 
@@ -182,6 +183,250 @@ func TestValidMasterInstanceGroup(t *testing.T) {
 	}
 }
 
+func TestValidEtcdInstanceGroup(t *testing.T) {
+	newCluster := func(name string, cloud kops.CloudProviderSpec, dns kops.DNSType) *kops.Cluster {
+		return &kops.Cluster{
+			ObjectMeta: v1.ObjectMeta{
+				Name: name,
+			},
+			Spec: kops.ClusterSpec{
+				CloudProvider: cloud,
+				Networking: kops.NetworkingSpec{
+					Topology: &kops.TopologySpec{
+						DNS: dns,
+					},
+				},
+				EtcdClusters: []kops.EtcdClusterSpec{
+					{
+						Name: "main",
+						Members: []kops.EtcdMemberSpec{
+							{
+								Name:          "a",
+								InstanceGroup: fi.PtrTo("etcd-a"),
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+	aws := kops.CloudProviderSpec{AWS: &kops.AWSSpec{}}
+
+	grid := []struct {
+		Cluster        *kops.Cluster
+		IG             string
+		ExpectedErrors int
+		Description    string
+	}{
+		{
+			Cluster:        newCluster("example.com", aws, kops.DNSTypePublic),
+			IG:             "etcd-a",
+			ExpectedErrors: 0,
+			Description:    "Valid etcd instance group failed to validate",
+		},
+		{
+			Cluster:        newCluster("example.com", aws, kops.DNSTypePublic),
+			IG:             "etcd-b",
+			ExpectedErrors: 1,
+			Description:    "Etcd IG without etcd member validated",
+		},
+		{
+			Cluster:        newCluster("example.com", kops.CloudProviderSpec{GCE: &kops.GCESpec{}}, kops.DNSTypePublic),
+			IG:             "etcd-a",
+			ExpectedErrors: 1,
+			Description:    "Etcd IG on GCE validated",
+		},
+		{
+			Cluster:        newCluster("example.com", aws, kops.DNSTypeNone),
+			IG:             "etcd-a",
+			ExpectedErrors: 1,
+			Description:    "Etcd IG with DNS none validated",
+		},
+		{
+			Cluster:        newCluster("example.k8s.local", aws, kops.DNSTypePublic),
+			IG:             "etcd-a",
+			ExpectedErrors: 1,
+			Description:    "Etcd IG with gossip validated",
+		},
+	}
+
+	for _, g := range grid {
+		ig := &kops.InstanceGroup{
+			ObjectMeta: v1.ObjectMeta{
+				Name: g.IG,
+			},
+			Spec: kops.InstanceGroupSpec{
+				Role: kops.InstanceGroupRoleEtcd,
+			},
+		}
+		errList := ValidateEtcdInstanceGroup(ig, g.Cluster)
+		if len(errList) != g.ExpectedErrors {
+			t.Errorf("%s: %v", g.Description, errList)
+		}
+	}
+}
+
+func TestValidateEtcdMemberPlacement(t *testing.T) {
+	newGroup := func(name string, role kops.InstanceGroupRole) *kops.InstanceGroup {
+		return &kops.InstanceGroup{
+			ObjectMeta: v1.ObjectMeta{
+				Name: name,
+			},
+			Spec: kops.InstanceGroupSpec{
+				Role: role,
+			},
+		}
+	}
+	newCluster := func(igs ...string) *kops.Cluster {
+		cluster := &kops.Cluster{}
+		etcdCluster := kops.EtcdClusterSpec{Name: "main"}
+		for _, ig := range igs {
+			etcdCluster.Members = append(etcdCluster.Members, kops.EtcdMemberSpec{Name: ig, InstanceGroup: fi.PtrTo(ig)})
+		}
+		cluster.Spec.EtcdClusters = []kops.EtcdClusterSpec{etcdCluster}
+		return cluster
+	}
+	groups := []*kops.InstanceGroup{
+		newGroup("control-plane-a", kops.InstanceGroupRoleControlPlane),
+		newGroup("etcd-a", kops.InstanceGroupRoleEtcd),
+		newGroup("etcd-b", kops.InstanceGroupRoleEtcd),
+		newGroup("nodes", kops.InstanceGroupRoleNode),
+	}
+
+	grid := []struct {
+		Cluster     *kops.Cluster
+		FeatureFlag bool
+		ExpectError bool
+		Description string
+	}{
+		{
+			Cluster:     newCluster("control-plane-a"),
+			Description: "members on control-plane nodes",
+		},
+		{
+			Cluster:     newCluster("etcd-a", "etcd-b"),
+			FeatureFlag: true,
+			Description: "members on etcd nodes",
+		},
+		{
+			Cluster:     newCluster("etcd-a", "etcd-b"),
+			ExpectError: true,
+			Description: "members on etcd nodes without the feature flag",
+		},
+		{
+			Cluster:     newCluster("control-plane-a", "etcd-a"),
+			FeatureFlag: true,
+			ExpectError: true,
+			Description: "members on control-plane and etcd nodes",
+		},
+		{
+			Cluster:     newCluster("nodes"),
+			ExpectError: true,
+			Description: "members on worker nodes",
+		},
+		{
+			Cluster:     newCluster("etcd-a"),
+			FeatureFlag: true,
+			ExpectError: false,
+			Description: "control-plane node without member when members are on etcd nodes",
+		},
+		{
+			Cluster:     newCluster(),
+			FeatureFlag: true,
+			ExpectError: true,
+			Description: "control-plane node without member",
+		},
+	}
+
+	defer featureflag.ParseFlags("-EtcdNodes")
+	for _, g := range grid {
+		if g.FeatureFlag {
+			featureflag.ParseFlags("EtcdNodes")
+		} else {
+			featureflag.ParseFlags("-EtcdNodes")
+		}
+		err := validateEtcdMemberPlacement(g.Cluster, groups)
+		if g.ExpectError && err == nil {
+			t.Errorf("%s: expected error", g.Description)
+		}
+		if !g.ExpectError && err != nil {
+			t.Errorf("%s: unexpected error: %v", g.Description, err)
+		}
+	}
+}
+
+func TestValidateEtcdMigrationToEtcdNodes(t *testing.T) {
+	newGroup := func(name string, role kops.InstanceGroupRole) *kops.InstanceGroup {
+		return &kops.InstanceGroup{
+			ObjectMeta: v1.ObjectMeta{
+				Name: name,
+			},
+			Spec: kops.InstanceGroupSpec{
+				Role: role,
+			},
+		}
+	}
+	groups := []*kops.InstanceGroup{
+		newGroup("control-plane-a", kops.InstanceGroupRoleControlPlane),
+		newGroup("etcd-a", kops.InstanceGroupRoleEtcd),
+		newGroup("nodes", kops.InstanceGroupRoleNode),
+	}
+	newEtcdCluster := func(name string, member string, ig string) kops.EtcdClusterSpec {
+		return kops.EtcdClusterSpec{
+			Name: name,
+			Members: []kops.EtcdMemberSpec{
+				{
+					Name:          member,
+					InstanceGroup: fi.PtrTo(ig),
+				},
+			},
+		}
+	}
+	status := &kops.ClusterStatus{
+		EtcdClusters: []kops.EtcdClusterStatus{
+			{
+				Name: "main",
+			},
+		},
+	}
+
+	defer featureflag.ParseFlags("-EtcdNodes")
+	featureflag.ParseFlags("EtcdNodes")
+
+	// The members of an existing etcd cluster are replaced by new members on the etcd nodes
+	old := newEtcdCluster("main", "a", "control-plane-a")
+	migrated := newEtcdCluster("main", "etcd-a", "etcd-a")
+	if errs := validateEtcdClusterUpdate(nil, migrated, status, old); len(errs) != 0 {
+		t.Errorf("unexpected errors replacing the members: %v", errs)
+	}
+	cluster := &kops.Cluster{}
+	cluster.Spec.EtcdClusters = []kops.EtcdClusterSpec{migrated, newEtcdCluster("events", "a", "control-plane-a")}
+	if err := validateEtcdMemberPlacement(cluster, groups); err != nil {
+		t.Errorf("unexpected error for members on etcd nodes: %v", err)
+	}
+
+	// An existing member cannot move
+	if errs := validateEtcdClusterUpdate(nil, newEtcdCluster("main", "a", "etcd-a"), status, old); len(errs) != 1 {
+		t.Errorf("expected error moving a member, got %v", errs)
+	}
+
+	// Only the etcd clusters of the API server can run on etcd nodes
+	cluster.Spec.EtcdClusters = append(cluster.Spec.EtcdClusters, newEtcdCluster("cilium", "etcd-a", "etcd-a"))
+	if err := validateEtcdMemberPlacement(cluster, groups); err == nil {
+		t.Errorf("expected error for the cilium etcd cluster on etcd nodes")
+	}
+
+	// A new cluster starts with etcd on the control-plane nodes
+	cluster.Spec.EtcdClusters = []kops.EtcdClusterSpec{old}
+	if err := ValidateNewClusterEtcdPlacement(cluster, groups); err != nil {
+		t.Errorf("unexpected error for a new cluster with etcd on the control-plane nodes: %v", err)
+	}
+	cluster.Spec.EtcdClusters = []kops.EtcdClusterSpec{migrated}
+	if err := ValidateNewClusterEtcdPlacement(cluster, groups); err == nil {
+		t.Errorf("expected error for a new cluster with etcd on etcd nodes")
+	}
+}
+
 func TestValidBootDevice(t *testing.T) {
 	cluster := &kops.Cluster{
 		Spec: kops.ClusterSpec{
@@ -293,6 +293,10 @@ func DeepValidate(c *kops.Cluster, groups []*kops.InstanceGroup, strict bool, vf
 		return fmt.Errorf("must configure at least one Node InstanceGroup")
 	}
 
+	if err := validateEtcdMemberPlacement(c, groups); err != nil {
+		return err
+	}
+
 	for _, g := range groups {
 		errs := CrossValidateInstanceGroup(g, c, cloud, strict)
 
@@ -309,6 +313,78 @@ func DeepValidate(c *kops.Cluster, groups []*kops.InstanceGroup, strict bool, vf
 	return nil
 }
 
+// validateEtcdMemberPlacement checks that the members of each etcd cluster run either on every control-plane instance group,
+// or on instance groups with role Etcd.
+func validateEtcdMemberPlacement(c *kops.Cluster, groups []*kops.InstanceGroup) error {
+	groupsByName := make(map[string]*kops.InstanceGroup)
+	for _, g := range groups {
+		groupsByName[g.ObjectMeta.Name] = g
+	}
+
+	for _, etcd := range c.Spec.EtcdClusters {
+		onEtcdNodes := false
+		memberGroups := make(map[string]bool)
+		for _, m := range etcd.Members {
+			igName := fi.ValueOf(m.InstanceGroup)
+			g := groupsByName[igName]
+			if g == nil {
+				continue
+			}
+			switch g.Spec.Role {
+			case kops.InstanceGroupRoleControlPlane:
+			case kops.InstanceGroupRoleEtcd:
+				if !featureflag.EtcdNodes.Enabled() {
+					return fmt.Errorf("etcd nodes require the EtcdNodes feature flag to be enabled")
+				}
+				onEtcdNodes = true
+			default:
+				return fmt.Errorf("member %q of etcd cluster %q must run on an InstanceGroup with role ControlPlane or Etcd, not %q", m.Name, etcd.Name, g.Spec.Role)
+			}
+			memberGroups[igName] = true
+		}
+
+		for _, m := range etcd.Members {
+			if g := groupsByName[fi.ValueOf(m.InstanceGroup)]; onEtcdNodes && g != nil && !g.IsEtcdOnly() {
+				return fmt.Errorf("the members of etcd cluster %q must all run on InstanceGroups with role Etcd, or all on InstanceGroups with role ControlPlane", etcd.Name)
+			}
+		}
+
+		// Only the API server is pointed at the etcd clusters on etcd nodes
+		if onEtcdNodes && etcd.Name != "main" && etcd.Name != "events" {
+			return fmt.Errorf("etcd cluster %q cannot run on InstanceGroups with role Etcd; only the main and events etcd clusters can", etcd.Name)
+		}
+
+		// Without the feature flag, ValidateControlPlaneInstanceGroup performs this check
+		if !onEtcdNodes && featureflag.EtcdNodes.Enabled() {
+			for _, g := range groups {
+				if g.IsControlPlane() && !memberGroups[g.ObjectMeta.Name] {
+					return fmt.Errorf("InstanceGroup %q with role ControlPlane must have a member in etcd cluster %q", g.ObjectMeta.Name, etcd.Name)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// ValidateNewClusterEtcdPlacement rejects etcd clusters on InstanceGroups with role Etcd in a cluster that is being created.
+// A new control plane bootstraps its etcd clusters itself; they can be moved to etcd nodes once the cluster is running.
+func ValidateNewClusterEtcdPlacement(c *kops.Cluster, groups []*kops.InstanceGroup) error {
+	groupsByName := make(map[string]*kops.InstanceGroup)
+	for _, g := range groups {
+		groupsByName[g.ObjectMeta.Name] = g
+	}
+
+	for _, etcd := range c.Spec.EtcdClusters {
+		for _, m := range etcd.Members {
+			if g := groupsByName[fi.ValueOf(m.InstanceGroup)]; g != nil && g.IsEtcdOnly() {
+				return fmt.Errorf("etcd cluster %q cannot run on InstanceGroups with role Etcd in a new cluster; create the cluster with etcd on the control-plane nodes, and move etcd once it is running", etcd.Name)
+			}
+		}
+	}
+	return nil
+}
+
 func isExperimentalClusterDNS(k *kops.KubeletConfigSpec, dns *kops.KubeDNSConfig) bool {
 	return k != nil && k.ClusterDNS != dns.ServerIP && dns.NodeLocalDNS != nil && k.ClusterDNS != dns.NodeLocalDNS.LocalIP
 }
@@ -17,6 +17,8 @@ limitations under the License.
 package validation
 
 import (
+	"bytes"
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"net"
@@ -29,6 +31,7 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/aws/arn"
 	"github.com/blang/semver/v4"
+	jsonpatch "github.com/evanphx/json-patch/v5"
 	"golang.org/x/net/ipv4"
 	"golang.org/x/net/ipv6"
 	"k8s.io/apimachinery/pkg/api/validation"
@@ -39,8 +42,10 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/kops/pkg/util/subnet"
 	netutils "k8s.io/utils/net"
+	"sigs.k8s.io/yaml"
 
 	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/imageverification"
 	"k8s.io/kops/pkg/model/components"
 	"k8s.io/kops/pkg/model/iam"
 	"k8s.io/kops/upup/pkg/fi"
@@ -123,6 +128,11 @@ func validateClusterSpec(spec *kops.ClusterSpec, c *kops.Cluster, fieldPath *fie
 		allErrs = append(allErrs, validateHookSpec(&spec.Hooks[i], fieldPath.Child("hooks").Index(i))...)
 	}
 
+	if spec.AddonDriftDetection != nil {
+		allErrs = append(allErrs, validateAddonDriftDetection(spec.AddonDriftDetection, fieldPath.Child("addonDriftDetection"))...)
+	}
+	allErrs = append(allErrs, validateAddonOverrides(spec.AddonOverrides, fieldPath.Child("addonOverrides"))...)
+
 	if spec.FileAssets != nil {
 		for i, x := range spec.FileAssets {
 			allErrs = append(allErrs, validateFileAssetSpec(&x, fieldPath.Child("fileAssets").Index(i))...)
@@ -215,6 +225,12 @@ func validateClusterSpec(spec *kops.ClusterSpec, c *kops.Cluster, fieldPath *fie
 		if spec.Assets.ContainerProxy != nil && spec.Assets.ContainerRegistry != nil {
 			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("assets", "containerProxy"), "containerProxy cannot be used in conjunction with containerRegistry"))
 		}
+		if spec.Assets.ImageVerification != nil {
+			allErrs = append(allErrs, validateImageVerification(spec.Assets.ImageVerification, fieldPath.Child("assets", "imageVerification"))...)
+		}
+		if spec.Assets.FileRepositoryConfig != nil {
+			allErrs = append(allErrs, validateFileRepositoryConfig(spec.Assets, fieldPath.Child("assets"))...)
+		}
 	}
 
 	for i, sysctlParameter := range spec.SysctlParameters {
@@ -1423,6 +1439,181 @@ func validateEtcdClusterSpec(spec kops.EtcdClusterSpec, c *kops.Cluster, fieldPa
 	for i, m := range spec.Members {
 		allErrs = append(allErrs, validateEtcdMemberSpec(m, fieldPath.Child("etcdMembers").Index(i))...)
 	}
+	if spec.Backups != nil && spec.Backups.Verification != nil {
+		allErrs = append(allErrs, validateEtcdBackupVerification(spec.Backups.Verification, fieldPath.Child("backups", "verification"))...)
+	}
+
+	return allErrs
+}
+
+func validateEtcdBackupVerification(spec *kops.EtcdBackupVerificationSpec, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	// A drill restores a full copy of etcd, so we don't want to run them too often
+	if spec.Interval != nil && spec.Interval.Duration < time.Hour {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("interval"), spec.Interval.Duration.String(), "must be at least 1h"))
+	}
+	if spec.MinKeys != nil && *spec.MinKeys < 0 {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("minKeys"), *spec.MinKeys, "must not be negative"))
+	}
+	for i, prefix := range spec.RequiredPrefixes {
+		if !strings.HasPrefix(prefix, "/") {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("requiredPrefixes").Index(i), prefix, "must start with /"))
+		}
+	}
+
+	return allErrs
+}
+
+func validateAddonDriftDetection(spec *kops.AddonDriftDetectionSpec, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	policies := []kops.AddonDriftPolicy{kops.AddonDriftPolicyEnforce, kops.AddonDriftPolicyReport, kops.AddonDriftPolicyIgnore}
+
+	// Every check re-applies all the addons, so we don't want to run them too often
+	if spec.Interval != nil && spec.Interval.Duration < time.Minute {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("interval"), spec.Interval.Duration.String(), "must be at least 1m"))
+	}
+	if spec.Policy != "" {
+		allErrs = append(allErrs, IsValidValue(fieldPath.Child("policy"), &spec.Policy, policies)...)
+	}
+	for name, policy := range spec.Addons {
+		allErrs = append(allErrs, IsValidValue(fieldPath.Child("addons").Key(name), &policy, policies)...)
+	}
+
+	return allErrs
+}
+
+func validateFileRepositoryConfig(spec *kops.AssetsSpec, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if spec.FileRepository == nil {
+		allErrs = append(allErrs, field.Forbidden(fieldPath.Child("fileRepositoryConfig"), "fileRepositoryConfig requires fileRepository"))
+		return allErrs
+	}
+
+	// Buckets are accessed with the credentials of the cloud, secrets only apply to web servers
+	if spec.FileRepositoryConfig.Secret != "" {
+		if u, err := url.Parse(*spec.FileRepository); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("fileRepositoryConfig", "secret"), "a secret can only be used with an http or https fileRepository"))
+		}
+	}
+
+	return allErrs
+}
+
+func validateImageVerification(spec *kops.ImageVerificationSpec, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if spec.Action != "" {
+		allErrs = append(allErrs, IsValidValue(fieldPath.Child("action"), &spec.Action, []kops.ImageVerificationAction{kops.ImageVerificationActionEnforce, kops.ImageVerificationActionWarn})...)
+	}
+	if len(spec.Policies) == 0 {
+		allErrs = append(allErrs, field.Required(fieldPath.Child("policies"), "at least one policy is required"))
+	}
+
+	for i, policy := range spec.Policies {
+		fieldPath := fieldPath.Child("policies").Index(i)
+		if len(policy.PublicKeys) == 0 && policy.Keyless == nil {
+			allErrs = append(allErrs, field.Required(fieldPath, "publicKeys or keyless is required"))
+		}
+		for j, image := range policy.Images {
+			if image == "" || strings.Contains(strings.TrimSuffix(image, "*"), "*") {
+				allErrs = append(allErrs, field.Invalid(fieldPath.Child("images").Index(j), image, "must be an image name, optionally followed by *"))
+			}
+		}
+		for j, key := range policy.PublicKeys {
+			if _, err := imageverification.ParsePublicKey([]byte(key)); err != nil {
+				allErrs = append(allErrs, field.Invalid(fieldPath.Child("publicKeys").Index(j), key, err.Error()))
+			}
+		}
+		if policy.Keyless != nil {
+			if len(policy.Keyless.Roots) == 0 {
+				allErrs = append(allErrs, field.Required(fieldPath.Child("keyless", "roots"), ""))
+			}
+			for j, root := range policy.Keyless.Roots {
+				if !x509.NewCertPool().AppendCertsFromPEM([]byte(root)) {
+					allErrs = append(allErrs, field.Invalid(fieldPath.Child("keyless", "roots").Index(j), root, "must be a PEM encoded certificate"))
+				}
+			}
+			if len(policy.Keyless.Identities) == 0 {
+				allErrs = append(allErrs, field.Required(fieldPath.Child("keyless", "identities"), ""))
+			}
+			for j, identity := range policy.Keyless.Identities {
+				if identity.Issuer == "" {
+					allErrs = append(allErrs, field.Required(fieldPath.Child("keyless", "identities").Index(j).Child("issuer"), ""))
+				}
+				if identity.Subject == "" {
+					allErrs = append(allErrs, field.Required(fieldPath.Child("keyless", "identities").Index(j).Child("subject"), ""))
+				}
+			}
+			if len(policy.Keyless.TransparencyLogPublicKeys) == 0 {
+				allErrs = append(allErrs, field.Required(fieldPath.Child("keyless", "transparencyLogPublicKeys"), "keyless signatures are only trusted when recorded in a transparency log"))
+			}
+			for j, key := range policy.Keyless.TransparencyLogPublicKeys {
+				if _, err := imageverification.ParsePublicKey([]byte(key)); err != nil {
+					allErrs = append(allErrs, field.Invalid(fieldPath.Child("keyless", "transparencyLogPublicKeys").Index(j), key, err.Error()))
+				}
+			}
+		}
+	}
+
+	return allErrs
+}
+
+func validateAddonOverrides(overrides []kops.AddonOverrideSpec, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	names := sets.New[string]()
+	for i, override := range overrides {
+		fieldPath := fieldPath.Index(i)
+		if override.Name == "" {
+			allErrs = append(allErrs, field.Required(fieldPath.Child("name"), ""))
+		} else if names.Has(override.Name) {
+			allErrs = append(allErrs, field.Duplicate(fieldPath.Child("name"), override.Name))
+		}
+		names.Insert(override.Name)
+
+		for j, patch := range override.Patches {
+			allErrs = append(allErrs, validateAddonPatch(&patch, fieldPath.Child("patches").Index(j))...)
+		}
+	}
+
+	return allErrs
+}
+
+func validateAddonPatch(patch *kops.AddonPatchSpec, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if patch.Target.Kind == "" {
+		allErrs = append(allErrs, field.Required(fieldPath.Child("target", "kind"), ""))
+	}
+	if patch.Target.Name == "" {
+		allErrs = append(allErrs, field.Required(fieldPath.Child("target", "name"), ""))
+	}
+
+	switch {
+	case patch.Patch != "" && patch.JSONPatch != "":
+		allErrs = append(allErrs, field.Forbidden(fieldPath.Child("jsonPatch"), "patch and jsonPatch are mutually exclusive"))
+	case patch.Patch != "":
+		patchJSON, err := yaml.YAMLToJSON([]byte(patch.Patch))
+		if err == nil && !bytes.HasPrefix(bytes.TrimSpace(patchJSON), []byte("{")) {
+			err = fmt.Errorf("must be an object")
+		}
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("patch"), patch.Patch, err.Error()))
+		}
+	case patch.JSONPatch != "":
+		patchJSON, err := yaml.YAMLToJSON([]byte(patch.JSONPatch))
+		if err == nil {
+			_, err = jsonpatch.DecodePatch(patchJSON)
+		}
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("jsonPatch"), patch.JSONPatch, err.Error()))
+		}
+	default:
+		allErrs = append(allErrs, field.Required(fieldPath.Child("patch"), "patch or jsonPatch is required"))
+	}
 
 	return allErrs
 }
@@ -1748,6 +1939,61 @@ func validateContainerdConfig(cluster *kops.Cluster, config *kops.ContainerdConf
 		allErrs = append(allErrs, validateNvidiaConfig(cluster, config.NvidiaGPU, fldPath.Child("nvidia"), inClusterConfig)...)
 	}
 
+	if len(config.RegistryHosts) != 0 {
+		allErrs = append(allErrs, validateContainerdRegistryHosts(config, fldPath.Child("registryHosts"))...)
+	}
+
+	return allErrs
+}
+
+func validateContainerdRegistryHosts(containerd *kops.ContainerdConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	// The registry mirrors are written as hosts.toml files too, so they can't configure the same registries
+	registries := sets.New[string]()
+	for mirror := range containerd.RegistryMirrors {
+		if mirror == "*" {
+			mirror = "_default"
+		}
+		registries.Insert(mirror)
+	}
+
+	for i, registry := range containerd.RegistryHosts {
+		fldPath := fldPath.Index(i)
+		if registry.Registry == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("registry"), ""))
+		} else if strings.ContainsAny(registry.Registry, "/\\") || registry.Registry == "." || registry.Registry == ".." {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("registry"), registry.Registry, "must be the host name of a registry, optionally with a port"))
+		} else if registries.Has(registry.Registry) {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("registry"), registry.Registry))
+		}
+		registries.Insert(registry.Registry)
+
+		if registry.Server != nil {
+			allErrs = append(allErrs, validateContainerdRegistryHost(registry.Server, fldPath.Child("server"), false)...)
+		}
+		for j := range registry.Hosts {
+			allErrs = append(allErrs, validateContainerdRegistryHost(&registry.Hosts[j], fldPath.Child("hosts").Index(j), true)...)
+		}
+	}
+
+	return allErrs
+}
+
+func validateContainerdRegistryHost(host *kops.ContainerdRegistryHost, fldPath *field.Path, urlRequired bool) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if host.URL == "" {
+		if urlRequired {
+			allErrs = append(allErrs, field.Required(fldPath.Child("url"), ""))
+		}
+	} else if u, err := url.Parse(host.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("url"), host.URL, "must be an http or https URL"))
+	}
+	for i, capability := range host.Capabilities {
+		allErrs = append(allErrs, IsValidValue(fldPath.Child("capabilities").Index(i), &capability, []string{"pull", "resolve", "push"})...)
+	}
+
 	return allErrs
 }
 
@@ -1805,7 +2051,7 @@ func validateRollingUpdate(rollingUpdate *kops.RollingUpdate, fldpath *field.Pat
 				fmt.Sprintf("Unable to parse: %v", err)))
 		}
 		if onControlPlaneInstanceGroup && surge != 0 {
-			allErrs = append(allErrs, field.Forbidden(fldpath.Child("maxSurge"), "Cannot surge instance groups with role \"ControlPlane\""))
+			allErrs = append(allErrs, field.Forbidden(fldpath.Child("maxSurge"), "Cannot surge instance groups with role \"ControlPlane\" or \"Etcd\""))
 		} else if surge < 0 {
 			allErrs = append(allErrs, field.Invalid(fldpath.Child("maxSurge"), rollingUpdate.MaxSurge, "Cannot be negative"))
 		}
@@ -1871,6 +2117,41 @@ func validateExternalDNS(cluster *kops.Cluster, spec *kops.ExternalDNSConfig, fl
 		}
 	}
 
+	if fi.ValueOf(spec.WatchGateway) && spec.Provider != "" && spec.Provider != kops.ExternalDNSProviderDNSController {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("watchGateway"), "watchGateway is only supported with dns-controller"))
+	}
+
+	if spec.TXTOwnerID != "" {
+		if spec.Provider != "" && spec.Provider != kops.ExternalDNSProviderDNSController {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("txtOwnerID"), "txtOwnerID is only supported with dns-controller"))
+		}
+		if cluster.UsesLegacyGossip() || cluster.UsesNoneDNS() {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("txtOwnerID"), "txtOwnerID requires public or private DNS topology"))
+		}
+		for _, msg := range utilvalidation.IsDNS1123Subdomain(spec.TXTOwnerID) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("txtOwnerID"), spec.TXTOwnerID, msg))
+		}
+	} else if spec.TXTAdoptUnowned {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("txtAdoptUnowned"), "txtAdoptUnowned requires txtOwnerID"))
+	}
+
+	if spec.DNSProvider != "" {
+		allErrs = append(allErrs, IsValidValue(fldPath.Child("dnsProvider"), &spec.DNSProvider, []string{"cloudflare", "rfc2136"})...)
+		if cluster.UsesLegacyGossip() || cluster.UsesNoneDNS() {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("dnsProvider"), "dnsProvider requires public or private DNS topology"))
+		}
+		if spec.Provider != "" && spec.Provider != kops.ExternalDNSProviderDNSController {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("dnsProvider"), "dnsProvider is only supported with dns-controller"))
+		}
+		// The records of load balancers are aliases in the DNS service of the cloud provider
+		if cluster.Spec.API.LoadBalancer != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("dnsProvider"), "dnsProvider is not supported with an API load balancer"))
+		}
+		if cluster.Spec.Networking.Topology != nil && cluster.Spec.Networking.Topology.Bastion != nil && cluster.Spec.Networking.Topology.Bastion.PublicName != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("dnsProvider"), "dnsProvider is not supported with a bastion public name"))
+		}
+	}
+
 	return allErrs
 }
 
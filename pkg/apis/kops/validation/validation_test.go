@@ -17,6 +17,12 @@ limitations under the License.
 package validation
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"math/big"
 	"net"
 	"testing"
 	"time"
@@ -1851,3 +1857,431 @@ func Test_Validate_NriConfig(t *testing.T) {
 		testErrors(t, g.Input.Containerd, errs, g.ExpectedErrors)
 	}
 }
+
+func Test_Validate_EtcdBackupVerification(t *testing.T) {
+	grid := []struct {
+		Input          kops.EtcdBackupVerificationSpec
+		ExpectedErrors []string
+	}{
+		{
+			Input: kops.EtcdBackupVerificationSpec{},
+		},
+		{
+			Input: kops.EtcdBackupVerificationSpec{
+				Interval:         &metav1.Duration{Duration: 24 * time.Hour},
+				MinKeys:          fi.PtrTo(int64(100)),
+				RequiredPrefixes: []string{"/registry/namespaces/", "/registry/secrets/"},
+			},
+		},
+		{
+			Input: kops.EtcdBackupVerificationSpec{
+				Interval: &metav1.Duration{Duration: 15 * time.Minute},
+			},
+			ExpectedErrors: []string{"Invalid value::backups.verification.interval"},
+		},
+		{
+			Input: kops.EtcdBackupVerificationSpec{
+				MinKeys: fi.PtrTo(int64(-1)),
+			},
+			ExpectedErrors: []string{"Invalid value::backups.verification.minKeys"},
+		},
+		{
+			Input: kops.EtcdBackupVerificationSpec{
+				RequiredPrefixes: []string{"/registry/", "registry/secrets/"},
+			},
+			ExpectedErrors: []string{"Invalid value::backups.verification.requiredPrefixes[1]"},
+		},
+	}
+	for _, g := range grid {
+		errs := validateEtcdBackupVerification(&g.Input, field.NewPath("backups", "verification"))
+		testErrors(t, g.Input, errs, g.ExpectedErrors)
+	}
+}
+
+func Test_Validate_ContainerdRegistryHosts(t *testing.T) {
+	grid := []struct {
+		Input          kops.ContainerdConfig
+		ExpectedErrors []string
+	}{
+		{
+			Input: kops.ContainerdConfig{
+				RegistryMirrors: map[string][]string{
+					"docker.io": {"https://mirror.example.com"},
+				},
+				RegistryHosts: []kops.ContainerdRegistryHostsConfig{
+					{
+						Registry: "registry.example.com:5000",
+						Server: &kops.ContainerdRegistryHost{
+							Secret: "registry-example",
+						},
+						Hosts: []kops.ContainerdRegistryHost{
+							{
+								URL:          "https://cache.example.com/v2/registry",
+								Capabilities: []string{"pull", "resolve"},
+								OverridePath: true,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			Input: kops.ContainerdConfig{
+				RegistryMirrors: map[string][]string{
+					"*": {"https://mirror.example.com"},
+				},
+				RegistryHosts: []kops.ContainerdRegistryHostsConfig{
+					{Registry: "_default"},
+					{Registry: "registry.example.com"},
+					{Registry: "registry.example.com"},
+					{Registry: "registry.example.com/path"},
+					{},
+				},
+			},
+			ExpectedErrors: []string{
+				"Duplicate value::registryHosts[0].registry",
+				"Duplicate value::registryHosts[2].registry",
+				"Invalid value::registryHosts[3].registry",
+				"Required value::registryHosts[4].registry",
+			},
+		},
+		{
+			Input: kops.ContainerdConfig{
+				RegistryHosts: []kops.ContainerdRegistryHostsConfig{
+					{
+						Registry: "registry.example.com",
+						Server: &kops.ContainerdRegistryHost{
+							URL: "registry.example.com",
+						},
+						Hosts: []kops.ContainerdRegistryHost{
+							{},
+							{
+								URL:          "https://cache.example.com",
+								Capabilities: []string{"pull", "delete"},
+							},
+						},
+					},
+				},
+			},
+			ExpectedErrors: []string{
+				"Invalid value::registryHosts[0].server.url",
+				"Required value::registryHosts[0].hosts[0].url",
+				"Unsupported value::registryHosts[0].hosts[1].capabilities[1]",
+			},
+		},
+	}
+	for _, g := range grid {
+		errs := validateContainerdRegistryHosts(&g.Input, field.NewPath("registryHosts"))
+		testErrors(t, g.Input, errs, g.ExpectedErrors)
+	}
+}
+
+func Test_Validate_AddonDriftDetection(t *testing.T) {
+	grid := []struct {
+		Input          kops.AddonDriftDetectionSpec
+		ExpectedErrors []string
+	}{
+		{
+			Input: kops.AddonDriftDetectionSpec{},
+		},
+		{
+			Input: kops.AddonDriftDetectionSpec{
+				Interval: &metav1.Duration{Duration: 30 * time.Minute},
+				Policy:   kops.AddonDriftPolicyEnforce,
+				Addons: map[string]kops.AddonDriftPolicy{
+					"coredns.addons.k8s.io": kops.AddonDriftPolicyIgnore,
+				},
+			},
+		},
+		{
+			Input: kops.AddonDriftDetectionSpec{
+				Interval: &metav1.Duration{Duration: 30 * time.Second},
+			},
+			ExpectedErrors: []string{"Invalid value::addonDriftDetection.interval"},
+		},
+		{
+			Input: kops.AddonDriftDetectionSpec{
+				Policy: "heal",
+			},
+			ExpectedErrors: []string{"Unsupported value::addonDriftDetection.policy"},
+		},
+		{
+			Input: kops.AddonDriftDetectionSpec{
+				Addons: map[string]kops.AddonDriftPolicy{
+					"coredns.addons.k8s.io": "",
+				},
+			},
+			ExpectedErrors: []string{"Unsupported value::addonDriftDetection.addons[coredns.addons.k8s.io]"},
+		},
+	}
+	for _, g := range grid {
+		errs := validateAddonDriftDetection(&g.Input, field.NewPath("addonDriftDetection"))
+		testErrors(t, g.Input, errs, g.ExpectedErrors)
+	}
+}
+
+func Test_Validate_AddonOverrides(t *testing.T) {
+	grid := []struct {
+		Input          []kops.AddonOverrideSpec
+		ExpectedErrors []string
+	}{
+		{
+			Input: []kops.AddonOverrideSpec{
+				{
+					Name: "coredns.addons.k8s.io",
+					Patches: []kops.AddonPatchSpec{
+						{
+							Target: kops.AddonPatchTarget{Group: "apps", Kind: "Deployment", Name: "coredns"},
+							Patch:  "spec:\n  replicas: 3\n",
+						},
+						{
+							Target:    kops.AddonPatchTarget{Group: "apps", Kind: "Deployment", Name: "coredns"},
+							JSONPatch: "- op: add\n  path: /spec/template/spec/priorityClassName\n  value: system-node-critical\n",
+						},
+					},
+				},
+			},
+		},
+		{
+			Input: []kops.AddonOverrideSpec{
+				{Name: "coredns.addons.k8s.io"},
+				{Name: "coredns.addons.k8s.io"},
+				{},
+			},
+			ExpectedErrors: []string{
+				"Duplicate value::addonOverrides[1].name",
+				"Required value::addonOverrides[2].name",
+			},
+		},
+		{
+			Input: []kops.AddonOverrideSpec{
+				{
+					Name: "coredns.addons.k8s.io",
+					Patches: []kops.AddonPatchSpec{
+						{},
+						{
+							Target:    kops.AddonPatchTarget{Kind: "ConfigMap", Name: "coredns"},
+							Patch:     "data: {}",
+							JSONPatch: "[]",
+						},
+						{
+							Target: kops.AddonPatchTarget{Kind: "ConfigMap", Name: "coredns"},
+							Patch:  "- data",
+						},
+						{
+							Target:    kops.AddonPatchTarget{Kind: "ConfigMap", Name: "coredns"},
+							JSONPatch: "data: {}",
+						},
+					},
+				},
+			},
+			ExpectedErrors: []string{
+				"Required value::addonOverrides[0].patches[0].target.kind",
+				"Required value::addonOverrides[0].patches[0].target.name",
+				"Required value::addonOverrides[0].patches[0].patch",
+				"Forbidden::addonOverrides[0].patches[1].jsonPatch",
+				"Invalid value::addonOverrides[0].patches[2].patch",
+				"Invalid value::addonOverrides[0].patches[3].jsonPatch",
+			},
+		},
+	}
+	for _, g := range grid {
+		errs := validateAddonOverrides(g.Input, field.NewPath("addonOverrides"))
+		testErrors(t, g.Input, errs, g.ExpectedErrors)
+	}
+}
+
+func Test_Validate_ImageVerification(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicKey, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicKeyPEM := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKey}))
+	template := &x509.Certificate{SerialNumber: big.NewInt(1), IsCA: true, BasicConstraintsValid: true}
+	root, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: root}))
+
+	grid := []struct {
+		Input          kops.ImageVerificationSpec
+		ExpectedErrors []string
+	}{
+		{
+			Input: kops.ImageVerificationSpec{
+				Action: kops.ImageVerificationActionWarn,
+				Policies: []kops.ImageVerificationPolicy{
+					{
+						Images:     []string{"registry.k8s.io/kube-apiserver", "registry.k8s.io/*"},
+						PublicKeys: []string{publicKeyPEM},
+					},
+					{
+						Keyless: &kops.KeylessImageVerification{
+							Roots:                     []string{rootPEM},
+							Identities:                []kops.KeylessIdentity{{Issuer: "https://accounts.google.com", Subject: "krel-trust@k8s-releng-prod.iam.gserviceaccount.com"}},
+							TransparencyLogPublicKeys: []string{publicKeyPEM},
+						},
+					},
+				},
+			},
+		},
+		{
+			Input: kops.ImageVerificationSpec{
+				Action: "audit",
+			},
+			ExpectedErrors: []string{
+				"Unsupported value::imageVerification.action",
+				"Required value::imageVerification.policies",
+			},
+		},
+		{
+			Input: kops.ImageVerificationSpec{
+				Policies: []kops.ImageVerificationPolicy{
+					{
+						Images: []string{"registry.k8s.io/*/kube-apiserver"},
+					},
+					{
+						PublicKeys: []string{"not a key"},
+					},
+					{
+						Keyless: &kops.KeylessImageVerification{
+							Roots:                     []string{"not a certificate"},
+							Identities:                []kops.KeylessIdentity{{}},
+							TransparencyLogPublicKeys: []string{"not a key"},
+						},
+					},
+					{
+						Keyless: &kops.KeylessImageVerification{},
+					},
+				},
+			},
+			ExpectedErrors: []string{
+				"Required value::imageVerification.policies[0]",
+				"Invalid value::imageVerification.policies[0].images[0]",
+				"Invalid value::imageVerification.policies[1].publicKeys[0]",
+				"Invalid value::imageVerification.policies[2].keyless.roots[0]",
+				"Required value::imageVerification.policies[2].keyless.identities[0].issuer",
+				"Required value::imageVerification.policies[2].keyless.identities[0].subject",
+				"Invalid value::imageVerification.policies[2].keyless.transparencyLogPublicKeys[0]",
+				"Required value::imageVerification.policies[3].keyless.roots",
+				"Required value::imageVerification.policies[3].keyless.identities",
+				"Required value::imageVerification.policies[3].keyless.transparencyLogPublicKeys",
+			},
+		},
+	}
+	for _, g := range grid {
+		errs := validateImageVerification(&g.Input, field.NewPath("imageVerification"))
+		testErrors(t, g.Input, errs, g.ExpectedErrors)
+	}
+}
+
+func Test_Validate_FileRepositoryConfig(t *testing.T) {
+	grid := []struct {
+		Input          kops.AssetsSpec
+		ExpectedErrors []string
+	}{
+		{
+			Input: kops.AssetsSpec{
+				FileRepository: fi.PtrTo("https://artifactory.example.com/kops"),
+				FileRepositoryConfig: &kops.FileRepositoryConfig{
+					Secret:              "artifactory",
+					FallbackToCanonical: true,
+				},
+			},
+		},
+		{
+			Input: kops.AssetsSpec{
+				FileRepository: fi.PtrTo("s3://files.example.com"),
+				FileRepositoryConfig: &kops.FileRepositoryConfig{
+					FallbackToCanonical: true,
+				},
+			},
+		},
+		{
+			Input: kops.AssetsSpec{
+				FileRepository: fi.PtrTo("s3://files.example.com"),
+				FileRepositoryConfig: &kops.FileRepositoryConfig{
+					Secret: "artifactory",
+				},
+			},
+			ExpectedErrors: []string{"Forbidden::assets.fileRepositoryConfig.secret"},
+		},
+		{
+			Input: kops.AssetsSpec{
+				FileRepositoryConfig: &kops.FileRepositoryConfig{
+					Secret: "artifactory",
+				},
+			},
+			ExpectedErrors: []string{"Forbidden::assets.fileRepositoryConfig"},
+		},
+	}
+	for _, g := range grid {
+		errs := validateFileRepositoryConfig(&g.Input, field.NewPath("assets"))
+		testErrors(t, g.Input, errs, g.ExpectedErrors)
+	}
+}
+
+func Test_Validate_ExternalDNS(t *testing.T) {
+	grid := []struct {
+		ClusterName    string
+		Input          kops.ExternalDNSConfig
+		ExpectedErrors []string
+	}{
+		{
+			ClusterName: "minimal.example.com",
+			Input: kops.ExternalDNSConfig{
+				TXTOwnerID:      "minimal.example.com",
+				TXTAdoptUnowned: true,
+			},
+		},
+		{
+			ClusterName: "minimal.example.com",
+			Input: kops.ExternalDNSConfig{
+				Provider:   kops.ExternalDNSProviderExternalDNS,
+				TXTOwnerID: "minimal",
+			},
+			ExpectedErrors: []string{"Forbidden::externalDNS.txtOwnerID"},
+		},
+		{
+			ClusterName: "minimal.k8s.local",
+			Input: kops.ExternalDNSConfig{
+				TXTOwnerID: "minimal",
+			},
+			ExpectedErrors: []string{"Forbidden::externalDNS.txtOwnerID"},
+		},
+		{
+			ClusterName: "minimal.example.com",
+			Input: kops.ExternalDNSConfig{
+				TXTOwnerID: "owner,id",
+			},
+			ExpectedErrors: []string{"Invalid value::externalDNS.txtOwnerID"},
+		},
+		{
+			ClusterName: "minimal.example.com",
+			Input: kops.ExternalDNSConfig{
+				TXTAdoptUnowned: true,
+			},
+			ExpectedErrors: []string{"Forbidden::externalDNS.txtAdoptUnowned"},
+		},
+		{
+			ClusterName: "minimal.example.com",
+			Input: kops.ExternalDNSConfig{
+				Provider:     kops.ExternalDNSProviderExternalDNS,
+				WatchGateway: fi.PtrTo(true),
+			},
+			ExpectedErrors: []string{"Forbidden::externalDNS.watchGateway"},
+		},
+	}
+	for _, g := range grid {
+		cluster := &kops.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: g.ClusterName},
+		}
+		errs := validateExternalDNS(cluster, &g.Input, field.NewPath("externalDNS"))
+		testErrors(t, g.Input, errs, g.ExpectedErrors)
+	}
+}
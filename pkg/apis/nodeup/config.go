@@ -93,6 +93,15 @@ type Config struct {
 	Hooks [][]kops.HookSpec
 	// ContainerdConfig holds the configuration for containerd.
 	ContainerdConfig *kops.ContainerdConfig `json:"containerdConfig,omitempty"`
+	// ImageVerification configures the verification of the images of the static pods.
+	ImageVerification *kops.ImageVerificationSpec `json:"imageVerification,omitempty"`
+	// ImageVerificationMirrors maps the repositories of the images copied to the container registry
+	// to the repositories they are copied from, for which their signatures are made.
+	ImageVerificationMirrors map[string]string `json:"imageVerificationMirrors,omitempty"`
+	// FileRepository is the location of the file repository, when files are downloaded from it with a secret.
+	FileRepository string `json:"fileRepository,omitempty"`
+	// FileRepositorySecret is the name of the secret holding the CA, client certificate and credentials of the FileRepository.
+	FileRepositorySecret string `json:"fileRepositorySecret,omitempty"`
 
 	// APIServerConfig is additional configuration for nodes running an APIServer.
 	APIServerConfig *APIServerConfig `json:",omitempty"`
@@ -216,6 +225,9 @@ type APIServerConfig struct {
 	EncryptionConfigSecretHash string `json:",omitempty"`
 	// ServiceAccountPublicKeys are the service-account public keys to trust.
 	ServiceAccountPublicKeys string
+	// RemoteEtcdHosts maps the client hostnames of the etcd clusters that run on etcd nodes
+	// to the instance groups of their members.
+	RemoteEtcdHosts map[string][]string `json:",omitempty"`
 }
 
 // ControlPlaneConfig is additional configuration for control-plane nodes.
@@ -272,6 +284,14 @@ func NewConfig(cluster *kops.Cluster, instanceGroup *kops.InstanceGroup) (*Confi
 		config.NvidiaGPU = buildNvidiaConfig(cluster, instanceGroup)
 	}
 
+	if cluster.Spec.Assets != nil {
+		config.ImageVerification = cluster.Spec.Assets.ImageVerification
+		if cluster.Spec.Assets.FileRepositoryConfig != nil && cluster.Spec.Assets.FileRepositoryConfig.Secret != "" {
+			config.FileRepository = aws.ToString(cluster.Spec.Assets.FileRepository)
+			config.FileRepositorySecret = cluster.Spec.Assets.FileRepositoryConfig.Secret
+		}
+	}
+
 	config.KubeProxy = buildKubeProxy(cluster, instanceGroup)
 
 	if cluster.Spec.NTP != nil && cluster.Spec.NTP.Managed != nil && !*cluster.Spec.NTP.Managed {
@@ -0,0 +1,56 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeup
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/yaml"
+)
+
+// FileRepositorySecret is the content of a secret holding the CA, client certificate and credentials of a file repository.
+type FileRepositorySecret struct {
+	// CA is the PEM encoded bundle of CA certificates that are trusted to serve the repository.
+	CA string `json:"ca,omitempty"`
+	// Certificate is the PEM encoded client certificate to present to the repository.
+	Certificate string `json:"certificate,omitempty"`
+	// Key is the PEM encoded private key of the client certificate.
+	Key string `json:"key,omitempty"`
+	// Username and Password are the credentials sent to the repository with basic authentication.
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	// Token is sent to the repository as a bearer token.
+	Token string `json:"token,omitempty"`
+}
+
+// ParseFileRepositorySecret parses the content of a file repository secret, in YAML or JSON.
+func ParseFileRepositorySecret(data []byte) (*FileRepositorySecret, error) {
+	secret := &FileRepositorySecret{}
+	if err := yaml.UnmarshalStrict(data, secret); err != nil {
+		return nil, fmt.Errorf("parsing file repository secret: %w", err)
+	}
+	if (secret.Certificate == "") != (secret.Key == "") {
+		return nil, fmt.Errorf("parsing file repository secret: certificate and key must be set together")
+	}
+	if secret.Password != "" && secret.Username == "" {
+		return nil, fmt.Errorf("parsing file repository secret: password requires a username")
+	}
+	if secret.Token != "" && secret.Username != "" {
+		return nil, fmt.Errorf("parsing file repository secret: token and username can't be set together")
+	}
+	return secret, nil
+}
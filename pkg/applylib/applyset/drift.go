@@ -0,0 +1,117 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package applyset
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// DriftedObject is an object whose state in the cluster differs from the desired state.
+type DriftedObject struct {
+	GVK schema.GroupVersionKind
+	NN  types.NamespacedName
+
+	// Live is the object in the cluster, or nil if it does not exist.
+	Live *unstructured.Unstructured
+}
+
+// Missing is true if the object does not exist in the cluster.
+func (d *DriftedObject) Missing() bool {
+	return d.Live == nil
+}
+
+func (d *DriftedObject) String() string {
+	s := d.GVK.Kind
+	if d.GVK.Group != "" {
+		s += "." + d.GVK.Group
+	}
+	return s + ":" + d.NN.String()
+}
+
+// FindDrift makes a server-side dry-run apply of all objects, and returns the objects that applying would change.
+// It does not change the cluster, so it doesn't migrate the client-side-apply field-managers like ApplyOnce.
+func (a *ApplySet) FindDrift(ctx context.Context) ([]*DriftedObject, error) {
+	// snapshot the state
+	a.mutex.Lock()
+	trackers := a.trackers
+	a.mutex.Unlock()
+
+	client := &UnstructuredClient{
+		client:     a.client,
+		restMapper: a.restMapper,
+	}
+
+	patchOptions := a.patchOptions
+	patchOptions.DryRun = []string{metav1.DryRunAll}
+
+	var drifted []*DriftedObject
+	for i := range trackers.items {
+		tracker := &trackers.items[i]
+		expectedObject := tracker.desired
+
+		gvk := expectedObject.GroupVersionKind()
+		nn := types.NamespacedName{Namespace: expectedObject.GetNamespace(), Name: expectedObject.GetName()}
+
+		currentObj, err := client.Get(ctx, gvk, nn)
+		if err != nil {
+			if !apierrors.IsNotFound(err) {
+				return nil, fmt.Errorf("error getting %v %v: %w", gvk, nn, err)
+			}
+			drifted = append(drifted, &DriftedObject{GVK: gvk, NN: nn})
+			continue
+		}
+
+		j, err := json.Marshal(expectedObject)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal object to JSON: %w", err)
+		}
+
+		applied, err := client.Patch(ctx, gvk, nn, types.ApplyPatchType, j, patchOptions)
+		if err != nil {
+			return nil, fmt.Errorf("error from dry-run apply of %v %v: %w", gvk, nn, err)
+		}
+
+		if hasDrifted(currentObj, applied) {
+			drifted = append(drifted, &DriftedObject{GVK: gvk, NN: nn, Live: currentObj})
+		}
+	}
+	return drifted, nil
+}
+
+// hasDrifted compares the object in the cluster with the result of a dry-run apply.
+// Applying updates the managed fields even when nothing else changes, so we ignore them,
+// along with the fields the server bumps on any change.
+func hasDrifted(live, applied *unstructured.Unstructured) bool {
+	return !equality.Semantic.DeepEqual(comparableContent(live), comparableContent(applied))
+}
+
+func comparableContent(u *unstructured.Unstructured) map[string]interface{} {
+	u = u.DeepCopy()
+	unstructured.RemoveNestedField(u.Object, "metadata", "managedFields")
+	unstructured.RemoveNestedField(u.Object, "metadata", "resourceVersion")
+	unstructured.RemoveNestedField(u.Object, "metadata", "generation")
+	return u.Object
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package applyset
+
+import (
+	"testing"
+)
+
+func TestHasDrifted(t *testing.T) {
+	live := `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: example
+  namespace: kube-system
+  generation: 3
+  resourceVersion: "100"
+  managedFields:
+  - manager: kops
+    operation: Apply
+    time: "2026-01-01T00:00:00Z"
+spec:
+  replicas: 3
+`
+	grid := []struct {
+		Name     string
+		Applied  string
+		Expected bool
+	}{
+		{
+			Name: "unchanged",
+			Applied: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: example
+  namespace: kube-system
+  generation: 3
+  resourceVersion: "100"
+  managedFields:
+  - manager: kops
+    operation: Apply
+    time: "2026-02-01T00:00:00Z"
+spec:
+  replicas: 3
+`,
+			Expected: false,
+		},
+		{
+			Name: "changed",
+			Applied: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: example
+  namespace: kube-system
+  generation: 4
+  resourceVersion: "101"
+spec:
+  replicas: 2
+`,
+			Expected: true,
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.Name, func(t *testing.T) {
+			actual := hasDrifted(parseObject(t, live), parseObject(t, g.Applied))
+			if actual != g.Expected {
+				t.Errorf("unexpected result; got %v, want %v", actual, g.Expected)
+			}
+		})
+	}
+}
@@ -20,7 +20,9 @@ import (
 	"encoding/json"
 	"strings"
 
+	appsv1 "k8s.io/api/apps/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/klog/v2"
 )
@@ -120,6 +122,150 @@ func isHealthy(u *unstructured.Unstructured) bool {
 	return ready
 }
 
+// IsReady reports whether the object is ready: Deployments, DaemonSets and StatefulSets must have
+// completed their rollout, and other objects must be healthy.
+//
+// Unlike the health reported by ApplyOnce, this waits for the controllers to act on the object,
+// so it is only meaningful some time after the object has been applied.
+func IsReady(u *unstructured.Unstructured) bool {
+	if !isHealthy(u) {
+		return false
+	}
+
+	switch u.GroupVersionKind().GroupKind() {
+	case schema.GroupKind{Group: "apps", Kind: "Deployment"}:
+		deployment := &appsv1.Deployment{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, deployment); err != nil {
+			klog.Warningf("unable to parse %s: %v", humanName(u), err)
+			return false
+		}
+		return isDeploymentRolledOut(u, deployment)
+
+	case schema.GroupKind{Group: "apps", Kind: "DaemonSet"}:
+		daemonSet := &appsv1.DaemonSet{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, daemonSet); err != nil {
+			klog.Warningf("unable to parse %s: %v", humanName(u), err)
+			return false
+		}
+		return isDaemonSetRolledOut(u, daemonSet)
+
+	case schema.GroupKind{Group: "apps", Kind: "StatefulSet"}:
+		statefulSet := &appsv1.StatefulSet{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, statefulSet); err != nil {
+			klog.Warningf("unable to parse %s: %v", humanName(u), err)
+			return false
+		}
+		return isStatefulSetRolledOut(u, statefulSet)
+	}
+
+	return true
+}
+
+// isDeploymentRolledOut follows the logic of kubectl rollout status
+func isDeploymentRolledOut(u *unstructured.Unstructured, deployment *appsv1.Deployment) bool {
+	if deployment.Status.ObservedGeneration < deployment.Generation {
+		klog.Infof("waiting for the rollout of %s to be observed", humanName(u))
+		return false
+	}
+	for _, condition := range deployment.Status.Conditions {
+		if condition.Type == appsv1.DeploymentProgressing && condition.Reason == "ProgressDeadlineExceeded" {
+			klog.Infof("rollout of %s exceeded its progress deadline", humanName(u))
+			return false
+		}
+	}
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	if deployment.Status.UpdatedReplicas < replicas {
+		klog.Infof("waiting for the rollout of %s: %d of %d replicas updated", humanName(u), deployment.Status.UpdatedReplicas, replicas)
+		return false
+	}
+	if deployment.Status.Replicas > deployment.Status.UpdatedReplicas {
+		klog.Infof("waiting for the rollout of %s: %d old replicas pending termination", humanName(u), deployment.Status.Replicas-deployment.Status.UpdatedReplicas)
+		return false
+	}
+	if deployment.Status.AvailableReplicas < deployment.Status.UpdatedReplicas {
+		klog.Infof("waiting for the rollout of %s: %d of %d updated replicas available", humanName(u), deployment.Status.AvailableReplicas, deployment.Status.UpdatedReplicas)
+		return false
+	}
+	return true
+}
+
+// isDaemonSetRolledOut follows the logic of kubectl rollout status
+func isDaemonSetRolledOut(u *unstructured.Unstructured, daemonSet *appsv1.DaemonSet) bool {
+	if daemonSet.Spec.UpdateStrategy.Type == appsv1.OnDeleteDaemonSetStrategyType {
+		// The pods are only updated when they are deleted, so there is no rollout to wait for
+		return true
+	}
+	if daemonSet.Status.ObservedGeneration < daemonSet.Generation {
+		klog.Infof("waiting for the rollout of %s to be observed", humanName(u))
+		return false
+	}
+	if daemonSet.Status.UpdatedNumberScheduled < daemonSet.Status.DesiredNumberScheduled {
+		klog.Infof("waiting for the rollout of %s: %d of %d pods updated", humanName(u), daemonSet.Status.UpdatedNumberScheduled, daemonSet.Status.DesiredNumberScheduled)
+		return false
+	}
+	if daemonSet.Status.NumberAvailable < daemonSet.Status.DesiredNumberScheduled {
+		klog.Infof("waiting for the rollout of %s: %d of %d updated pods available", humanName(u), daemonSet.Status.NumberAvailable, daemonSet.Status.DesiredNumberScheduled)
+		return false
+	}
+	return true
+}
+
+// isStatefulSetRolledOut follows the logic of kubectl rollout status
+func isStatefulSetRolledOut(u *unstructured.Unstructured, statefulSet *appsv1.StatefulSet) bool {
+	if statefulSet.Spec.UpdateStrategy.Type == appsv1.OnDeleteStatefulSetStrategyType {
+		// The pods are only updated when they are deleted, so there is no rollout to wait for
+		return true
+	}
+	if statefulSet.Status.ObservedGeneration < statefulSet.Generation {
+		klog.Infof("waiting for the rollout of %s to be observed", humanName(u))
+		return false
+	}
+	replicas := int32(1)
+	if statefulSet.Spec.Replicas != nil {
+		replicas = *statefulSet.Spec.Replicas
+	}
+	if statefulSet.Status.ReadyReplicas < replicas {
+		klog.Infof("waiting for the rollout of %s: %d of %d replicas ready", humanName(u), statefulSet.Status.ReadyReplicas, replicas)
+		return false
+	}
+	rollingUpdate := statefulSet.Spec.UpdateStrategy.RollingUpdate
+	if rollingUpdate != nil && rollingUpdate.Partition != nil && *rollingUpdate.Partition > 0 {
+		// Only the replicas above the partition are updated
+		if statefulSet.Status.UpdatedReplicas < replicas-*rollingUpdate.Partition {
+			klog.Infof("waiting for the partitioned rollout of %s: %d of %d replicas updated", humanName(u), statefulSet.Status.UpdatedReplicas, replicas-*rollingUpdate.Partition)
+			return false
+		}
+		return true
+	}
+	if statefulSet.Status.UpdateRevision != statefulSet.Status.CurrentRevision {
+		klog.Infof("waiting for the rollout of %s: %d of %d replicas updated", humanName(u), statefulSet.Status.UpdatedReplicas, replicas)
+		return false
+	}
+	return true
+}
+
+// HasTrueCondition reports whether the object has a status condition of the given type, with status True.
+func HasTrueCondition(u *unstructured.Unstructured, conditionType string) bool {
+	conditions, _, err := unstructured.NestedSlice(u.Object, "status", "conditions")
+	if err != nil {
+		klog.Warningf("unable to read status.conditions of %s: %v", humanName(u), err)
+		return false
+	}
+	for _, condition := range conditions {
+		conditionMap, ok := condition.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if conditionMap["type"] == conditionType {
+			return conditionMap["status"] == "True"
+		}
+	}
+	return false
+}
+
 // humanName returns an identifier for the object suitable for printing in log messages
 func humanName(u *unstructured.Unstructured) string {
 	gvk := u.GroupVersionKind()
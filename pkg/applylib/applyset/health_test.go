@@ -0,0 +1,248 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package applyset
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+func parseObject(t *testing.T, y string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	if err := yaml.Unmarshal([]byte(y), &u.Object); err != nil {
+		t.Fatalf("error parsing object: %v", err)
+	}
+	return u
+}
+
+func TestIsReady(t *testing.T) {
+	grid := []struct {
+		Name     string
+		Object   string
+		Expected bool
+	}{
+		{
+			Name: "deployment rolled out",
+			Object: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: example
+  namespace: kube-system
+  generation: 2
+spec:
+  replicas: 2
+status:
+  observedGeneration: 2
+  replicas: 2
+  updatedReplicas: 2
+  availableReplicas: 2
+`,
+			Expected: true,
+		},
+		{
+			Name: "deployment not observed",
+			Object: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: example
+  namespace: kube-system
+  generation: 3
+spec:
+  replicas: 2
+status:
+  observedGeneration: 2
+  replicas: 2
+  updatedReplicas: 2
+  availableReplicas: 2
+`,
+			Expected: false,
+		},
+		{
+			Name: "deployment with old replicas",
+			Object: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: example
+  namespace: kube-system
+  generation: 2
+spec:
+  replicas: 2
+status:
+  observedGeneration: 2
+  replicas: 3
+  updatedReplicas: 2
+  availableReplicas: 2
+`,
+			Expected: false,
+		},
+		{
+			Name: "deployment not available",
+			Object: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: example
+  namespace: kube-system
+  generation: 2
+spec:
+  replicas: 2
+status:
+  observedGeneration: 2
+  replicas: 2
+  updatedReplicas: 2
+  availableReplicas: 1
+`,
+			Expected: false,
+		},
+		{
+			Name: "daemonset rolled out",
+			Object: `
+apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: example
+  namespace: kube-system
+  generation: 1
+status:
+  observedGeneration: 1
+  desiredNumberScheduled: 3
+  updatedNumberScheduled: 3
+  numberAvailable: 3
+`,
+			Expected: true,
+		},
+		{
+			Name: "daemonset not updated",
+			Object: `
+apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: example
+  namespace: kube-system
+  generation: 1
+status:
+  observedGeneration: 1
+  desiredNumberScheduled: 3
+  updatedNumberScheduled: 2
+  numberAvailable: 3
+`,
+			Expected: false,
+		},
+		{
+			Name: "statefulset rolled out",
+			Object: `
+apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+  name: example
+  namespace: kube-system
+  generation: 1
+spec:
+  replicas: 2
+status:
+  observedGeneration: 1
+  readyReplicas: 2
+  updatedReplicas: 2
+  currentRevision: example-1
+  updateRevision: example-1
+`,
+			Expected: true,
+		},
+		{
+			Name: "statefulset updating",
+			Object: `
+apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+  name: example
+  namespace: kube-system
+  generation: 1
+spec:
+  replicas: 2
+status:
+  observedGeneration: 1
+  readyReplicas: 2
+  updatedReplicas: 1
+  currentRevision: example-1
+  updateRevision: example-2
+`,
+			Expected: false,
+		},
+		{
+			Name: "false condition",
+			Object: `
+apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: example
+status:
+  conditions:
+  - type: Ready
+    status: "False"
+`,
+			Expected: false,
+		},
+		{
+			Name: "no status",
+			Object: `
+apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: example
+`,
+			Expected: true,
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.Name, func(t *testing.T) {
+			actual := IsReady(parseObject(t, g.Object))
+			if actual != g.Expected {
+				t.Errorf("expected IsReady=%v, got %v", g.Expected, actual)
+			}
+		})
+	}
+}
+
+func TestHasTrueCondition(t *testing.T) {
+	u := parseObject(t, `
+apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: example
+status:
+  conditions:
+  - type: Available
+    status: "True"
+  - type: Degraded
+    status: "False"
+`)
+
+	if !HasTrueCondition(u, "Available") {
+		t.Errorf("expected condition Available to be true")
+	}
+	if HasTrueCondition(u, "Degraded") {
+		t.Errorf("expected condition Degraded not to be true")
+	}
+	if HasTrueCondition(u, "Ready") {
+		t.Errorf("expected missing condition Ready not to be true")
+	}
+}
@@ -111,6 +111,8 @@ type FileAsset struct {
 	CanonicalURL *url.URL
 	// SHAValue is the SHA hash of the FileAsset.
 	SHAValue *hashing.Hash
+	// FallbackToCanonical is true if nodes should download the asset from the CanonicalURL when the DownloadURL fails.
+	FallbackToCanonical bool
 }
 
 // NewAssetBuilder creates a new AssetBuilder.
@@ -224,6 +226,7 @@ func (a *AssetBuilder) RemapFile(canonicalURL *url.URL, knownHash *hashing.Hash)
 
 		if canonicalURL.Host != normalizedFile.Host {
 			fileAsset.DownloadURL = normalizedFile
+			fileAsset.FallbackToCanonical = a.AssetsLocation.FileRepositoryConfig != nil && a.AssetsLocation.FileRepositoryConfig.FallbackToCanonical
 			klog.V(4).Infof("adding remapped file: %q", fileAsset.DownloadURL.String())
 		}
 	}
@@ -0,0 +1,580 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package assets
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"k8s.io/klog/v2"
+	kopsroot "k8s.io/kops"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/util/pkg/vfs"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// bundleManifestFile lists the assets in a bundle
+	bundleManifestFile = "bundle.yaml"
+	// bundleChecksumsFile holds the SHA-256 of every other file in a bundle, in the format of sha256sum
+	bundleChecksumsFile = "SHA256SUMS"
+	// bundleImagesDir is an OCI image layout holding all the images of a bundle
+	bundleImagesDir = "images"
+	// bundleFilesDir holds the file assets of a bundle
+	bundleFilesDir = "files"
+
+	// annotationImageRefName is the OCI annotation naming the images in the image layout
+	annotationImageRefName = "org.opencontainers.image.ref.name"
+)
+
+// BundleManifest lists the assets in an install bundle.
+type BundleManifest struct {
+	// KopsVersion is the version of kOps that created the bundle.
+	KopsVersion string         `json:"kopsVersion"`
+	Images      []*BundleImage `json:"images,omitempty"`
+	Files       []*BundleFile  `json:"files,omitempty"`
+
+	// ArchiveSHA256 is the SHA-256 of the archive, set by CreateBundle.
+	// It is not part of the archive, as the archive could then be replaced along with it.
+	ArchiveSHA256 string `json:"-"`
+}
+
+// BundleImage is an image in an install bundle.
+type BundleImage struct {
+	Canonical string `json:"canonical"`
+	Download  string `json:"download"`
+	// Digest is the digest of the image manifest or index.
+	Digest string `json:"digest"`
+}
+
+// BundleFile is a file in an install bundle.
+type BundleFile struct {
+	Canonical string `json:"canonical"`
+	Download  string `json:"download"`
+	SHA       string `json:"sha"`
+	// Path is the location of the file in the bundle.
+	Path string `json:"path"`
+}
+
+// PushBundleOptions configures where the assets of a bundle are pushed.
+type PushBundleOptions struct {
+	// ContainerRegistry replaces the registry of the images, like assets.containerRegistry in the cluster spec.
+	// By default, images are pushed to the download location recorded in the bundle.
+	ContainerRegistry string
+	// FileRepository is the repository files are pushed to, like assets.fileRepository in the cluster spec.
+	// By default, files are pushed to the download location recorded in the bundle.
+	FileRepository string
+	// SHA256 is the expected SHA-256 of the archive, as reported when it was created.
+	SHA256 string
+}
+
+// CreateBundle downloads the image and file assets from their canonical locations, and writes them to w as a tar archive.
+// The archive is staged in workDir, which must be empty and have room for all the assets.
+func CreateBundle(ctx context.Context, vfsContext *vfs.VFSContext, imageAssets []*ImageAsset, fileAssets []*FileAsset, workDir string, w io.Writer) (*BundleManifest, error) {
+	manifest := &BundleManifest{
+		KopsVersion: kopsroot.Version,
+	}
+
+	imageLayout, err := layout.Write(filepath.Join(workDir, bundleImagesDir), empty.Index)
+	if err != nil {
+		return nil, fmt.Errorf("error creating image layout: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	for _, imageAsset := range imageAssets {
+		if seen[imageAsset.CanonicalLocation] {
+			continue
+		}
+		seen[imageAsset.CanonicalLocation] = true
+
+		digest, err := addBundleImage(ctx, imageLayout, imageAsset.CanonicalLocation)
+		if err != nil {
+			return nil, err
+		}
+		manifest.Images = append(manifest.Images, &BundleImage{
+			Canonical: imageAsset.CanonicalLocation,
+			Download:  imageAsset.DownloadLocation,
+			Digest:    digest.String(),
+		})
+	}
+
+	seen = make(map[string]bool)
+	for _, fileAsset := range fileAssets {
+		canonical := fileAsset.CanonicalURL.String()
+		if seen[canonical] {
+			continue
+		}
+		seen[canonical] = true
+
+		file, err := addBundleFile(vfsContext, fileAsset, workDir)
+		if err != nil {
+			return nil, err
+		}
+		manifest.Files = append(manifest.Files, file)
+	}
+
+	manifestYAML, err := yaml.Marshal(manifest)
+	if err != nil {
+		return nil, fmt.Errorf("error serializing bundle manifest: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(workDir, bundleManifestFile), manifestYAML, 0o644); err != nil {
+		return nil, err
+	}
+
+	checksums, err := computeBundleChecksums(workDir)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(workDir, bundleChecksumsFile), formatBundleChecksums(checksums), 0o644); err != nil {
+		return nil, err
+	}
+
+	h := sha256.New()
+	if err := writeBundleArchive(workDir, io.MultiWriter(w, h)); err != nil {
+		return nil, err
+	}
+	manifest.ArchiveSHA256 = hex.EncodeToString(h.Sum(nil))
+	return manifest, nil
+}
+
+// addBundleImage adds the image, or all the images of a multi-architecture image, to the image layout.
+func addBundleImage(ctx context.Context, imageLayout layout.Path, image string) (v1.Hash, error) {
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return v1.Hash{}, fmt.Errorf("parsing reference %q: %w", image, err)
+	}
+
+	klog.Infof("downloading image %q", image)
+	desc, err := remote.Get(ref, remote.WithAuthFromKeychain(authn.DefaultKeychain), remote.WithContext(ctx))
+	if err != nil {
+		return v1.Hash{}, fmt.Errorf("fetching %q: %w", image, err)
+	}
+
+	annotations := layout.WithAnnotations(map[string]string{annotationImageRefName: image})
+	if desc.MediaType.IsIndex() {
+		idx, err := desc.ImageIndex()
+		if err != nil {
+			return v1.Hash{}, fmt.Errorf("reading index %q: %w", image, err)
+		}
+		if err := imageLayout.AppendIndex(idx, annotations); err != nil {
+			return v1.Hash{}, fmt.Errorf("writing index %q: %w", image, err)
+		}
+	} else {
+		// Assume anything else is an image, since some registries don't set mediaTypes properly.
+		img, err := desc.Image()
+		if err != nil {
+			return v1.Hash{}, fmt.Errorf("reading image %q: %w", image, err)
+		}
+		if err := imageLayout.AppendImage(img, annotations); err != nil {
+			return v1.Hash{}, fmt.Errorf("writing image %q: %w", image, err)
+		}
+	}
+	return desc.Digest, nil
+}
+
+// addBundleFile downloads the file, checks its hash, and writes it under workDir.
+func addBundleFile(vfsContext *vfs.VFSContext, fileAsset *FileAsset, workDir string) (*BundleFile, error) {
+	canonical := fileAsset.CanonicalURL.String()
+	if fileAsset.SHAValue == nil {
+		return nil, fmt.Errorf("no hash is known for file %q", canonical)
+	}
+
+	klog.Infof("downloading file %q", canonical)
+	data, err := vfsContext.ReadFile(canonical)
+	if err != nil {
+		return nil, fmt.Errorf("error downloading file %q: %w", canonical, err)
+	}
+	dataHash, err := fileAsset.SHAValue.Algorithm.Hash(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("unable to hash file %q: %w", canonical, err)
+	}
+	if !fileAsset.SHAValue.Equal(dataHash) {
+		return nil, fmt.Errorf("the hash of %q is %q, expected %q", canonical, dataHash.Hex(), fileAsset.SHAValue.Hex())
+	}
+
+	p := path.Join(bundleFilesDir, fileAsset.CanonicalURL.Host, path.Clean("/"+fileAsset.CanonicalURL.Path))
+	localPath := filepath.Join(workDir, filepath.FromSlash(p))
+	if err := os.MkdirAll(filepath.Dir(localPath), 0o755); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(localPath, data, 0o644); err != nil {
+		return nil, err
+	}
+
+	return &BundleFile{
+		Canonical: canonical,
+		Download:  fileAsset.DownloadURL.String(),
+		SHA:       fileAsset.SHAValue.Hex(),
+		Path:      p,
+	}, nil
+}
+
+// PushBundle reads a bundle created by CreateBundle, verifies its SHA-256 and checksums, and pushes its images and files.
+// The archive is extracted in workDir, which must be empty and have room for all the assets.
+func PushBundle(ctx context.Context, vfsContext *vfs.VFSContext, r io.Reader, workDir string, options *PushBundleOptions) (*BundleManifest, error) {
+	// The checksums in the archive only detect corruption, as they can be replaced along with the assets.
+	// The SHA-256 of the whole archive, obtained from its creator, authenticates it.
+	if options.SHA256 == "" {
+		return nil, fmt.Errorf("the SHA-256 of the bundle is required")
+	}
+
+	h := sha256.New()
+	tr := io.TeeReader(r, h)
+	if err := extractBundleArchive(tr, workDir); err != nil {
+		return nil, err
+	}
+	// The archive ends with padding which the tar reader doesn't need to read
+	if _, err := io.Copy(io.Discard, tr); err != nil {
+		return nil, fmt.Errorf("error reading bundle: %w", err)
+	}
+	if actual := hex.EncodeToString(h.Sum(nil)); !strings.EqualFold(actual, options.SHA256) {
+		return nil, fmt.Errorf("SHA-256 of the bundle is %s, expected %s", actual, options.SHA256)
+	}
+
+	manifest, err := verifyBundle(workDir)
+	if err != nil {
+		return nil, err
+	}
+
+	targets := &AssetBuilder{AssetsLocation: &kops.AssetsSpec{}}
+	if options.ContainerRegistry != "" {
+		targets.AssetsLocation.ContainerRegistry = &options.ContainerRegistry
+	}
+	if options.FileRepository != "" {
+		targets.AssetsLocation.FileRepository = &options.FileRepository
+	}
+
+	if len(manifest.Images) != 0 {
+		imageLayout, err := layout.FromPath(filepath.Join(workDir, bundleImagesDir))
+		if err != nil {
+			return nil, fmt.Errorf("error reading image layout: %w", err)
+		}
+		for _, image := range manifest.Images {
+			target := image.Download
+			if options.ContainerRegistry != "" {
+				target = NormalizeImage(targets, image.Canonical)
+			}
+			if target == image.Canonical {
+				return nil, fmt.Errorf("no registry to push image %q to; specify the container registry", image.Canonical)
+			}
+			if err := pushBundleImage(ctx, imageLayout, image, target); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Nodes are not configured by the bundle, so we don't need the cluster to choose ACLs
+	cluster := &kops.Cluster{}
+	for _, file := range manifest.Files {
+		target := file.Download
+		if options.FileRepository != "" {
+			canonicalURL, err := url.Parse(file.Canonical)
+			if err != nil {
+				return nil, fmt.Errorf("parsing url %q: %w", file.Canonical, err)
+			}
+			targetURL, err := targets.remapURL(canonicalURL)
+			if err != nil {
+				return nil, err
+			}
+			target = targetURL.String()
+		}
+		if target == file.Canonical {
+			return nil, fmt.Errorf("no repository to push file %q to; specify the file repository", file.Canonical)
+		}
+
+		data, err := os.ReadFile(filepath.Join(workDir, filepath.FromSlash(file.Path)))
+		if err != nil {
+			return nil, err
+		}
+		klog.Infof("uploading %q to %q", file.Canonical, target)
+		if err := uploadFile(ctx, vfsContext, cluster, file.Path, data, target, file.SHA); err != nil {
+			return nil, fmt.Errorf("unable to upload %q to %q: %w", file.Path, target, err)
+		}
+	}
+
+	return manifest, nil
+}
+
+// pushBundleImage pushes an image of the image layout to the target reference.
+func pushBundleImage(ctx context.Context, imageLayout layout.Path, image *BundleImage, target string) error {
+	targetRef, err := name.ParseReference(target)
+	if err != nil {
+		return fmt.Errorf("parsing reference %q: %w", target, err)
+	}
+
+	index, err := imageLayout.ImageIndex()
+	if err != nil {
+		return fmt.Errorf("error reading image layout: %w", err)
+	}
+	indexManifest, err := index.IndexManifest()
+	if err != nil {
+		return fmt.Errorf("error reading image layout: %w", err)
+	}
+
+	var desc *v1.Descriptor
+	for i := range indexManifest.Manifests {
+		if indexManifest.Manifests[i].Annotations[annotationImageRefName] == image.Canonical {
+			desc = &indexManifest.Manifests[i]
+		}
+	}
+	if desc == nil {
+		return fmt.Errorf("image %q is not in the bundle", image.Canonical)
+	}
+	if desc.Digest.String() != image.Digest {
+		return fmt.Errorf("image %q has digest %q, expected %q", image.Canonical, desc.Digest, image.Digest)
+	}
+
+	klog.Infof("pushing image %q to %q", image.Canonical, target)
+	options := []remote.Option{remote.WithAuthFromKeychain(authn.DefaultKeychain), remote.WithContext(ctx)}
+	if desc.MediaType.IsIndex() {
+		idx, err := index.ImageIndex(desc.Digest)
+		if err != nil {
+			return fmt.Errorf("reading index %q: %w", image.Canonical, err)
+		}
+		return remote.WriteIndex(targetRef, idx, options...)
+	}
+	img, err := index.Image(desc.Digest)
+	if err != nil {
+		return fmt.Errorf("reading image %q: %w", image.Canonical, err)
+	}
+	return remote.Write(targetRef, img, options...)
+}
+
+// verifyBundle checks every file of an extracted bundle against the checksums, and returns the bundle manifest.
+func verifyBundle(workDir string) (*BundleManifest, error) {
+	expected, err := readBundleChecksums(filepath.Join(workDir, bundleChecksumsFile))
+	if err != nil {
+		return nil, err
+	}
+	actual, err := computeBundleChecksums(workDir)
+	if err != nil {
+		return nil, err
+	}
+
+	for p, sum := range actual {
+		expectedSum, found := expected[p]
+		if !found {
+			return nil, fmt.Errorf("file %q of the bundle is not listed in %s", p, bundleChecksumsFile)
+		}
+		if sum != expectedSum {
+			return nil, fmt.Errorf("checksum of %q is %s, expected %s", p, sum, expectedSum)
+		}
+	}
+	for p := range expected {
+		if _, found := actual[p]; !found {
+			return nil, fmt.Errorf("file %q listed in %s is missing from the bundle", p, bundleChecksumsFile)
+		}
+	}
+
+	b, err := os.ReadFile(filepath.Join(workDir, bundleManifestFile))
+	if err != nil {
+		return nil, fmt.Errorf("error reading bundle manifest: %w", err)
+	}
+	manifest := &BundleManifest{}
+	if err := yaml.Unmarshal(b, manifest); err != nil {
+		return nil, fmt.Errorf("error parsing bundle manifest: %w", err)
+	}
+	return manifest, nil
+}
+
+// computeBundleChecksums returns the SHA-256 of every file under workDir except the checksums, keyed by slash-separated path.
+func computeBundleChecksums(workDir string) (map[string]string, error) {
+	checksums := make(map[string]string)
+	err := filepath.WalkDir(workDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(workDir, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if rel == bundleChecksumsFile {
+			return nil
+		}
+
+		f, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		h := sha256.New()
+		if _, err := io.Copy(h, f); err != nil {
+			return fmt.Errorf("error hashing %q: %w", rel, err)
+		}
+		checksums[rel] = hex.EncodeToString(h.Sum(nil))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return checksums, nil
+}
+
+func formatBundleChecksums(checksums map[string]string) []byte {
+	paths := make([]string, 0, len(checksums))
+	for p := range checksums {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	var b bytes.Buffer
+	for _, p := range paths {
+		fmt.Fprintf(&b, "%s  %s\n", checksums[p], p)
+	}
+	return b.Bytes()
+}
+
+func readBundleChecksums(p string) (map[string]string, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return nil, fmt.Errorf("error reading checksums of the bundle: %w", err)
+	}
+	defer f.Close()
+
+	checksums := make(map[string]string)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+		sum, file, found := strings.Cut(line, "  ")
+		if !found {
+			return nil, fmt.Errorf("invalid line in %s: %q", bundleChecksumsFile, line)
+		}
+		checksums[file] = sum
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading checksums of the bundle: %w", err)
+	}
+	return checksums, nil
+}
+
+// writeBundleArchive writes the files under workDir to w as a tar archive.
+func writeBundleArchive(workDir string, w io.Writer) error {
+	tw := tar.NewWriter(w)
+	err := filepath.WalkDir(workDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(workDir, p)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(rel)
+		if d.IsDir() {
+			header.Name += "/"
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		f, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("error writing bundle: %w", err)
+	}
+	return tw.Close()
+}
+
+// extractBundleArchive extracts a tar archive written by writeBundleArchive into workDir.
+func extractBundleArchive(r io.Reader, workDir string) error {
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("error reading bundle: %w", err)
+		}
+
+		name := path.Clean(header.Name)
+		if path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+			return fmt.Errorf("invalid path %q in bundle", header.Name)
+		}
+		p := filepath.Join(workDir, filepath.FromSlash(name))
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(p, 0o755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+				return err
+			}
+			f, err := os.OpenFile(p, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+			if err != nil {
+				return err
+			}
+			if _, err := io.Copy(f, tr); err != nil {
+				f.Close()
+				return fmt.Errorf("error extracting %q: %w", header.Name, err)
+			}
+			if err := f.Close(); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("unsupported entry %q in bundle", header.Name)
+		}
+	}
+}
@@ -0,0 +1,207 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package assets
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"k8s.io/kops/util/pkg/hashing"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+func TestBundleCreateAndPush(t *testing.T) {
+	ctx := context.Background()
+
+	server := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "http://")
+
+	canonicalImage := host + "/upstream/app:v1"
+	img, err := random.Image(1024, 2)
+	if err != nil {
+		t.Fatalf("error building image: %v", err)
+	}
+	ref, err := name.ParseReference(canonicalImage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := remote.Write(ref, img); err != nil {
+		t.Fatalf("error pushing image: %v", err)
+	}
+	imageDigest, err := img.Digest()
+	if err != nil {
+		t.Fatalf("error getting digest: %v", err)
+	}
+
+	sourceDir := t.TempDir()
+	sourceFile := filepath.Join(sourceDir, "release", "kubelet")
+	if err := os.MkdirAll(filepath.Dir(sourceFile), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(sourceFile, []byte("kubelet binary"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	fileHash, err := hashing.HashAlgorithmSHA256.HashFile(sourceFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	canonicalURL := &url.URL{Scheme: "file", Path: sourceFile}
+
+	imageAssets := []*ImageAsset{
+		{CanonicalLocation: canonicalImage, DownloadLocation: host + "/mirror/app:v1"},
+	}
+	fileAssets := []*FileAsset{
+		{CanonicalURL: canonicalURL, DownloadURL: canonicalURL, SHAValue: fileHash},
+	}
+
+	var bundle bytes.Buffer
+	created, err := CreateBundle(ctx, vfs.Context, imageAssets, fileAssets, t.TempDir(), &bundle)
+	if err != nil {
+		t.Fatalf("error creating bundle: %v", err)
+	}
+	if len(created.Images) != 1 || created.Images[0].Digest != imageDigest.String() {
+		t.Errorf("unexpected images in bundle: %+v", created.Images)
+	}
+	archiveSHA := sha256.Sum256(bundle.Bytes())
+	if created.ArchiveSHA256 != hex.EncodeToString(archiveSHA[:]) {
+		t.Errorf("unexpected archive SHA-256 %q", created.ArchiveSHA256)
+	}
+
+	// The archive is only trusted with the SHA-256 reported when it was created
+	if _, err := PushBundle(ctx, vfs.Context, bytes.NewReader(bundle.Bytes()), t.TempDir(), &PushBundleOptions{}); err == nil || !strings.Contains(err.Error(), "SHA-256 of the bundle is required") {
+		t.Errorf("expected error for missing SHA-256, got %v", err)
+	}
+	if _, err := PushBundle(ctx, vfs.Context, bytes.NewReader(bundle.Bytes()), t.TempDir(), &PushBundleOptions{SHA256: strings.Repeat("0", 64)}); err == nil || !strings.Contains(err.Error(), "expected "+strings.Repeat("0", 64)) {
+		t.Errorf("expected error for mismatched SHA-256, got %v", err)
+	}
+
+	// The download location of the file is its canonical location, so we must say where to push it
+	if _, err := PushBundle(ctx, vfs.Context, bytes.NewReader(bundle.Bytes()), t.TempDir(), &PushBundleOptions{SHA256: created.ArchiveSHA256}); err == nil || !strings.Contains(err.Error(), "no repository to push file") {
+		t.Errorf("expected error for missing file repository, got %v", err)
+	}
+
+	repositoryDir := t.TempDir()
+	options := &PushBundleOptions{
+		FileRepository: "file://" + repositoryDir,
+		SHA256:         created.ArchiveSHA256,
+	}
+	if _, err := PushBundle(ctx, vfs.Context, bytes.NewReader(bundle.Bytes()), t.TempDir(), options); err != nil {
+		t.Fatalf("error pushing bundle: %v", err)
+	}
+
+	pushedRef, err := name.ParseReference(host + "/mirror/app:v1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	desc, err := remote.Get(pushedRef)
+	if err != nil {
+		t.Fatalf("error getting pushed image: %v", err)
+	}
+	if desc.Digest != imageDigest {
+		t.Errorf("pushed image has digest %v, expected %v", desc.Digest, imageDigest)
+	}
+
+	pushedFile := filepath.Join(repositoryDir, sourceFile)
+	data, err := os.ReadFile(pushedFile)
+	if err != nil {
+		t.Fatalf("error reading pushed file: %v", err)
+	}
+	if string(data) != "kubelet binary" {
+		t.Errorf("unexpected pushed file contents %q", data)
+	}
+	sha, err := os.ReadFile(pushedFile + ".sha256")
+	if err != nil {
+		t.Fatalf("error reading pushed hash: %v", err)
+	}
+	if string(sha) != fileHash.Hex() {
+		t.Errorf("unexpected pushed hash %q", sha)
+	}
+}
+
+func TestVerifyBundle(t *testing.T) {
+	workDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(workDir, bundleManifestFile), []byte("kopsVersion: 1.35.0\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(workDir, bundleFilesDir), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	filePath := filepath.Join(workDir, bundleFilesDir, "kubelet")
+	if err := os.WriteFile(filePath, []byte("kubelet binary"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	checksums, err := computeBundleChecksums(workDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(workDir, bundleChecksumsFile), formatBundleChecksums(checksums), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := verifyBundle(workDir); err != nil {
+		t.Fatalf("unexpected error verifying bundle: %v", err)
+	}
+
+	if err := os.WriteFile(filePath, []byte("tampered binary"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := verifyBundle(workDir); err == nil || !strings.Contains(err.Error(), `checksum of "files/kubelet"`) {
+		t.Errorf("expected checksum error, got %v", err)
+	}
+
+	if err := os.Remove(filePath); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := verifyBundle(workDir); err == nil || !strings.Contains(err.Error(), "is missing from the bundle") {
+		t.Errorf("expected missing file error, got %v", err)
+	}
+}
+
+func TestExtractBundleArchiveRejectsTraversal(t *testing.T) {
+	var archive bytes.Buffer
+	tw := tar.NewWriter(&archive)
+	if err := tw.WriteHeader(&tar.Header{Name: "../evil", Typeflag: tar.TypeReg, Mode: 0o644, Size: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tw.Write([]byte("x")); err != nil {
+		t.Fatal(err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	err := extractBundleArchive(&archive, t.TempDir())
+	if err == nil || !strings.Contains(err.Error(), "invalid path") {
+		t.Errorf("expected invalid path error, got %v", err)
+	}
+}
@@ -22,6 +22,7 @@ import (
 
 	"k8s.io/klog/v2"
 	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/imageverification"
 	"k8s.io/kops/util/pkg/vfs"
 )
 
@@ -32,12 +33,22 @@ type assetTask interface {
 func Copy(imageAssets []*ImageAsset, fileAssets []*FileAsset, vfsContext *vfs.VFSContext, cluster *kops.Cluster) error {
 	tasks := map[string]assetTask{}
 
+	var verifier *imageverification.Verifier
+	if cluster.Spec.Assets != nil {
+		v, err := imageverification.NewVerifier(cluster.Spec.Assets.ImageVerification, nil)
+		if err != nil {
+			return fmt.Errorf("building image verifier: %w", err)
+		}
+		verifier = v
+	}
+
 	for _, imageAsset := range imageAssets {
 		if imageAsset.DownloadLocation != imageAsset.CanonicalLocation {
 			copyImageTask := &CopyImage{
 				Name:        imageAsset.DownloadLocation,
 				SourceImage: imageAsset.CanonicalLocation,
 				TargetImage: imageAsset.DownloadLocation,
+				Verifier:    verifier,
 			}
 
 			if existing, ok := tasks[copyImageTask.Name]; ok {
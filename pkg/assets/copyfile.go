@@ -114,6 +114,12 @@ func transferFile(ctx context.Context, vfsContext *vfs.VFSContext, cluster *kops
 		return fmt.Errorf("error downloading file %q: %v", source, err)
 	}
 
+	return uploadFile(ctx, vfsContext, cluster, source, data, target, sha)
+}
+
+// uploadFile validates the file matches the SHA, and uploads the file and its SHA to the target location.
+// source is only used in messages.
+func uploadFile(ctx context.Context, vfsContext *vfs.VFSContext, cluster *kops.Cluster, source string, data []byte, target string, sha string) error {
 	objectStore, err := buildVFSPath(target)
 	if err != nil {
 		return err
@@ -17,6 +17,7 @@ limitations under the License.
 package assets
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/google/go-containerregistry/pkg/authn"
@@ -24,6 +25,7 @@ import (
 	"github.com/google/go-containerregistry/pkg/v1/remote"
 	"github.com/google/go-containerregistry/pkg/v1/types"
 	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/imageverification"
 )
 
 // CopyImage copies a docker image from a source registry, to a target registry,
@@ -32,6 +34,9 @@ type CopyImage struct {
 	Name        string
 	SourceImage string
 	TargetImage string
+	// Verifier verifies the signatures of the source image, if set.
+	// The signatures are copied along with the image, so that nodes can verify the target image.
+	Verifier *imageverification.Verifier
 }
 
 func (e *CopyImage) Run() error {
@@ -55,10 +60,23 @@ func (e *CopyImage) Run() error {
 		return fmt.Errorf("fetching %q: %v", source, err)
 	}
 
+	if e.Verifier != nil {
+		verified, err := e.Verifier.VerifySource(context.TODO(), target, source, options...)
+		if err == nil && verified != desc.Digest {
+			err = fmt.Errorf("image %q changed from %v to %v while it was verified", source, desc.Digest, verified)
+		}
+		if err != nil {
+			if e.Verifier.Enforced() {
+				return fmt.Errorf("verifying signatures: %w", err)
+			}
+			klog.Warningf("image %q failed verification: %v", source, err)
+		}
+	}
+
 	targetDesc, err := remote.Get(targetRef, options...)
 	if err == nil && desc.Digest.String() == targetDesc.Digest.String() {
 		klog.Infof("no need to copy image from %v to %v", sourceRef, targetRef)
-		return nil
+		return e.copySignatures(desc, sourceRef, targetRef, options...)
 	}
 
 	switch desc.MediaType {
@@ -74,6 +92,29 @@ func (e *CopyImage) Run() error {
 		}
 	}
 
+	return e.copySignatures(desc, sourceRef, targetRef, options...)
+}
+
+// copySignatures copies the signatures of the image, when the image is verified.
+func (e *CopyImage) copySignatures(desc *remote.Descriptor, sourceRef name.Reference, targetRef name.Reference, options ...remote.Option) error {
+	if e.Verifier == nil {
+		return nil
+	}
+
+	sourceSig := imageverification.SignatureReference(sourceRef, desc.Digest)
+	targetSig := imageverification.SignatureReference(targetRef, desc.Digest)
+	klog.Infof("copying signatures from %v to %v", sourceSig, targetSig)
+
+	sigImage, err := remote.Image(sourceSig, options...)
+	if err == nil {
+		err = remote.Write(targetSig, sigImage, options...)
+	}
+	if err != nil {
+		if e.Verifier.Enforced() {
+			return fmt.Errorf("failed to copy signatures: %w", err)
+		}
+		klog.Warningf("failed to copy signatures of %q: %v", sourceRef, err)
+	}
 	return nil
 }
 
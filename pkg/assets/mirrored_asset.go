@@ -17,6 +17,7 @@ limitations under the License.
 package assets
 
 import (
+	"slices"
 	"strings"
 
 	"k8s.io/klog/v2"
@@ -41,6 +42,14 @@ func BuildMirroredAsset(asset *FileAsset) *MirroredAsset {
 		a.Locations = []string{u.String()}
 	} else {
 		a.Locations = FindURLMirrors(u.String())
+		if asset.FallbackToCanonical {
+			// The canonical locations come after the file repository, so they are only used when it fails
+			for _, location := range FindURLMirrors(asset.CanonicalURL.String()) {
+				if !slices.Contains(a.Locations, location) {
+					a.Locations = append(a.Locations, location)
+				}
+			}
+		}
 	}
 
 	return a
@@ -0,0 +1,514 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package assets
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/uuid"
+	"k8s.io/klog/v2"
+	kopsroot "k8s.io/kops"
+)
+
+// SBOMComponentType is the kind of asset described by an SBOM component.
+type SBOMComponentType string
+
+const (
+	// SBOMComponentImage is a container image asset.
+	SBOMComponentImage SBOMComponentType = "image"
+	// SBOMComponentFile is a file asset, downloaded by nodeup.
+	SBOMComponentFile SBOMComponentType = "file"
+	// SBOMComponentManifest is an addon manifest, applied by channels.
+	SBOMComponentManifest SBOMComponentType = "manifest"
+)
+
+// noAssertion is the SPDX value for information that was not determined.
+const noAssertion = "NOASSERTION"
+
+// SBOM is a software bill of materials for the assets of a cluster.
+type SBOM struct {
+	// Name is the name of the cluster the SBOM describes.
+	Name string
+	// KopsVersion is the version of kOps that created the SBOM.
+	KopsVersion string
+	// Created is the time the SBOM was created.
+	Created time.Time
+	// Serial uniquely identifies the SBOM.
+	Serial string
+	// Components are the assets of the cluster, ordered by type and location.
+	Components []*SBOMComponent
+}
+
+// SBOMComponent is an asset in an SBOM.
+type SBOMComponent struct {
+	Type SBOMComponentType
+	// Name is the short name of the asset, e.g. the image repository or the file name.
+	Name string
+	// Version is the version of the asset, if known.
+	Version string
+	// Location is the canonical location of the asset.
+	Location string
+	// Download is the location the cluster downloads the asset from, if it differs from Location.
+	Download string
+	// SHA256 is the hex encoded SHA-256 of the file or manifest, or the digest of the image.
+	SHA256 string
+	// License is the SPDX license expression of the asset, if known.
+	License string
+	// PURL is the package URL of the asset.
+	PURL string
+}
+
+// SBOMManifest is an addon manifest to be included in an SBOM.
+type SBOMManifest struct {
+	// Location is the path of the manifest in the state store, e.g. addons/coredns.addons.k8s.io/k8s-1.12.yaml.
+	Location string
+	Contents []byte
+}
+
+// ImageDigestResolver returns the digest of an image, in the form sha256:<hex>.
+type ImageDigestResolver func(ctx context.Context, image string) (string, error)
+
+// ResolveImageDigest is an ImageDigestResolver that queries the registry of the image.
+func ResolveImageDigest(ctx context.Context, image string) (string, error) {
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return "", fmt.Errorf("parsing image reference %q: %w", image, err)
+	}
+	desc, err := remote.Head(ref, remote.WithAuthFromKeychain(authn.DefaultKeychain), remote.WithContext(ctx))
+	if err != nil {
+		return "", fmt.Errorf("getting digest of image %q: %w", image, err)
+	}
+	return desc.Digest.String(), nil
+}
+
+// knownLicenses maps the canonical location prefixes of assets to their licenses.
+// Assets that don't match any prefix are reported with NOASSERTION.
+var knownLicenses = []struct {
+	prefix  string
+	license string
+}{
+	{"registry.k8s.io/", "Apache-2.0"},
+	{"https://dl.k8s.io/", "Apache-2.0"},
+	{"https://cdn.dl.k8s.io/", "Apache-2.0"},
+	{"https://storage.googleapis.com/kubernetes-release/", "Apache-2.0"},
+	{"https://artifacts.k8s.io/binaries/kops/", "Apache-2.0"},
+	{"https://github.com/containerd/", "Apache-2.0"},
+	{"https://github.com/opencontainers/runc/", "Apache-2.0"},
+	{"https://github.com/kubernetes-sigs/cri-tools/", "Apache-2.0"},
+	{"https://github.com/containernetworking/plugins/", "Apache-2.0"},
+	{"https://github.com/etcd-io/etcd/", "Apache-2.0"},
+	{"quay.io/cilium/", "Apache-2.0"},
+	{"quay.io/calico/", "Apache-2.0"},
+	{"docker.io/calico/", "Apache-2.0"},
+	{"ghcr.io/flannel-io/", "Apache-2.0"},
+	{"docker.io/cloudnativelabs/kube-router", "Apache-2.0"},
+}
+
+// versionRegexp matches the semantic version in the URL of a file asset.
+var versionRegexp = regexp.MustCompile(`v?[0-9]+\.[0-9]+\.[0-9]+(-(alpha|beta|rc)\.?[0-9]+)?`)
+
+// BuildSBOM creates an SBOM for the image and file assets of a cluster and its addon manifests.
+// Image digests that aren't part of the image reference are looked up using resolveDigest, if it is not nil.
+// Images whose digest can't be resolved are included without one.
+func BuildSBOM(ctx context.Context, clusterName string, imageAssets []*ImageAsset, fileAssets []*FileAsset, manifests []*SBOMManifest, resolveDigest ImageDigestResolver) (*SBOM, error) {
+	sbom := &SBOM{
+		Name:        clusterName,
+		KopsVersion: kopsroot.Version,
+		Created:     time.Now().UTC(),
+		Serial:      uuid.New().String(),
+	}
+
+	seen := make(map[string]bool)
+	for _, imageAsset := range imageAssets {
+		if seen[imageAsset.CanonicalLocation] {
+			continue
+		}
+		seen[imageAsset.CanonicalLocation] = true
+
+		component, err := imageComponent(ctx, imageAsset, resolveDigest)
+		if err != nil {
+			return nil, err
+		}
+		sbom.Components = append(sbom.Components, component)
+	}
+
+	seen = make(map[string]bool)
+	for _, fileAsset := range fileAssets {
+		canonical := fileAsset.CanonicalURL.String()
+		if seen[canonical] {
+			continue
+		}
+		seen[canonical] = true
+
+		sbom.Components = append(sbom.Components, fileComponent(fileAsset))
+	}
+
+	seen = make(map[string]bool)
+	for _, manifest := range manifests {
+		if seen[manifest.Location] {
+			continue
+		}
+		seen[manifest.Location] = true
+
+		sum := sha256.Sum256(manifest.Contents)
+		sbom.Components = append(sbom.Components, &SBOMComponent{
+			Type:     SBOMComponentManifest,
+			Name:     strings.TrimPrefix(manifest.Location, "addons/"),
+			Location: manifest.Location,
+			SHA256:   hex.EncodeToString(sum[:]),
+		})
+	}
+
+	typeOrder := map[SBOMComponentType]int{SBOMComponentImage: 0, SBOMComponentFile: 1, SBOMComponentManifest: 2}
+	sort.SliceStable(sbom.Components, func(i, j int) bool {
+		a, b := sbom.Components[i], sbom.Components[j]
+		if a.Type != b.Type {
+			return typeOrder[a.Type] < typeOrder[b.Type]
+		}
+		return a.Location < b.Location
+	})
+
+	return sbom, nil
+}
+
+func imageComponent(ctx context.Context, imageAsset *ImageAsset, resolveDigest ImageDigestResolver) (*SBOMComponent, error) {
+	ref, err := name.ParseReference(imageAsset.CanonicalLocation)
+	if err != nil {
+		return nil, fmt.Errorf("parsing image reference %q: %w", imageAsset.CanonicalLocation, err)
+	}
+	repository := ref.Context()
+
+	component := &SBOMComponent{
+		Type:     SBOMComponentImage,
+		Name:     path.Base(repository.RepositoryStr()),
+		Location: imageAsset.CanonicalLocation,
+		License:  licenseFor(repository.Name()),
+	}
+	if imageAsset.DownloadLocation != imageAsset.CanonicalLocation {
+		component.Download = imageAsset.DownloadLocation
+	}
+
+	// The canonical location may carry both a tag and a digest, which name.ParseReference doesn't allow
+	var tag, digest string
+	location := imageAsset.CanonicalLocation
+	if i := strings.Index(location, "@"); i != -1 {
+		location, digest = location[:i], location[i+1:]
+	}
+	if i := strings.LastIndex(location, ":"); i != -1 && !strings.Contains(location[i:], "/") {
+		tag = location[i+1:]
+	}
+	if digest == "" && resolveDigest != nil {
+		digest, err = resolveDigest(ctx, imageAsset.CanonicalLocation)
+		if err != nil {
+			klog.Warningf("unable to resolve digest of image %q: %v", imageAsset.CanonicalLocation, err)
+			digest = ""
+		}
+	}
+	component.Version = tag
+	component.SHA256 = strings.TrimPrefix(digest, "sha256:")
+
+	// https://github.com/package-url/purl-spec/blob/master/PURL-TYPES.rst#oci
+	purl := "pkg:oci/" + url.PathEscape(component.Name)
+	if digest != "" {
+		purl += "@" + url.PathEscape(digest)
+	}
+	query := url.Values{}
+	query.Set("repository_url", repository.Name())
+	if tag != "" {
+		query.Set("tag", tag)
+	}
+	component.PURL = purl + "?" + query.Encode()
+
+	return component, nil
+}
+
+func fileComponent(fileAsset *FileAsset) *SBOMComponent {
+	canonical := fileAsset.CanonicalURL.String()
+
+	component := &SBOMComponent{
+		Type:     SBOMComponentFile,
+		Name:     path.Base(fileAsset.CanonicalURL.Path),
+		Version:  versionRegexp.FindString(fileAsset.CanonicalURL.Path),
+		Location: canonical,
+		License:  licenseFor(canonical),
+	}
+	if fileAsset.DownloadURL != nil && fileAsset.DownloadURL.String() != canonical {
+		component.Download = fileAsset.DownloadURL.String()
+	}
+	if fileAsset.SHAValue != nil {
+		component.SHA256 = fileAsset.SHAValue.Hex()
+	}
+
+	// https://github.com/package-url/purl-spec/blob/master/PURL-TYPES.rst#generic
+	purl := "pkg:generic/" + url.PathEscape(component.Name)
+	if component.Version != "" {
+		purl += "@" + url.PathEscape(component.Version)
+	}
+	query := url.Values{}
+	query.Set("download_url", canonical)
+	if component.SHA256 != "" {
+		query.Set("checksum", "sha256:"+component.SHA256)
+	}
+	component.PURL = purl + "?" + query.Encode()
+
+	return component
+}
+
+// licenseFor returns the license of the asset at location, or the empty string if it is not known.
+func licenseFor(location string) string {
+	for _, known := range knownLicenses {
+		if strings.HasPrefix(location, known.prefix) {
+			return known.license
+		}
+	}
+	return ""
+}
+
+type spdxDocument struct {
+	SPDXVersion       string             `json:"spdxVersion"`
+	DataLicense       string             `json:"dataLicense"`
+	SPDXID            string             `json:"SPDXID"`
+	Name              string             `json:"name"`
+	DocumentNamespace string             `json:"documentNamespace"`
+	CreationInfo      spdxCreationInfo   `json:"creationInfo"`
+	Packages          []spdxPackage      `json:"packages"`
+	Relationships     []spdxRelationship `json:"relationships"`
+}
+
+type spdxCreationInfo struct {
+	Created  string   `json:"created"`
+	Creators []string `json:"creators"`
+}
+
+type spdxPackage struct {
+	Name                  string            `json:"name"`
+	SPDXID                string            `json:"SPDXID"`
+	VersionInfo           string            `json:"versionInfo,omitempty"`
+	DownloadLocation      string            `json:"downloadLocation"`
+	SourceInfo            string            `json:"sourceInfo,omitempty"`
+	FilesAnalyzed         bool              `json:"filesAnalyzed"`
+	Checksums             []spdxChecksum    `json:"checksums,omitempty"`
+	LicenseConcluded      string            `json:"licenseConcluded"`
+	LicenseDeclared       string            `json:"licenseDeclared"`
+	CopyrightText         string            `json:"copyrightText"`
+	ExternalRefs          []spdxExternalRef `json:"externalRefs,omitempty"`
+	PrimaryPackagePurpose string            `json:"primaryPackagePurpose"`
+}
+
+type spdxChecksum struct {
+	Algorithm     string `json:"algorithm"`
+	ChecksumValue string `json:"checksumValue"`
+}
+
+type spdxExternalRef struct {
+	ReferenceCategory string `json:"referenceCategory"`
+	ReferenceType     string `json:"referenceType"`
+	ReferenceLocator  string `json:"referenceLocator"`
+}
+
+type spdxRelationship struct {
+	SPDXElementID      string `json:"spdxElementId"`
+	RelationshipType   string `json:"relationshipType"`
+	RelatedSPDXElement string `json:"relatedSpdxElement"`
+}
+
+// WriteSPDX writes the SBOM as an SPDX 2.3 JSON document.
+func (s *SBOM) WriteSPDX(w io.Writer) error {
+	doc := spdxDocument{
+		SPDXVersion:       "SPDX-2.3",
+		DataLicense:       "CC0-1.0",
+		SPDXID:            "SPDXRef-DOCUMENT",
+		Name:              s.Name,
+		DocumentNamespace: "https://kops.sigs.k8s.io/spdx/" + url.PathEscape(s.Name) + "-" + s.Serial,
+		CreationInfo: spdxCreationInfo{
+			Created:  s.Created.Format(time.RFC3339),
+			Creators: []string{"Tool: kops-" + s.KopsVersion},
+		},
+		Packages:      []spdxPackage{},
+		Relationships: []spdxRelationship{},
+	}
+
+	for i, component := range s.Components {
+		pkg := spdxPackage{
+			Name:             component.Name,
+			SPDXID:           fmt.Sprintf("SPDXRef-%s-%d", component.Type, i),
+			VersionInfo:      component.Version,
+			DownloadLocation: component.Location,
+			LicenseConcluded: noAssertion,
+			LicenseDeclared:  noAssertion,
+			CopyrightText:    noAssertion,
+		}
+		if component.Type == SBOMComponentManifest {
+			// Addon manifests are generated by kOps and stored in the state store
+			pkg.DownloadLocation = noAssertion
+			pkg.SourceInfo = "kOps state store path " + component.Location
+		}
+		if component.Download != "" {
+			pkg.SourceInfo = "downloaded from " + component.Download
+		}
+		if component.License != "" {
+			pkg.LicenseDeclared = component.License
+		}
+		if component.SHA256 != "" {
+			pkg.Checksums = []spdxChecksum{{Algorithm: "SHA256", ChecksumValue: component.SHA256}}
+		}
+		if component.PURL != "" {
+			pkg.ExternalRefs = []spdxExternalRef{{ReferenceCategory: "PACKAGE-MANAGER", ReferenceType: "purl", ReferenceLocator: component.PURL}}
+		}
+		switch component.Type {
+		case SBOMComponentImage:
+			pkg.PrimaryPackagePurpose = "CONTAINER"
+		case SBOMComponentFile:
+			pkg.PrimaryPackagePurpose = "FILE"
+		case SBOMComponentManifest:
+			pkg.PrimaryPackagePurpose = "INSTALL"
+		}
+
+		doc.Packages = append(doc.Packages, pkg)
+		doc.Relationships = append(doc.Relationships, spdxRelationship{
+			SPDXElementID:      doc.SPDXID,
+			RelationshipType:   "DESCRIBES",
+			RelatedSPDXElement: pkg.SPDXID,
+		})
+	}
+
+	return writeJSON(w, doc)
+}
+
+type cycloneDXDocument struct {
+	BOMFormat    string               `json:"bomFormat"`
+	SpecVersion  string               `json:"specVersion"`
+	SerialNumber string               `json:"serialNumber"`
+	Version      int                  `json:"version"`
+	Metadata     cycloneDXMetadata    `json:"metadata"`
+	Components   []cycloneDXComponent `json:"components"`
+}
+
+type cycloneDXMetadata struct {
+	Timestamp string             `json:"timestamp"`
+	Tools     cycloneDXTools     `json:"tools"`
+	Component cycloneDXComponent `json:"component"`
+}
+
+type cycloneDXTools struct {
+	Components []cycloneDXComponent `json:"components"`
+}
+
+type cycloneDXComponent struct {
+	Type               string                 `json:"type"`
+	BOMRef             string                 `json:"bom-ref,omitempty"`
+	Name               string                 `json:"name"`
+	Version            string                 `json:"version,omitempty"`
+	Hashes             []cycloneDXHash        `json:"hashes,omitempty"`
+	Licenses           []cycloneDXLicense     `json:"licenses,omitempty"`
+	PURL               string                 `json:"purl,omitempty"`
+	ExternalReferences []cycloneDXExternalRef `json:"externalReferences,omitempty"`
+}
+
+type cycloneDXHash struct {
+	Alg     string `json:"alg"`
+	Content string `json:"content"`
+}
+
+type cycloneDXLicense struct {
+	License cycloneDXLicenseID `json:"license"`
+}
+
+type cycloneDXLicenseID struct {
+	ID string `json:"id"`
+}
+
+type cycloneDXExternalRef struct {
+	Type    string `json:"type"`
+	URL     string `json:"url"`
+	Comment string `json:"comment,omitempty"`
+}
+
+// WriteCycloneDX writes the SBOM as a CycloneDX 1.5 JSON document.
+func (s *SBOM) WriteCycloneDX(w io.Writer) error {
+	doc := cycloneDXDocument{
+		BOMFormat:    "CycloneDX",
+		SpecVersion:  "1.5",
+		SerialNumber: "urn:uuid:" + s.Serial,
+		Version:      1,
+		Metadata: cycloneDXMetadata{
+			Timestamp: s.Created.Format(time.RFC3339),
+			Tools: cycloneDXTools{
+				Components: []cycloneDXComponent{{Type: "application", Name: "kops", Version: s.KopsVersion}},
+			},
+			Component: cycloneDXComponent{Type: "platform", Name: s.Name},
+		},
+		Components: []cycloneDXComponent{},
+	}
+
+	for _, component := range s.Components {
+		c := cycloneDXComponent{
+			BOMRef:  string(component.Type) + ":" + component.Location,
+			Name:    component.Name,
+			Version: component.Version,
+			PURL:    component.PURL,
+		}
+		switch component.Type {
+		case SBOMComponentImage:
+			c.Type = "container"
+		default:
+			c.Type = "file"
+		}
+		if component.SHA256 != "" {
+			c.Hashes = []cycloneDXHash{{Alg: "SHA-256", Content: component.SHA256}}
+		}
+		if component.License != "" {
+			c.Licenses = []cycloneDXLicense{{License: cycloneDXLicenseID{ID: component.License}}}
+		}
+		if component.Type != SBOMComponentManifest {
+			c.ExternalReferences = append(c.ExternalReferences, cycloneDXExternalRef{Type: "distribution", URL: component.Location})
+		}
+		if component.Download != "" {
+			c.ExternalReferences = append(c.ExternalReferences, cycloneDXExternalRef{Type: "distribution", URL: component.Download, Comment: "mirror"})
+		}
+
+		doc.Components = append(doc.Components, c)
+	}
+
+	return writeJSON(w, doc)
+}
+
+func writeJSON(w io.Writer, v interface{}) error {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to marshal JSON: %w", err)
+	}
+	b = append(b, '\n')
+	if _, err := w.Write(b); err != nil {
+		return fmt.Errorf("error writing to output: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,266 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package assets
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"k8s.io/kops/util/pkg/hashing"
+)
+
+const testDigest = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+func testSBOM(t *testing.T) *SBOM {
+	t.Helper()
+
+	mustParse := func(s string) *url.URL {
+		u, err := url.Parse(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return u
+	}
+	kubeletHash, err := hashing.FromString("aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	imageAssets := []*ImageAsset{
+		{CanonicalLocation: "registry.k8s.io/kube-proxy:v1.30.0", DownloadLocation: "mirror.example.com/kube-proxy:v1.30.0"},
+		{CanonicalLocation: "registry.k8s.io/kube-proxy:v1.30.0", DownloadLocation: "mirror.example.com/kube-proxy:v1.30.0"},
+		{CanonicalLocation: "example.com/unknown/app:v2@" + testDigest, DownloadLocation: "example.com/unknown/app:v2@" + testDigest},
+		{CanonicalLocation: "example.com/unreachable:v3", DownloadLocation: "example.com/unreachable:v3"},
+	}
+	fileAssets := []*FileAsset{
+		{
+			CanonicalURL: mustParse("https://dl.k8s.io/release/v1.30.0/bin/linux/amd64/kubelet"),
+			DownloadURL:  mustParse("https://dl.k8s.io/release/v1.30.0/bin/linux/amd64/kubelet"),
+			SHAValue:     kubeletHash,
+		},
+	}
+	manifests := []*SBOMManifest{
+		{Location: "addons/bootstrap-channel.yaml", Contents: []byte("kind: Addons\n")},
+	}
+
+	resolve := func(ctx context.Context, image string) (string, error) {
+		switch image {
+		case "registry.k8s.io/kube-proxy:v1.30.0":
+			return testDigest, nil
+		case "example.com/unknown/app:v2@" + testDigest:
+			return "", fmt.Errorf("unexpected lookup of pinned image %q", image)
+		}
+		return "", fmt.Errorf("image %q not found", image)
+	}
+
+	sbom, err := BuildSBOM(context.Background(), "minimal.example.com", imageAssets, fileAssets, manifests, resolve)
+	if err != nil {
+		t.Fatalf("error building SBOM: %v", err)
+	}
+	sbom.KopsVersion = "1.35.0"
+	sbom.Created = time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	sbom.Serial = "00000000-0000-0000-0000-000000000000"
+	return sbom
+}
+
+func TestBuildSBOM(t *testing.T) {
+	sbom := testSBOM(t)
+
+	expected := []*SBOMComponent{
+		{
+			Type:     SBOMComponentImage,
+			Name:     "app",
+			Version:  "v2",
+			Location: "example.com/unknown/app:v2@" + testDigest,
+			SHA256:   strings.TrimPrefix(testDigest, "sha256:"),
+			PURL:     "pkg:oci/app@sha256:" + strings.TrimPrefix(testDigest, "sha256:") + "?repository_url=example.com%2Funknown%2Fapp&tag=v2",
+		},
+		{
+			Type:     SBOMComponentImage,
+			Name:     "unreachable",
+			Version:  "v3",
+			Location: "example.com/unreachable:v3",
+			PURL:     "pkg:oci/unreachable?repository_url=example.com%2Funreachable&tag=v3",
+		},
+		{
+			Type:     SBOMComponentImage,
+			Name:     "kube-proxy",
+			Version:  "v1.30.0",
+			Location: "registry.k8s.io/kube-proxy:v1.30.0",
+			Download: "mirror.example.com/kube-proxy:v1.30.0",
+			SHA256:   strings.TrimPrefix(testDigest, "sha256:"),
+			License:  "Apache-2.0",
+			PURL:     "pkg:oci/kube-proxy@sha256:" + strings.TrimPrefix(testDigest, "sha256:") + "?repository_url=registry.k8s.io%2Fkube-proxy&tag=v1.30.0",
+		},
+		{
+			Type:     SBOMComponentFile,
+			Name:     "kubelet",
+			Version:  "v1.30.0",
+			Location: "https://dl.k8s.io/release/v1.30.0/bin/linux/amd64/kubelet",
+			SHA256:   "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+			License:  "Apache-2.0",
+			PURL:     "pkg:generic/kubelet@v1.30.0?checksum=sha256%3Aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa&download_url=https%3A%2F%2Fdl.k8s.io%2Frelease%2Fv1.30.0%2Fbin%2Flinux%2Famd64%2Fkubelet",
+		},
+		{
+			Type:     SBOMComponentManifest,
+			Name:     "bootstrap-channel.yaml",
+			Location: "addons/bootstrap-channel.yaml",
+			SHA256:   "1d4e28cfbd249a204bb4d61e9b2af02d87215582d92e3e33d30c756d0fbca9a8",
+		},
+	}
+
+	if !reflect.DeepEqual(sbom.Components, expected) {
+		actual, _ := json.MarshalIndent(sbom.Components, "", "  ")
+		want, _ := json.MarshalIndent(expected, "", "  ")
+		t.Errorf("unexpected components\nactual: %s\nexpected: %s", actual, want)
+	}
+}
+
+func TestWriteSPDX(t *testing.T) {
+	sbom := testSBOM(t)
+
+	var buf bytes.Buffer
+	if err := sbom.WriteSPDX(&buf); err != nil {
+		t.Fatalf("error writing SPDX: %v", err)
+	}
+
+	var doc spdxDocument
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("error parsing SPDX: %v", err)
+	}
+	if doc.SPDXVersion != "SPDX-2.3" {
+		t.Errorf("unexpected spdxVersion %q", doc.SPDXVersion)
+	}
+	if doc.DocumentNamespace != "https://kops.sigs.k8s.io/spdx/minimal.example.com-00000000-0000-0000-0000-000000000000" {
+		t.Errorf("unexpected documentNamespace %q", doc.DocumentNamespace)
+	}
+	if doc.CreationInfo.Created != "2026-01-02T03:04:05Z" {
+		t.Errorf("unexpected created %q", doc.CreationInfo.Created)
+	}
+	if len(doc.Packages) != len(sbom.Components) || len(doc.Relationships) != len(sbom.Components) {
+		t.Fatalf("expected %d packages and relationships, got %d and %d", len(sbom.Components), len(doc.Packages), len(doc.Relationships))
+	}
+
+	kubeProxy := doc.Packages[2]
+	if kubeProxy.LicenseDeclared != "Apache-2.0" || kubeProxy.PrimaryPackagePurpose != "CONTAINER" || kubeProxy.SourceInfo != "downloaded from mirror.example.com/kube-proxy:v1.30.0" {
+		t.Errorf("unexpected kube-proxy package %+v", kubeProxy)
+	}
+	unreachable := doc.Packages[1]
+	if unreachable.LicenseDeclared != noAssertion || len(unreachable.Checksums) != 0 {
+		t.Errorf("unexpected unreachable package %+v", unreachable)
+	}
+	manifest := doc.Packages[4]
+	if manifest.DownloadLocation != noAssertion || manifest.PrimaryPackagePurpose != "INSTALL" || len(manifest.Checksums) != 1 {
+		t.Errorf("unexpected manifest package %+v", manifest)
+	}
+}
+
+func TestWriteCycloneDX(t *testing.T) {
+	sbom := testSBOM(t)
+
+	var buf bytes.Buffer
+	if err := sbom.WriteCycloneDX(&buf); err != nil {
+		t.Fatalf("error writing CycloneDX: %v", err)
+	}
+
+	var doc cycloneDXDocument
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("error parsing CycloneDX: %v", err)
+	}
+	if doc.BOMFormat != "CycloneDX" || doc.SpecVersion != "1.5" {
+		t.Errorf("unexpected format %q %q", doc.BOMFormat, doc.SpecVersion)
+	}
+	if doc.SerialNumber != "urn:uuid:00000000-0000-0000-0000-000000000000" {
+		t.Errorf("unexpected serialNumber %q", doc.SerialNumber)
+	}
+	if doc.Metadata.Component.Name != "minimal.example.com" {
+		t.Errorf("unexpected metadata component %+v", doc.Metadata.Component)
+	}
+	if len(doc.Components) != len(sbom.Components) {
+		t.Fatalf("expected %d components, got %d", len(sbom.Components), len(doc.Components))
+	}
+
+	kubeProxy := doc.Components[2]
+	expected := cycloneDXComponent{
+		Type:     "container",
+		BOMRef:   "image:registry.k8s.io/kube-proxy:v1.30.0",
+		Name:     "kube-proxy",
+		Version:  "v1.30.0",
+		Hashes:   []cycloneDXHash{{Alg: "SHA-256", Content: strings.TrimPrefix(testDigest, "sha256:")}},
+		Licenses: []cycloneDXLicense{{License: cycloneDXLicenseID{ID: "Apache-2.0"}}},
+		PURL:     sbom.Components[2].PURL,
+		ExternalReferences: []cycloneDXExternalRef{
+			{Type: "distribution", URL: "registry.k8s.io/kube-proxy:v1.30.0"},
+			{Type: "distribution", URL: "mirror.example.com/kube-proxy:v1.30.0", Comment: "mirror"},
+		},
+	}
+	if !reflect.DeepEqual(kubeProxy, expected) {
+		t.Errorf("unexpected kube-proxy component\nactual: %+v\nexpected: %+v", kubeProxy, expected)
+	}
+	if manifest := doc.Components[4]; manifest.Type != "file" || len(manifest.ExternalReferences) != 0 {
+		t.Errorf("unexpected manifest component %+v", manifest)
+	}
+}
+
+func TestResolveImageDigest(t *testing.T) {
+	server := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "http://")
+
+	image := host + "/upstream/app:v1"
+	img, err := random.Image(1024, 1)
+	if err != nil {
+		t.Fatalf("error building image: %v", err)
+	}
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := remote.Write(ref, img); err != nil {
+		t.Fatalf("error pushing image: %v", err)
+	}
+	expected, err := img.Digest()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	digest, err := ResolveImageDigest(context.Background(), image)
+	if err != nil {
+		t.Fatalf("error resolving digest: %v", err)
+	}
+	if digest != expected.String() {
+		t.Errorf("expected digest %q, got %q", expected, digest)
+	}
+
+	if _, err := ResolveImageDigest(context.Background(), host+"/upstream/missing:v1"); err == nil {
+		t.Errorf("expected error resolving missing image")
+	}
+}
@@ -69,7 +69,7 @@ type Clientset interface {
 // Because we want to support storing these directly in a cluster, we don't group them
 type AddonsClient interface {
 	// Replace replaces all the addon objects with the provided list
-	Replace(objects kubemanifest.ObjectList) error
+	Replace(ctx context.Context, objects kubemanifest.ObjectList) error
 
 	// List returns all the addon objects
 	List(ctx context.Context) (kubemanifest.ObjectList, error)
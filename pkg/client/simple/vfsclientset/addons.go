@@ -33,7 +33,8 @@ import (
 )
 
 type vfsAddonsClient struct {
-	basePath vfs.Path
+	basePath   vfs.Path
+	configBase vfs.Path
 
 	clusterName string
 	cluster     *kops.Cluster
@@ -52,15 +53,14 @@ func newAddonsVFS(c *VFSClientset, cluster *kops.Cluster) *vfsAddonsClient {
 		cluster:     cluster,
 		clusterName: clusterName,
 	}
-	r.basePath = c.basePath.Join(clusterName, "clusteraddons")
+	r.configBase = c.basePath.Join(clusterName)
+	r.basePath = r.configBase.Join("clusteraddons")
 
 	return r
 }
 
 // TODO: Offer partial replacement?
-func (c *vfsAddonsClient) Replace(addons kubemanifest.ObjectList) error {
-	ctx := context.TODO()
-
+func (c *vfsAddonsClient) Replace(ctx context.Context, addons kubemanifest.ObjectList) error {
 	for _, addon := range addons {
 		fieldPath := field.NewPath("addons")
 		if kind := addon.Kind(); kind != "" {
@@ -93,6 +93,8 @@ func (c *vfsAddonsClient) Replace(addons kubemanifest.ObjectList) error {
 		return fmt.Errorf("error writing addons file %s: %v", configPath, err)
 	}
 
+	recordHistory(ctx, c.configBase, c.cluster, "replace addons")
+
 	return nil
 }
 
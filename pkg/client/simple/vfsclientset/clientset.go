@@ -36,6 +36,9 @@ import (
 type VFSClientset struct {
 	vfsContext *vfs.VFSContext
 	basePath   vfs.Path
+
+	// cache is an optional on-disk cache for reads from the state store
+	cache *vfs.DiskCache
 }
 
 var _ simple.Clientset = &VFSClientset{}
@@ -44,6 +47,24 @@ func (c *VFSClientset) VFSContext() *vfs.VFSContext {
 	return c.vfsContext
 }
 
+// cached returns the path wrapped in the disk cache, if one is configured.
+func (c *VFSClientset) cached(p vfs.Path) vfs.Path {
+	if c.cache == nil {
+		return p
+	}
+	return c.cache.Wrap(p)
+}
+
+// cachedSecrets returns the path of a keystore or secret store wrapped in the disk cache,
+// if one is configured and it was built to hold private keys and secrets.
+// A custom store path need not be named pki or secrets, so we cannot leave this to the cache.
+func (c *VFSClientset) cachedSecrets(p vfs.Path) vfs.Path {
+	if c.cache == nil || !c.cache.CachesSecrets() {
+		return vfs.Unwrap(p)
+	}
+	return c.cache.Wrap(p)
+}
+
 func (c *VFSClientset) clusters() *ClusterVFS {
 	return newClusterVFS(c.VFSContext(), c.basePath)
 }
@@ -97,11 +118,14 @@ func (c *VFSClientset) SecretStore(cluster *kops.Cluster) (fi.SecretStore, error
 		if err != nil {
 			return nil, err
 		}
-		basedir := configBase.Join("secrets")
+		basedir := c.cachedSecrets(configBase.Join("secrets"))
 		return secrets.NewVFSSecretStore(cluster, basedir), nil
 	} else {
 		storePath, err := c.VFSContext().BuildVfsPath(cluster.Spec.ConfigStore.Secrets)
-		return secrets.NewVFSSecretStore(cluster, storePath), err
+		if err != nil {
+			return nil, err
+		}
+		return secrets.NewVFSSecretStore(cluster, c.cachedSecrets(storePath)), nil
 	}
 }
 
@@ -130,10 +154,13 @@ func (c *VFSClientset) pkiPath(cluster *kops.Cluster) (vfs.Path, error) {
 		if err != nil {
 			return nil, err
 		}
-		return configBase.Join("pki"), nil
+		return c.cachedSecrets(configBase.Join("pki")), nil
 	} else {
 		storePath, err := c.VFSContext().BuildVfsPath(cluster.Spec.ConfigStore.Keypairs)
-		return storePath, err
+		if err != nil {
+			return nil, err
+		}
+		return c.cachedSecrets(storePath), nil
 	}
 }
 
@@ -182,6 +209,9 @@ func DeleteAllClusterState(ctx context.Context, basePath vfs.Path) error {
 		if strings.HasPrefix(relativePath, "backups/") {
 			continue
 		}
+		if strings.HasPrefix(relativePath, registry.PathHistory+"/") {
+			continue
+		}
 
 		return fmt.Errorf("refusing to delete: unknown file found: %s", path)
 	}
@@ -225,7 +255,7 @@ func (c *VFSClientset) DeleteCluster(ctx context.Context, cluster *kops.Cluster)
 		if err != nil {
 			return err
 		}
-		err = path.RemoveAll(ctx)
+		err = c.cached(path).RemoveAll(ctx)
 		if err != nil {
 			return err
 		}
@@ -237,7 +267,7 @@ func (c *VFSClientset) DeleteCluster(ctx context.Context, cluster *kops.Cluster)
 		if err != nil {
 			return err
 		}
-		err = path.RemoveAll(ctx)
+		err = c.cached(path).RemoveAll(ctx)
 		if err != nil {
 			return err
 		}
@@ -248,7 +278,7 @@ func (c *VFSClientset) DeleteCluster(ctx context.Context, cluster *kops.Cluster)
 		return err
 	}
 
-	return DeleteAllClusterState(ctx, configBase)
+	return DeleteAllClusterState(ctx, c.cached(configBase))
 }
 
 func NewVFSClientset(vfsContext *vfs.VFSContext, basePath vfs.Path) simple.Clientset {
@@ -258,3 +288,13 @@ func NewVFSClientset(vfsContext *vfs.VFSContext, basePath vfs.Path) simple.Clien
 	}
 	return vfsClientset
 }
+
+// NewCachedVFSClientset builds a VFS clientset that reads the state store through the on-disk cache.
+func NewCachedVFSClientset(vfsContext *vfs.VFSContext, basePath vfs.Path, cache *vfs.DiskCache) simple.Clientset {
+	vfsClientset := &VFSClientset{
+		vfsContext: vfsContext,
+		basePath:   cache.Wrap(basePath),
+		cache:      cache,
+	}
+	return vfsClientset
+}
@@ -119,6 +119,8 @@ func (r *ClusterVFS) Create(c *api.Cluster) (*api.Cluster, error) {
 		return nil, fmt.Errorf("error writing Cluster %q: %v", c.ObjectMeta.Name, err)
 	}
 
+	recordHistory(ctx, r.basePath.Join(clusterName), c, "create Cluster")
+
 	return c, nil
 }
 
@@ -148,12 +150,14 @@ func (r *ClusterVFS) Update(c *api.Cluster, status *api.ClusterStatus) (*api.Clu
 	}
 
 	if err := r.writeConfig(ctx, c, r.basePath.Join(clusterName, registry.PathCluster), c, vfs.WriteOptionOnlyIfExists); err != nil {
-		if os.IsNotExist(err) {
+		if os.IsNotExist(err) || errors.IsConflict(err) {
 			return nil, err
 		}
 		return nil, fmt.Errorf("error writing Cluster: %v", err)
 	}
 
+	recordHistory(ctx, r.basePath.Join(clusterName), c, "update Cluster")
+
 	return c, nil
 }
 
@@ -19,15 +19,18 @@ package vfsclientset
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"reflect"
 	"sort"
 	"time"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/klog/v2"
 	"k8s.io/kops/pkg/acls"
 	"k8s.io/kops/pkg/apis/kops"
@@ -116,7 +119,7 @@ func (c *VFSClientBase) serialize(o runtime.Object) ([]byte, error) {
 }
 
 func (c *VFSClientBase) readConfig(ctx context.Context, configPath vfs.Path) (runtime.Object, error) {
-	data, err := configPath.ReadFile(ctx)
+	data, version, err := vfs.ReadFileWithVersion(ctx, configPath)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil, err
@@ -128,11 +131,32 @@ func (c *VFSClientBase) readConfig(ctx context.Context, configPath vfs.Path) (ru
 	if err != nil {
 		return nil, fmt.Errorf("error parsing %s: %v", configPath, err)
 	}
+
+	// The ResourceVersion is the version of the stored file, and is used as a precondition for updates
+	objectMeta, err := meta.Accessor(object)
+	if err != nil {
+		return nil, err
+	}
+	objectMeta.SetResourceVersion(version)
+
 	return object, nil
 }
 
+// writeConfig writes the object to configPath.
+// If the object has a ResourceVersion and we are updating an existing file,
+// the write only succeeds if the file has not been changed since the object was read;
+// otherwise a Conflict error is returned.
 func (c *VFSClientBase) writeConfig(ctx context.Context, cluster *kops.Cluster, configPath vfs.Path, o runtime.Object, writeOptions ...vfs.WriteOption) error {
+	objectMeta, err := meta.Accessor(o)
+	if err != nil {
+		return err
+	}
+
+	// The ResourceVersion is never persisted; it is the version of the file when reading
+	resourceVersion := objectMeta.GetResourceVersion()
+	objectMeta.SetResourceVersion("")
 	data, err := c.serialize(o)
+	objectMeta.SetResourceVersion(resourceVersion)
 	if err != nil {
 		return fmt.Errorf("error marshaling object: %v", err)
 	}
@@ -161,8 +185,11 @@ func (c *VFSClientBase) writeConfig(ctx context.Context, cluster *kops.Cluster,
 	}
 
 	rs := bytes.NewReader(data)
+	newVersion := ""
 	if create {
 		err = configPath.CreateFile(ctx, rs, acl)
+	} else if resourceVersion != "" {
+		newVersion, err = vfs.WriteFileIfMatch(ctx, configPath, rs, acl, resourceVersion)
 	} else {
 		err = configPath.WriteFile(ctx, rs, acl)
 	}
@@ -171,8 +198,14 @@ func (c *VFSClientBase) writeConfig(ctx context.Context, cluster *kops.Cluster,
 			klog.Warningf("failed to create file as already exists: %v", configPath)
 			return err
 		}
+		if errors.Is(err, vfs.ErrPreconditionFailed) {
+			return apierrors.NewConflict(schema.GroupResource{Group: kops.GroupName, Resource: c.kind}, objectMeta.GetName(), err)
+		}
 		return fmt.Errorf("error writing configuration file %s: %v", configPath, err)
 	}
+
+	// Only a conditional write tells us the version we wrote; otherwise the next update is unconditional
+	objectMeta.SetResourceVersion(newVersion)
 	return nil
 }
 
@@ -196,6 +229,9 @@ func (c *VFSClientBase) update(ctx context.Context, cluster *kops.Cluster, i run
 
 	err = c.writeConfig(ctx, cluster, c.basePath.Join(objectMeta.GetName()), i, vfs.WriteOptionOnlyIfExists)
 	if err != nil {
+		if apierrors.IsConflict(err) {
+			return err
+		}
 		return fmt.Errorf("error writing %s: %v", c.kind, err)
 	}
 
@@ -0,0 +1,533 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vfsclientset
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/klog/v2"
+	kopsversion "k8s.io/kops"
+	"k8s.io/kops/pkg/acls"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/apis/kops/registry"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+const (
+	// historyRevisionFile holds the metadata for a revision
+	historyRevisionFile = "revision.json"
+	// historyIndexFile records the latest and oldest revisions, so writers need not list the history
+	historyIndexFile = "index.json"
+	// HistoryPathCluster is the path of the cluster object in a revision
+	HistoryPathCluster = "cluster"
+	// HistoryPathInstanceGroups is the path of the instance group objects in a revision
+	HistoryPathInstanceGroups = "instancegroup"
+	// HistoryPathAddons is the path of the addon objects in a revision
+	HistoryPathAddons = "clusteraddons"
+
+	// maxHistoryRecordAttempts bounds how often we retry when another writer claims the same revision number
+	maxHistoryRecordAttempts = 5
+
+	// DefaultHistoryLimit is the number of revisions kept when KOPS_HISTORY_LIMIT is not set
+	DefaultHistoryLimit = 100
+)
+
+// HistoryRevision is the metadata for a snapshot of the cluster configuration.
+type HistoryRevision struct {
+	// Revision is the sequence number of the snapshot, starting at 1
+	Revision int `json:"revision"`
+	// Timestamp is when the snapshot was recorded
+	Timestamp time.Time `json:"timestamp"`
+	// Author identifies the user and host that made the change
+	Author string `json:"author,omitempty"`
+	// KopsVersion is the version of kOps that made the change
+	KopsVersion string `json:"kopsVersion,omitempty"`
+	// CommandLine is the command line of the process that made the change
+	CommandLine []string `json:"commandLine,omitempty"`
+	// Reason describes the write that triggered the snapshot
+	Reason string `json:"reason,omitempty"`
+}
+
+// HistorySnapshot is a recorded revision of the cluster configuration.
+type HistorySnapshot struct {
+	HistoryRevision
+
+	// Files holds the contents of the state store files, keyed by their path relative to the revision
+	Files map[string][]byte
+}
+
+// Render returns the files of the snapshot as a single document, in a stable order suitable for diffing.
+func (s *HistorySnapshot) Render() string {
+	keys := make([]string, 0, len(s.Files))
+	for k := range s.Files {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, k := range keys {
+		b.WriteString("# " + k + "\n")
+		b.Write(s.Files[k])
+		if !bytes.HasSuffix(s.Files[k], []byte("\n")) {
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+// historyIndex is the contents of the history index file.
+type historyIndex struct {
+	// Latest is the most recently claimed revision number
+	Latest int `json:"latest"`
+	// Oldest is the oldest revision that has not been pruned
+	Oldest int `json:"oldest"`
+}
+
+// History gives access to the configuration history of a cluster.
+// A snapshot of the cluster, its instance groups and its addons is appended
+// every time one of them is written through the VFS clientset.
+type History struct {
+	configBase vfs.Path
+	cluster    *kops.Cluster
+
+	// limit is the number of revisions to keep; older revisions are pruned when a new one is recorded.
+	// Zero means that no revisions are pruned.
+	limit int
+}
+
+// NewHistory returns the History for the cluster stored at configBase.
+// The number of kept revisions is read from KOPS_HISTORY_LIMIT, defaulting to DefaultHistoryLimit.
+func NewHistory(configBase vfs.Path, cluster *kops.Cluster) *History {
+	return &History{
+		configBase: configBase,
+		cluster:    cluster,
+		limit:      historyLimitFromEnv(),
+	}
+}
+
+// historyLimitFromEnv returns the number of revisions to keep, as configured by KOPS_HISTORY_LIMIT.
+func historyLimitFromEnv() int {
+	s := os.Getenv("KOPS_HISTORY_LIMIT")
+	if s == "" {
+		return DefaultHistoryLimit
+	}
+	limit, err := strconv.Atoi(s)
+	if err != nil || limit < 0 {
+		klog.Warningf("ignoring invalid KOPS_HISTORY_LIMIT %q, keeping %d revisions", s, DefaultHistoryLimit)
+		return DefaultHistoryLimit
+	}
+	return limit
+}
+
+func (h *History) historyPath() vfs.Path {
+	return h.configBase.Join(registry.PathHistory)
+}
+
+func (h *History) revisionPath(revision int) vfs.Path {
+	return h.historyPath().Join(fmt.Sprintf("%08d", revision))
+}
+
+// List returns the metadata of all recorded revisions, oldest first.
+func (h *History) List(ctx context.Context) ([]*HistoryRevision, error) {
+	revisions, err := h.listRevisionNumbers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []*HistoryRevision
+	for _, revision := range revisions {
+		item, err := h.readRevision(ctx, revision)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// Get returns the snapshot for the given revision.
+func (h *History) Get(ctx context.Context, revision int) (*HistorySnapshot, error) {
+	meta, err := h.readRevision(ctx, revision)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("revision %d not found in history", revision)
+		}
+		return nil, err
+	}
+
+	basePath := h.revisionPath(revision)
+	paths, err := basePath.ReadTree(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error listing files in %s: %w", basePath, err)
+	}
+
+	snapshot := &HistorySnapshot{
+		HistoryRevision: *meta,
+		Files:           make(map[string][]byte),
+	}
+	for _, p := range paths {
+		relativePath, err := vfs.RelativePath(basePath, p)
+		if err != nil {
+			return nil, err
+		}
+		if relativePath == historyRevisionFile {
+			continue
+		}
+		data, err := p.ReadFile(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("error reading %s: %w", p, err)
+		}
+		snapshot.Files[relativePath] = data
+	}
+
+	return snapshot, nil
+}
+
+// Current returns a snapshot of the current configuration, in the same form as a recorded revision.
+func (h *History) Current(ctx context.Context) (*HistorySnapshot, error) {
+	files, err := h.readCurrentFiles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &HistorySnapshot{Files: files}, nil
+}
+
+// readCurrentFiles reads the files that make up the cluster configuration.
+func (h *History) readCurrentFiles(ctx context.Context) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+
+	clusterData, err := h.configBase.Join(registry.PathCluster).ReadFile(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error reading cluster configuration: %w", err)
+	}
+	files[HistoryPathCluster] = clusterData
+
+	for _, dir := range []string{HistoryPathInstanceGroups, HistoryPathAddons} {
+		basePath := h.configBase.Join(dir)
+		paths, err := basePath.ReadTree(ctx)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, fmt.Errorf("error listing files in %s: %w", basePath, err)
+		}
+		for _, p := range paths {
+			relativePath, err := vfs.RelativePath(h.configBase, p)
+			if err != nil {
+				return nil, err
+			}
+			data, err := p.ReadFile(ctx)
+			if err != nil {
+				if os.IsNotExist(err) {
+					continue
+				}
+				return nil, fmt.Errorf("error reading %s: %w", p, err)
+			}
+			files[relativePath] = data
+		}
+	}
+
+	return files, nil
+}
+
+func (h *History) readRevision(ctx context.Context, revision int) (*HistoryRevision, error) {
+	p := h.revisionPath(revision).Join(historyRevisionFile)
+	data, err := p.ReadFile(ctx)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("error reading %s: %w", p, err)
+	}
+
+	meta := &HistoryRevision{}
+	if err := json.Unmarshal(data, meta); err != nil {
+		return nil, fmt.Errorf("error parsing %s: %w", p, err)
+	}
+	return meta, nil
+}
+
+// listRevisionNumbers returns the revision numbers found in the state store, in ascending order.
+func (h *History) listRevisionNumbers(ctx context.Context) ([]int, error) {
+	basePath := h.historyPath()
+	paths, err := basePath.ReadTree(ctx)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error listing history in %s: %w", basePath, err)
+	}
+
+	seen := make(map[int]bool)
+	for _, p := range paths {
+		relativePath, err := vfs.RelativePath(basePath, p)
+		if err != nil {
+			return nil, err
+		}
+		tokens := strings.SplitN(relativePath, "/", 2)
+		if len(tokens) != 2 || tokens[1] != historyRevisionFile {
+			continue
+		}
+		revision, err := strconv.Atoi(tokens[0])
+		if err != nil {
+			klog.Warningf("ignoring unexpected file in history: %s", p)
+			continue
+		}
+		seen[revision] = true
+	}
+
+	var revisions []int
+	for revision := range seen {
+		revisions = append(revisions, revision)
+	}
+	sort.Ints(revisions)
+	return revisions, nil
+}
+
+// Record appends a snapshot of the current cluster configuration to the history,
+// and prunes the revisions that exceed the history limit.
+func (h *History) Record(ctx context.Context, reason string) error {
+	files, err := h.readCurrentFiles(ctx)
+	if err != nil {
+		return err
+	}
+
+	meta := HistoryRevision{
+		Timestamp:   time.Now().UTC(),
+		Author:      historyAuthor(),
+		KopsVersion: kopsversion.Version,
+		CommandLine: historyCommandLine(os.Args),
+		Reason:      reason,
+	}
+
+	// We claim a revision number by advancing the index, then create its metadata file;
+	// if another writer got there first (for example with a history written before the index existed) we try the next one
+	var revisionPath vfs.Path
+	var pruneFrom, pruneTo int
+	for attempt := 0; ; attempt++ {
+		previous, index, err := h.claimRevision(ctx)
+		if err != nil {
+			return err
+		}
+		meta.Revision = index.Latest
+		if attempt == 0 {
+			pruneFrom = max(previous.Oldest, 1)
+		}
+		pruneTo = index.Oldest
+
+		data, err := json.MarshalIndent(meta, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error serializing revision metadata: %w", err)
+		}
+
+		revisionPath = h.revisionPath(meta.Revision)
+		err = h.writeFile(ctx, revisionPath.Join(historyRevisionFile), data, true)
+		if err == nil {
+			break
+		}
+		if !os.IsExist(err) || attempt >= maxHistoryRecordAttempts {
+			return err
+		}
+	}
+
+	keys := make([]string, 0, len(files))
+	for k := range files {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if err := h.writeFile(ctx, revisionPath.Join(k), files[k], false); err != nil {
+			return err
+		}
+	}
+
+	klog.V(2).Infof("recorded revision %d of cluster %q in history", meta.Revision, h.cluster.Name)
+
+	// The revision is recorded, so a failure to prune is only logged; the next Record will not retry it.
+	for revision := pruneFrom; revision < pruneTo; revision++ {
+		if err := h.removeRevision(ctx, revision); err != nil {
+			klog.Warningf("unable to prune revision %d of cluster %q from history: %v", revision, h.cluster.Name, err)
+		}
+	}
+	return nil
+}
+
+// claimRevision advances the history index by one revision, returning the index before and after the change.
+// The oldest revision in the new index is advanced to respect the history limit; the caller removes the revisions in between.
+func (h *History) claimRevision(ctx context.Context) (*historyIndex, *historyIndex, error) {
+	p := h.historyPath().Join(historyIndexFile)
+	acl, err := acls.GetACL(ctx, p, h.cluster)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for attempt := 0; ; attempt++ {
+		previous := &historyIndex{}
+		data, version, err := vfs.ReadFileWithVersion(ctx, p)
+		if err == nil {
+			if err := json.Unmarshal(data, previous); err != nil {
+				return nil, nil, fmt.Errorf("error parsing %s: %w", p, err)
+			}
+		} else if os.IsNotExist(err) {
+			// Histories recorded before the index was introduced are listed once to build it
+			revisions, err := h.listRevisionNumbers(ctx)
+			if err != nil {
+				return nil, nil, err
+			}
+			if len(revisions) != 0 {
+				previous.Oldest = revisions[0]
+				previous.Latest = revisions[len(revisions)-1]
+			}
+			version = ""
+		} else {
+			return nil, nil, fmt.Errorf("error reading %s: %w", p, err)
+		}
+
+		index := &historyIndex{
+			Latest: previous.Latest + 1,
+			Oldest: previous.Oldest,
+		}
+		if index.Oldest == 0 {
+			index.Oldest = index.Latest
+		}
+		if h.limit > 0 && index.Latest-index.Oldest >= h.limit {
+			index.Oldest = index.Latest - h.limit + 1
+		}
+
+		data, err = json.Marshal(index)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error serializing history index: %w", err)
+		}
+		if version == "" {
+			err = p.CreateFile(ctx, bytes.NewReader(data), acl)
+		} else {
+			_, err = vfs.WriteFileIfMatch(ctx, p, bytes.NewReader(data), acl, version)
+		}
+		if err == nil {
+			return previous, index, nil
+		}
+		if !(os.IsExist(err) || errors.Is(err, vfs.ErrPreconditionFailed)) || attempt >= maxHistoryRecordAttempts {
+			return nil, nil, fmt.Errorf("error writing %s: %w", p, err)
+		}
+	}
+}
+
+// removeRevision deletes a revision from the history.
+// The metadata file is removed first, so that readers never see a partially removed revision.
+func (h *History) removeRevision(ctx context.Context, revision int) error {
+	basePath := h.revisionPath(revision)
+	if err := basePath.Join(historyRevisionFile).Remove(ctx); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	return basePath.RemoveAll(ctx)
+}
+
+func (h *History) writeFile(ctx context.Context, p vfs.Path, data []byte, create bool) error {
+	acl, err := acls.GetACL(ctx, p, h.cluster)
+	if err != nil {
+		return err
+	}
+
+	if create {
+		err = p.CreateFile(ctx, bytes.NewReader(data), acl)
+	} else {
+		err = p.WriteFile(ctx, bytes.NewReader(data), acl)
+	}
+	if err != nil {
+		if create && os.IsExist(err) {
+			return err
+		}
+		return fmt.Errorf("error writing %s: %w", p, err)
+	}
+	return nil
+}
+
+// historyCommandLine returns the command line to record for a revision.
+// Flag values may hold credentials and are not stored in the state store, so we keep only
+// the command name, the subcommands and positional arguments before the first flag, and the names of the flags.
+func historyCommandLine(args []string) []string {
+	if len(args) == 0 {
+		return nil
+	}
+
+	commandLine := []string{filepath.Base(args[0])}
+	flags := false
+	for _, arg := range args[1:] {
+		if arg == "--" {
+			break
+		}
+		if strings.HasPrefix(arg, "-") {
+			flags = true
+			name, _, _ := strings.Cut(arg, "=")
+			commandLine = append(commandLine, name)
+			continue
+		}
+		if !flags {
+			commandLine = append(commandLine, arg)
+		}
+	}
+	return commandLine
+}
+
+// historyAuthor returns a description of the user making the change.
+func historyAuthor() string {
+	author := ""
+	if u, err := user.Current(); err == nil {
+		author = u.Username
+	}
+	if hostname, err := os.Hostname(); err == nil {
+		author += "@" + hostname
+	}
+	return author
+}
+
+// skipHistoryKey is the context key set by WithoutHistory.
+type skipHistoryKey struct{}
+
+// WithoutHistory returns a context in which writes through the clientset do not record a revision each.
+// It is used when several writes form one logical change; the caller then records a single revision with History.Record.
+func WithoutHistory(ctx context.Context) context.Context {
+	return context.WithValue(ctx, skipHistoryKey{}, true)
+}
+
+// recordHistory appends a snapshot to the history after a successful write.
+// The write has already happened, so a failure to record is logged rather than returned.
+func recordHistory(ctx context.Context, configBase vfs.Path, cluster *kops.Cluster, reason string) {
+	if skip, _ := ctx.Value(skipHistoryKey{}).(bool); skip {
+		return
+	}
+	if err := NewHistory(configBase, cluster).Record(ctx, reason); err != nil {
+		klog.Warningf("unable to record change to cluster %q in state store history: %v", cluster.Name, err)
+	}
+}
@@ -0,0 +1,264 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vfsclientset
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+func TestHistoryRecordsInstanceGroupChanges(t *testing.T) {
+	ctx := context.TODO()
+
+	vfs.Context.ResetMemfsContext(true)
+	basePath, err := vfs.Context.BuildVfsPath("memfs://state")
+	if err != nil {
+		t.Fatalf("error building path: %v", err)
+	}
+	clientset := &VFSClientset{
+		vfsContext: vfs.Context,
+		basePath:   basePath,
+	}
+
+	cluster := &kops.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test.k8s.local"},
+	}
+	configBase := basePath.Join(cluster.Name)
+	if err := configBase.Join("config").WriteFile(ctx, bytes.NewReader([]byte("kind: Cluster\n")), nil); err != nil {
+		t.Fatalf("error writing cluster config: %v", err)
+	}
+
+	igs := clientset.InstanceGroupsFor(cluster)
+	ig := &kops.InstanceGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "nodes"},
+		Spec: kops.InstanceGroupSpec{
+			Role:        kops.InstanceGroupRoleNode,
+			Subnets:     []string{"subnet-1"},
+			MachineType: "m5.large",
+		},
+	}
+	ig, err = igs.Create(ctx, ig, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("error creating instance group: %v", err)
+	}
+	ig.Spec.MachineType = "m5.xlarge"
+	if _, err := igs.Update(ctx, ig, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error updating instance group: %v", err)
+	}
+
+	history := NewHistory(configBase, cluster)
+	revisions, err := history.List(ctx)
+	if err != nil {
+		t.Fatalf("error listing history: %v", err)
+	}
+	if len(revisions) != 2 {
+		t.Fatalf("expected 2 revisions, got %d", len(revisions))
+	}
+	for i, revision := range revisions {
+		if revision.Revision != i+1 {
+			t.Errorf("expected revision %d, got %d", i+1, revision.Revision)
+		}
+		if revision.KopsVersion == "" {
+			t.Errorf("expected kops version to be recorded in revision %d", revision.Revision)
+		}
+	}
+	if revisions[1].Reason != `update InstanceGroup "nodes"` {
+		t.Errorf("unexpected reason %q", revisions[1].Reason)
+	}
+
+	first, err := history.Get(ctx, 1)
+	if err != nil {
+		t.Fatalf("error reading revision 1: %v", err)
+	}
+	if _, found := first.Files[HistoryPathCluster]; !found {
+		t.Errorf("expected cluster in revision 1, got %v", first.Files)
+	}
+	igData := string(first.Files[HistoryPathInstanceGroups+"/nodes"])
+	if !strings.Contains(igData, "machineType: m5.large") {
+		t.Errorf("expected original machine type in revision 1, got %q", igData)
+	}
+
+	current, err := history.Current(ctx)
+	if err != nil {
+		t.Fatalf("error reading current configuration: %v", err)
+	}
+	second, err := history.Get(ctx, 2)
+	if err != nil {
+		t.Fatalf("error reading revision 2: %v", err)
+	}
+	if current.Render() != second.Render() {
+		t.Errorf("expected latest revision to match current configuration")
+	}
+	if first.Render() == second.Render() {
+		t.Errorf("expected revisions 1 and 2 to differ")
+	}
+
+	// The history must not be mistaken for a cluster
+	clusters, err := clientset.clusters().listNames(ctx)
+	if err != nil {
+		t.Fatalf("error listing clusters: %v", err)
+	}
+	if len(clusters) != 1 || clusters[0] != cluster.Name {
+		t.Errorf("unexpected clusters %v", clusters)
+	}
+}
+
+func TestHistoryWithoutHistoryRecordsOneRevision(t *testing.T) {
+	ctx := context.TODO()
+
+	vfs.Context.ResetMemfsContext(true)
+	basePath, err := vfs.Context.BuildVfsPath("memfs://state")
+	if err != nil {
+		t.Fatalf("error building path: %v", err)
+	}
+	clientset := &VFSClientset{
+		vfsContext: vfs.Context,
+		basePath:   basePath,
+	}
+
+	cluster := &kops.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test.k8s.local"},
+	}
+	configBase := basePath.Join(cluster.Name)
+	if err := configBase.Join("config").WriteFile(ctx, bytes.NewReader([]byte("kind: Cluster\n")), nil); err != nil {
+		t.Fatalf("error writing cluster config: %v", err)
+	}
+
+	// Several writes made as one change are not recorded individually
+	batchCtx := WithoutHistory(ctx)
+	igs := clientset.InstanceGroupsFor(cluster)
+	for _, name := range []string{"nodes-a", "nodes-b"} {
+		ig := &kops.InstanceGroup{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: kops.InstanceGroupSpec{
+				Role:        kops.InstanceGroupRoleNode,
+				Subnets:     []string{"subnet-1"},
+				MachineType: "m5.large",
+			},
+		}
+		if _, err := igs.Create(batchCtx, ig, metav1.CreateOptions{}); err != nil {
+			t.Fatalf("error creating instance group: %v", err)
+		}
+	}
+
+	history := NewHistory(configBase, cluster)
+	revisions, err := history.List(ctx)
+	if err != nil {
+		t.Fatalf("error listing history: %v", err)
+	}
+	if len(revisions) != 0 {
+		t.Fatalf("expected no revisions, got %d", len(revisions))
+	}
+
+	if err := history.Record(ctx, "rollback to revision 1"); err != nil {
+		t.Fatalf("error recording revision: %v", err)
+	}
+	revisions, err = history.List(ctx)
+	if err != nil {
+		t.Fatalf("error listing history: %v", err)
+	}
+	if len(revisions) != 1 {
+		t.Fatalf("expected 1 revision, got %d", len(revisions))
+	}
+	snapshot, err := history.Get(ctx, 1)
+	if err != nil {
+		t.Fatalf("error reading revision 1: %v", err)
+	}
+	for _, name := range []string{"nodes-a", "nodes-b"} {
+		if _, found := snapshot.Files[HistoryPathInstanceGroups+"/"+name]; !found {
+			t.Errorf("expected InstanceGroup %q in revision 1, got %v", name, snapshot.Files)
+		}
+	}
+}
+
+func TestHistoryPrunesToLimit(t *testing.T) {
+	ctx := context.TODO()
+
+	vfs.Context.ResetMemfsContext(true)
+	basePath, err := vfs.Context.BuildVfsPath("memfs://state")
+	if err != nil {
+		t.Fatalf("error building path: %v", err)
+	}
+
+	cluster := &kops.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test.k8s.local"},
+	}
+	configBase := basePath.Join(cluster.Name)
+	if err := configBase.Join("config").WriteFile(ctx, bytes.NewReader([]byte("kind: Cluster\n")), nil); err != nil {
+		t.Fatalf("error writing cluster config: %v", err)
+	}
+
+	history := NewHistory(configBase, cluster)
+	history.limit = 3
+	for i := 0; i < 5; i++ {
+		if err := history.Record(ctx, "update Cluster"); err != nil {
+			t.Fatalf("error recording revision: %v", err)
+		}
+	}
+
+	revisions, err := history.List(ctx)
+	if err != nil {
+		t.Fatalf("error listing history: %v", err)
+	}
+	var numbers []int
+	for _, revision := range revisions {
+		numbers = append(numbers, revision.Revision)
+	}
+	if !reflect.DeepEqual(numbers, []int{3, 4, 5}) {
+		t.Errorf("expected revisions [3 4 5], got %v", numbers)
+	}
+	if _, err := history.Get(ctx, 2); err == nil {
+		t.Errorf("expected revision 2 to be pruned")
+	}
+	if _, err := configBase.Join("history", "00000002", HistoryPathCluster).ReadFile(ctx); !os.IsNotExist(err) {
+		t.Errorf("expected files of revision 2 to be removed, got %v", err)
+	}
+}
+
+func TestHistoryCommandLine(t *testing.T) {
+	grid := []struct {
+		args     []string
+		expected []string
+	}{
+		{
+			args:     []string{"/usr/local/bin/kops", "edit", "cluster", "--name", "test.k8s.local"},
+			expected: []string{"kops", "edit", "cluster", "--name"},
+		},
+		{
+			args:     []string{"kops", "create", "secret", "sshpublickey", "--ssh-public-key=~/.ssh/id_rsa.pub", "-v", "2"},
+			expected: []string{"kops", "create", "secret", "sshpublickey", "--ssh-public-key", "-v"},
+		},
+		{
+			args:     []string{"kops", "update", "cluster", "--yes", "test.k8s.local", "--", "secret"},
+			expected: []string{"kops", "update", "cluster", "--yes"},
+		},
+	}
+	for _, g := range grid {
+		actual := historyCommandLine(g.args)
+		if !reflect.DeepEqual(actual, g.expected) {
+			t.Errorf("historyCommandLine(%v): expected %v, got %v", g.args, g.expected, actual)
+		}
+	}
+}
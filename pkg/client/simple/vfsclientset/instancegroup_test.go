@@ -0,0 +1,102 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vfsclientset
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+func TestInstanceGroupUpdateConflict(t *testing.T) {
+	ctx := context.TODO()
+
+	vfs.Context.ResetMemfsContext(true)
+	basePath, err := vfs.Context.BuildVfsPath("memfs://state")
+	if err != nil {
+		t.Fatalf("error building path: %v", err)
+	}
+	clientset := &VFSClientset{
+		vfsContext: vfs.Context,
+		basePath:   basePath,
+	}
+
+	cluster := &kops.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test.k8s.local"},
+	}
+	igs := clientset.InstanceGroupsFor(cluster)
+
+	ig := &kops.InstanceGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "nodes"},
+		Spec: kops.InstanceGroupSpec{
+			Role:    kops.InstanceGroupRoleNode,
+			Subnets: []string{"subnet-1"},
+		},
+	}
+	if _, err := igs.Create(ctx, ig, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error creating instance group: %v", err)
+	}
+
+	first, err := igs.Get(ctx, "nodes", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("error reading instance group: %v", err)
+	}
+	if first.ResourceVersion == "" {
+		t.Fatalf("expected ResourceVersion to be set")
+	}
+	second, err := igs.Get(ctx, "nodes", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("error reading instance group: %v", err)
+	}
+
+	first.Spec.MachineType = "m5.large"
+	updated, err := igs.Update(ctx, first, metav1.UpdateOptions{})
+	if err != nil {
+		t.Fatalf("error updating instance group: %v", err)
+	}
+
+	// A second update of the object we wrote should succeed, because the ResourceVersion was refreshed
+	updated.Spec.MachineType = "m5.xlarge"
+	if _, err := igs.Update(ctx, updated, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error updating instance group again: %v", err)
+	}
+
+	// An update of a stale copy should fail with a Conflict
+	second.Spec.MachineType = "m5.2xlarge"
+	_, err = igs.Update(ctx, second, metav1.UpdateOptions{})
+	if !errors.IsConflict(err) {
+		t.Fatalf("expected Conflict error updating stale instance group, got %v", err)
+	}
+
+	// Without a ResourceVersion, the update is unconditional
+	second.ResourceVersion = ""
+	if _, err := igs.Update(ctx, second, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error updating instance group without ResourceVersion: %v", err)
+	}
+
+	actual, err := igs.Get(ctx, "nodes", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("error reading instance group: %v", err)
+	}
+	if actual.Spec.MachineType != "m5.2xlarge" {
+		t.Errorf("unexpected MachineType %q", actual.Spec.MachineType)
+	}
+}
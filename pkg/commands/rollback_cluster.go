@@ -0,0 +1,165 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/client/simple"
+	"k8s.io/kops/pkg/client/simple/vfsclientset"
+	"k8s.io/kops/pkg/kopscodecs"
+	"k8s.io/kops/pkg/kubemanifest"
+)
+
+// RollbackCluster restores the cluster, its instance groups and its addons to the state recorded in snapshot.
+// The writes go through the clientset, so they are validated; the rollback as a whole is recorded as a single revision in the history.
+func RollbackCluster(ctx context.Context, clientset simple.Clientset, current *kops.Cluster, snapshot *vfsclientset.HistorySnapshot) error {
+	clusterData, found := snapshot.Files[vfsclientset.HistoryPathCluster]
+	if !found {
+		return fmt.Errorf("revision %d does not contain a cluster", snapshot.Revision)
+	}
+	obj, _, err := kopscodecs.Decode(clusterData, nil)
+	if err != nil {
+		return fmt.Errorf("error parsing cluster in revision %d: %w", snapshot.Revision, err)
+	}
+	cluster, ok := obj.(*kops.Cluster)
+	if !ok {
+		return fmt.Errorf("unexpected object type %T for cluster in revision %d", obj, snapshot.Revision)
+	}
+	if cluster.Name != current.Name {
+		return fmt.Errorf("revision %d is for cluster %q, not %q", snapshot.Revision, cluster.Name, current.Name)
+	}
+	// Fail rather than overwrite a concurrent change
+	cluster.ResourceVersion = current.ResourceVersion
+	if cluster.Spec.ConfigStore.Base == "" {
+		cluster.Spec.ConfigStore.Base = current.Spec.ConfigStore.Base
+	}
+
+	var instanceGroups []*kops.InstanceGroup
+	var addons kubemanifest.ObjectList
+	for k, data := range snapshot.Files {
+		switch {
+		case strings.HasPrefix(k, vfsclientset.HistoryPathInstanceGroups+"/"):
+			obj, _, err := kopscodecs.Decode(data, nil)
+			if err != nil {
+				return fmt.Errorf("error parsing %s in revision %d: %w", k, snapshot.Revision, err)
+			}
+			ig, ok := obj.(*kops.InstanceGroup)
+			if !ok {
+				return fmt.Errorf("unexpected object type %T for %s in revision %d", obj, k, snapshot.Revision)
+			}
+			instanceGroups = append(instanceGroups, ig)
+
+		case strings.HasPrefix(k, vfsclientset.HistoryPathAddons+"/"):
+			objects, err := kubemanifest.LoadObjectsFrom(data)
+			if err != nil {
+				return fmt.Errorf("error parsing %s in revision %d: %w", k, snapshot.Revision, err)
+			}
+			addons = append(addons, objects...)
+		}
+	}
+	sort.Slice(instanceGroups, func(i, j int) bool {
+		return instanceGroups[i].Name < instanceGroups[j].Name
+	})
+
+	configBase, err := clientset.ConfigBaseFor(current)
+	if err != nil {
+		return err
+	}
+
+	history := vfsclientset.NewHistory(configBase, cluster)
+	before, err := history.Current(ctx)
+	if err != nil {
+		return err
+	}
+
+	restoreErr := restoreSnapshot(vfsclientset.WithoutHistory(ctx), clientset, cluster, instanceGroups, addons, snapshot.Revision)
+
+	// Record whatever was written, even if the rollback stopped part way, so the history matches the state store
+	after, err := history.Current(ctx)
+	if err != nil {
+		klog.Warningf("unable to read state of cluster %q after rollback: %v", cluster.Name, err)
+	} else if after.Render() != before.Render() {
+		reason := fmt.Sprintf("rollback to revision %d", snapshot.Revision)
+		if restoreErr != nil {
+			reason = fmt.Sprintf("incomplete rollback to revision %d", snapshot.Revision)
+		}
+		if err := history.Record(ctx, reason); err != nil {
+			klog.Warningf("unable to record rollback of cluster %q in state store history: %v", cluster.Name, err)
+		}
+	}
+
+	return restoreErr
+}
+
+// restoreSnapshot writes the cluster, instance groups and addons of a revision through the clientset.
+func restoreSnapshot(ctx context.Context, clientset simple.Clientset, cluster *kops.Cluster, instanceGroups []*kops.InstanceGroup, addons kubemanifest.ObjectList, revision int) error {
+	if err := UpdateCluster(ctx, clientset, cluster, instanceGroups); err != nil {
+		return fmt.Errorf("error restoring cluster: %w", err)
+	}
+
+	igClient := clientset.InstanceGroupsFor(cluster)
+	existing, err := igClient.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("error listing instance groups: %w", err)
+	}
+	existingByName := make(map[string]*kops.InstanceGroup)
+	for i := range existing.Items {
+		existingByName[existing.Items[i].Name] = &existing.Items[i]
+	}
+
+	for _, ig := range instanceGroups {
+		if old := existingByName[ig.Name]; old != nil {
+			ig.ResourceVersion = old.ResourceVersion
+			klog.Infof("restoring InstanceGroup %q", ig.Name)
+			if _, err := igClient.Update(ctx, ig, metav1.UpdateOptions{}); err != nil {
+				return fmt.Errorf("error restoring InstanceGroup %q: %w", ig.Name, err)
+			}
+			delete(existingByName, ig.Name)
+		} else {
+			ig.ResourceVersion = ""
+			klog.Infof("recreating InstanceGroup %q", ig.Name)
+			if _, err := igClient.Create(ctx, ig, metav1.CreateOptions{}); err != nil {
+				return fmt.Errorf("error recreating InstanceGroup %q: %w", ig.Name, err)
+			}
+		}
+	}
+	for name := range existingByName {
+		klog.Infof("deleting InstanceGroup %q, which did not exist in revision %d", name, revision)
+		if err := igClient.Delete(ctx, name, metav1.DeleteOptions{}); err != nil {
+			return fmt.Errorf("error deleting InstanceGroup %q: %w", name, err)
+		}
+	}
+
+	currentAddons, err := clientset.AddonsFor(cluster).List(ctx)
+	if err != nil {
+		return fmt.Errorf("error listing addons: %w", err)
+	}
+	if len(addons) != 0 || len(currentAddons) != 0 {
+		if err := clientset.AddonsFor(cluster).Replace(ctx, addons); err != nil {
+			return fmt.Errorf("error restoring addons: %w", err)
+		}
+	}
+
+	return nil
+}
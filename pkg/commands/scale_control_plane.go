@@ -0,0 +1,294 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/apis/kops/model"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup"
+)
+
+// ControlPlaneScalePlan describes the changes needed to change the number of control-plane nodes.
+// Each control-plane instance group runs one node, with one member of each etcd cluster.
+type ControlPlaneScalePlan struct {
+	// Cluster is a copy of the cluster, with the etcd members of the target control plane
+	Cluster *kops.Cluster
+	// Current is the current number of control-plane nodes
+	Current int
+	// Replicas is the target number of control-plane nodes
+	Replicas int
+	// AddInstanceGroups are the control-plane instance groups to create
+	AddInstanceGroups []*kops.InstanceGroup
+	// RemoveInstanceGroups are the control-plane instance groups to delete
+	RemoveInstanceGroups []*kops.InstanceGroup
+	// Warnings are concerns about the target control plane that do not prevent scaling
+	Warnings []string
+}
+
+// ScaleUp returns true if control-plane nodes are added.
+func (p *ControlPlaneScalePlan) ScaleUp() bool {
+	return p.Replicas > p.Current
+}
+
+// KeepsQuorum returns true if the etcd clusters keep quorum while the removed control-plane nodes are deleted,
+// i.e. the remaining members are a majority of the current members.
+func (p *ControlPlaneScalePlan) KeepsQuorum() bool {
+	return p.Replicas > p.Current/2
+}
+
+// RemainingInstanceGroups returns the instance groups that are not removed by the plan.
+func (p *ControlPlaneScalePlan) RemainingInstanceGroups(instanceGroups []*kops.InstanceGroup) []*kops.InstanceGroup {
+	removed := make(map[string]bool)
+	for _, ig := range p.RemoveInstanceGroups {
+		removed[ig.Name] = true
+	}
+	var remaining []*kops.InstanceGroup
+	for _, ig := range instanceGroups {
+		if !removed[ig.Name] {
+			remaining = append(remaining, ig)
+		}
+	}
+	return remaining
+}
+
+// PlanControlPlaneScale plans the instance groups and etcd members for running replicas control-plane nodes.
+// New instance groups are copies of an existing control-plane instance group, placed round-robin in zones if given,
+// otherwise in the zones with the fewest control-plane nodes.
+// Instance groups are removed in reverse name order.
+func PlanControlPlaneScale(cluster *kops.Cluster, instanceGroups []*kops.InstanceGroup, replicas int, zones []string) (*ControlPlaneScalePlan, error) {
+	if replicas < 1 || replicas%2 == 0 {
+		return nil, fmt.Errorf("the number of control-plane nodes must be odd (1, 3, 5...) for etcd quorum, got %d", replicas)
+	}
+
+	var controlPlanes []*kops.InstanceGroup
+	for _, ig := range instanceGroups {
+		if ig.Spec.Role != kops.InstanceGroupRoleControlPlane {
+			continue
+		}
+		if fi.ValueOf(ig.Spec.MinSize) != 1 || fi.ValueOf(ig.Spec.MaxSize) != 1 {
+			return nil, fmt.Errorf("control-plane instance group %q does not have exactly one node; scaling is only supported with one node per control-plane instance group", ig.Name)
+		}
+		controlPlanes = append(controlPlanes, ig)
+	}
+	if len(controlPlanes) == 0 {
+		return nil, fmt.Errorf("no control-plane instance groups found")
+	}
+	sort.Slice(controlPlanes, func(i, j int) bool { return controlPlanes[i].Name < controlPlanes[j].Name })
+
+	controlPlaneNames := sets.New[string]()
+	for _, ig := range controlPlanes {
+		controlPlaneNames.Insert(ig.Name)
+	}
+	for _, etcdCluster := range cluster.Spec.EtcdClusters {
+		memberIGs := sets.New[string]()
+		for _, member := range etcdCluster.Members {
+			memberIGs.Insert(fi.ValueOf(member.InstanceGroup))
+		}
+		if !memberIGs.Equal(controlPlaneNames) || len(etcdCluster.Members) != len(controlPlanes) {
+			return nil, fmt.Errorf("etcd cluster %q does not have exactly one member in each control-plane instance group", etcdCluster.Name)
+		}
+	}
+
+	plan := &ControlPlaneScalePlan{
+		Cluster:  cluster.DeepCopy(),
+		Current:  len(controlPlanes),
+		Replicas: replicas,
+	}
+	switch {
+	case replicas == plan.Current:
+		return nil, fmt.Errorf("the cluster already has %d control-plane nodes", replicas)
+	case replicas > plan.Current:
+		if err := plan.addControlPlanes(controlPlanes, instanceGroups, zones); err != nil {
+			return nil, err
+		}
+	default:
+		if len(zones) != 0 {
+			return nil, fmt.Errorf("zones can only be specified when adding control-plane nodes")
+		}
+		plan.removeControlPlanes(controlPlanes)
+	}
+	return plan, nil
+}
+
+func (p *ControlPlaneScalePlan) addControlPlanes(controlPlanes []*kops.InstanceGroup, instanceGroups []*kops.InstanceGroup, zones []string) error {
+	cluster := p.Cluster
+	template := controlPlanes[0]
+
+	if len(template.Spec.Subnets) != 1 {
+		return fmt.Errorf("control-plane instance group %q must have exactly one subnet to be used as a template", template.Name)
+	}
+	templateSubnet := model.FindSubnet(cluster, template.Spec.Subnets[0])
+	if templateSubnet == nil {
+		return fmt.Errorf("subnet %q of instance group %q not found in cluster", template.Spec.Subnets[0], template.Name)
+	}
+
+	// Instance groups either declare their zone (e.g. GCE, where subnets are regional), or get it from their subnet
+	zoneSubnets := make(map[string]string)
+	if len(template.Spec.Zones) != 0 {
+		for _, ig := range instanceGroups {
+			for _, zone := range ig.Spec.Zones {
+				zoneSubnets[zone] = templateSubnet.Name
+			}
+		}
+	} else {
+		for _, subnet := range cluster.Spec.Networking.Subnets {
+			if subnet.Type == templateSubnet.Type && subnet.Zone != "" {
+				if _, found := zoneSubnets[subnet.Zone]; !found {
+					zoneSubnets[subnet.Zone] = subnet.Name
+				}
+			}
+		}
+	}
+
+	zoneCounts := make(map[string]int)
+	for _, ig := range controlPlanes {
+		igZones, err := model.FindZonesForInstanceGroup(cluster, ig)
+		if err != nil {
+			return err
+		}
+		for _, zone := range igZones {
+			zoneCounts[zone]++
+		}
+	}
+
+	candidates := zones
+	if len(candidates) == 0 {
+		candidates = sets.List(sets.KeySet(zoneSubnets))
+	}
+	if len(candidates) == 0 {
+		return fmt.Errorf("no zones found for new control-plane nodes; specify the zones to use")
+	}
+	for _, zone := range candidates {
+		if _, found := zoneSubnets[zone]; !found {
+			return fmt.Errorf("no %s subnet found in zone %q; add one to the cluster first", templateSubnet.Type, zone)
+		}
+	}
+
+	prefix := "control-plane-"
+	if strings.HasPrefix(template.Name, "master-") {
+		prefix = "master-"
+	}
+	names := sets.New[string]()
+	for _, ig := range instanceGroups {
+		names.Insert(ig.Name)
+	}
+
+	for i := range p.Replicas - p.Current {
+		var zone string
+		if len(zones) != 0 {
+			// The new nodes go round-robin into the zones given
+			zone = zones[i%len(zones)]
+		} else {
+			// Spread the control-plane nodes over the zones with the fewest control-plane nodes
+			zone = candidates[0]
+			for _, candidate := range candidates {
+				if zoneCounts[candidate] < zoneCounts[zone] {
+					zone = candidate
+				}
+			}
+		}
+		if zoneCounts[zone] != 0 {
+			p.Warnings = append(p.Warnings, fmt.Sprintf("more than one control-plane node will run in zone %q; redundancy will be reduced", zone))
+		}
+		zoneCounts[zone]++
+
+		name := prefix + zone
+		for i := 2; names.Has(name); i++ {
+			name = prefix + zone + "-" + strconv.Itoa(i)
+		}
+		names.Insert(name)
+
+		ig := &kops.InstanceGroup{}
+		ig.Name = name
+		ig.Spec = *template.Spec.DeepCopy()
+		ig.Spec.Subnets = []string{zoneSubnets[zone]}
+		if len(template.Spec.Zones) != 0 {
+			ig.Spec.Zones = []string{zone}
+		}
+		p.AddInstanceGroups = append(p.AddInstanceGroups, ig)
+	}
+
+	// Name the new etcd members as kops create cluster would, falling back to the full name on conflicts
+	var igNames []string
+	for _, ig := range controlPlanes {
+		igNames = append(igNames, ig.Name)
+	}
+	for _, ig := range p.AddInstanceGroups {
+		igNames = append(igNames, ig.Name)
+	}
+	for i, name := range igNames {
+		name = strings.TrimPrefix(name, "control-plane-")
+		igNames[i] = strings.TrimPrefix(name, "master-")
+	}
+	memberNames := cloudup.TrimCommonPrefix(igNames)[len(controlPlanes):]
+
+	for i := range cluster.Spec.EtcdClusters {
+		etcdCluster := &cluster.Spec.EtcdClusters[i]
+
+		var templateMember *kops.EtcdMemberSpec
+		existing := sets.New[string]()
+		for j := range etcdCluster.Members {
+			member := &etcdCluster.Members[j]
+			existing.Insert(member.Name)
+			if fi.ValueOf(member.InstanceGroup) == template.Name {
+				templateMember = member
+			}
+		}
+
+		for j, ig := range p.AddInstanceGroups {
+			member := *templateMember.DeepCopy()
+			member.Name = memberNames[j]
+			if existing.Has(member.Name) {
+				member.Name = strings.TrimPrefix(strings.TrimPrefix(ig.Name, "control-plane-"), "master-")
+			}
+			if existing.Has(member.Name) {
+				return fmt.Errorf("etcd cluster %q already has a member named %q", etcdCluster.Name, member.Name)
+			}
+			existing.Insert(member.Name)
+			member.InstanceGroup = fi.PtrTo(ig.Name)
+			etcdCluster.Members = append(etcdCluster.Members, member)
+		}
+	}
+
+	return nil
+}
+
+func (p *ControlPlaneScalePlan) removeControlPlanes(controlPlanes []*kops.InstanceGroup) {
+	remove := sets.New[string]()
+	for i := len(controlPlanes) - 1; i >= p.Replicas; i-- {
+		p.RemoveInstanceGroups = append(p.RemoveInstanceGroups, controlPlanes[i])
+		remove.Insert(controlPlanes[i].Name)
+	}
+
+	for i := range p.Cluster.Spec.EtcdClusters {
+		etcdCluster := &p.Cluster.Spec.EtcdClusters[i]
+		var members []kops.EtcdMemberSpec
+		for _, member := range etcdCluster.Members {
+			if !remove.Has(fi.ValueOf(member.InstanceGroup)) {
+				members = append(members, member)
+			}
+		}
+		etcdCluster.Members = members
+	}
+}
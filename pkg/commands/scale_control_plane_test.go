@@ -0,0 +1,214 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+func buildScaleTestCluster(controlPlaneZones ...string) (*kops.Cluster, []*kops.InstanceGroup) {
+	cluster := &kops.Cluster{}
+	cluster.Name = "test.example.com"
+	for _, zone := range []string{"us-test-1a", "us-test-1b", "us-test-1c"} {
+		cluster.Spec.Networking.Subnets = append(cluster.Spec.Networking.Subnets,
+			kops.ClusterSubnetSpec{Name: zone, Zone: zone, Type: kops.SubnetTypePrivate},
+			kops.ClusterSubnetSpec{Name: "utility-" + zone, Zone: zone, Type: kops.SubnetTypeUtility},
+		)
+	}
+
+	instanceGroups := []*kops.InstanceGroup{
+		{
+			Spec: kops.InstanceGroupSpec{
+				Role:    kops.InstanceGroupRoleNode,
+				MinSize: fi.PtrTo(int32(3)),
+				MaxSize: fi.PtrTo(int32(3)),
+				Subnets: []string{"us-test-1a", "us-test-1b", "us-test-1c"},
+			},
+		},
+	}
+	instanceGroups[0].Name = "nodes"
+
+	for _, etcdName := range []string{"main", "events"} {
+		etcdCluster := kops.EtcdClusterSpec{Name: etcdName}
+		for _, zone := range controlPlaneZones {
+			etcdCluster.Members = append(etcdCluster.Members, kops.EtcdMemberSpec{
+				Name:            zone[len(zone)-1:],
+				InstanceGroup:   fi.PtrTo("control-plane-" + zone),
+				EncryptedVolume: fi.PtrTo(true),
+			})
+		}
+		cluster.Spec.EtcdClusters = append(cluster.Spec.EtcdClusters, etcdCluster)
+	}
+	for _, zone := range controlPlaneZones {
+		ig := &kops.InstanceGroup{
+			Spec: kops.InstanceGroupSpec{
+				Role:        kops.InstanceGroupRoleControlPlane,
+				MinSize:     fi.PtrTo(int32(1)),
+				MaxSize:     fi.PtrTo(int32(1)),
+				MachineType: "m5.large",
+				Subnets:     []string{zone},
+			},
+		}
+		ig.Name = "control-plane-" + zone
+		instanceGroups = append(instanceGroups, ig)
+	}
+	return cluster, instanceGroups
+}
+
+func etcdMembers(cluster *kops.Cluster) map[string][]string {
+	members := make(map[string][]string)
+	for _, etcdCluster := range cluster.Spec.EtcdClusters {
+		for _, member := range etcdCluster.Members {
+			members[etcdCluster.Name] = append(members[etcdCluster.Name], member.Name+"="+fi.ValueOf(member.InstanceGroup))
+		}
+	}
+	return members
+}
+
+func TestPlanControlPlaneScaleUp(t *testing.T) {
+	cluster, instanceGroups := buildScaleTestCluster("us-test-1a")
+
+	plan, err := PlanControlPlaneScale(cluster, instanceGroups, 3, nil)
+	if err != nil {
+		t.Fatalf("PlanControlPlaneScale: %v", err)
+	}
+	if !plan.ScaleUp() || len(plan.Warnings) != 0 {
+		t.Errorf("unexpected plan %+v", plan)
+	}
+
+	var names, subnets []string
+	for _, ig := range plan.AddInstanceGroups {
+		names = append(names, ig.Name)
+		subnets = append(subnets, ig.Spec.Subnets...)
+		if ig.Spec.MachineType != "m5.large" || ig.Spec.Role != kops.InstanceGroupRoleControlPlane {
+			t.Errorf("instance group %q was not copied from the existing control plane: %+v", ig.Name, ig.Spec)
+		}
+	}
+	if !reflect.DeepEqual(names, []string{"control-plane-us-test-1b", "control-plane-us-test-1c"}) {
+		t.Errorf("unexpected instance groups %v", names)
+	}
+	if !reflect.DeepEqual(subnets, []string{"us-test-1b", "us-test-1c"}) {
+		t.Errorf("unexpected subnets %v", subnets)
+	}
+
+	expected := []string{"a=control-plane-us-test-1a", "b=control-plane-us-test-1b", "c=control-plane-us-test-1c"}
+	for name, members := range etcdMembers(plan.Cluster) {
+		if !reflect.DeepEqual(members, expected) {
+			t.Errorf("unexpected members of etcd cluster %q: %v", name, members)
+		}
+	}
+	for _, member := range plan.Cluster.Spec.EtcdClusters[0].Members {
+		if !fi.ValueOf(member.EncryptedVolume) {
+			t.Errorf("member %q was not copied from the existing member", member.Name)
+		}
+	}
+	// The input cluster is not modified
+	if len(cluster.Spec.EtcdClusters[0].Members) != 1 {
+		t.Errorf("the input cluster was modified")
+	}
+
+	// With more nodes than zones, zones are shared
+	cluster, instanceGroups = buildScaleTestCluster("us-test-1a", "us-test-1b", "us-test-1c")
+	plan, err = PlanControlPlaneScale(cluster, instanceGroups, 5, nil)
+	if err != nil {
+		t.Fatalf("PlanControlPlaneScale: %v", err)
+	}
+	names = nil
+	for _, ig := range plan.AddInstanceGroups {
+		names = append(names, ig.Name)
+	}
+	if !reflect.DeepEqual(names, []string{"control-plane-us-test-1a-2", "control-plane-us-test-1b-2"}) {
+		t.Errorf("unexpected instance groups %v", names)
+	}
+	if len(plan.Warnings) != 2 {
+		t.Errorf("expected warnings about shared zones, got %v", plan.Warnings)
+	}
+	if members := etcdMembers(plan.Cluster)["main"]; !reflect.DeepEqual(members[3:], []string{"a-2=control-plane-us-test-1a-2", "b-2=control-plane-us-test-1b-2"}) {
+		t.Errorf("unexpected members %v", members)
+	}
+
+	// Zones can be chosen
+	cluster, instanceGroups = buildScaleTestCluster("us-test-1a")
+	plan, err = PlanControlPlaneScale(cluster, instanceGroups, 3, []string{"us-test-1c", "us-test-1a"})
+	if err != nil {
+		t.Fatalf("PlanControlPlaneScale: %v", err)
+	}
+	names = nil
+	for _, ig := range plan.AddInstanceGroups {
+		names = append(names, ig.Name)
+	}
+	if !reflect.DeepEqual(names, []string{"control-plane-us-test-1c", "control-plane-us-test-1a-2"}) {
+		t.Errorf("unexpected instance groups %v", names)
+	}
+}
+
+func TestPlanControlPlaneScaleDown(t *testing.T) {
+	cluster, instanceGroups := buildScaleTestCluster("us-test-1a", "us-test-1b", "us-test-1c")
+
+	plan, err := PlanControlPlaneScale(cluster, instanceGroups, 1, nil)
+	if err != nil {
+		t.Fatalf("PlanControlPlaneScale: %v", err)
+	}
+	if plan.ScaleUp() || plan.KeepsQuorum() {
+		t.Errorf("scaling from 3 to 1 should lose quorum")
+	}
+	var names []string
+	for _, ig := range plan.RemoveInstanceGroups {
+		names = append(names, ig.Name)
+	}
+	if !reflect.DeepEqual(names, []string{"control-plane-us-test-1c", "control-plane-us-test-1b"}) {
+		t.Errorf("unexpected instance groups %v", names)
+	}
+	if members := etcdMembers(plan.Cluster)["events"]; !reflect.DeepEqual(members, []string{"a=control-plane-us-test-1a"}) {
+		t.Errorf("unexpected members %v", members)
+	}
+	names = nil
+	for _, ig := range plan.RemainingInstanceGroups(instanceGroups) {
+		if ig.Spec.Role == kops.InstanceGroupRoleControlPlane {
+			names = append(names, ig.Name)
+		}
+	}
+	if !reflect.DeepEqual(names, []string{"control-plane-us-test-1a"}) {
+		t.Errorf("unexpected remaining control-plane instance groups %v", names)
+	}
+
+	plan, err = PlanControlPlaneScale(cluster, instanceGroups, 3, nil)
+	if err == nil || !strings.Contains(err.Error(), "already has 3") {
+		t.Errorf("expected error for unchanged replicas, got %v", err)
+	}
+}
+
+func TestPlanControlPlaneScaleErrors(t *testing.T) {
+	cluster, instanceGroups := buildScaleTestCluster("us-test-1a")
+
+	if _, err := PlanControlPlaneScale(cluster, instanceGroups, 2, nil); err == nil {
+		t.Errorf("expected error for an even number of nodes")
+	}
+	if _, err := PlanControlPlaneScale(cluster, instanceGroups, 3, []string{"us-test-1d"}); err == nil {
+		t.Errorf("expected error for a zone without a subnet")
+	}
+
+	cluster.Spec.EtcdClusters[1].Members = nil
+	if _, err := PlanControlPlaneScale(cluster, instanceGroups, 3, nil); err == nil {
+		t.Errorf("expected error for an etcd cluster without a member on the control plane")
+	}
+}
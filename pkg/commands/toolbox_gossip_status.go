@@ -0,0 +1,270 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/kops/pkg/wellknownports"
+	"k8s.io/kops/protokube/pkg/gossip"
+	"k8s.io/kops/util/pkg/tables"
+)
+
+type ToolboxGossipStatusOptions struct {
+	// Output is the output format, table or json
+	Output string
+
+	// StaleAfter is the time after which members that are not alive are reported
+	StaleAfter time.Duration
+}
+
+// GossipStatusReport is the gossip status of the control plane nodes, and the problems found in it
+type GossipStatusReport struct {
+	Nodes []*gossip.NodeStatus `json:"nodes"`
+	// Problems are the split-brains, stale members and stale records found, and the nodes that could not be queried
+	Problems []string `json:"problems,omitempty"`
+}
+
+// gossipStatusRow is a row of the table output
+type gossipStatusRow struct {
+	Node     string
+	Protocol string
+	Members  int
+	Alive    int
+	Records  int
+}
+
+// RunToolboxGossipStatus queries the gossip status of every control plane node through the API server proxy,
+// and reports the nodes that disagree on the members or on the replicated records
+func RunToolboxGossipStatus(ctx context.Context, out io.Writer, k8sClient kubernetes.Interface, options *ToolboxGossipStatusOptions) error {
+	nodes, err := k8sClient.CoreV1().Nodes().List(ctx, metav1.ListOptions{
+		LabelSelector: "node-role.kubernetes.io/control-plane",
+	})
+	if err != nil {
+		return fmt.Errorf("listing control plane nodes: %w", err)
+	}
+	if len(nodes.Items) == 0 {
+		return fmt.Errorf("no control plane nodes found")
+	}
+
+	report := &GossipStatusReport{}
+	var fetchProblems []string
+	for _, node := range nodes.Items {
+		status, err := fetchGossipStatus(ctx, k8sClient, node.Name)
+		if err != nil {
+			fetchProblems = append(fetchProblems, fmt.Sprintf("node %s: error querying gossip status: %v", node.Name, err))
+			continue
+		}
+		report.Nodes = append(report.Nodes, status)
+	}
+	report.Problems = append(fetchProblems, AnalyzeGossipStatus(report.Nodes, options.StaleAfter)...)
+
+	switch options.Output {
+	case "json":
+		b, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshaling gossip status: %w", err)
+		}
+		if _, err := out.Write(append(b, '\n')); err != nil {
+			return err
+		}
+	case "table", "":
+		if err := writeGossipStatusTable(out, report); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unsupported output format: %q", options.Output)
+	}
+
+	if len(report.Problems) != 0 {
+		return fmt.Errorf("found %d gossip problems", len(report.Problems))
+	}
+	return nil
+}
+
+// fetchGossipStatus queries the gossip status endpoint of protokube on a node, through the API server proxy.
+// protokube only accepts the client certificate that the API server presents to the nodes.
+func fetchGossipStatus(ctx context.Context, k8sClient kubernetes.Interface, nodeName string) (*gossip.NodeStatus, error) {
+	b, err := k8sClient.CoreV1().RESTClient().Get().
+		Resource("nodes").
+		Name(fmt.Sprintf("https:%s:%d", nodeName, wellknownports.ProtokubeGossipStatus)).
+		SubResource("proxy").
+		Suffix(gossip.StatusPath).
+		DoRaw(ctx)
+	if err != nil {
+		return nil, err
+	}
+	status := &gossip.NodeStatus{}
+	if err := json.Unmarshal(b, status); err != nil {
+		return nil, fmt.Errorf("parsing gossip status: %w", err)
+	}
+	return status, nil
+}
+
+func writeGossipStatusTable(out io.Writer, report *GossipStatusReport) error {
+	var rows []*gossipStatusRow
+	for _, node := range report.Nodes {
+		for _, state := range node.States {
+			row := &gossipStatusRow{
+				Node:     node.Node,
+				Protocol: state.Protocol,
+				Records:  len(state.Records),
+			}
+			for _, member := range state.Members {
+				if member.State == gossip.MemberStateGone {
+					continue
+				}
+				row.Members++
+				if member.State == gossip.MemberStateSelf || member.State == gossip.MemberStateAlive {
+					row.Alive++
+				}
+			}
+			rows = append(rows, row)
+		}
+	}
+
+	t := &tables.Table{}
+	t.AddColumn("NODE", func(r *gossipStatusRow) string {
+		return r.Node
+	})
+	t.AddColumn("PROTOCOL", func(r *gossipStatusRow) string {
+		return r.Protocol
+	})
+	t.AddColumn("MEMBERS", func(r *gossipStatusRow) string {
+		return fmt.Sprintf("%d", r.Members)
+	})
+	t.AddColumn("ALIVE", func(r *gossipStatusRow) string {
+		return fmt.Sprintf("%d", r.Alive)
+	})
+	t.AddColumn("RECORDS", func(r *gossipStatusRow) string {
+		return fmt.Sprintf("%d", r.Records)
+	})
+	if err := t.Render(rows, out, "NODE", "PROTOCOL", "MEMBERS", "ALIVE", "RECORDS"); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "\n")
+	if len(report.Problems) == 0 {
+		fmt.Fprintf(out, "No gossip problems found\n")
+		return nil
+	}
+	fmt.Fprintf(out, "Problems:\n")
+	for _, problem := range report.Problems {
+		fmt.Fprintf(out, "  %s\n", problem)
+	}
+	return nil
+}
+
+// AnalyzeGossipStatus compares the gossip statuses of the nodes, and returns the problems found:
+// nodes seeing different sets of alive members (split-brain), members that have not been seen alive
+// for longer than staleAfter, and records that are missing or older on some nodes.
+func AnalyzeGossipStatus(nodes []*gossip.NodeStatus, staleAfter time.Duration) []string {
+	var problems []string
+
+	// protocol -> node -> status
+	byProtocol := make(map[string]map[string]*gossip.GossipStatus)
+	nodeTimes := make(map[string]time.Time)
+	for _, node := range nodes {
+		nodeTimes[node.Node] = node.Time
+		for _, state := range node.States {
+			if byProtocol[state.Protocol] == nil {
+				byProtocol[state.Protocol] = make(map[string]*gossip.GossipStatus)
+			}
+			byProtocol[state.Protocol][node.Node] = state
+		}
+	}
+
+	for _, protocol := range sortedKeys(byProtocol) {
+		statuses := byProtocol[protocol]
+		nodeNames := sortedKeys(statuses)
+
+		// Split-brain: group the nodes by the members they see alive
+		views := make(map[string][]string)
+		for _, nodeName := range nodeNames {
+			var alive []string
+			for _, member := range statuses[nodeName].Members {
+				if member.State == gossip.MemberStateSelf || member.State == gossip.MemberStateAlive {
+					alive = append(alive, member.Name)
+				}
+			}
+			sort.Strings(alive)
+			view := strings.Join(alive, ",")
+			views[view] = append(views[view], nodeName)
+		}
+		if len(views) > 1 {
+			var groups []string
+			for _, view := range sortedKeys(views) {
+				groups = append(groups, fmt.Sprintf("nodes %s see [%s]", strings.Join(views[view], ","), view))
+			}
+			problems = append(problems, fmt.Sprintf("%s: split brain, %s", protocol, strings.Join(groups, "; ")))
+		}
+
+		// Stale members
+		for _, nodeName := range nodeNames {
+			for _, member := range statuses[nodeName].Members {
+				if member.State != gossip.MemberStateSuspect && member.State != gossip.MemberStateGone {
+					continue
+				}
+				if member.LastSeen == nil {
+					problems = append(problems, fmt.Sprintf("%s: node %s sees member %s as %s, never seen alive", protocol, nodeName, member.Name, member.State))
+					continue
+				}
+				age := nodeTimes[nodeName].Sub(*member.LastSeen)
+				if age > staleAfter {
+					problems = append(problems, fmt.Sprintf("%s: node %s sees member %s as %s, last seen %s ago", protocol, nodeName, member.Name, member.State, age.Round(time.Second)))
+				}
+			}
+		}
+
+		// Stale records: records missing or older than on the other nodes
+		newest := make(map[string]gossip.RecordStatus)
+		records := make(map[string]map[string]gossip.RecordStatus)
+		for _, nodeName := range nodeNames {
+			records[nodeName] = make(map[string]gossip.RecordStatus)
+			for _, record := range statuses[nodeName].Records {
+				records[nodeName][record.Key] = record
+				if existing, found := newest[record.Key]; !found || record.Updated.After(existing.Updated) {
+					newest[record.Key] = record
+				}
+			}
+		}
+		for _, key := range sortedKeys(newest) {
+			var stale []string
+			for _, nodeName := range nodeNames {
+				record, found := records[nodeName][key]
+				if !found {
+					stale = append(stale, nodeName+" (missing)")
+				} else if record.Updated.Before(newest[key].Updated) || record.Value != newest[key].Value || record.Deleted != newest[key].Deleted {
+					stale = append(stale, fmt.Sprintf("%s (updated %s)", nodeName, record.Updated.Format(time.RFC3339)))
+				}
+			}
+			if len(stale) != 0 {
+				problems = append(problems, fmt.Sprintf("%s: record %s is stale on %s, last updated %s", protocol, key, strings.Join(stale, ", "), newest[key].Updated.Format(time.RFC3339)))
+			}
+		}
+	}
+
+	return problems
+}
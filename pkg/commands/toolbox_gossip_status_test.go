@@ -0,0 +1,97 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/kops/protokube/pkg/gossip"
+)
+
+func TestAnalyzeGossipStatus(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	recent := now.Add(-30 * time.Second)
+	old := now.Add(-10 * time.Minute)
+
+	apiRecord := gossip.RecordStatus{Key: "dns/local/A/api.internal.example.k8s.local", Value: "10.0.0.1,10.0.0.2", Updated: now.Add(-time.Hour)}
+	newAPIRecord := gossip.RecordStatus{Key: apiRecord.Key, Value: "10.0.0.1,10.0.0.2,10.0.0.3", Updated: now.Add(-time.Minute)}
+	etcdRecord := gossip.RecordStatus{Key: "dns/local/A/etcd-a.internal.example.k8s.local", Value: "10.0.0.1", Updated: now.Add(-time.Hour)}
+
+	healthy := func(node string) *gossip.NodeStatus {
+		return &gossip.NodeStatus{
+			Node: node,
+			Time: now,
+			States: []*gossip.GossipStatus{
+				{
+					Protocol: "mesh",
+					Self:     node,
+					Members: []gossip.MemberStatus{
+						{Name: "i-a", State: gossip.MemberStateAlive, LastSeen: &recent},
+						{Name: "i-b", State: gossip.MemberStateAlive, LastSeen: &recent},
+						{Name: "i-c", State: gossip.MemberStateAlive, LastSeen: &recent},
+					},
+					Records: []gossip.RecordStatus{apiRecord, etcdRecord},
+				},
+			},
+		}
+	}
+
+	{
+		nodes := []*gossip.NodeStatus{healthy("i-a"), healthy("i-b"), healthy("i-c")}
+		for _, node := range nodes {
+			for i := range node.States[0].Members {
+				if node.States[0].Members[i].Name == node.Node {
+					node.States[0].Members[i].State = gossip.MemberStateSelf
+				}
+			}
+		}
+		if problems := AnalyzeGossipStatus(nodes, 2*time.Minute); len(problems) != 0 {
+			t.Errorf("unexpected problems for healthy gossip: %v", problems)
+		}
+	}
+
+	{
+		a := healthy("i-a")
+		b := healthy("i-b")
+		c := healthy("i-c")
+		// i-c was partitioned from i-a and i-b, and missed an update of the api record
+		a.States[0].Members[2] = gossip.MemberStatus{Name: "i-c", State: gossip.MemberStateGone, LastSeen: &old}
+		b.States[0].Members[2] = gossip.MemberStatus{Name: "i-c", State: gossip.MemberStateSuspect, LastSeen: &recent}
+		a.States[0].Records = []gossip.RecordStatus{newAPIRecord, etcdRecord}
+		b.States[0].Records = []gossip.RecordStatus{newAPIRecord}
+		c.States[0].Members = []gossip.MemberStatus{
+			{Name: "i-a", State: gossip.MemberStateGone, LastSeen: &old},
+			{Name: "i-b", State: gossip.MemberStateGone, LastSeen: &old},
+			{Name: "i-c", State: gossip.MemberStateSelf},
+		}
+
+		expected := []string{
+			"mesh: split brain, nodes i-a,i-b see [i-a,i-b]; nodes i-c see [i-c]",
+			"mesh: node i-a sees member i-c as gone, last seen 10m0s ago",
+			"mesh: node i-c sees member i-a as gone, last seen 10m0s ago",
+			"mesh: node i-c sees member i-b as gone, last seen 10m0s ago",
+			"mesh: record dns/local/A/api.internal.example.k8s.local is stale on i-c (updated 2026-01-01T11:00:00Z), last updated 2026-01-01T11:59:00Z",
+			"mesh: record dns/local/A/etcd-a.internal.example.k8s.local is stale on i-b (missing), last updated 2026-01-01T11:00:00Z",
+		}
+		problems := AnalyzeGossipStatus([]*gossip.NodeStatus{a, b, c}, 2*time.Minute)
+		if strings.Join(problems, "\n") != strings.Join(expected, "\n") {
+			t.Errorf("unexpected problems\nexpected:\n%s\nactual:\n%s", strings.Join(expected, "\n"), strings.Join(problems, "\n"))
+		}
+	}
+}
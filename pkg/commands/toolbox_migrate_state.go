@@ -0,0 +1,570 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/acls"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/apis/kops/registry"
+	"k8s.io/kops/pkg/client/simple"
+	"k8s.io/kops/pkg/kubemanifest"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+type ToolboxMigrateStateOptions struct {
+	// From is the state store to copy from
+	From string
+	// To is the state store to copy to
+	To string
+
+	// ClusterNames limits the migration to the named clusters; all clusters are migrated if empty
+	ClusterNames []string
+
+	// Yes must be set to actually copy the state; otherwise we only print what would be copied
+	Yes bool
+}
+
+// migrationPlan is the state of a single cluster that we copy between state stores.
+type migrationPlan struct {
+	source *kops.Cluster
+	target *kops.Cluster
+
+	instanceGroups []*kops.InstanceGroup
+	addons         kubemanifest.ObjectList
+	keysets        map[string]*fi.Keyset
+	secrets        map[string]*fi.Secret
+	sshPublicKeys  []string
+
+	// trees are the directories that are copied file by file: the history and the etcd backups
+	trees []*migrationTree
+}
+
+// migrationTree is a directory that is copied file by file between state stores.
+type migrationTree struct {
+	// description is shown in the plan
+	description string
+	from        vfs.Path
+	to          vfs.Path
+	// files holds the paths of the files to copy, relative to from
+	files []string
+}
+
+// RunToolboxMigrateState copies clusters, with their instance groups, addons, keysets, secrets, SSH keys,
+// history and etcd backups, from one state store to another,
+// rewriting the ConfigStore and etcd backup store paths that pointed into the old state store.
+func RunToolboxMigrateState(ctx context.Context, out io.Writer, from simple.Clientset, to simple.Clientset, options *ToolboxMigrateStateOptions) error {
+	fromBase := strings.TrimSuffix(options.From, "/")
+	toBase := strings.TrimSuffix(options.To, "/")
+	if fromBase == "" || toBase == "" {
+		return fmt.Errorf("--from and --to are required")
+	}
+	if fromBase == toBase {
+		return fmt.Errorf("--from and --to must be different state stores")
+	}
+
+	clusterNames := options.ClusterNames
+	if len(clusterNames) == 0 {
+		clusters, err := from.ListClusters(ctx, metav1.ListOptions{})
+		if err != nil {
+			return fmt.Errorf("error listing clusters in %s: %w", fromBase, err)
+		}
+		for _, cluster := range clusters.Items {
+			clusterNames = append(clusterNames, cluster.Name)
+		}
+		if len(clusterNames) == 0 {
+			return fmt.Errorf("no clusters found in %s", fromBase)
+		}
+	}
+	sort.Strings(clusterNames)
+
+	var plans []*migrationPlan
+	for _, clusterName := range clusterNames {
+		plan, err := buildMigrationPlan(ctx, from, to, clusterName, fromBase, toBase)
+		if err != nil {
+			return err
+		}
+		plans = append(plans, plan)
+	}
+
+	for _, plan := range plans {
+		fmt.Fprintf(out, "Cluster %q:\n", plan.source.Name)
+		printPathChange(out, "configStore.base", plan.source.Spec.ConfigStore.Base, plan.target.Spec.ConfigStore.Base)
+		printPathChange(out, "configStore.keypairs", plan.source.Spec.ConfigStore.Keypairs, plan.target.Spec.ConfigStore.Keypairs)
+		printPathChange(out, "configStore.secrets", plan.source.Spec.ConfigStore.Secrets, plan.target.Spec.ConfigStore.Secrets)
+		fmt.Fprintf(out, "  instance groups: %d\n", len(plan.instanceGroups))
+		fmt.Fprintf(out, "  keysets: %d\n", len(plan.keysets))
+		fmt.Fprintf(out, "  secrets: %d\n", len(plan.secrets))
+		fmt.Fprintf(out, "  ssh public keys: %d\n", len(plan.sshPublicKeys))
+		fmt.Fprintf(out, "  addons: %v\n", len(plan.addons) != 0)
+		for i, etcdCluster := range plan.source.Spec.EtcdClusters {
+			if etcdCluster.Backups != nil {
+				printPathChange(out, "etcd "+etcdCluster.Name+" backupStore", etcdCluster.Backups.BackupStore, plan.target.Spec.EtcdClusters[i].Backups.BackupStore)
+			}
+		}
+		for _, tree := range plan.trees {
+			fmt.Fprintf(out, "  %s: %d files\n", tree.description, len(tree.files))
+		}
+	}
+
+	if !options.Yes {
+		fmt.Fprintf(out, "\nMust specify --yes to copy the state to %s\n", toBase)
+		return nil
+	}
+
+	for _, plan := range plans {
+		if err := plan.copyTo(ctx, to); err != nil {
+			return fmt.Errorf("error copying cluster %q: %w", plan.source.Name, err)
+		}
+		if err := plan.verify(ctx, to); err != nil {
+			return fmt.Errorf("error verifying cluster %q after copying: %w", plan.source.Name, err)
+		}
+		fmt.Fprintf(out, "\nCopied and verified cluster %q\n", plan.source.Name)
+	}
+
+	fmt.Fprintf(out, "\nThe state has been copied; the original state store has not been modified.\n")
+	fmt.Fprintf(out, "Point KOPS_STATE_STORE at %s, then run kops update cluster --yes so that the nodes use the new state store.\n", toBase)
+	fmt.Fprintf(out, "Once a rolling update has replaced all nodes, the old state store can be removed.\n")
+	return nil
+}
+
+func printPathChange(out io.Writer, name string, from, to string) {
+	if from == to {
+		if from != "" {
+			fmt.Fprintf(out, "  %s: %s (unchanged)\n", name, from)
+		}
+		return
+	}
+	fmt.Fprintf(out, "  %s: %s -> %s\n", name, from, to)
+}
+
+// rebasePath moves p from under fromBase to under toBase.  Paths outside fromBase are not changed.
+func rebasePath(p string, fromBase, toBase string) string {
+	if p == fromBase {
+		return toBase
+	}
+	if strings.HasPrefix(p, fromBase+"/") {
+		return toBase + strings.TrimPrefix(p, fromBase)
+	}
+	return p
+}
+
+func buildMigrationPlan(ctx context.Context, from simple.Clientset, to simple.Clientset, clusterName string, fromBase, toBase string) (*migrationPlan, error) {
+	source, err := from.GetCluster(ctx, clusterName)
+	if err != nil {
+		return nil, fmt.Errorf("error reading cluster %q: %w", clusterName, err)
+	}
+
+	existing, err := to.GetCluster(ctx, clusterName)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("error checking for cluster %q in %s: %w", clusterName, toBase, err)
+	}
+	if err == nil && existing != nil {
+		return nil, fmt.Errorf("cluster %q already exists in %s", clusterName, toBase)
+	}
+
+	plan := &migrationPlan{
+		source:  source,
+		keysets: make(map[string]*fi.Keyset),
+		secrets: make(map[string]*fi.Secret),
+	}
+
+	target := source.DeepCopy()
+	target.ResourceVersion = ""
+	configStore := &target.Spec.ConfigStore
+	configStore.Base = rebasePath(configStore.Base, fromBase, toBase)
+	configStore.Keypairs = rebasePath(configStore.Keypairs, fromBase, toBase)
+	configStore.Secrets = rebasePath(configStore.Secrets, fromBase, toBase)
+	if configStore.Base == source.Spec.ConfigStore.Base {
+		klog.Warningf("configStore.base %q of cluster %q is not inside %s; it will not be changed", configStore.Base, clusterName, fromBase)
+	}
+	for i := range target.Spec.EtcdClusters {
+		backups := target.Spec.EtcdClusters[i].Backups
+		if backups != nil && backups.BackupStore != "" {
+			backups.BackupStore = rebasePath(backups.BackupStore, fromBase, toBase)
+		}
+	}
+	plan.target = target
+
+	if err := plan.addTrees(ctx, from, to); err != nil {
+		return nil, err
+	}
+
+	igs, err := from.InstanceGroupsFor(source).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error reading instance groups for cluster %q: %w", clusterName, err)
+	}
+	for i := range igs.Items {
+		ig := igs.Items[i].DeepCopy()
+		ig.ResourceVersion = ""
+		plan.instanceGroups = append(plan.instanceGroups, ig)
+	}
+
+	addons, err := from.AddonsFor(source).List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error reading addons for cluster %q: %w", clusterName, err)
+	}
+	plan.addons = addons
+
+	keyStore, err := from.KeyStore(source)
+	if err != nil {
+		return nil, err
+	}
+	keysets, err := keyStore.ListKeysets()
+	if err != nil {
+		return nil, fmt.Errorf("error reading keysets for cluster %q: %w", clusterName, err)
+	}
+	for name, keyset := range keysets {
+		plan.keysets[name] = keyset
+	}
+
+	secretStore, err := from.SecretStore(source)
+	if err != nil {
+		return nil, err
+	}
+	secretNames, err := secretStore.ListSecrets()
+	if err != nil {
+		return nil, fmt.Errorf("error reading secrets for cluster %q: %w", clusterName, err)
+	}
+	for _, name := range secretNames {
+		secret, err := secretStore.FindSecret(name)
+		if err != nil {
+			return nil, fmt.Errorf("error reading secret %q for cluster %q: %w", name, clusterName, err)
+		}
+		if secret != nil {
+			plan.secrets[name] = secret
+		}
+	}
+
+	sshCredentialStore, err := from.SSHCredentialStore(source)
+	if err != nil {
+		return nil, err
+	}
+	sshCredentials, err := sshCredentialStore.FindSSHPublicKeys()
+	if err != nil {
+		return nil, fmt.Errorf("error reading SSH public keys for cluster %q: %w", clusterName, err)
+	}
+	for _, sshCredential := range sshCredentials {
+		plan.sshPublicKeys = append(plan.sshPublicKeys, sshCredential.Spec.PublicKey)
+	}
+	sort.Strings(plan.sshPublicKeys)
+
+	return plan, nil
+}
+
+// addTrees adds the history and the etcd backups of the cluster to the plan.
+// Etcd backup stores outside the source state store are left where they are.
+func (p *migrationPlan) addTrees(ctx context.Context, from simple.Clientset, to simple.Clientset) error {
+	fromConfigBase, err := from.ConfigBaseFor(p.source)
+	if err != nil {
+		return err
+	}
+	toConfigBase, err := to.ConfigBaseFor(p.target)
+	if err != nil {
+		return err
+	}
+
+	if err := p.addTree(ctx, "history", fromConfigBase.Join(registry.PathHistory), toConfigBase.Join(registry.PathHistory)); err != nil {
+		return err
+	}
+
+	for i, etcdCluster := range p.source.Spec.EtcdClusters {
+		var fromStore, toStore vfs.Path
+		if etcdCluster.Backups != nil && etcdCluster.Backups.BackupStore != "" {
+			targetStore := p.target.Spec.EtcdClusters[i].Backups.BackupStore
+			if targetStore == etcdCluster.Backups.BackupStore {
+				klog.Warningf("etcd backup store %q of cluster %q is not inside the state store; it will not be copied", targetStore, p.source.Name)
+				continue
+			}
+			fromStore, err = from.VFSContext().BuildVfsPath(etcdCluster.Backups.BackupStore)
+			if err != nil {
+				return fmt.Errorf("error building path for etcd backup store %q: %w", etcdCluster.Backups.BackupStore, err)
+			}
+			toStore, err = to.VFSContext().BuildVfsPath(targetStore)
+			if err != nil {
+				return fmt.Errorf("error building path for etcd backup store %q: %w", targetStore, err)
+			}
+		} else {
+			// This is the default backup store, see pkg/model/components/etcdmanager
+			fromStore = fromConfigBase.Join("backups", "etcd", etcdCluster.Name)
+			toStore = toConfigBase.Join("backups", "etcd", etcdCluster.Name)
+		}
+		if err := p.addTree(ctx, "etcd "+etcdCluster.Name+" backups", fromStore, toStore); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// addTree lists the files under from, and adds them to the plan to be copied to the same relative paths under to.
+func (p *migrationPlan) addTree(ctx context.Context, description string, from, to vfs.Path) error {
+	paths, err := from.ReadTree(ctx)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("error listing %s: %w", from, err)
+	}
+	if len(paths) == 0 {
+		return nil
+	}
+
+	tree := &migrationTree{
+		description: description,
+		from:        from,
+		to:          to,
+	}
+	for _, path := range paths {
+		relativePath, err := vfs.RelativePath(from, path)
+		if err != nil {
+			return err
+		}
+		tree.files = append(tree.files, relativePath)
+	}
+	sort.Strings(tree.files)
+	p.trees = append(p.trees, tree)
+	return nil
+}
+
+// copyTree copies the files of a tree, one at a time.
+func (p *migrationPlan) copyTree(ctx context.Context, tree *migrationTree) error {
+	for _, f := range tree.files {
+		data, err := tree.from.Join(f).ReadFile(ctx)
+		if err != nil {
+			return fmt.Errorf("error reading %s: %w", tree.from.Join(f), err)
+		}
+		dest := tree.to.Join(f)
+		acl, err := acls.GetACL(ctx, dest, p.target)
+		if err != nil {
+			return err
+		}
+		if err := dest.WriteFile(ctx, bytes.NewReader(data), acl); err != nil {
+			return fmt.Errorf("error writing %s: %w", dest, err)
+		}
+	}
+	return nil
+}
+
+// copyTo writes the cluster state to the target clientset.
+// The keysets and secrets are written first, so that the cluster never exists without its PKI.
+// The history is copied before the cluster is created, so that the revisions recorded by the copy follow it.
+func (p *migrationPlan) copyTo(ctx context.Context, to simple.Clientset) error {
+	keyStore, err := to.KeyStore(p.target)
+	if err != nil {
+		return err
+	}
+	for _, name := range sortedKeys(p.keysets) {
+		klog.Infof("copying keyset %q", name)
+		if err := keyStore.StoreKeyset(ctx, name, p.keysets[name]); err != nil {
+			return fmt.Errorf("error writing keyset %q: %w", name, err)
+		}
+	}
+
+	secretStore, err := to.SecretStore(p.target)
+	if err != nil {
+		return err
+	}
+	for _, name := range sortedKeys(p.secrets) {
+		klog.Infof("copying secret %q", name)
+		current, _, err := secretStore.GetOrCreateSecret(ctx, name, p.secrets[name])
+		if err != nil {
+			return fmt.Errorf("error writing secret %q: %w", name, err)
+		}
+		if !bytes.Equal(current.Data, p.secrets[name].Data) {
+			return fmt.Errorf("secret %q already exists in the target state store with different contents", name)
+		}
+	}
+
+	sshCredentialStore, err := to.SSHCredentialStore(p.target)
+	if err != nil {
+		return err
+	}
+	for _, publicKey := range p.sshPublicKeys {
+		if err := sshCredentialStore.AddSSHPublicKey(ctx, []byte(publicKey)); err != nil {
+			return fmt.Errorf("error writing SSH public key: %w", err)
+		}
+	}
+
+	for _, tree := range p.trees {
+		klog.Infof("copying %s", tree.description)
+		if err := p.copyTree(ctx, tree); err != nil {
+			return fmt.Errorf("error copying %s: %w", tree.description, err)
+		}
+	}
+
+	klog.Infof("copying cluster %q", p.target.Name)
+	if _, err := to.CreateCluster(ctx, p.target.DeepCopy()); err != nil {
+		return fmt.Errorf("error writing cluster: %w", err)
+	}
+
+	created, err := to.GetCluster(ctx, p.target.Name)
+	if err != nil {
+		return err
+	}
+	for _, ig := range p.instanceGroups {
+		klog.Infof("copying InstanceGroup %q", ig.Name)
+		if _, err := to.InstanceGroupsFor(created).Create(ctx, ig.DeepCopy(), metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("error writing InstanceGroup %q: %w", ig.Name, err)
+		}
+	}
+
+	if len(p.addons) != 0 {
+		if err := to.AddonsFor(created).Replace(ctx, p.addons); err != nil {
+			return fmt.Errorf("error writing addons: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// verify reads the state back from the target clientset and checks it matches what we copied.
+func (p *migrationPlan) verify(ctx context.Context, to simple.Clientset) error {
+	cluster, err := to.GetCluster(ctx, p.target.Name)
+	if err != nil {
+		return err
+	}
+	if !apiequality.Semantic.DeepEqual(cluster.Spec, p.target.Spec) {
+		return fmt.Errorf("cluster spec does not match")
+	}
+
+	igs, err := to.InstanceGroupsFor(cluster).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	if len(igs.Items) != len(p.instanceGroups) {
+		return fmt.Errorf("expected %d instance groups, found %d", len(p.instanceGroups), len(igs.Items))
+	}
+	copied := make(map[string]*kops.InstanceGroup)
+	for i := range igs.Items {
+		copied[igs.Items[i].Name] = &igs.Items[i]
+	}
+	for _, ig := range p.instanceGroups {
+		actual := copied[ig.Name]
+		if actual == nil {
+			return fmt.Errorf("InstanceGroup %q not found", ig.Name)
+		}
+		if !apiequality.Semantic.DeepEqual(actual.Spec, ig.Spec) {
+			return fmt.Errorf("InstanceGroup %q spec does not match", ig.Name)
+		}
+	}
+
+	addons, err := to.AddonsFor(cluster).List(ctx)
+	if err != nil {
+		return err
+	}
+	if len(addons) != 0 || len(p.addons) != 0 {
+		actual, err := addons.ToYAML()
+		if err != nil {
+			return err
+		}
+		expected, err := p.addons.ToYAML()
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(actual, expected) {
+			return fmt.Errorf("addons do not match")
+		}
+	}
+
+	keyStore, err := to.KeyStore(cluster)
+	if err != nil {
+		return err
+	}
+	for name, expected := range p.keysets {
+		actual, err := keyStore.FindKeyset(ctx, name)
+		if err != nil {
+			return fmt.Errorf("error reading keyset %q: %w", name, err)
+		}
+		if actual == nil {
+			return fmt.Errorf("keyset %q not found", name)
+		}
+		if actual.Primary == nil || expected.Primary == nil || actual.Primary.Id != expected.Primary.Id || len(actual.Items) != len(expected.Items) {
+			return fmt.Errorf("keyset %q does not match", name)
+		}
+	}
+
+	secretStore, err := to.SecretStore(cluster)
+	if err != nil {
+		return err
+	}
+	for name, expected := range p.secrets {
+		actual, err := secretStore.FindSecret(name)
+		if err != nil {
+			return fmt.Errorf("error reading secret %q: %w", name, err)
+		}
+		if actual == nil || !bytes.Equal(actual.Data, expected.Data) {
+			return fmt.Errorf("secret %q does not match", name)
+		}
+	}
+
+	sshCredentialStore, err := to.SSHCredentialStore(cluster)
+	if err != nil {
+		return err
+	}
+	sshCredentials, err := sshCredentialStore.FindSSHPublicKeys()
+	if err != nil {
+		return err
+	}
+	var sshPublicKeys []string
+	for _, sshCredential := range sshCredentials {
+		sshPublicKeys = append(sshPublicKeys, sshCredential.Spec.PublicKey)
+	}
+	sort.Strings(sshPublicKeys)
+	if strings.Join(sshPublicKeys, "\n") != strings.Join(p.sshPublicKeys, "\n") {
+		return fmt.Errorf("SSH public keys do not match")
+	}
+
+	for _, tree := range p.trees {
+		for _, f := range tree.files {
+			expected, err := tree.from.Join(f).ReadFile(ctx)
+			if err != nil {
+				return fmt.Errorf("error reading %s: %w", tree.from.Join(f), err)
+			}
+			actual, err := tree.to.Join(f).ReadFile(ctx)
+			if err != nil {
+				return fmt.Errorf("error reading %s: %w", tree.to.Join(f), err)
+			}
+			if !bytes.Equal(actual, expected) {
+				return fmt.Errorf("%s does not match", tree.to.Join(f))
+			}
+		}
+	}
+
+	return nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+func TestRebasePath(t *testing.T) {
+	grid := []struct {
+		Path     string
+		Expected string
+	}{
+		{
+			Path:     "s3://old/cluster.example.com",
+			Expected: "gs://new/cluster.example.com",
+		},
+		{
+			Path:     "s3://old/cluster.example.com/pki",
+			Expected: "gs://new/cluster.example.com/pki",
+		},
+		{
+			Path:     "s3://old",
+			Expected: "gs://new",
+		},
+		{
+			// Only whole path segments are rebased
+			Path:     "s3://older/cluster.example.com",
+			Expected: "s3://older/cluster.example.com",
+		},
+		{
+			Path:     "s3://elsewhere/cluster.example.com",
+			Expected: "s3://elsewhere/cluster.example.com",
+		},
+		{
+			Path:     "",
+			Expected: "",
+		},
+	}
+
+	for _, g := range grid {
+		actual := rebasePath(g.Path, "s3://old", "gs://new")
+		if actual != g.Expected {
+			t.Errorf("rebasePath(%q): expected %q, got %q", g.Path, g.Expected, actual)
+		}
+	}
+}
+
+func TestMigrationPlanCopiesTrees(t *testing.T) {
+	ctx := context.TODO()
+
+	vfs.Context.ResetMemfsContext(true)
+	from, err := vfs.Context.BuildVfsPath("memfs://old/cluster.example.com/backups/etcd/main")
+	if err != nil {
+		t.Fatalf("error building path: %v", err)
+	}
+	to, err := vfs.Context.BuildVfsPath("memfs://new/cluster.example.com/backups/etcd/main")
+	if err != nil {
+		t.Fatalf("error building path: %v", err)
+	}
+
+	files := map[string]string{
+		"2026-01-01T00:00:00Z-000001/etcd.backup.gz":    "backup",
+		"2026-01-01T00:00:00Z-000001/_etcd_backup.meta": "meta",
+		"control/etcd-cluster-spec":                     "spec",
+	}
+	for k, v := range files {
+		if err := from.Join(k).WriteFile(ctx, bytes.NewReader([]byte(v)), nil); err != nil {
+			t.Fatalf("error writing %s: %v", k, err)
+		}
+	}
+
+	plan := &migrationPlan{
+		target: &kops.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster.example.com"}},
+	}
+	if err := plan.addTree(ctx, "etcd main backups", from, to); err != nil {
+		t.Fatalf("error listing tree: %v", err)
+	}
+	if err := plan.addTree(ctx, "history", from.Join("missing"), to.Join("missing")); err != nil {
+		t.Fatalf("error listing missing tree: %v", err)
+	}
+	if len(plan.trees) != 1 {
+		t.Fatalf("expected 1 tree, got %d", len(plan.trees))
+	}
+	expected := []string{
+		"2026-01-01T00:00:00Z-000001/_etcd_backup.meta",
+		"2026-01-01T00:00:00Z-000001/etcd.backup.gz",
+		"control/etcd-cluster-spec",
+	}
+	if !reflect.DeepEqual(plan.trees[0].files, expected) {
+		t.Errorf("expected files %v, got %v", expected, plan.trees[0].files)
+	}
+
+	if err := plan.copyTree(ctx, plan.trees[0]); err != nil {
+		t.Fatalf("error copying tree: %v", err)
+	}
+	for k, v := range files {
+		data, err := to.Join(k).ReadFile(ctx)
+		if err != nil {
+			t.Fatalf("error reading copied %s: %v", k, err)
+		}
+		if string(data) != v {
+			t.Errorf("expected %q in copied %s, got %q", v, k, data)
+		}
+	}
+}
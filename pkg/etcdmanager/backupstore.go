@@ -0,0 +1,293 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcdmanager
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+// The layout of the backup store is defined by etcd-manager; these must match its constants.
+const (
+	// controlDir holds the cluster spec and the pending commands
+	controlDir = "control"
+	// clusterSpecFile holds the desired etcd cluster spec, written by kops update cluster
+	clusterSpecFile = "etcd-cluster-spec"
+	// commandFile is the name of the file holding each command
+	commandFile = "_command.json"
+	// backupMetaFile holds the BackupInfo of each backup
+	backupMetaFile = "_etcd_backup.meta"
+)
+
+// ClusterSpec is the desired spec of an etcd cluster, as understood by etcd-manager.
+type ClusterSpec struct {
+	MemberCount int32  `json:"memberCount,omitempty"`
+	EtcdVersion string `json:"etcdVersion,omitempty"`
+}
+
+// BackupInfo is the metadata etcd-manager stores alongside each backup.
+type BackupInfo struct {
+	EtcdVersion string       `json:"etcdVersion,omitempty"`
+	Timestamp   int64        `json:"timestamp,omitempty"`
+	ClusterSpec *ClusterSpec `json:"clusterSpec,omitempty"`
+}
+
+// Command is a request to etcd-manager, picked up from the backup store by the leader.
+// etcd-manager removes the command once it has been applied.
+type Command struct {
+	// Timestamp is when the command was created, in nanoseconds since the epoch
+	Timestamp int64 `json:"timestamp,omitempty"`
+
+	RestoreBackup *RestoreBackupCommand `json:"restoreBackup,omitempty"`
+	BackupNow     *BackupNowCommand     `json:"backupNow,omitempty"`
+}
+
+// RestoreBackupCommand asks etcd-manager to replace the cluster with a new one, created from a backup.
+type RestoreBackupCommand struct {
+	ClusterSpec *ClusterSpec `json:"clusterSpec,omitempty"`
+	Backup      string       `json:"backup,omitempty"`
+}
+
+// BackupNowCommand asks etcd-manager to take a backup without waiting for the backup interval.
+// etcd-manager versions that do not know the command leave it pending, and take the backup at the next interval.
+type BackupNowCommand struct{}
+
+// Backup is a backup in the backup store.
+type Backup struct {
+	// Name is the name of the backup, as used by restore
+	Name string
+	Info *BackupInfo
+}
+
+// PendingCommand is a command that etcd-manager has not yet applied.
+type PendingCommand struct {
+	Path    vfs.Path
+	Command *Command
+}
+
+// BackupStore gives access to the etcd-manager backups and commands of one etcd cluster.
+type BackupStore struct {
+	base vfs.Path
+}
+
+// NewBackupStore builds a BackupStore rooted at base.
+func NewBackupStore(base vfs.Path) *BackupStore {
+	return &BackupStore{base: base}
+}
+
+// BackupStoreFor returns the backup store of the etcd cluster, defaulting to the location used by kops update cluster.
+func BackupStoreFor(vfsContext *vfs.VFSContext, configBase vfs.Path, etcdCluster *kops.EtcdClusterSpec) (*BackupStore, error) {
+	if etcdCluster.Backups != nil && etcdCluster.Backups.BackupStore != "" {
+		p, err := vfsContext.BuildVfsPath(etcdCluster.Backups.BackupStore)
+		if err != nil {
+			return nil, fmt.Errorf("error building backup store path for etcd cluster %q: %w", etcdCluster.Name, err)
+		}
+		return NewBackupStore(p), nil
+	}
+	return NewBackupStore(configBase.Join("backups", "etcd", etcdCluster.Name)), nil
+}
+
+// Path returns the base of the backup store.
+func (s *BackupStore) Path() vfs.Path {
+	return s.base
+}
+
+// LoadClusterSpec reads the desired cluster spec, returning nil if it has not been written.
+func (s *BackupStore) LoadClusterSpec(ctx context.Context) (*ClusterSpec, error) {
+	spec := &ClusterSpec{}
+	found, err := readJSON(ctx, s.base.Join(controlDir, clusterSpecFile), spec)
+	if err != nil || !found {
+		return nil, err
+	}
+	return spec, nil
+}
+
+// WriteClusterSpec replaces the desired cluster spec, which etcd-manager reconciles the etcd cluster towards.
+func (s *BackupStore) WriteClusterSpec(ctx context.Context, spec *ClusterSpec) error {
+	data, err := json.MarshalIndent(spec, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error serializing cluster spec: %w", err)
+	}
+	p := s.base.Join(controlDir, clusterSpecFile)
+	if err := p.WriteFile(ctx, bytes.NewReader(data), nil); err != nil {
+		return fmt.Errorf("error writing %s: %w", p, err)
+	}
+	return nil
+}
+
+// ListBackups returns the backups in the store, oldest first.
+// Backups whose metadata cannot be read (for example, a backup still being written) are skipped.
+func (s *BackupStore) ListBackups(ctx context.Context) ([]*Backup, error) {
+	children, err := s.base.ReadDir()
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error listing backups in %s: %w", s.base, err)
+	}
+
+	var names []string
+	for _, child := range children {
+		name := child.Base()
+		if name == controlDir {
+			continue
+		}
+		names = append(names, name)
+	}
+	// etcd-manager names backups by timestamp, so they sort in time order
+	sort.Strings(names)
+
+	var backups []*Backup
+	for _, name := range names {
+		info := &BackupInfo{}
+		found, err := readJSON(ctx, s.base.Join(name, backupMetaFile), info)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			continue
+		}
+		backups = append(backups, &Backup{Name: name, Info: info})
+	}
+	return backups, nil
+}
+
+// FindBackup returns the named backup; the name "latest" refers to the most recent backup.
+func (s *BackupStore) FindBackup(ctx context.Context, name string) (*Backup, error) {
+	backups, err := s.ListBackups(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(backups) == 0 {
+		return nil, fmt.Errorf("no backups found in %s", s.base)
+	}
+	if name == "latest" {
+		return backups[len(backups)-1], nil
+	}
+	for _, backup := range backups {
+		if backup.Name == name {
+			return backup, nil
+		}
+	}
+	return nil, fmt.Errorf("backup %q not found in %s", name, s.base)
+}
+
+// AddCommand writes a command for etcd-manager, returning the path of the command.
+func (s *BackupStore) AddCommand(ctx context.Context, command *Command, now time.Time) (vfs.Path, error) {
+	command.Timestamp = now.UnixNano()
+	data, err := json.Marshal(command)
+	if err != nil {
+		return nil, fmt.Errorf("error serializing command: %w", err)
+	}
+
+	p := s.base.Join(controlDir, now.UTC().Format(time.RFC3339Nano), commandFile)
+	if err := p.CreateFile(ctx, bytes.NewReader(data), nil); err != nil {
+		return nil, fmt.Errorf("error writing command to %s: %w", p, err)
+	}
+	return p, nil
+}
+
+// ListCommands returns the commands that etcd-manager has not yet applied, oldest first.
+func (s *BackupStore) ListCommands(ctx context.Context) ([]*PendingCommand, error) {
+	control := s.base.Join(controlDir)
+	tree, err := control.ReadTree(ctx)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error listing commands in %s: %w", control, err)
+	}
+
+	var commands []*PendingCommand
+	for _, p := range tree {
+		if p.Base() != commandFile {
+			continue
+		}
+		command := &Command{}
+		found, err := readJSON(ctx, p, command)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			continue
+		}
+		commands = append(commands, &PendingCommand{Path: p, Command: command})
+	}
+	sort.Slice(commands, func(i, j int) bool {
+		return commands[i].Command.Timestamp < commands[j].Command.Timestamp
+	})
+	return commands, nil
+}
+
+// IsPending returns true if the command at p has not yet been applied.
+func (s *BackupStore) IsPending(ctx context.Context, p vfs.Path) (bool, error) {
+	_, err := p.ReadFile(ctx)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("error reading command %s: %w", p, err)
+	}
+	return true, nil
+}
+
+// String returns a short description of the command, for display.
+func (c *Command) String() string {
+	var actions []string
+	if c.RestoreBackup != nil {
+		actions = append(actions, "restore-backup "+c.RestoreBackup.Backup)
+	}
+	if c.BackupNow != nil {
+		actions = append(actions, "backup-now")
+	}
+	if len(actions) == 0 {
+		return "unknown"
+	}
+	return strings.Join(actions, ", ")
+}
+
+// Time returns when the backup was taken.
+func (b *Backup) Time() time.Time {
+	if b.Info == nil || b.Info.Timestamp == 0 {
+		return time.Time{}
+	}
+	return time.Unix(b.Info.Timestamp, 0)
+}
+
+// readJSON reads and parses the JSON file at p, returning false if it does not exist.
+func readJSON(ctx context.Context, p vfs.Path, into any) (bool, error) {
+	data, err := p.ReadFile(ctx)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("error reading %s: %w", p, err)
+	}
+	if err := json.Unmarshal(data, into); err != nil {
+		return false, fmt.Errorf("error parsing %s: %w", p, err)
+	}
+	return true, nil
+}
@@ -0,0 +1,327 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcdmanager
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+// newTestBackupStore builds a memfs backup store laid out the way etcd-manager writes it.
+func newTestBackupStore(t *testing.T, spec *ClusterSpec, backups map[string]time.Time) *BackupStore {
+	t.Helper()
+	ctx := context.TODO()
+
+	base, err := vfs.NewTestingVFSContext().BuildVfsPath("memfs://tests/backups/etcd/main")
+	if err != nil {
+		t.Fatalf("error building path: %v", err)
+	}
+
+	write := func(p vfs.Path, v any) {
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Fatalf("error serializing: %v", err)
+		}
+		if err := p.WriteFile(ctx, strings.NewReader(string(data)), nil); err != nil {
+			t.Fatalf("error writing %s: %v", p, err)
+		}
+	}
+	if spec != nil {
+		write(base.Join("control", "etcd-cluster-spec"), spec)
+	}
+	for name, timestamp := range backups {
+		write(base.Join(name, "_etcd_backup.meta"), &BackupInfo{
+			EtcdVersion: "3.5.21",
+			Timestamp:   timestamp.Unix(),
+			ClusterSpec: &ClusterSpec{MemberCount: 1, EtcdVersion: "3.5.21"},
+		})
+		if err := base.Join(name, "etcd.backup.gz").WriteFile(ctx, strings.NewReader("snapshot"), nil); err != nil {
+			t.Fatalf("error writing backup: %v", err)
+		}
+	}
+	return NewBackupStore(base)
+}
+
+func TestListBackups(t *testing.T) {
+	ctx := context.TODO()
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	store := newTestBackupStore(t, &ClusterSpec{MemberCount: 3, EtcdVersion: "3.5.21"}, map[string]time.Time{
+		"2026-10-01T11:00:00Z-000002": now.Add(-time.Hour),
+		"2026-10-01T10:00:00Z-000001": now.Add(-2 * time.Hour),
+	})
+
+	backups, err := store.ListBackups(ctx)
+	if err != nil {
+		t.Fatalf("ListBackups: %v", err)
+	}
+	var names []string
+	for _, backup := range backups {
+		names = append(names, backup.Name)
+	}
+	if strings.Join(names, ",") != "2026-10-01T10:00:00Z-000001,2026-10-01T11:00:00Z-000002" {
+		t.Errorf("unexpected backups %v", names)
+	}
+	if !backups[1].Time().Equal(now.Add(-time.Hour)) {
+		t.Errorf("unexpected backup time %v", backups[1].Time())
+	}
+
+	latest, err := store.FindBackup(ctx, "latest")
+	if err != nil {
+		t.Fatalf("FindBackup: %v", err)
+	}
+	if latest.Name != "2026-10-01T11:00:00Z-000002" {
+		t.Errorf("unexpected latest backup %q", latest.Name)
+	}
+	if _, err := store.FindBackup(ctx, "2026-01-01T00:00:00Z-000001"); err == nil {
+		t.Errorf("expected error finding missing backup")
+	}
+}
+
+func TestRequestRestore(t *testing.T) {
+	ctx := context.TODO()
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	store := newTestBackupStore(t, &ClusterSpec{MemberCount: 3, EtcdVersion: "3.5.21"}, map[string]time.Time{
+		"2026-10-01T11:00:00Z-000001": now.Add(-time.Hour),
+	})
+
+	backup, p, err := RequestRestore(ctx, store, "latest", now)
+	if err != nil {
+		t.Fatalf("RequestRestore: %v", err)
+	}
+	if backup.Name != "2026-10-01T11:00:00Z-000001" {
+		t.Errorf("unexpected backup %q", backup.Name)
+	}
+	if expected := "memfs://tests/backups/etcd/main/control/2026-10-01T12:00:00Z/_command.json"; p.Path() != expected {
+		t.Errorf("expected command at %s, got %s", expected, p.Path())
+	}
+
+	// The command must be in the form etcd-manager reads
+	data, err := p.ReadFile(ctx)
+	if err != nil {
+		t.Fatalf("error reading command: %v", err)
+	}
+	expected := `{"timestamp":1790856000000000000,"restoreBackup":{"clusterSpec":{"memberCount":3,"etcdVersion":"3.5.21"},"backup":"2026-10-01T11:00:00Z-000001"}}`
+	if string(data) != expected {
+		t.Errorf("unexpected command\nexpected: %s\nactual:   %s", expected, string(data))
+	}
+
+	if _, _, err := RequestRestore(ctx, store, "latest", now.Add(time.Minute)); err == nil || !strings.Contains(err.Error(), "already pending") {
+		t.Errorf("expected error for second restore, got %v", err)
+	}
+
+	// etcd-manager removes the command once it has been applied
+	if err := WaitForCommand(ctx, store, p, time.Millisecond, 10*time.Millisecond); err == nil {
+		t.Errorf("expected timeout waiting for pending command")
+	}
+	if err := p.Remove(ctx); err != nil {
+		t.Fatalf("error removing command: %v", err)
+	}
+	if err := WaitForCommand(ctx, store, p, time.Millisecond, time.Second); err != nil {
+		t.Errorf("WaitForCommand: %v", err)
+	}
+}
+
+func TestRequestBackup(t *testing.T) {
+	ctx := context.TODO()
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	store := newTestBackupStore(t, &ClusterSpec{MemberCount: 3}, nil)
+
+	p, err := RequestBackup(ctx, store, now)
+	if err != nil {
+		t.Fatalf("RequestBackup: %v", err)
+	}
+	data, err := p.ReadFile(ctx)
+	if err != nil {
+		t.Fatalf("error reading command: %v", err)
+	}
+	expected := `{"timestamp":1790856000000000000,"backupNow":{}}`
+	if string(data) != expected {
+		t.Errorf("unexpected command\nexpected: %s\nactual:   %s", expected, string(data))
+	}
+
+	// A pending request is reused
+	again, err := RequestBackup(ctx, store, now.Add(time.Minute))
+	if err != nil {
+		t.Fatalf("RequestBackup: %v", err)
+	}
+	if again.Path() != p.Path() {
+		t.Errorf("expected pending command %s to be reused, got %s", p, again)
+	}
+
+	// Once the backup has been taken, a command that etcd-manager did not apply is withdrawn
+	if err := WithdrawCommand(ctx, store, p); err != nil {
+		t.Fatalf("WithdrawCommand: %v", err)
+	}
+	pending, err := store.ListCommands(ctx)
+	if err != nil {
+		t.Fatalf("ListCommands: %v", err)
+	}
+	if len(pending) != 0 {
+		t.Errorf("expected no pending commands, got %v", pending)
+	}
+	if err := WithdrawCommand(ctx, store, p); err != nil {
+		t.Errorf("WithdrawCommand of an applied command: %v", err)
+	}
+}
+
+func TestCheckHealth(t *testing.T) {
+	ctx := context.TODO()
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	etcdCluster := &kops.EtcdClusterSpec{
+		Name: "main",
+		Members: []kops.EtcdMemberSpec{
+			{Name: "a", InstanceGroup: fi.PtrTo("control-plane-a")},
+			{Name: "b", InstanceGroup: fi.PtrTo("control-plane-b")},
+			{Name: "c", InstanceGroup: fi.PtrTo("control-plane-c")},
+		},
+	}
+
+	grid := []struct {
+		name     string
+		spec     *ClusterSpec
+		backups  map[string]time.Time
+		manager  *kops.EtcdManagerSpec
+		problems []string
+	}{
+		{
+			name:    "healthy",
+			spec:    &ClusterSpec{MemberCount: 3},
+			backups: map[string]time.Time{"2026-10-01T11:50:00Z-000001": now.Add(-10 * time.Minute)},
+		},
+		{
+			name:     "no backups",
+			spec:     &ClusterSpec{MemberCount: 3},
+			problems: []string{"no backups found"},
+		},
+		{
+			name:     "stale backup",
+			spec:     &ClusterSpec{MemberCount: 3},
+			backups:  map[string]time.Time{"2026-10-01T10:00:00Z-000001": now.Add(-2 * time.Hour)},
+			problems: []string{"the latest backup is 2h0m0s old, expected a backup every 15m0s"},
+		},
+		{
+			name:    "longer backup interval",
+			spec:    &ClusterSpec{MemberCount: 3},
+			backups: map[string]time.Time{"2026-10-01T10:00:00Z-000001": now.Add(-2 * time.Hour)},
+			manager: &kops.EtcdManagerSpec{BackupInterval: &metav1.Duration{Duration: time.Hour}},
+		},
+		{
+			name:     "member count mismatch",
+			spec:     &ClusterSpec{MemberCount: 1},
+			backups:  map[string]time.Time{"2026-10-01T11:50:00Z-000001": now.Add(-10 * time.Minute)},
+			problems: []string{"the etcd cluster spec has 1 members, but the cluster has 3; run kops update cluster"},
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			store := newTestBackupStore(t, g.spec, g.backups)
+			cluster := etcdCluster.DeepCopy()
+			cluster.Manager = g.manager
+
+			health, err := CheckHealth(ctx, store, cluster, now)
+			if err != nil {
+				t.Fatalf("CheckHealth: %v", err)
+			}
+			if strings.Join(health.Problems, "\n") != strings.Join(g.problems, "\n") {
+				t.Errorf("expected problems %q, got %q", g.problems, health.Problems)
+			}
+			if health.Healthy() != (len(g.problems) == 0) {
+				t.Errorf("unexpected Healthy() %v", health.Healthy())
+			}
+		})
+	}
+
+	// Commands that etcd-manager does not apply are reported
+	store := newTestBackupStore(t, &ClusterSpec{MemberCount: 3}, map[string]time.Time{"2026-10-01T11:50:00Z-000001": now.Add(-10 * time.Minute)})
+	if _, err := store.AddCommand(ctx, &Command{RestoreBackup: &RestoreBackupCommand{Backup: "2026-10-01T11:50:00Z-000001"}}, now.Add(-time.Hour)); err != nil {
+		t.Fatalf("AddCommand: %v", err)
+	}
+	health, err := CheckHealth(ctx, store, etcdCluster, now)
+	if err != nil {
+		t.Fatalf("CheckHealth: %v", err)
+	}
+	if len(health.PendingCommands) != 1 || health.Healthy() {
+		t.Errorf("expected an unhealthy cluster with a pending command, got %+v", health)
+	}
+}
+
+func TestWaitForMemberCount(t *testing.T) {
+	ctx := context.TODO()
+	now := time.Now()
+
+	store := newTestBackupStore(t, &ClusterSpec{MemberCount: 3}, map[string]time.Time{"2026-10-01T11:50:00Z-000001": now.Add(-time.Hour)})
+
+	// The existing backup is too old
+	if _, err := WaitForMemberCount(ctx, store, 1, now, time.Millisecond, 20*time.Millisecond); err == nil {
+		t.Fatalf("expected timeout waiting for member count")
+	}
+	// Nothing is asked of etcd-manager while waiting
+	pending, err := store.ListCommands(ctx)
+	if err != nil {
+		t.Fatalf("ListCommands: %v", err)
+	}
+	if len(pending) != 0 {
+		t.Fatalf("expected no commands, got %v", pending)
+	}
+
+	// etcd-manager takes its next backup, recording its current spec
+	data, err := json.Marshal(&BackupInfo{Timestamp: now.Unix(), ClusterSpec: &ClusterSpec{MemberCount: 1}})
+	if err != nil {
+		t.Fatalf("error serializing: %v", err)
+	}
+	if err := store.Path().Join("2026-10-01T12:00:00Z-000002", "_etcd_backup.meta").WriteFile(ctx, strings.NewReader(string(data)), nil); err != nil {
+		t.Fatalf("error writing backup: %v", err)
+	}
+
+	backup, err := WaitForMemberCount(ctx, store, 1, now, time.Millisecond, time.Second)
+	if err != nil {
+		t.Fatalf("WaitForMemberCount: %v", err)
+	}
+	if backup.Name != "2026-10-01T12:00:00Z-000002" {
+		t.Errorf("unexpected backup %q", backup.Name)
+	}
+}
+
+func TestWriteClusterSpec(t *testing.T) {
+	ctx := context.TODO()
+
+	store := newTestBackupStore(t, &ClusterSpec{MemberCount: 3, EtcdVersion: "3.5.21"}, nil)
+
+	if err := store.WriteClusterSpec(ctx, &ClusterSpec{MemberCount: 1, EtcdVersion: "3.5.21"}); err != nil {
+		t.Fatalf("WriteClusterSpec: %v", err)
+	}
+
+	spec, err := store.LoadClusterSpec(ctx)
+	if err != nil {
+		t.Fatalf("LoadClusterSpec: %v", err)
+	}
+	if spec == nil || spec.MemberCount != 1 || spec.EtcdVersion != "3.5.21" {
+		t.Errorf("unexpected cluster spec %+v", spec)
+	}
+}
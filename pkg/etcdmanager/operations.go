@@ -0,0 +1,251 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcdmanager
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+// DefaultBackupInterval is how often etcd-manager takes backups, unless configured otherwise.
+const DefaultBackupInterval = 15 * time.Minute
+
+// RequestRestore writes a command asking etcd-manager to restore the named backup ("latest" for the most recent).
+// The cluster spec of the restored cluster is the current desired spec, falling back to the spec recorded in the backup.
+func RequestRestore(ctx context.Context, store *BackupStore, backupName string, now time.Time) (*Backup, vfs.Path, error) {
+	backup, err := store.FindBackup(ctx, backupName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pending, err := store.ListCommands(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, command := range pending {
+		if command.Command.RestoreBackup != nil {
+			return nil, nil, fmt.Errorf("a restore is already pending in %s: %s", store.Path(), command.Command)
+		}
+	}
+
+	spec, err := store.LoadClusterSpec(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if spec == nil {
+		spec = backup.Info.ClusterSpec
+	}
+	if spec == nil {
+		return nil, nil, fmt.Errorf("cannot determine the cluster spec for restoring %q; run kops update cluster first", backup.Name)
+	}
+
+	p, err := store.AddCommand(ctx, &Command{
+		RestoreBackup: &RestoreBackupCommand{
+			ClusterSpec: spec,
+			Backup:      backup.Name,
+		},
+	}, now)
+	if err != nil {
+		return nil, nil, err
+	}
+	return backup, p, nil
+}
+
+// RequestBackup writes a command asking etcd-manager to take a backup now.
+// If a backup has already been requested and not yet taken, the pending command is returned instead.
+func RequestBackup(ctx context.Context, store *BackupStore, now time.Time) (vfs.Path, error) {
+	pending, err := store.ListCommands(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, command := range pending {
+		if command.Command.BackupNow != nil {
+			return command.Path, nil
+		}
+	}
+	return store.AddCommand(ctx, &Command{BackupNow: &BackupNowCommand{}}, now)
+}
+
+// WithdrawCommand removes the command at p if etcd-manager has not applied it yet.
+// It is used once a requested backup has been taken anyway, so that an etcd-manager that does not know the command does not keep it pending.
+func WithdrawCommand(ctx context.Context, store *BackupStore, p vfs.Path) error {
+	pending, err := store.IsPending(ctx, p)
+	if err != nil || !pending {
+		return err
+	}
+	if err := p.Remove(ctx); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error removing command %s: %w", p, err)
+	}
+	return nil
+}
+
+// WaitForCommand polls until etcd-manager has applied the command at p, or the timeout expires.
+func WaitForCommand(ctx context.Context, store *BackupStore, p vfs.Path, interval time.Duration, timeout time.Duration) error {
+	err := wait.PollUntilContextTimeout(ctx, interval, timeout, true, func(ctx context.Context) (bool, error) {
+		pending, err := store.IsPending(ctx, p)
+		return !pending, err
+	})
+	if err != nil {
+		return fmt.Errorf("command %s was not applied by etcd-manager: %w", p, err)
+	}
+	return nil
+}
+
+// WaitForBackup polls until a backup taken after since appears, or the timeout expires.
+func WaitForBackup(ctx context.Context, store *BackupStore, since time.Time, interval time.Duration, timeout time.Duration) (*Backup, error) {
+	var backup *Backup
+	err := wait.PollUntilContextTimeout(ctx, interval, timeout, true, func(ctx context.Context) (bool, error) {
+		backups, err := store.ListBackups(ctx)
+		if err != nil {
+			return false, err
+		}
+		if len(backups) == 0 {
+			return false, nil
+		}
+		latest := backups[len(backups)-1]
+		// Backup timestamps only have second precision
+		if latest.Time().Before(since.Truncate(time.Second)) {
+			return false, nil
+		}
+		backup = latest
+		return true, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("no new backup appeared in %s: %w", store.Path(), err)
+	}
+	return backup, nil
+}
+
+// WaitForMemberCount polls until etcd-manager takes a backup of the etcd cluster running with memberCount members,
+// or the timeout expires. etcd-manager records its cluster spec in each backup, and only backs up a healthy cluster,
+// so such a backup shows that etcd-manager has finished adding or removing members.
+// etcd-manager cannot be asked for a backup, so the timeout must allow for at least one backup interval.
+func WaitForMemberCount(ctx context.Context, store *BackupStore, memberCount int32, since time.Time, interval time.Duration, timeout time.Duration) (*Backup, error) {
+	var backup *Backup
+	err := wait.PollUntilContextTimeout(ctx, interval, timeout, true, func(ctx context.Context) (bool, error) {
+		backups, err := store.ListBackups(ctx)
+		if err != nil {
+			return false, err
+		}
+		if len(backups) == 0 {
+			return false, nil
+		}
+		latest := backups[len(backups)-1]
+		// Backup timestamps only have second precision
+		if latest.Time().Before(since.Truncate(time.Second)) || latest.Info.ClusterSpec == nil || latest.Info.ClusterSpec.MemberCount != memberCount {
+			return false, nil
+		}
+		backup = latest
+		return true, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("etcd cluster in %s did not reach %d members: %w", store.Path(), memberCount, err)
+	}
+	return backup, nil
+}
+
+// ClusterHealth summarizes the state of an etcd cluster, as seen from its backup store.
+type ClusterHealth struct {
+	// Name is the name of the etcd cluster, e.g. main
+	Name string
+	// LatestBackup is the most recent backup, or nil if there are none
+	LatestBackup *Backup
+	// PendingCommands are the commands etcd-manager has not yet applied
+	PendingCommands []*PendingCommand
+	// Verification is the status of the backup verifications, or nil if they are not enabled or have not run
+	Verification *VerificationStatus
+	// Problems lists the reasons the cluster is not healthy; it is empty for a healthy cluster
+	Problems []string
+}
+
+// Healthy returns true if no problems were found.
+func (h *ClusterHealth) Healthy() bool {
+	return len(h.Problems) == 0
+}
+
+// BackupInterval returns how often etcd-manager backs up the etcd cluster.
+func BackupInterval(etcdCluster *kops.EtcdClusterSpec) time.Duration {
+	if etcdCluster.Manager != nil && etcdCluster.Manager.BackupInterval != nil {
+		return etcdCluster.Manager.BackupInterval.Duration
+	}
+	return DefaultBackupInterval
+}
+
+// CheckHealth checks that etcd-manager is taking backups and applying commands for the etcd cluster.
+// etcd-manager only backs up a healthy cluster, so a recent backup indicates a healthy cluster.
+// If backup verification is enabled, the latest verification must have succeeded recently.
+func CheckHealth(ctx context.Context, store *BackupStore, etcdCluster *kops.EtcdClusterSpec, now time.Time) (*ClusterHealth, error) {
+	health := &ClusterHealth{Name: etcdCluster.Name}
+
+	backupInterval := BackupInterval(etcdCluster)
+	// Allow for a missed backup, and for the time taken by the backup itself
+	maxBackupAge := 3 * backupInterval
+
+	spec, err := store.LoadClusterSpec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if spec == nil {
+		health.Problems = append(health.Problems, "the etcd cluster spec has not been written; run kops update cluster")
+	} else if int(spec.MemberCount) != len(etcdCluster.Members) {
+		health.Problems = append(health.Problems, fmt.Sprintf("the etcd cluster spec has %d members, but the cluster has %d; run kops update cluster", spec.MemberCount, len(etcdCluster.Members)))
+	}
+
+	backups, err := store.ListBackups(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(backups) == 0 {
+		health.Problems = append(health.Problems, "no backups found")
+	} else {
+		health.LatestBackup = backups[len(backups)-1]
+		if age := now.Sub(health.LatestBackup.Time()); age > maxBackupAge {
+			health.Problems = append(health.Problems, fmt.Sprintf("the latest backup is %s old, expected a backup every %s", age.Round(time.Second), backupInterval))
+		}
+	}
+
+	health.PendingCommands, err = store.ListCommands(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, command := range health.PendingCommands {
+		age := now.Sub(time.Unix(0, command.Command.Timestamp))
+		if age > maxBackupAge {
+			health.Problems = append(health.Problems, fmt.Sprintf("command %q has not been applied after %s", command.Command, age.Round(time.Second)))
+		}
+	}
+
+	if etcdCluster.Backups != nil && etcdCluster.Backups.Verification != nil {
+		verificationInterval := DefaultVerificationInterval
+		if etcdCluster.Backups.Verification.Interval != nil {
+			verificationInterval = etcdCluster.Backups.Verification.Interval.Duration
+		}
+		health.Verification, err = store.LoadVerificationStatus(ctx)
+		if err != nil {
+			return nil, err
+		}
+		health.Problems = append(health.Problems, checkVerification(health.Verification, verificationInterval, now)...)
+	}
+
+	return health, nil
+}
@@ -0,0 +1,292 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcdmanager
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// verificationStatusFile holds the VerificationStatus, next to the cluster spec.
+	// etcd-manager only reads _command.json files and the cluster spec from the control directory.
+	verificationStatusFile = "backup-verification.json"
+	// backupDataFile holds the compressed etcd snapshot of each backup
+	backupDataFile = "etcd.backup.gz"
+
+	// ConditionBackupVerified is the type of the condition reporting the latest backup verification
+	ConditionBackupVerified = "BackupVerified"
+
+	// DefaultVerificationInterval is how often backups are verified, unless configured otherwise.
+	DefaultVerificationInterval = 24 * time.Hour
+)
+
+// VerificationResult is the outcome of restoring a backup and checking its contents.
+type VerificationResult struct {
+	// Backup is the name of the backup that was verified
+	Backup string `json:"backup,omitempty"`
+	// Time is when the verification finished
+	Time metav1.Time `json:"time"`
+	// Keys is the number of keys in the restored backup
+	Keys int64 `json:"keys"`
+	// Revision is the etcd revision of the restored backup
+	Revision int64 `json:"revision"`
+	// PrefixKeys is the number of keys under each of the required prefixes
+	PrefixKeys map[string]int64 `json:"prefixKeys,omitempty"`
+	// Problems lists the reasons the verification failed; it is empty for a verified backup
+	Problems []string `json:"problems,omitempty"`
+}
+
+// Verified returns true if no problems were found.
+func (r *VerificationResult) Verified() bool {
+	return len(r.Problems) == 0
+}
+
+// VerificationStatus records the backup verifications of an etcd cluster.
+type VerificationStatus struct {
+	// Conditions holds the BackupVerified condition
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// LastResult is the result of the latest verification
+	LastResult *VerificationResult `json:"lastResult,omitempty"`
+	// LastVerified is the result of the latest successful verification
+	LastVerified *VerificationResult `json:"lastVerified,omitempty"`
+}
+
+// Record updates the status with the result of a verification.
+func (s *VerificationStatus) Record(result *VerificationResult) {
+	condition := metav1.Condition{
+		Type:    ConditionBackupVerified,
+		Status:  metav1.ConditionTrue,
+		Reason:  "Verified",
+		Message: fmt.Sprintf("backup %s restored with %d keys at revision %d", result.Backup, result.Keys, result.Revision),
+	}
+	if !result.Verified() {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "VerificationFailed"
+		condition.Message = fmt.Sprintf("backup %s: %s", result.Backup, result.Problems[0])
+	}
+	meta.SetStatusCondition(&s.Conditions, condition)
+
+	s.LastResult = result
+	if result.Verified() {
+		s.LastVerified = result
+	}
+}
+
+// checkVerification reports a failed verification, or a verification that has not run for two intervals.
+func checkVerification(status *VerificationStatus, interval time.Duration, now time.Time) []string {
+	if status == nil || status.LastResult == nil {
+		return []string{"no backup verification has completed yet"}
+	}
+
+	var problems []string
+	condition := meta.FindStatusCondition(status.Conditions, ConditionBackupVerified)
+	if condition != nil && condition.Status != metav1.ConditionTrue {
+		problems = append(problems, "backup verification failed: "+condition.Message)
+	}
+	if age := now.Sub(status.LastResult.Time.Time); age > 2*interval {
+		problems = append(problems, fmt.Sprintf("the latest backup verification is %s old, expected a verification every %s", age.Round(time.Second), interval))
+	}
+	return problems
+}
+
+// LoadVerificationStatus reads the verification status, returning nil if no verification has run.
+func (s *BackupStore) LoadVerificationStatus(ctx context.Context) (*VerificationStatus, error) {
+	status := &VerificationStatus{}
+	found, err := readJSON(ctx, s.base.Join(controlDir, verificationStatusFile), status)
+	if err != nil || !found {
+		return nil, err
+	}
+	return status, nil
+}
+
+// WriteVerificationStatus replaces the verification status.
+func (s *BackupStore) WriteVerificationStatus(ctx context.Context, status *VerificationStatus) error {
+	data, err := json.Marshal(status)
+	if err != nil {
+		return fmt.Errorf("error serializing verification status: %w", err)
+	}
+	p := s.base.Join(controlDir, verificationStatusFile)
+	if err := p.WriteFile(ctx, bytes.NewReader(data), nil); err != nil {
+		return fmt.Errorf("error writing %s: %w", p, err)
+	}
+	return nil
+}
+
+// FetchBackup downloads the named backup and writes the uncompressed etcd snapshot to dest.
+func (s *BackupStore) FetchBackup(ctx context.Context, name string, dest string) error {
+	p := s.base.Join(name, backupDataFile)
+	data, err := p.ReadFile(ctx)
+	if err != nil {
+		return fmt.Errorf("error reading backup %s: %w", p, err)
+	}
+
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("error decompressing backup %s: %w", p, err)
+	}
+	defer gz.Close()
+
+	f, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, gz); err != nil {
+		f.Close()
+		return fmt.Errorf("error decompressing backup %s: %w", p, err)
+	}
+	return f.Close()
+}
+
+// VerificationCheck describes what a restored backup must hold.
+type VerificationCheck struct {
+	// MinKeys is the minimum number of keys
+	MinKeys int64
+	// RequiredPrefixes are the prefixes that must each have at least one key
+	RequiredPrefixes []string
+	// PreviousRevision is the revision of the last verified backup; backups must not go back in time
+	PreviousRevision int64
+}
+
+// CheckRestoredEtcd counts the keys in a restored etcd, using the JSON gateway of its client endpoint.
+// The endpoint is retried until etcd is serving or ctx is done.
+func CheckRestoredEtcd(ctx context.Context, httpClient *http.Client, endpoint string, check *VerificationCheck, backup string) (*VerificationResult, error) {
+	result := &VerificationResult{
+		Backup:     backup,
+		PrefixKeys: make(map[string]int64),
+	}
+
+	var err error
+	for {
+		result.Keys, result.Revision, err = countKeys(ctx, httpClient, endpoint, "\x00", "\x00")
+		if err == nil {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("etcd did not start serving the restored backup: %w", err)
+		case <-time.After(2 * time.Second):
+		}
+	}
+
+	if result.Revision <= 0 {
+		result.Problems = append(result.Problems, "the restored backup has no revision")
+	}
+	if check.PreviousRevision != 0 && result.Revision < check.PreviousRevision {
+		result.Problems = append(result.Problems, fmt.Sprintf("the revision %d is older than the revision %d of the previously verified backup", result.Revision, check.PreviousRevision))
+	}
+	if result.Keys < check.MinKeys {
+		result.Problems = append(result.Problems, fmt.Sprintf("the restored backup has %d keys, expected at least %d", result.Keys, check.MinKeys))
+	}
+	for _, prefix := range check.RequiredPrefixes {
+		count, _, err := countKeys(ctx, httpClient, endpoint, prefix, prefixRangeEnd(prefix))
+		if err != nil {
+			return nil, err
+		}
+		result.PrefixKeys[prefix] = count
+		if count == 0 {
+			result.Problems = append(result.Problems, fmt.Sprintf("no keys found with prefix %q", prefix))
+		}
+	}
+
+	result.Time = metav1.Now()
+	return result, nil
+}
+
+// prefixRangeEnd returns the end of the key range holding all keys with the prefix, as etcdctl --prefix does.
+func prefixRangeEnd(prefix string) string {
+	end := []byte(prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return string(end[:i+1])
+		}
+	}
+	// The prefix is all 0xff, so the range extends to the end of the keyspace
+	return "\x00"
+}
+
+// countKeys returns the number of keys in the range, and the current revision.
+func countKeys(ctx context.Context, httpClient *http.Client, endpoint string, key string, rangeEnd string) (int64, int64, error) {
+	request := map[string]any{
+		"key":        base64.StdEncoding.EncodeToString([]byte(key)),
+		"range_end":  base64.StdEncoding.EncodeToString([]byte(rangeEnd)),
+		"count_only": true,
+	}
+	body, err := json.Marshal(request)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint+"/v3/kv/range", bytes.NewReader(body))
+	if err != nil {
+		return 0, 0, err
+	}
+	httpRequest.Header.Set("Content-Type", "application/json")
+	response, err := httpClient.Do(httpRequest)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer response.Body.Close()
+
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		return 0, 0, err
+	}
+	if response.StatusCode != http.StatusOK {
+		return 0, 0, fmt.Errorf("unexpected response from etcd: %s: %s", response.Status, string(responseBody))
+	}
+
+	// The gateway encodes int64 values as strings, and omits zero values
+	var rangeResponse struct {
+		Header struct {
+			Revision string `json:"revision"`
+		} `json:"header"`
+		Count string `json:"count"`
+	}
+	if err := json.Unmarshal(responseBody, &rangeResponse); err != nil {
+		return 0, 0, fmt.Errorf("error parsing response from etcd: %w", err)
+	}
+	count, err := parseOptionalInt64(rangeResponse.Count)
+	if err != nil {
+		return 0, 0, fmt.Errorf("error parsing count from etcd: %w", err)
+	}
+	revision, err := parseOptionalInt64(rangeResponse.Header.Revision)
+	if err != nil {
+		return 0, 0, fmt.Errorf("error parsing revision from etcd: %w", err)
+	}
+	return count, revision, nil
+}
+
+func parseOptionalInt64(s string) (int64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	return strconv.ParseInt(s, 10, 64)
+}
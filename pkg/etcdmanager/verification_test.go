@@ -0,0 +1,241 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcdmanager
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kops/pkg/apis/kops"
+)
+
+// fakeEtcdGateway serves range requests from the etcd JSON gateway, over a fixed set of keys.
+func fakeEtcdGateway(t *testing.T, revision int64, keys []string) *httptest.Server {
+	sort.Strings(keys)
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v3/kv/range" || r.Method != http.MethodPost {
+			http.NotFound(w, r)
+			return
+		}
+		// []byte fields are base64 encoded, as in the gateway
+		var request struct {
+			Key       []byte `json:"key"`
+			RangeEnd  []byte `json:"range_end"`
+			CountOnly bool   `json:"count_only"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Errorf("error parsing request: %v", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if !request.CountOnly {
+			t.Errorf("expected count_only request")
+		}
+
+		var count int64
+		for _, key := range keys {
+			if key < string(request.Key) {
+				continue
+			}
+			// A range end of \x00 means all keys from the key
+			if string(request.RangeEnd) != "\x00" && key >= string(request.RangeEnd) {
+				continue
+			}
+			count++
+		}
+
+		// The gateway encodes int64 values as strings, and omits zero values
+		response := map[string]any{
+			"header": map[string]any{"revision": fmt.Sprintf("%d", revision)},
+		}
+		if count != 0 {
+			response["count"] = fmt.Sprintf("%d", count)
+		}
+		json.NewEncoder(w).Encode(response)
+	}))
+}
+
+func TestCheckRestoredEtcd(t *testing.T) {
+	ctx := context.TODO()
+
+	keys := []string{
+		"/registry/namespaces/default",
+		"/registry/namespaces/kube-system",
+		"/registry/secrets/kube-system/token",
+	}
+	server := fakeEtcdGateway(t, 1234, keys)
+	defer server.Close()
+
+	grid := []struct {
+		name     string
+		check    VerificationCheck
+		problems []string
+	}{
+		{
+			name:  "verified",
+			check: VerificationCheck{MinKeys: 3, RequiredPrefixes: []string{"/registry/namespaces/", "/registry/secrets/"}, PreviousRevision: 1000},
+		},
+		{
+			name:     "too few keys",
+			check:    VerificationCheck{MinKeys: 10},
+			problems: []string{"the restored backup has 3 keys, expected at least 10"},
+		},
+		{
+			name:     "missing prefix",
+			check:    VerificationCheck{RequiredPrefixes: []string{"/registry/configmaps/"}},
+			problems: []string{`no keys found with prefix "/registry/configmaps/"`},
+		},
+		{
+			name:     "older revision",
+			check:    VerificationCheck{PreviousRevision: 2000},
+			problems: []string{"the revision 1234 is older than the revision 2000 of the previously verified backup"},
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			result, err := CheckRestoredEtcd(ctx, server.Client(), server.URL, &g.check, "backup-1")
+			if err != nil {
+				t.Fatalf("CheckRestoredEtcd: %v", err)
+			}
+			if result.Keys != 3 || result.Revision != 1234 {
+				t.Errorf("unexpected keys %d and revision %d", result.Keys, result.Revision)
+			}
+			if strings.Join(result.Problems, "\n") != strings.Join(g.problems, "\n") {
+				t.Errorf("expected problems %q, got %q", g.problems, result.Problems)
+			}
+			for _, prefix := range g.check.RequiredPrefixes {
+				if _, found := result.PrefixKeys[prefix]; !found {
+					t.Errorf("expected key count for prefix %q", prefix)
+				}
+			}
+		})
+	}
+
+	// etcd not serving is an error, not a failed verification
+	timeoutCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	if _, err := CheckRestoredEtcd(timeoutCtx, server.Client(), server.URL+"/missing", &VerificationCheck{}, "backup-1"); err == nil {
+		t.Errorf("expected error when etcd is not serving")
+	}
+}
+
+func TestPrefixRangeEnd(t *testing.T) {
+	grid := map[string]string{
+		"/registry/":     "/registry0",
+		"a":              "b",
+		"a\xff":          "b",
+		"\xff\xff":       "\x00",
+		"/registry/\x00": "/registry/\x01",
+	}
+	for prefix, expected := range grid {
+		if actual := prefixRangeEnd(prefix); actual != expected {
+			t.Errorf("prefixRangeEnd(%q): expected %q, got %q", prefix, expected, actual)
+		}
+	}
+}
+
+func TestFetchBackup(t *testing.T) {
+	ctx := context.TODO()
+
+	store := newTestBackupStore(t, nil, nil)
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	gz.Write([]byte("snapshot data"))
+	gz.Close()
+	if err := store.Path().Join("backup-1", "etcd.backup.gz").WriteFile(ctx, bytes.NewReader(compressed.Bytes()), nil); err != nil {
+		t.Fatalf("error writing backup: %v", err)
+	}
+
+	dest := filepath.Join(t.TempDir(), "snapshot.db")
+	if err := store.FetchBackup(ctx, "backup-1", dest); err != nil {
+		t.Fatalf("FetchBackup: %v", err)
+	}
+	data, err := os.ReadFile(dest)
+	if err != nil {
+		t.Fatalf("error reading snapshot: %v", err)
+	}
+	if string(data) != "snapshot data" {
+		t.Errorf("unexpected snapshot %q", string(data))
+	}
+}
+
+func TestVerificationStatus(t *testing.T) {
+	ctx := context.TODO()
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	store := newTestBackupStore(t, &ClusterSpec{MemberCount: 1}, map[string]time.Time{"2026-10-01T11:50:00Z-000001": now.Add(-10 * time.Minute)})
+	etcdCluster := &kops.EtcdClusterSpec{
+		Name:    "main",
+		Members: []kops.EtcdMemberSpec{{Name: "a"}},
+		Backups: &kops.EtcdBackupSpec{
+			Verification: &kops.EtcdBackupVerificationSpec{},
+		},
+	}
+
+	checkProblems := func(expected ...string) {
+		t.Helper()
+		health, err := CheckHealth(ctx, store, etcdCluster, now)
+		if err != nil {
+			t.Fatalf("CheckHealth: %v", err)
+		}
+		if strings.Join(health.Problems, "\n") != strings.Join(expected, "\n") {
+			t.Errorf("expected problems %q, got %q", expected, health.Problems)
+		}
+	}
+
+	checkProblems("no backup verification has completed yet")
+
+	status := &VerificationStatus{}
+	status.Record(&VerificationResult{Backup: "backup-1", Time: metav1.NewTime(now.Add(-time.Hour)), Keys: 10, Revision: 100})
+	status.Record(&VerificationResult{Backup: "backup-2", Time: metav1.NewTime(now), Problems: []string{"no keys found"}})
+	if err := store.WriteVerificationStatus(ctx, status); err != nil {
+		t.Fatalf("WriteVerificationStatus: %v", err)
+	}
+
+	loaded, err := store.LoadVerificationStatus(ctx)
+	if err != nil {
+		t.Fatalf("LoadVerificationStatus: %v", err)
+	}
+	condition := meta.FindStatusCondition(loaded.Conditions, ConditionBackupVerified)
+	if condition == nil || condition.Status != metav1.ConditionFalse || condition.Reason != "VerificationFailed" {
+		t.Errorf("unexpected condition %+v", condition)
+	}
+	if loaded.LastVerified == nil || loaded.LastVerified.Backup != "backup-1" {
+		t.Errorf("expected last verified backup-1, got %+v", loaded.LastVerified)
+	}
+	checkProblems("backup verification failed: backup backup-2: no keys found")
+
+	// A verification that stops running is reported
+	status.Record(&VerificationResult{Backup: "backup-3", Time: metav1.NewTime(now.Add(-72 * time.Hour)), Keys: 10, Revision: 200})
+	if err := store.WriteVerificationStatus(ctx, status); err != nil {
+		t.Fatalf("WriteVerificationStatus: %v", err)
+	}
+	checkProblems("the latest backup verification is 72h0m0s old, expected a verification every 24h0m0s")
+}
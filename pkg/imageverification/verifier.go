@@ -0,0 +1,550 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package imageverification
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"k8s.io/kops/pkg/apis/kops"
+)
+
+// The signatures are stored in the format used by cosign: an image tagged sha256-<digest>.sig
+// in the repository of the signed image, with one layer per signature.
+const (
+	// SimpleSigningMediaType is the media type of the layers holding a signed payload.
+	SimpleSigningMediaType types.MediaType = "application/vnd.dev.cosign.simplesigning.v1+json"
+	// SignatureAnnotation holds the base64 encoded signature of the payload.
+	SignatureAnnotation = "dev.cosignproject.cosign/signature"
+	// CertificateAnnotation holds the PEM encoded certificate of a keyless signature.
+	CertificateAnnotation = "dev.sigstore.cosign/certificate"
+	// ChainAnnotation holds the PEM encoded intermediate certificates of a keyless signature.
+	ChainAnnotation = "dev.sigstore.cosign/chain"
+	// BundleAnnotation holds the transparency log entry of a keyless signature, as a JSON encoded Bundle.
+	BundleAnnotation = "dev.sigstore.cosign/bundle"
+	// SignatureType is the type of the payload of an image signature.
+	SignatureType = "cosign container image signature"
+
+	signatureTagSuffix = ".sig"
+)
+
+var (
+	// oidIssuer is the Fulcio extension holding the OIDC issuer as a raw string.
+	oidIssuer = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 1}
+	// oidIssuerV2 is the Fulcio extension holding the OIDC issuer as a DER encoded UTF8String.
+	oidIssuerV2 = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 8}
+)
+
+// Payload is the signed payload of an image signature.
+type Payload struct {
+	Critical struct {
+		Identity struct {
+			DockerReference string `json:"docker-reference"`
+		} `json:"identity"`
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+		Type string `json:"type"`
+	} `json:"critical"`
+	Optional map[string]interface{} `json:"optional"`
+}
+
+// Bundle is the proof that a signature was recorded in a transparency log, such as Rekor.
+type Bundle struct {
+	// SignedEntryTimestamp is the signature of the log over the canonical JSON encoding of the Payload.
+	SignedEntryTimestamp []byte        `json:"SignedEntryTimestamp"`
+	Payload              BundlePayload `json:"Payload"`
+}
+
+// BundlePayload is the log entry of a signature. The fields are in the order of their canonical JSON encoding.
+type BundlePayload struct {
+	// Body is the base64 encoded hashedrekord entry recording the signature.
+	Body           string `json:"body"`
+	IntegratedTime int64  `json:"integratedTime"`
+	LogID          string `json:"logID"`
+	LogIndex       int64  `json:"logIndex"`
+}
+
+// hashedRekord is the body of the log entry of a signature.
+type hashedRekord struct {
+	Kind string `json:"kind"`
+	Spec struct {
+		Data struct {
+			Hash struct {
+				Algorithm string `json:"algorithm"`
+				Value     string `json:"value"`
+			} `json:"hash"`
+		} `json:"data"`
+		Signature struct {
+			Content   []byte `json:"content"`
+			PublicKey struct {
+				Content []byte `json:"content"`
+			} `json:"publicKey"`
+		} `json:"signature"`
+	} `json:"spec"`
+}
+
+// Verifier verifies the signatures of images against an ImageVerificationSpec.
+type Verifier struct {
+	action   kops.ImageVerificationAction
+	policies []*policy
+	// mirrors maps the repositories that images are copied to, to the repositories they are copied from.
+	mirrors map[string]string
+}
+
+type policy struct {
+	images     []string
+	keys       []crypto.PublicKey
+	roots      *x509.CertPool
+	identities []kops.KeylessIdentity
+	// logs are the keys of the trusted transparency logs, by log ID.
+	logs map[string]crypto.PublicKey
+}
+
+// NewVerifier builds a Verifier from the spec. It returns nil if the spec is nil.
+// The signatures of an image copied to a mirror are made for the repository it is copied from,
+// so mirrors maps the repositories of the mirrored images to the repositories they are copied from.
+func NewVerifier(spec *kops.ImageVerificationSpec, mirrors map[string]string) (*Verifier, error) {
+	if spec == nil {
+		return nil, nil
+	}
+
+	v := &Verifier{action: spec.Action, mirrors: mirrors}
+	if v.action == "" {
+		v.action = kops.ImageVerificationActionEnforce
+	}
+	for i, p := range spec.Policies {
+		pol := &policy{images: p.Images}
+		for j, key := range p.PublicKeys {
+			pub, err := ParsePublicKey([]byte(key))
+			if err != nil {
+				return nil, fmt.Errorf("parsing public key %d of policy %d: %w", j, i, err)
+			}
+			pol.keys = append(pol.keys, pub)
+		}
+		if p.Keyless != nil {
+			pol.roots = x509.NewCertPool()
+			for j, root := range p.Keyless.Roots {
+				if !pol.roots.AppendCertsFromPEM([]byte(root)) {
+					return nil, fmt.Errorf("parsing root %d of policy %d: no certificates found", j, i)
+				}
+			}
+			pol.identities = p.Keyless.Identities
+			pol.logs = make(map[string]crypto.PublicKey)
+			for j, key := range p.Keyless.TransparencyLogPublicKeys {
+				pub, err := ParsePublicKey([]byte(key))
+				if err != nil {
+					return nil, fmt.Errorf("parsing transparency log public key %d of policy %d: %w", j, i, err)
+				}
+				logID, err := LogID(pub)
+				if err != nil {
+					return nil, fmt.Errorf("parsing transparency log public key %d of policy %d: %w", j, i, err)
+				}
+				pol.logs[logID] = pub
+			}
+		}
+		v.policies = append(v.policies, pol)
+	}
+	return v, nil
+}
+
+// ParsePublicKey parses a PEM encoded public key.
+func ParsePublicKey(data []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM block found")
+	}
+	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	switch pub.(type) {
+	case *ecdsa.PublicKey, *rsa.PublicKey, ed25519.PublicKey:
+		return pub, nil
+	default:
+		return nil, fmt.Errorf("unsupported public key type %T", pub)
+	}
+}
+
+// LogID returns the ID of the transparency log with the public key: the hex encoded SHA-256 of the key.
+func LogID(key crypto.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(der)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// Enforced returns true if images that fail verification must be refused.
+func (v *Verifier) Enforced() bool {
+	return v.action != kops.ImageVerificationActionWarn
+}
+
+// Verify verifies the signatures of the image, read from the registry of the image.
+// It returns the digest that was verified, which must be used to refer to the image from then on,
+// as the tag can be moved to another image.
+func (v *Verifier) Verify(ctx context.Context, image string, options ...remote.Option) (v1.Hash, error) {
+	return v.VerifySource(ctx, image, image, options...)
+}
+
+// VerifySource verifies the signatures of an image that is copied from source, returning the digest that was verified.
+// The policy is selected by the name of the image, and the image and signatures are read from source.
+func (v *Verifier) VerifySource(ctx context.Context, image string, source string, options ...remote.Option) (v1.Hash, error) {
+	pol, err := v.findPolicy(image)
+	if err != nil {
+		return v1.Hash{}, err
+	}
+
+	ref, err := name.ParseReference(source)
+	if err != nil {
+		return v1.Hash{}, fmt.Errorf("parsing reference %q: %w", source, err)
+	}
+	options = append(options, remote.WithContext(ctx))
+
+	desc, err := remote.Get(ref, options...)
+	if err != nil {
+		return v1.Hash{}, fmt.Errorf("fetching %q: %w", source, err)
+	}
+
+	sigRef := SignatureReference(ref, desc.Digest)
+	sigImage, err := remote.Image(sigRef, options...)
+	if err != nil {
+		return v1.Hash{}, fmt.Errorf("fetching signatures of %q from %q: %w", source, sigRef, err)
+	}
+	layers, err := sigImage.Layers()
+	if err != nil {
+		return v1.Hash{}, fmt.Errorf("reading signatures of %q: %w", source, err)
+	}
+	manifest, err := sigImage.Manifest()
+	if err != nil {
+		return v1.Hash{}, fmt.Errorf("reading signatures of %q: %w", source, err)
+	}
+	if len(manifest.Layers) != len(layers) {
+		return v1.Hash{}, fmt.Errorf("reading signatures of %q: unexpected number of layers", source)
+	}
+
+	var errs []error
+	for i, layer := range layers {
+		if manifest.Layers[i].MediaType != SimpleSigningMediaType {
+			continue
+		}
+		payload, err := readLayer(layer)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if err := pol.verify(desc.Digest, v.signedRepositories(ref), payload, manifest.Layers[i].Annotations); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		return desc.Digest, nil
+	}
+	if len(errs) == 0 {
+		return v1.Hash{}, fmt.Errorf("no signatures found for image %q", source)
+	}
+	return v1.Hash{}, fmt.Errorf("no valid signature for image %q: %w", source, errors.Join(errs...))
+}
+
+// PinDigest returns the reference to the image with the digest, keeping the tag for readability.
+// Images that already refer to a digest are returned unchanged.
+func PinDigest(image string, digest v1.Hash) string {
+	if strings.Contains(image, "@") {
+		return image
+	}
+	return image + "@" + digest.String()
+}
+
+// SignatureReference returns the tag holding the signatures of the image with the digest.
+func SignatureReference(ref name.Reference, digest v1.Hash) name.Tag {
+	return ref.Context().Tag(digest.Algorithm + "-" + digest.Hex + signatureTagSuffix)
+}
+
+// signedRepositories returns the repositories that the signatures of the image can be made for:
+// the repository of the image, and the repository it is copied from if it is mirrored.
+func (v *Verifier) signedRepositories(ref name.Reference) []string {
+	repository := ref.Context().Name()
+	repositories := []string{repository}
+	if source, found := v.mirrors[repository]; found {
+		repositories = append(repositories, source)
+	}
+	return repositories
+}
+
+func (v *Verifier) findPolicy(image string) (*policy, error) {
+	repository := image
+	if ref, err := name.ParseReference(image); err == nil {
+		repository = ref.Context().Name()
+	}
+	for _, pol := range v.policies {
+		if len(pol.images) == 0 {
+			return pol, nil
+		}
+		for _, pattern := range pol.images {
+			if matchesImage(pattern, image) || matchesImage(pattern, repository) {
+				return pol, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("no image verification policy matches image %q", image)
+}
+
+func matchesImage(pattern string, image string) bool {
+	if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+		return strings.HasPrefix(image, prefix)
+	}
+	return pattern == image
+}
+
+func readLayer(layer v1.Layer) ([]byte, error) {
+	r, err := layer.Uncompressed()
+	if err != nil {
+		return nil, fmt.Errorf("reading signature payload: %w", err)
+	}
+	defer r.Close()
+	payload, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("reading signature payload: %w", err)
+	}
+	return payload, nil
+}
+
+func (p *policy) verify(digest v1.Hash, repositories []string, payload []byte, annotations map[string]string) error {
+	var signed Payload
+	if err := json.Unmarshal(payload, &signed); err != nil {
+		return fmt.Errorf("parsing signature payload: %w", err)
+	}
+	if signed.Critical.Type != SignatureType {
+		return fmt.Errorf("signature has type %q, not %q", signed.Critical.Type, SignatureType)
+	}
+	if signed.Critical.Image.DockerManifestDigest != digest.String() {
+		return fmt.Errorf("signature is for digest %q, not %q", signed.Critical.Image.DockerManifestDigest, digest)
+	}
+	if !signsRepository(signed.Critical.Identity.DockerReference, repositories) {
+		return fmt.Errorf("signature is for image %q, not %q", signed.Critical.Identity.DockerReference, strings.Join(repositories, `" or "`))
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(annotations[SignatureAnnotation])
+	if err != nil || len(sig) == 0 {
+		return fmt.Errorf("signature annotation is missing or invalid")
+	}
+
+	for _, key := range p.keys {
+		if verifySignature(key, payload, sig) == nil {
+			return nil
+		}
+	}
+
+	if cert := annotations[CertificateAnnotation]; cert != "" && p.roots != nil {
+		return p.verifyKeyless([]byte(cert), []byte(annotations[ChainAnnotation]), []byte(annotations[BundleAnnotation]), payload, sig)
+	}
+	return fmt.Errorf("signature is not made with a trusted key")
+}
+
+// signsRepository returns true if the docker reference of a signature is one of the repositories.
+func signsRepository(reference string, repositories []string) bool {
+	ref, err := name.ParseReference(reference)
+	if err != nil {
+		return false
+	}
+	for _, repository := range repositories {
+		if ref.Context().Name() == repository {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *policy) verifyKeyless(certPEM []byte, chainPEM []byte, bundle []byte, payload []byte, sig []byte) error {
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		return fmt.Errorf("parsing signing certificate: no PEM block found")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return fmt.Errorf("parsing signing certificate: %w", err)
+	}
+
+	intermediates := x509.NewCertPool()
+	if len(chainPEM) != 0 {
+		intermediates.AppendCertsFromPEM(chainPEM)
+	}
+
+	// Signing certificates are short-lived, so the chain is verified at the time the transparency log
+	// recorded the signature. Without the log, a key stolen after its certificate expired could still sign.
+	signedAt, err := p.verifyBundle(bundle, cert, payload, sig)
+	if err != nil {
+		return err
+	}
+	_, err = cert.Verify(x509.VerifyOptions{
+		Roots:         p.roots,
+		Intermediates: intermediates,
+		CurrentTime:   signedAt,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+	})
+	if err != nil {
+		return fmt.Errorf("verifying signing certificate: %w", err)
+	}
+
+	if !p.trustsIdentity(cert) {
+		return fmt.Errorf("signing certificate is not issued to a trusted identity")
+	}
+
+	return verifySignature(cert.PublicKey, payload, sig)
+}
+
+// verifyBundle verifies that a trusted transparency log recorded the signature, returning when it was recorded.
+func (p *policy) verifyBundle(data []byte, cert *x509.Certificate, payload []byte, sig []byte) (time.Time, error) {
+	if len(data) == 0 {
+		return time.Time{}, fmt.Errorf("signature is not recorded in a transparency log")
+	}
+	var bundle Bundle
+	if err := json.Unmarshal(data, &bundle); err != nil {
+		return time.Time{}, fmt.Errorf("parsing transparency log bundle: %w", err)
+	}
+
+	key, ok := p.logs[bundle.Payload.LogID]
+	if !ok {
+		return time.Time{}, fmt.Errorf("signature is recorded in untrusted transparency log %q", bundle.Payload.LogID)
+	}
+	canonical, err := json.Marshal(&bundle.Payload)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("encoding transparency log entry: %w", err)
+	}
+	if err := verifySignature(key, canonical, bundle.SignedEntryTimestamp); err != nil {
+		return time.Time{}, fmt.Errorf("verifying transparency log entry: %w", err)
+	}
+
+	body, err := base64.StdEncoding.DecodeString(bundle.Payload.Body)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parsing transparency log entry: %w", err)
+	}
+	var entry hashedRekord
+	if err := json.Unmarshal(body, &entry); err != nil {
+		return time.Time{}, fmt.Errorf("parsing transparency log entry: %w", err)
+	}
+	payloadHash := sha256.Sum256(payload)
+	if entry.Kind != "hashedrekord" || entry.Spec.Data.Hash.Algorithm != "sha256" || entry.Spec.Data.Hash.Value != hex.EncodeToString(payloadHash[:]) {
+		return time.Time{}, fmt.Errorf("transparency log entry is not for the signed payload")
+	}
+	if !bytes.Equal(entry.Spec.Signature.Content, sig) {
+		return time.Time{}, fmt.Errorf("transparency log entry is not for the signature")
+	}
+	block, _ := pem.Decode(entry.Spec.Signature.PublicKey.Content)
+	if block == nil || !bytes.Equal(block.Bytes, cert.Raw) {
+		return time.Time{}, fmt.Errorf("transparency log entry is not for the signing certificate")
+	}
+
+	signedAt := time.Unix(bundle.Payload.IntegratedTime, 0)
+	if signedAt.Before(cert.NotBefore) || signedAt.After(cert.NotAfter) {
+		return time.Time{}, fmt.Errorf("signature was recorded at %v, outside the validity of the signing certificate", signedAt.UTC())
+	}
+	return signedAt, nil
+}
+
+func (p *policy) trustsIdentity(cert *x509.Certificate) bool {
+	issuer := certificateIssuer(cert)
+	subjects := cert.EmailAddresses
+	for _, uri := range cert.URIs {
+		subjects = append(subjects, uri.String())
+	}
+	for _, identity := range p.identities {
+		if identity.Issuer != issuer {
+			continue
+		}
+		for _, subject := range subjects {
+			if identity.Subject == subject {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func certificateIssuer(cert *x509.Certificate) string {
+	for _, ext := range cert.Extensions {
+		if ext.Id.Equal(oidIssuerV2) {
+			var issuer string
+			if _, err := asn1.Unmarshal(ext.Value, &issuer); err == nil {
+				return issuer
+			}
+		}
+	}
+	for _, ext := range cert.Extensions {
+		if ext.Id.Equal(oidIssuer) {
+			return string(ext.Value)
+		}
+	}
+	return ""
+}
+
+func verifySignature(key crypto.PublicKey, payload []byte, sig []byte) error {
+	switch key := key.(type) {
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(key, ecdsaDigest(key, payload), sig) {
+			return fmt.Errorf("invalid signature")
+		}
+		return nil
+	case *rsa.PublicKey:
+		hash := sha256.Sum256(payload)
+		return rsa.VerifyPKCS1v15(key, crypto.SHA256, hash[:], sig)
+	case ed25519.PublicKey:
+		if !ed25519.Verify(key, payload, sig) {
+			return fmt.Errorf("invalid signature")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported public key type %T", key)
+	}
+}
+
+// ecdsaDigest hashes the payload with the hash matching the curve of the key, as cosign does.
+func ecdsaDigest(key *ecdsa.PublicKey, payload []byte) []byte {
+	switch key.Curve {
+	case elliptic.P384():
+		hash := sha512.Sum384(payload)
+		return hash[:]
+	case elliptic.P521():
+		hash := sha512.Sum512(payload)
+		return hash[:]
+	default:
+		hash := sha256.Sum256(payload)
+		return hash[:]
+	}
+}
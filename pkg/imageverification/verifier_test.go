@@ -0,0 +1,606 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package imageverification
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"log"
+	"math/big"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"k8s.io/kops/pkg/apis/kops"
+)
+
+type testRegistry struct {
+	t    *testing.T
+	host string
+}
+
+func newTestRegistry(t *testing.T) *testRegistry {
+	server := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	t.Cleanup(server.Close)
+	return &testRegistry{t: t, host: strings.TrimPrefix(server.URL, "http://")}
+}
+
+// pushImage pushes a random image, returning its name and digest.
+func (r *testRegistry) pushImage(repository string) (string, v1.Hash) {
+	image := r.host + "/" + repository + ":v1"
+	img, err := random.Image(256, 1)
+	if err != nil {
+		r.t.Fatalf("error building image: %v", err)
+	}
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		r.t.Fatal(err)
+	}
+	if err := remote.Write(ref, img); err != nil {
+		r.t.Fatalf("error pushing image: %v", err)
+	}
+	digest, err := img.Digest()
+	if err != nil {
+		r.t.Fatal(err)
+	}
+	return image, digest
+}
+
+// copyImage copies the image to another repository of the registry, returning the name of the copy.
+func (r *testRegistry) copyImage(image string, repository string) string {
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		r.t.Fatal(err)
+	}
+	img, err := remote.Image(ref)
+	if err != nil {
+		r.t.Fatalf("error fetching image: %v", err)
+	}
+	target := r.host + "/" + repository + ":v1"
+	targetRef, err := name.ParseReference(target)
+	if err != nil {
+		r.t.Fatal(err)
+	}
+	if err := remote.Write(targetRef, img); err != nil {
+		r.t.Fatalf("error pushing image: %v", err)
+	}
+	return target
+}
+
+// signedPayload returns the payload of a signature of the digest of the image.
+func signedPayload(t *testing.T, image string, digest v1.Hash) Payload {
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var payload Payload
+	payload.Critical.Identity.DockerReference = ref.Context().Name()
+	payload.Critical.Image.DockerManifestDigest = digest.String()
+	payload.Critical.Type = "cosign container image signature"
+	return payload
+}
+
+// pushSignature signs the digest of the image, storing the signature next to the image.
+// The annotations of the signature are extended with those returned by annotate, if set.
+func (r *testRegistry) pushSignature(image string, digest v1.Hash, signer crypto.Signer, annotate func(payload []byte, sig []byte) map[string]string) {
+	r.pushPayload(image, digest, signedPayload(r.t, image, digest), signer, annotate)
+}
+
+// pushPayload signs the payload, storing the signature as a signature of the digest of the image.
+func (r *testRegistry) pushPayload(image string, digest v1.Hash, payload Payload, signer crypto.Signer, annotate func(payload []byte, sig []byte) map[string]string) {
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		r.t.Fatal(err)
+	}
+
+	data, err := json.Marshal(&payload)
+	if err != nil {
+		r.t.Fatal(err)
+	}
+	// cosign hashes the payload with SHA-384 for P-384 keys
+	hash := crypto.SHA256
+	if key, ok := signer.Public().(*ecdsa.PublicKey); ok && key.Curve == elliptic.P384() {
+		hash = crypto.SHA384
+	}
+	hasher := hash.New()
+	hasher.Write(data)
+	sig, err := signer.Sign(rand.Reader, hasher.Sum(nil), hash)
+	if err != nil {
+		r.t.Fatalf("error signing: %v", err)
+	}
+
+	layerAnnotations := map[string]string{SignatureAnnotation: base64.StdEncoding.EncodeToString(sig)}
+	if annotate != nil {
+		for k, v := range annotate(data, sig) {
+			layerAnnotations[k] = v
+		}
+	}
+	sigImage, err := mutate.Append(empty.Image, mutate.Addendum{
+		Layer:       static.NewLayer(data, SimpleSigningMediaType),
+		Annotations: layerAnnotations,
+	})
+	if err != nil {
+		r.t.Fatal(err)
+	}
+	if err := remote.Write(SignatureReference(ref, digest), sigImage); err != nil {
+		r.t.Fatalf("error pushing signature: %v", err)
+	}
+}
+
+// copySignature stores the signatures of one image as the signatures of another.
+func (r *testRegistry) copySignature(from string, fromDigest v1.Hash, to string, toDigest v1.Hash) {
+	fromRef, err := name.ParseReference(from)
+	if err != nil {
+		r.t.Fatal(err)
+	}
+	toRef, err := name.ParseReference(to)
+	if err != nil {
+		r.t.Fatal(err)
+	}
+	sigImage, err := remote.Image(SignatureReference(fromRef, fromDigest))
+	if err != nil {
+		r.t.Fatalf("error fetching signature: %v", err)
+	}
+	if err := remote.Write(SignatureReference(toRef, toDigest), sigImage); err != nil {
+		r.t.Fatalf("error pushing signature: %v", err)
+	}
+}
+
+func generateKey(t *testing.T) (*ecdsa.PrivateKey, string) {
+	return generateCurveKey(t, elliptic.P256())
+}
+
+func generateCurveKey(t *testing.T, curve elliptic.Curve) (*ecdsa.PrivateKey, string) {
+	key, err := ecdsa.GenerateKey(curve, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key, string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+type testLog struct {
+	key *ecdsa.PrivateKey
+	pem string
+}
+
+func newTestLog(t *testing.T) *testLog {
+	key, keyPEM := generateKey(t)
+	return &testLog{key: key, pem: keyPEM}
+}
+
+// record returns the annotations of a keyless signature made with the certificate,
+// recorded in the log at the time.
+func (l *testLog) record(t *testing.T, certPEM string, at time.Time) func(payload []byte, sig []byte) map[string]string {
+	return func(payload []byte, sig []byte) map[string]string {
+		var entry hashedRekord
+		entry.Kind = "hashedrekord"
+		payloadHash := sha256.Sum256(payload)
+		entry.Spec.Data.Hash.Algorithm = "sha256"
+		entry.Spec.Data.Hash.Value = hex.EncodeToString(payloadHash[:])
+		entry.Spec.Signature.Content = sig
+		entry.Spec.Signature.PublicKey.Content = []byte(certPEM)
+		body, err := json.Marshal(&entry)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		logID, err := LogID(&l.key.PublicKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		bundle := Bundle{
+			Payload: BundlePayload{
+				Body:           base64.StdEncoding.EncodeToString(body),
+				IntegratedTime: at.Unix(),
+				LogID:          logID,
+				LogIndex:       1,
+			},
+		}
+		canonical, err := json.Marshal(&bundle.Payload)
+		if err != nil {
+			t.Fatal(err)
+		}
+		hash := sha256.Sum256(canonical)
+		bundle.SignedEntryTimestamp, err = l.key.Sign(rand.Reader, hash[:], crypto.SHA256)
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := json.Marshal(&bundle)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return map[string]string{CertificateAnnotation: certPEM, BundleAnnotation: string(data)}
+	}
+}
+
+type testCA struct {
+	key  *ecdsa.PrivateKey
+	cert *x509.Certificate
+	pem  string
+}
+
+func newTestCA(t *testing.T) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testCA{key: key, cert: cert, pem: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))}
+}
+
+// issue issues a short-lived signing certificate to the email address, authenticated by the issuer.
+func (ca *testCA) issue(t *testing.T, email string, issuer string) (*ecdsa.PrivateKey, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	issuerValue, err := asn1.Marshal(issuer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:    big.NewInt(2),
+		NotBefore:       time.Now().Add(-30 * time.Minute),
+		NotAfter:        time.Now().Add(-20 * time.Minute),
+		EmailAddresses:  []string{email},
+		KeyUsage:        x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+		ExtraExtensions: []pkix.Extension{{Id: oidIssuerV2, Value: issuerValue}},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key, string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func TestVerifyPublicKey(t *testing.T) {
+	ctx := context.Background()
+	r := newTestRegistry(t)
+
+	trustedKey, trustedPEM := generateKey(t)
+	p384Key, p384PEM := generateCurveKey(t, elliptic.P384())
+	otherKey, _ := generateKey(t)
+
+	signed, signedDigest := r.pushImage("signed")
+	r.pushSignature(signed, signedDigest, trustedKey, nil)
+
+	p384Signed, p384Digest := r.pushImage("p384-signed")
+	r.pushSignature(p384Signed, p384Digest, p384Key, nil)
+
+	otherType, otherTypeDigest := r.pushImage("other-type")
+	otherTypePayload := signedPayload(t, otherType, otherTypeDigest)
+	otherTypePayload.Critical.Type = "cosign attestation"
+	r.pushPayload(otherType, otherTypeDigest, otherTypePayload, trustedKey, nil)
+
+	// A signature of the digest, made for another repository.
+	otherRepository, otherRepositoryDigest := r.pushImage("other-repository")
+	otherRepositoryPayload := signedPayload(t, otherRepository, otherRepositoryDigest)
+	otherRepositoryPayload.Critical.Identity.DockerReference = r.host + "/signed"
+	r.pushPayload(otherRepository, otherRepositoryDigest, otherRepositoryPayload, trustedKey, nil)
+
+	otherSigned, otherDigest := r.pushImage("other-signed")
+	r.pushSignature(otherSigned, otherDigest, otherKey, nil)
+
+	unsigned, _ := r.pushImage("unsigned")
+
+	// The signature of another image, stored as if it were the signature of this one.
+	wrongDigest, wrongImageDigest := r.pushImage("wrong-digest")
+	r.copySignature(signed, signedDigest, wrongDigest, wrongImageDigest)
+
+	verifier, err := NewVerifier(&kops.ImageVerificationSpec{
+		Policies: []kops.ImageVerificationPolicy{
+			{
+				Images:     []string{r.host + "/*"},
+				PublicKeys: []string{trustedPEM, p384PEM},
+			},
+		},
+	}, nil)
+	if err != nil {
+		t.Fatalf("error building verifier: %v", err)
+	}
+
+	grid := []struct {
+		Image         string
+		ExpectedError string
+	}{
+		{Image: signed},
+		{Image: p384Signed},
+		{Image: otherSigned, ExpectedError: "signature is not made with a trusted key"},
+		{Image: otherType, ExpectedError: "signature has type"},
+		{Image: otherRepository, ExpectedError: "signature is for image"},
+		{Image: unsigned, ExpectedError: "fetching signatures"},
+		{Image: wrongDigest, ExpectedError: "signature is for digest"},
+		{Image: "example.com/unmatched:v1", ExpectedError: "no image verification policy matches"},
+	}
+	for _, g := range grid {
+		t.Run(g.Image, func(t *testing.T) {
+			_, err := verifier.Verify(ctx, g.Image)
+			if g.ExpectedError == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected error containing %q, got none", g.ExpectedError)
+			}
+			if !strings.Contains(err.Error(), g.ExpectedError) {
+				t.Errorf("expected error containing %q, got %v", g.ExpectedError, err)
+			}
+		})
+	}
+}
+
+func TestVerifyKeyless(t *testing.T) {
+	ctx := context.Background()
+	r := newTestRegistry(t)
+
+	ca := newTestCA(t)
+	otherCA := newTestCA(t)
+	tlog := newTestLog(t)
+	otherLog := newTestLog(t)
+
+	const issuer = "https://accounts.example.com"
+	// The signing certificates are valid from 30 to 20 minutes ago.
+	signedAt := time.Now().Add(-25 * time.Minute)
+
+	trustedKey, trustedCert := ca.issue(t, "release@example.com", issuer)
+	trusted, trustedDigest := r.pushImage("trusted")
+	r.pushSignature(trusted, trustedDigest, trustedKey, tlog.record(t, trustedCert, signedAt))
+
+	otherSubjectKey, otherSubjectCert := ca.issue(t, "someone@example.com", issuer)
+	otherSubject, otherSubjectDigest := r.pushImage("other-subject")
+	r.pushSignature(otherSubject, otherSubjectDigest, otherSubjectKey, tlog.record(t, otherSubjectCert, signedAt))
+
+	otherIssuerKey, otherIssuerCert := ca.issue(t, "release@example.com", "https://other.example.com")
+	otherIssuer, otherIssuerDigest := r.pushImage("other-issuer")
+	r.pushSignature(otherIssuer, otherIssuerDigest, otherIssuerKey, tlog.record(t, otherIssuerCert, signedAt))
+
+	untrustedKey, untrustedCert := otherCA.issue(t, "release@example.com", issuer)
+	untrusted, untrustedDigest := r.pushImage("untrusted")
+	r.pushSignature(untrusted, untrustedDigest, untrustedKey, tlog.record(t, untrustedCert, signedAt))
+
+	// A signature whose certificate doesn't match the key that made it.
+	mismatchedKey, _ := generateKey(t)
+	mismatched, mismatchedDigest := r.pushImage("mismatched")
+	r.pushSignature(mismatched, mismatchedDigest, mismatchedKey, tlog.record(t, trustedCert, signedAt))
+
+	unrecorded, unrecordedDigest := r.pushImage("unrecorded")
+	r.pushSignature(unrecorded, unrecordedDigest, trustedKey, func(payload []byte, sig []byte) map[string]string {
+		return map[string]string{CertificateAnnotation: trustedCert}
+	})
+
+	// A signature made with the key of an expired certificate, as if the key were stolen.
+	expired, expiredDigest := r.pushImage("expired")
+	r.pushSignature(expired, expiredDigest, trustedKey, tlog.record(t, trustedCert, time.Now()))
+
+	otherLogged, otherLoggedDigest := r.pushImage("other-log")
+	r.pushSignature(otherLogged, otherLoggedDigest, trustedKey, otherLog.record(t, trustedCert, signedAt))
+
+	// A signature presented with the log entry of another signature.
+	replayed, replayedDigest := r.pushImage("replayed")
+	r.pushSignature(replayed, replayedDigest, trustedKey, func(payload []byte, sig []byte) map[string]string {
+		return tlog.record(t, trustedCert, signedAt)([]byte("another payload"), sig)
+	})
+
+	// A log entry whose time is changed after it was signed.
+	backdated, backdatedDigest := r.pushImage("backdated")
+	r.pushSignature(backdated, backdatedDigest, trustedKey, func(payload []byte, sig []byte) map[string]string {
+		annotations := tlog.record(t, trustedCert, time.Now())(payload, sig)
+		var bundle Bundle
+		if err := json.Unmarshal([]byte(annotations[BundleAnnotation]), &bundle); err != nil {
+			t.Fatal(err)
+		}
+		bundle.Payload.IntegratedTime = signedAt.Unix()
+		data, err := json.Marshal(&bundle)
+		if err != nil {
+			t.Fatal(err)
+		}
+		annotations[BundleAnnotation] = string(data)
+		return annotations
+	})
+
+	verifier, err := NewVerifier(&kops.ImageVerificationSpec{
+		Policies: []kops.ImageVerificationPolicy{
+			{
+				Keyless: &kops.KeylessImageVerification{
+					Roots:                     []string{ca.pem},
+					Identities:                []kops.KeylessIdentity{{Issuer: issuer, Subject: "release@example.com"}},
+					TransparencyLogPublicKeys: []string{tlog.pem},
+				},
+			},
+		},
+	}, nil)
+	if err != nil {
+		t.Fatalf("error building verifier: %v", err)
+	}
+
+	grid := []struct {
+		Image         string
+		Digest        v1.Hash
+		ExpectedError string
+	}{
+		{Image: trusted, Digest: trustedDigest},
+		{Image: otherSubject, ExpectedError: "not issued to a trusted identity"},
+		{Image: otherIssuer, ExpectedError: "not issued to a trusted identity"},
+		{Image: untrusted, ExpectedError: "verifying signing certificate"},
+		{Image: mismatched, ExpectedError: "invalid signature"},
+		{Image: unrecorded, ExpectedError: "not recorded in a transparency log"},
+		{Image: expired, ExpectedError: "outside the validity of the signing certificate"},
+		{Image: otherLogged, ExpectedError: "untrusted transparency log"},
+		{Image: replayed, ExpectedError: "not for the signed payload"},
+		{Image: backdated, ExpectedError: "verifying transparency log entry"},
+	}
+	for _, g := range grid {
+		t.Run(g.Image, func(t *testing.T) {
+			digest, err := verifier.Verify(ctx, g.Image)
+			if g.ExpectedError == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				if digest != g.Digest {
+					t.Errorf("expected digest %v, got %v", g.Digest, digest)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected error containing %q, got none", g.ExpectedError)
+			}
+			if !strings.Contains(err.Error(), g.ExpectedError) {
+				t.Errorf("expected error containing %q, got %v", g.ExpectedError, err)
+			}
+		})
+	}
+}
+
+func TestVerifyMirror(t *testing.T) {
+	ctx := context.Background()
+	r := newTestRegistry(t)
+
+	key, keyPEM := generateKey(t)
+	source, digest := r.pushImage("source")
+	r.pushSignature(source, digest, key, nil)
+
+	// The image and its signatures copied to a mirror, as by kops get assets --copy
+	mirrored := r.copyImage(source, "mirror")
+	r.copySignature(source, digest, mirrored, digest)
+
+	spec := &kops.ImageVerificationSpec{
+		Policies: []kops.ImageVerificationPolicy{
+			{PublicKeys: []string{keyPEM}},
+		},
+	}
+
+	verifier, err := NewVerifier(spec, map[string]string{r.host + "/mirror": r.host + "/source"})
+	if err != nil {
+		t.Fatalf("error building verifier: %v", err)
+	}
+	if _, err := verifier.Verify(ctx, mirrored); err != nil {
+		t.Errorf("unexpected error verifying mirrored image: %v", err)
+	}
+
+	verifier, err = NewVerifier(spec, nil)
+	if err != nil {
+		t.Fatalf("error building verifier: %v", err)
+	}
+	if _, err := verifier.Verify(ctx, mirrored); err == nil || !strings.Contains(err.Error(), "signature is for image") {
+		t.Errorf("expected the signature of the source not to be accepted for an unknown mirror, got %v", err)
+	}
+}
+
+func TestFindPolicy(t *testing.T) {
+	_, keyPEM := generateKey(t)
+	verifier, err := NewVerifier(&kops.ImageVerificationSpec{
+		Action: kops.ImageVerificationActionWarn,
+		Policies: []kops.ImageVerificationPolicy{
+			{Images: []string{"registry.k8s.io/kube-apiserver"}, PublicKeys: []string{keyPEM}},
+			{Images: []string{"example.com/mirror/*"}, PublicKeys: []string{keyPEM}},
+		},
+	}, nil)
+	if err != nil {
+		t.Fatalf("error building verifier: %v", err)
+	}
+	if verifier.Enforced() {
+		t.Errorf("expected warn action not to be enforced")
+	}
+
+	grid := []struct {
+		Image    string
+		Expected int
+	}{
+		{Image: "registry.k8s.io/kube-apiserver:v1.34.0", Expected: 0},
+		{Image: "registry.k8s.io/kube-apiserver:v1.34.0@sha256:0000000000000000000000000000000000000000000000000000000000000000", Expected: 0},
+		{Image: "registry.k8s.io/kube-apiserver-amd64:v1.34.0", Expected: -1},
+		{Image: "example.com/mirror/etcd:v3", Expected: 1},
+		{Image: "example.com/other/etcd:v3", Expected: -1},
+	}
+	for _, g := range grid {
+		t.Run(g.Image, func(t *testing.T) {
+			pol, err := verifier.findPolicy(g.Image)
+			if g.Expected == -1 {
+				if err == nil {
+					t.Errorf("expected no policy to match")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if pol != verifier.policies[g.Expected] {
+				t.Errorf("expected policy %d to match", g.Expected)
+			}
+		})
+	}
+}
+
+func TestPinDigest(t *testing.T) {
+	digest := v1.Hash{Algorithm: "sha256", Hex: strings.Repeat("1", 64)}
+	grid := []struct {
+		Image    string
+		Expected string
+	}{
+		{Image: "registry.k8s.io/kube-apiserver:v1.34.0", Expected: "registry.k8s.io/kube-apiserver:v1.34.0@" + digest.String()},
+		{Image: "registry.k8s.io/pause@sha256:" + strings.Repeat("0", 64), Expected: "registry.k8s.io/pause@sha256:" + strings.Repeat("0", 64)},
+	}
+	for _, g := range grid {
+		if actual := PinDigest(g.Image, digest); actual != g.Expected {
+			t.Errorf("PinDigest(%q) = %q, expected %q", g.Image, actual, g.Expected)
+		}
+	}
+}
@@ -160,10 +160,11 @@ func (c *RollingUpdateCluster) rollingUpdateInstanceGroup(ctx context.Context, g
 		maxSurge = 0
 	}
 
-	if group.InstanceGroup.Spec.Role == api.InstanceGroupRoleControlPlane && maxSurge != 0 {
+	if (group.InstanceGroup.Spec.Role == api.InstanceGroupRoleControlPlane || group.InstanceGroup.Spec.Role == api.InstanceGroupRoleEtcd) && maxSurge != 0 {
 		// Control plane nodes are incapable of surging because they rely on registering themselves through
 		// the local apiserver. That apiserver depends on the local etcd, which relies on being
 		// joined to the etcd cluster.
+		// Etcd nodes cannot surge because the etcd volumes can only be attached to one instance.
 		maxSurge = 0
 		maxConcurrency = settings.MaxUnavailable.IntValue()
 		if maxConcurrency == 0 {
@@ -590,7 +591,7 @@ func hasFailureRelevantToGroup(failures []*validation.ValidationError, group *cl
 		}
 
 		// if there is a failure in the same instance group or a failure which has cluster wide impact
-		if (failure.InstanceGroup.IsControlPlane()) || (failure.InstanceGroup == group.InstanceGroup) {
+		if failure.InstanceGroup.IsControlPlane() || failure.InstanceGroup.IsEtcdOnly() || (failure.InstanceGroup == group.InstanceGroup) {
 			return true
 		}
 	}
@@ -691,7 +692,7 @@ func (c *RollingUpdateCluster) drainNode(ctx context.Context, u *cloudinstances.
 		if u.CloudInstanceGroup != nil && u.CloudInstanceGroup.InstanceGroup != nil {
 			role := u.CloudInstanceGroup.InstanceGroup.Spec.Role
 			switch role {
-			case api.InstanceGroupRoleAPIServer, api.InstanceGroupRoleControlPlane:
+			case api.InstanceGroupRoleAPIServer, api.InstanceGroupRoleControlPlane, api.InstanceGroupRoleEtcd:
 				klog.Infof("skipping deregistration of instance %q, as part of instancegroup with role %q", u.ID, role)
 				shouldDeregister = false
 			}
@@ -739,6 +740,8 @@ func (c *RollingUpdateCluster) UpdateSingleInstance(ctx context.Context, cloudMe
 	if detach {
 		if cloudMember.CloudInstanceGroup.InstanceGroup.IsControlPlane() {
 			klog.Warning("cannot detach control-plane instances. Assuming --surge=false")
+		} else if cloudMember.CloudInstanceGroup.InstanceGroup.IsEtcdOnly() {
+			klog.Warning("cannot detach etcd instances. Assuming --surge=false")
 		} else if cloudMember.CloudInstanceGroup.InstanceGroup.Spec.Manager != api.InstanceManagerKarpenter {
 			err := c.detachInstance(cloudMember)
 			if err != nil {
@@ -117,6 +117,7 @@ func (c *RollingUpdateCluster) RollingUpdate(ctx context.Context, groups map[str
 	results := make(map[string]error)
 
 	masterGroups := make(map[string]*cloudinstances.CloudInstanceGroup)
+	etcdGroups := make(map[string]*cloudinstances.CloudInstanceGroup)
 	apiServerGroups := make(map[string]*cloudinstances.CloudInstanceGroup)
 	nodeGroups := make(map[string]*cloudinstances.CloudInstanceGroup)
 	bastionGroups := make(map[string]*cloudinstances.CloudInstanceGroup)
@@ -128,6 +129,8 @@ func (c *RollingUpdateCluster) RollingUpdate(ctx context.Context, groups map[str
 			apiServerGroups[k] = group
 		case api.InstanceGroupRoleControlPlane:
 			masterGroups[k] = group
+		case api.InstanceGroupRoleEtcd:
+			etcdGroups[k] = group
 		case api.InstanceGroupRoleBastion:
 			bastionGroups[k] = group
 		default:
@@ -166,6 +169,19 @@ func (c *RollingUpdateCluster) RollingUpdate(ctx context.Context, groups map[str
 		}
 	}
 
+	// Upgrade etcd nodes next, before the API servers that use them.
+	{
+		// We run etcd nodes in series, so that the etcd clusters keep quorum.
+
+		for _, k := range sortGroups(etcdGroups) {
+			err := c.rollingUpdateInstanceGroup(ctx, etcdGroups[k], c.MasterInterval)
+			// Do not continue update if etcd node(s) failed; cluster is potentially in an unhealthy state.
+			if err != nil {
+				return fmt.Errorf("etcd node not healthy after update, stopping rolling-update: %q", err)
+			}
+		}
+	}
+
 	// Upgrade control plane next.
 	{
 		// We run control-plane nodes in series, even if they are in separate instance groups
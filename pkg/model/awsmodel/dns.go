@@ -72,6 +72,11 @@ func (b *DNSModelBuilder) ensureDNSZone(c *fi.CloudupModelBuilderContext) error
 }
 
 func (b *DNSModelBuilder) Build(c *fi.CloudupModelBuilderContext) error {
+	// dns-controller publishes the records in the DNS provider of the cluster
+	if !b.Cluster.UsesCloudDNSProvider() {
+		return nil
+	}
+
 	// Add a HostedZone if we are going to publish a dns record that depends on it
 	if b.Cluster.PublishesDNSRecords() {
 		if err := b.ensureDNSZone(c); err != nil {
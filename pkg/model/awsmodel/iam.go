@@ -238,7 +238,7 @@ func (b *IAMModelBuilder) buildIAMRolePolicy(role iam.Subject, iamName string, i
 		},
 	}
 
-	if b.Cluster.PublishesDNSRecords() {
+	if b.Cluster.PublishesDNSRecords() && b.Cluster.UsesCloudDNSProvider() {
 		// This is slightly tricky; we need to know the hosted zone id,
 		// but we might be creating the hosted zone dynamically.
 		// We create a stub-reference which will be combined by the execution engine.
@@ -272,6 +272,8 @@ func (b *IAMModelBuilder) roleKey(role iam.Subject) (string, bool) {
 		return "master", false
 	case *iam.NodeRoleAPIServer:
 		return strings.ToLower(string(kops.InstanceGroupRoleAPIServer)), false
+	case *iam.NodeRoleEtcd:
+		return strings.ToLower(string(kops.InstanceGroupRoleEtcd)), false
 	case *iam.NodeRoleNode:
 		return strings.ToLower(string(kops.InstanceGroupRoleNode)), false
 	case *iam.NodeRoleBastion:
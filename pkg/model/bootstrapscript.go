@@ -302,6 +302,20 @@ func (b *BootstrapScript) Run(c *fi.CloudupContext) error {
 
 	nodeupScript.CompressUserData = fi.ValueOf(b.ig.Spec.CompressUserData)
 
+	// nodeup is downloaded before the file repository secret is available, so only its CA is passed in the script
+	if spec := b.cluster.Spec.Assets; spec != nil && spec.FileRepository != nil && spec.FileRepositoryConfig != nil && spec.FileRepositoryConfig.Secret != "" {
+		secret, err := c.T.SecretStore.Secret(spec.FileRepositoryConfig.Secret)
+		if err != nil {
+			return fmt.Errorf("loading file repository secret %q: %w", spec.FileRepositoryConfig.Secret, err)
+		}
+		repositorySecret, err := nodeup.ParseFileRepositorySecret(secret.Data)
+		if err != nil {
+			return fmt.Errorf("secret %q: %w", spec.FileRepositoryConfig.Secret, err)
+		}
+		nodeupScript.FileRepository = *spec.FileRepository
+		nodeupScript.FileRepositoryCA = repositorySecret.CA
+	}
+
 	nodeupScript.CloudProvider = string(c.T.Cluster.GetCloudProvider())
 
 	nodeupScriptResource, err := nodeupScript.Build()
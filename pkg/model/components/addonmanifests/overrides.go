@@ -0,0 +1,148 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addonmanifests
+
+import (
+	"encoding/json"
+	"fmt"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/kubemanifest"
+	"sigs.k8s.io/yaml"
+)
+
+// FindAddonOverride returns the overrides of the named addon, or nil if there are none.
+func FindAddonOverride(cluster *kops.Cluster, name string) *kops.AddonOverrideSpec {
+	for i := range cluster.Spec.AddonOverrides {
+		override := &cluster.Spec.AddonOverrides[i]
+		if override.Name == name {
+			return override
+		}
+	}
+	return nil
+}
+
+// ApplyAddonOverride applies the patches of the override to the objects of the addon.
+// Every patch must match an object, so that patches don't silently stop applying when the manifest changes.
+func ApplyAddonOverride(override *kops.AddonOverrideSpec, objects kubemanifest.ObjectList) error {
+	for i := range override.Patches {
+		patch := &override.Patches[i]
+
+		matched := false
+		for j, object := range objects {
+			if object.IsEmptyObject() || !MatchesAddonPatchTarget(&patch.Target, object) {
+				continue
+			}
+			patched, err := applyAddonPatch(patch, object)
+			if err != nil {
+				return fmt.Errorf("error patching %s %q of addon %q: %w", patch.Target.Kind, patch.Target.Name, override.Name, err)
+			}
+			objects[j] = patched
+			matched = true
+		}
+		if !matched {
+			return fmt.Errorf("patch %d of addon %q does not match any object: no %s %q", i, override.Name, patch.Target.Kind, patch.Target.Name)
+		}
+	}
+	return nil
+}
+
+// MatchesAddonPatchTarget returns true if the object is selected by the target.
+func MatchesAddonPatchTarget(target *kops.AddonPatchTarget, object *kubemanifest.Object) bool {
+	gvk := object.GroupVersionKind()
+	if gvk.Group != target.Group || gvk.Kind != target.Kind {
+		return false
+	}
+	if target.Version != "" && gvk.Version != target.Version {
+		return false
+	}
+	if target.Namespace != "" && object.GetNamespace() != target.Namespace {
+		return false
+	}
+	return object.GetName() == target.Name
+}
+
+// ParseAddonPatch converts a patch, in YAML or JSON, to JSON.
+func ParseAddonPatch(patch string) ([]byte, error) {
+	return yaml.YAMLToJSON([]byte(patch))
+}
+
+func applyAddonPatch(patch *kops.AddonPatchSpec, object *kubemanifest.Object) (*kubemanifest.Object, error) {
+	original, err := object.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	var patched []byte
+	switch {
+	case patch.Patch != "":
+		patchJSON, err := ParseAddonPatch(patch.Patch)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing patch: %w", err)
+		}
+		patched, err = strategicMergePatch(object.GroupVersionKind(), original, patchJSON)
+		if err != nil {
+			return nil, err
+		}
+
+	case patch.JSONPatch != "":
+		patchJSON, err := ParseAddonPatch(patch.JSONPatch)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing jsonPatch: %w", err)
+		}
+		decoded, err := jsonpatch.DecodePatch(patchJSON)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing jsonPatch: %w", err)
+		}
+		patched, err = decoded.Apply(original)
+		if err != nil {
+			return nil, fmt.Errorf("error applying jsonPatch: %w", err)
+		}
+
+	default:
+		return nil, fmt.Errorf("patch or jsonPatch is required")
+	}
+
+	data := make(map[string]interface{})
+	if err := json.Unmarshal(patched, &data); err != nil {
+		return nil, fmt.Errorf("error parsing patched object: %w", err)
+	}
+	return kubemanifest.NewObject(data), nil
+}
+
+// strategicMergePatch applies a strategic merge patch, which needs the Go type of the object to know how to merge lists.
+// We don't have the types of custom resources, for which we fall back to a JSON merge patch, as kubectl does.
+func strategicMergePatch(gvk schema.GroupVersionKind, original, patch []byte) ([]byte, error) {
+	typed, err := scheme.Scheme.New(gvk)
+	if err != nil {
+		patched, err := jsonpatch.MergePatch(original, patch)
+		if err != nil {
+			return nil, fmt.Errorf("error applying merge patch: %w", err)
+		}
+		return patched, nil
+	}
+
+	patched, err := strategicpatch.StrategicMergePatch(original, patch, typed)
+	if err != nil {
+		return nil, fmt.Errorf("error applying strategic merge patch: %w", err)
+	}
+	return patched, nil
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addonmanifests
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/kubemanifest"
+)
+
+const testAddonManifest = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: coredns
+  namespace: kube-system
+spec:
+  replicas: 2
+  template:
+    spec:
+      containers:
+      - name: coredns
+        image: coredns:1.0
+        resources:
+          requests:
+            cpu: 100m
+      - name: sidecar
+        image: sidecar:1.0
+---
+apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: coredns
+  namespace: kube-system
+spec:
+  sizes:
+  - small
+  color: blue
+`
+
+func TestApplyAddonOverride(t *testing.T) {
+	objects, err := kubemanifest.LoadObjectsFrom([]byte(testAddonManifest))
+	if err != nil {
+		t.Fatalf("error parsing manifest: %v", err)
+	}
+
+	override := &kops.AddonOverrideSpec{
+		Name: "coredns.addons.k8s.io",
+		Patches: []kops.AddonPatchSpec{
+			{
+				// Containers are merged by name
+				Target: kops.AddonPatchTarget{Group: "apps", Kind: "Deployment", Name: "coredns"},
+				Patch: `spec:
+  template:
+    spec:
+      containers:
+      - name: coredns
+        resources:
+          requests:
+            cpu: 200m
+`,
+			},
+			{
+				Target:    kops.AddonPatchTarget{Group: "apps", Version: "v1", Kind: "Deployment", Namespace: "kube-system", Name: "coredns"},
+				JSONPatch: `[{"op": "replace", "path": "/spec/replicas", "value": 3}]`,
+			},
+			{
+				// Lists of custom resources are replaced
+				Target: kops.AddonPatchTarget{Group: "example.com", Kind: "Widget", Name: "coredns"},
+				Patch: `spec:
+  sizes:
+  - large
+`,
+			},
+		},
+	}
+	if err := ApplyAddonOverride(override, objects); err != nil {
+		t.Fatalf("error applying override: %v", err)
+	}
+
+	actual, err := objects.ToYAML()
+	if err != nil {
+		t.Fatalf("error serializing objects: %v", err)
+	}
+	expected := `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: coredns
+  namespace: kube-system
+spec:
+  replicas: 3
+  template:
+    spec:
+      containers:
+      - image: coredns:1.0
+        name: coredns
+        resources:
+          requests:
+            cpu: 200m
+      - image: sidecar:1.0
+        name: sidecar
+
+---
+
+apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: coredns
+  namespace: kube-system
+spec:
+  color: blue
+  sizes:
+  - large
+`
+	if strings.TrimSpace(string(actual)) != strings.TrimSpace(expected) {
+		t.Errorf("unexpected manifest:\n%s\nexpected:\n%s", actual, expected)
+	}
+}
+
+func TestApplyAddonOverrideNoMatch(t *testing.T) {
+	objects, err := kubemanifest.LoadObjectsFrom([]byte(testAddonManifest))
+	if err != nil {
+		t.Fatalf("error parsing manifest: %v", err)
+	}
+
+	override := &kops.AddonOverrideSpec{
+		Name: "coredns.addons.k8s.io",
+		Patches: []kops.AddonPatchSpec{
+			{
+				Target: kops.AddonPatchTarget{Kind: "Deployment", Name: "coredns"},
+				Patch:  `spec: {replicas: 3}`,
+			},
+		},
+	}
+	err = ApplyAddonOverride(override, objects)
+	if err == nil || !strings.Contains(err.Error(), "does not match any object") {
+		t.Errorf("expected error for unmatched patch, got %v", err)
+	}
+}
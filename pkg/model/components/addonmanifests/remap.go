@@ -61,6 +61,13 @@ func RemapAddonManifest(addon *addonsapi.AddonSpec, context *model.KopsModelCont
 			return nil, fmt.Errorf("failed to add service account for %q: %w", name, err)
 		}
 
+		// User overrides are applied last, so they can change anything we set
+		if override := FindAddonOverride(context.Cluster, name); override != nil {
+			if err := ApplyAddonOverride(override, objects); err != nil {
+				return nil, err
+			}
+		}
+
 		b, err := objects.ToYAML()
 		if err != nil {
 			return nil, err
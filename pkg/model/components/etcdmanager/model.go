@@ -365,8 +365,12 @@ func (b *EtcdManagerBuilder) buildPod(etcdCluster kops.EtcdClusterSpec, instance
 
 	var clientHost string
 
-	if featureflag.APIServerNodes.Enabled() {
-		clientHost = etcdCluster.Name + ".etcd.internal." + b.ClusterName()
+	// When the API servers do not run alongside etcd, they reach etcd through a DNS name maintained by dns-controller;
+	// on the control-plane nodes, protokube also resolves the names of the etcd clusters on etcd nodes.
+	remoteClients := featureflag.APIServerNodes.Enabled() || b.RunsOnEtcdNodes(&etcdCluster)
+
+	if remoteClients {
+		clientHost = RemoteClientHost(b.ClusterName(), etcdCluster.Name)
 	} else {
 		clientHost = "__name__"
 	}
@@ -383,7 +387,7 @@ func (b *EtcdManagerBuilder) buildPod(etcdCluster kops.EtcdClusterSpec, instance
 		pod.Annotations = make(map[string]string)
 	}
 
-	if featureflag.APIServerNodes.Enabled() {
+	if remoteClients {
 		pod.Annotations["dns.alpha.kubernetes.io/internal"] = clientHost
 	}
 
@@ -411,7 +415,7 @@ func (b *EtcdManagerBuilder) buildPod(etcdCluster kops.EtcdClusterSpec, instance
 		// ok
 
 	case "cilium":
-		if !featureflag.APIServerNodes.Enabled() {
+		if !remoteClients {
 			clientHost = b.Cluster.APIInternalName()
 		}
 	default:
@@ -724,6 +728,11 @@ func SelectorForCluster(etcdCluster kops.EtcdClusterSpec) map[string]string {
 	}
 }
 
+// RemoteClientHost returns the name by which API servers that do not run alongside etcd reach the etcd cluster.
+func RemoteClientHost(clusterName string, etcdClusterName string) string {
+	return etcdClusterName + ".etcd.internal." + clusterName
+}
+
 type Ports struct {
 	ClientPort          int
 	PeerPort            int
@@ -116,6 +116,20 @@ func etcdSupportedVersions() []etcdVersion {
 	return versions
 }
 
+// EtcdImage returns the image holding the binaries for the etcd version, or "" if the version is not supported.
+func EtcdImage(version string) string {
+	version = strings.TrimPrefix(version, "v")
+	for _, etcdVersion := range etcdSupportedImages {
+		if etcdVersion.Version == version {
+			if etcdVersion.SymlinkToVersion != "" {
+				return EtcdImage(etcdVersion.SymlinkToVersion)
+			}
+			return etcdVersion.Image
+		}
+	}
+	return ""
+}
+
 func etcdVersionIsSupported(version string) bool {
 	version = strings.TrimPrefix(version, "v")
 	for _, etcdVersion := range etcdSupportedImages {
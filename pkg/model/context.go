@@ -222,6 +222,10 @@ func (b *KopsModelContext) CloudTagsForInstanceGroup(ig *kops.InstanceGroup) (ma
 			labels[awstasks.CloudTagInstanceGroupRolePrefix+strings.ToLower(string(kops.InstanceGroupRoleAPIServer))] = "1"
 		}
 
+		if ig.Spec.Role == kops.InstanceGroupRoleEtcd {
+			labels[awstasks.CloudTagInstanceGroupRolePrefix+strings.ToLower(string(kops.InstanceGroupRoleEtcd))] = "1"
+		}
+
 		if ig.Spec.Role == kops.InstanceGroupRoleNode {
 			labels[awstasks.CloudTagInstanceGroupRolePrefix+strings.ToLower(string(kops.InstanceGroupRoleNode))] = "1"
 		}
@@ -310,6 +314,18 @@ func (b *KopsModelContext) UsesSSHBastion() bool {
 	return false
 }
 
+// RunsOnEtcdNodes checks if the members of the etcd cluster run on instance groups with role Etcd,
+// rather than alongside the API server on the control-plane nodes.
+func (b *KopsModelContext) RunsOnEtcdNodes(etcdCluster *kops.EtcdClusterSpec) bool {
+	for _, member := range etcdCluster.Members {
+		ig := b.FindInstanceGroup(fi.ValueOf(member.InstanceGroup))
+		if ig != nil && ig.IsEtcdOnly() {
+			return true
+		}
+	}
+	return false
+}
+
 // UseLoadBalancerForAPI checks if we are using a load balancer for the kubeapi
 func (b *KopsModelContext) UseLoadBalancerForAPI() bool {
 	return b.Cluster.Spec.API.LoadBalancer != nil
@@ -38,6 +38,8 @@ func DefaultInstanceGroupVolumeSize(role kops.InstanceGroupRole) (int32, error)
 		return DefaultVolumeSizeMaster, nil
 	case kops.InstanceGroupRoleAPIServer:
 		return DefaultVolumeSizeNode, nil
+	case kops.InstanceGroupRoleEtcd:
+		return DefaultVolumeSizeMaster, nil
 	case kops.InstanceGroupRoleNode:
 		return DefaultVolumeSizeNode, nil
 	case kops.InstanceGroupRoleBastion:
@@ -457,6 +457,34 @@ func (r *NodeRoleMaster) BuildAWSPolicy(b *PolicyBuilder) (*Policy, error) {
 	return p, nil
 }
 
+// BuildAWSPolicy generates a custom policy for an etcd node.
+func (r *NodeRoleEtcd) BuildAWSPolicy(b *PolicyBuilder) (*Policy, error) {
+	p := NewPolicy(b.Cluster.GetName(), b.Partition)
+
+	addEtcdNodeManagerPermissions(p)
+	b.addNodeupPermissions(p, false)
+
+	if err := b.AddS3Permissions(p); err != nil {
+		return nil, fmt.Errorf("failed to generate AWS IAM S3 access statements: %v", err)
+	}
+
+	addKMSIAMPolicies(p)
+
+	if b.Cluster.Spec.IAM != nil && b.Cluster.Spec.IAM.AllowContainerRegistry {
+		addECRPermissions(p)
+	}
+
+	if b.Cluster.Spec.Containerd != nil && b.Cluster.Spec.Containerd.UseECRCredentialsForMirrors {
+		addECRPullThroughPermissions(p)
+	}
+
+	if b.Cluster.Spec.Networking.AmazonVPC != nil {
+		addAmazonVPCCNIPermissions(p)
+	}
+
+	return p, nil
+}
+
 // BuildAWSPolicy generates a custom policy for a Kubernetes node.
 func (r *NodeRoleNode) BuildAWSPolicy(b *PolicyBuilder) (*Policy, error) {
 	p := NewPolicy(b.Cluster.GetName(), b.Partition)
@@ -678,7 +706,7 @@ func WriteableVFSPaths(cluster *kops.Cluster, role Subject) ([]vfs.Path, error)
 
 	// etcd-manager needs write permissions to the backup store
 	switch role.(type) {
-	case *NodeRoleMaster:
+	case *NodeRoleMaster, *NodeRoleEtcd:
 		backupStores := sets.NewString()
 		for _, c := range cluster.Spec.EtcdClusters {
 			if c.Backups == nil || c.Backups.BackupStore == "" || backupStores.Has(c.Backups.BackupStore) {
@@ -705,7 +733,7 @@ func ReadableStatePaths(cluster *kops.Cluster, role Subject) ([]string, error) {
 	var paths []string
 
 	switch role.(type) {
-	case *NodeRoleMaster, *NodeRoleAPIServer:
+	case *NodeRoleMaster, *NodeRoleAPIServer, *NodeRoleEtcd:
 		paths = append(paths, "/*")
 
 	case *NodeRoleNode:
@@ -863,6 +891,43 @@ func addEtcdManagerPermissions(p *Policy) {
 	)
 }
 
+// addEtcdNodeManagerPermissions grants etcd-manager on etcd nodes permission to attach the etcd volumes.
+// The etcd volumes are tagged as control-plane volumes, but the etcd instances are tagged with the etcd role.
+func addEtcdNodeManagerPermissions(p *Policy) {
+	p.unconditionalAction.Insert(
+		"ec2:DescribeVolumes", // aws.go
+	)
+
+	p.Statement = append(p.Statement,
+		&Statement{
+			Effect: StatementEffectAllow,
+			Action: stringorset.Of(
+				"ec2:AttachVolume",
+			),
+			Resource: stringorset.Set([]string{"arn:" + p.partition + ":ec2:*:*:volume/*"}),
+			Condition: Condition{
+				"StringEquals": map[string]string{
+					"aws:ResourceTag/k8s.io/role/master": "1",
+					"aws:ResourceTag/KubernetesCluster":  p.clusterName,
+				},
+			},
+		},
+		&Statement{
+			Effect: StatementEffectAllow,
+			Action: stringorset.Of(
+				"ec2:AttachVolume",
+			),
+			Resource: stringorset.Set([]string{"arn:" + p.partition + ":ec2:*:*:instance/*"}),
+			Condition: Condition{
+				"StringEquals": map[string]string{
+					"aws:ResourceTag/k8s.io/role/etcd":  "1",
+					"aws:ResourceTag/KubernetesCluster": p.clusterName,
+				},
+			},
+		},
+	)
+}
+
 func AddCCMPermissions(p *Policy, cloudRoutes bool) {
 	p.unconditionalAction.Insert(
 		"autoscaling:DescribeAutoScalingGroups",
@@ -54,6 +54,14 @@ func (_ *NodeRoleAPIServer) ServiceAccount() (types.NamespacedName, bool) {
 	return types.NamespacedName{}, false
 }
 
+// NodeRoleEtcd represents the role of etcd-only nodes, and implements Subject.
+type NodeRoleEtcd struct{}
+
+// ServiceAccount implements Subject.
+func (_ *NodeRoleEtcd) ServiceAccount() (types.NamespacedName, bool) {
+	return types.NamespacedName{}, false
+}
+
 // NodeRoleNode represents the role of normal ("worker") nodes, and implements Subject.
 type NodeRoleNode struct {
 	enableLifecycleHookPermissions bool
@@ -94,6 +102,8 @@ func BuildNodeRoleSubject(igRole kops.InstanceGroupRole, enableLifecycleHookPerm
 		return &NodeRoleAPIServer{
 			warmPool: enableLifecycleHookPermissions,
 		}, nil
+	case kops.InstanceGroupRoleEtcd:
+		return &NodeRoleEtcd{}, nil
 	case kops.InstanceGroupRoleNode:
 		return &NodeRoleNode{
 			enableLifecycleHookPermissions: enableLifecycleHookPermissions,
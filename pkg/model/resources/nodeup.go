@@ -51,6 +51,11 @@ NODEUP_URL_AMD64={{ NodeUpSourceAmd64 }}
 NODEUP_HASH_AMD64={{ NodeUpSourceHashAmd64 }}
 NODEUP_URL_ARM64={{ NodeUpSourceArm64 }}
 NODEUP_HASH_ARM64={{ NodeUpSourceHashArm64 }}
+{{- if FileRepositoryCA }}
+
+FILE_REPOSITORY={{ FileRepository }}
+FILE_REPOSITORY_CA_PEM="{{ FileRepositoryCA }}"
+{{- end }}
 
 {{ EnvironmentVariables }}
 
@@ -93,6 +98,17 @@ download-or-bust() {
         "curl -f -Lo ${file} --connect-timeout 20 --retry 6 --retry-delay 10"
         "wget -O ${file} --connect-timeout=20 --tries=6 --wait=10"
       )
+{{- if FileRepositoryCA }}
+      # The file repository is served with a certificate issued by its own CA
+      if [[ "${url}" == "${FILE_REPOSITORY}"* ]]; then
+        commands=(
+          "curl -f --compressed -Lo ${file} --connect-timeout 20 --retry 6 --retry-delay 10 --cacert ${FILE_REPOSITORY_CA}"
+          "wget --compression=auto -O ${file} --connect-timeout=20 --tries=6 --wait=10 --ca-certificate=${FILE_REPOSITORY_CA}"
+          "curl -f -Lo ${file} --connect-timeout 20 --retry 6 --retry-delay 10 --cacert ${FILE_REPOSITORY_CA}"
+          "wget -O ${file} --connect-timeout=20 --tries=6 --wait=10 --ca-certificate=${FILE_REPOSITORY_CA}"
+        )
+      fi
+{{- end }}
       for cmd in "${commands[@]}"; do
         echo "== Downloading ${url} using ${cmd} =="
         if ! (${cmd} "${url}"); then
@@ -143,6 +159,10 @@ function download-release() {
   esac
 
   cd ${INSTALL_DIR}/bin
+{{- if FileRepositoryCA }}
+  FILE_REPOSITORY_CA="${INSTALL_DIR}/conf/file-repository-ca.crt"
+  echo "${FILE_REPOSITORY_CA_PEM}" > "${FILE_REPOSITORY_CA}"
+{{- end }}
   download-or-bust nodeup "${NODEUP_HASH}" "${NODEUP_URL}"
 
   chmod +x nodeup
@@ -179,8 +199,13 @@ echo "== nodeup node config done =="
 
 // NodeUpScript is responsible for creating the nodeup script
 type NodeUpScript struct {
-	NodeUpAssets         map[architectures.Architecture]*assets.MirroredAsset
-	BootConfig           *nodeup.BootConfig
+	NodeUpAssets map[architectures.Architecture]*assets.MirroredAsset
+	BootConfig   *nodeup.BootConfig
+	// FileRepository is the URL of the file repository, and FileRepositoryCA the PEM encoded CA certificates
+	// trusted to serve it, if it isn't served with a certificate from a public CA.
+	// The credentials of the repository are not included, as the script is readable from the instance metadata.
+	FileRepository       string
+	FileRepositoryCA     string
 	CompressUserData     bool
 	SetSysctls           string
 	CloudProvider        string
@@ -251,6 +276,13 @@ func (b *NodeUpScript) Build() (fi.Resource, error) {
 			return b.SetSysctls
 		},
 
+		"FileRepository": func() string {
+			return b.FileRepository
+		},
+		"FileRepositoryCA": func() string {
+			return strings.TrimSpace(b.FileRepositoryCA)
+		},
+
 		"ProxyEnv":             b.ProxyEnv,
 		"EnvironmentVariables": b.EnvironmentVariables,
 	}
@@ -362,6 +394,14 @@ func buildEnvironmentVariables(cluster *kops.Cluster, ig *kops.InstanceGroup) (m
 		}
 	}
 
+	// The nodes read the state store with the same credentials as kOps when it is on a WebDAV server
+	if strings.HasPrefix(cluster.Spec.ConfigStore.Base, "webdav") && os.Getenv("WEBDAV_USERNAME") != "" {
+		if ig.IsControlPlane() || cluster.UsesLegacyGossip() {
+			env["WEBDAV_USERNAME"] = fmt.Sprintf("'%s'", os.Getenv("WEBDAV_USERNAME"))
+			env["WEBDAV_PASSWORD"] = fmt.Sprintf("'%s'", os.Getenv("WEBDAV_PASSWORD"))
+		}
+	}
+
 	if cluster.GetCloudProvider() == kops.CloudProviderOpenstack {
 
 		osEnvs := []string{
@@ -19,6 +19,9 @@ package resources
 import (
 	"strings"
 	"testing"
+
+	"k8s.io/kops/pkg/apis/nodeup"
+	"k8s.io/kops/upup/pkg/fi"
 )
 
 func Test_NodeUpTabs(t *testing.T) {
@@ -28,3 +31,48 @@ func Test_NodeUpTabs(t *testing.T) {
 		}
 	}
 }
+
+func Test_NodeUpFileRepositoryCA(t *testing.T) {
+	grid := []struct {
+		CA       string
+		Expected []string
+	}{
+		{
+			CA: "-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----\n",
+			Expected: []string{
+				"FILE_REPOSITORY=https://example.com/files\n",
+				"FILE_REPOSITORY_CA_PEM=\"-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----\"\n",
+				`if [[ "${url}" == "${FILE_REPOSITORY}"* ]]; then`,
+				"--cacert ${FILE_REPOSITORY_CA}",
+				"--ca-certificate=${FILE_REPOSITORY_CA}",
+				`echo "${FILE_REPOSITORY_CA_PEM}" > "${FILE_REPOSITORY_CA}"`,
+			},
+		},
+		{
+			CA: "",
+		},
+	}
+	for _, g := range grid {
+		script := &NodeUpScript{
+			BootConfig:       &nodeup.BootConfig{},
+			FileRepository:   "https://example.com/files",
+			FileRepositoryCA: g.CA,
+		}
+		resource, err := script.Build()
+		if err != nil {
+			t.Fatalf("error building script: %v", err)
+		}
+		actual, err := fi.ResourceAsString(resource)
+		if err != nil {
+			t.Fatalf("error rendering script: %v", err)
+		}
+		for _, expected := range g.Expected {
+			if !strings.Contains(actual, expected) {
+				t.Errorf("expected script to contain %q", expected)
+			}
+		}
+		if g.CA == "" && strings.Contains(actual, "FILE_REPOSITORY") {
+			t.Errorf("expected script not to refer to the file repository without a CA")
+		}
+	}
+}
@@ -26,6 +26,7 @@ import (
 
 const (
 	RoleLabelAPIServer16 = "node-role.kubernetes.io/api-server"
+	RoleLabelEtcd        = "node-role.kubernetes.io/etcd"
 	RoleLabelNode16      = "node-role.kubernetes.io/node"
 
 	RoleLabelControlPlane20 = "node-role.kubernetes.io/control-plane"
@@ -36,12 +37,15 @@ const (
 func BuildNodeLabels(cluster *api.Cluster, instanceGroup *api.InstanceGroup) (map[string]string, error) {
 	isControlPlane := false
 	isAPIServer := false
+	isEtcd := false
 	isNode := false
 	switch instanceGroup.Spec.Role {
 	case api.InstanceGroupRoleControlPlane:
 		isControlPlane = true
 	case api.InstanceGroupRoleAPIServer:
 		isAPIServer = true
+	case api.InstanceGroupRoleEtcd:
+		isEtcd = true
 	case api.InstanceGroupRoleNode:
 		isNode = true
 	case api.InstanceGroupRoleBastion:
@@ -77,6 +81,13 @@ func BuildNodeLabels(cluster *api.Cluster, instanceGroup *api.InstanceGroup) (ma
 		}
 	}
 
+	if isEtcd {
+		if nodeLabels == nil {
+			nodeLabels = make(map[string]string)
+		}
+		nodeLabels[RoleLabelEtcd] = ""
+	}
+
 	if isNode {
 		if nodeLabels == nil {
 			nodeLabels = make(map[string]string)
@@ -27,11 +27,15 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/google/go-containerregistry/pkg/name"
+	"k8s.io/klog/v2"
 	"k8s.io/kops/pkg/apis/kops"
 	kopsmodel "k8s.io/kops/pkg/apis/kops/model"
 	"k8s.io/kops/pkg/apis/nodeup"
 	"k8s.io/kops/pkg/assets"
+	"k8s.io/kops/pkg/featureflag"
 	"k8s.io/kops/pkg/model"
+	"k8s.io/kops/pkg/model/components/etcdmanager"
 	"k8s.io/kops/pkg/nodemodel/wellknownassets"
 	"k8s.io/kops/pkg/wellknownports"
 	"k8s.io/kops/pkg/wellknownservices"
@@ -174,7 +178,7 @@ func NewNodeUpConfigBuilder(cluster *kops.Cluster, assetBuilder *assets.AssetBui
 			}
 		}
 
-		if isMaster {
+		if isMaster || role == kops.InstanceGroupRoleEtcd {
 			for _, etcdCluster := range cluster.Spec.EtcdClusters {
 				for _, member := range etcdCluster.Members {
 					instanceGroup := fi.ValueOf(member.InstanceGroup)
@@ -216,6 +220,7 @@ func (n *nodeUpConfigBuilder) BuildConfig(ig *kops.InstanceGroup, wellKnownAddre
 	usesLegacyGossip := cluster.UsesLegacyGossip()
 	isMaster := role == kops.InstanceGroupRoleControlPlane
 	hasAPIServer := isMaster || role == kops.InstanceGroupRoleAPIServer
+	hasEtcd := isMaster || role == kops.InstanceGroupRoleEtcd
 
 	config, bootConfig := nodeup.NewConfig(cluster, ig)
 
@@ -257,7 +262,7 @@ func (n *nodeUpConfigBuilder) BuildConfig(ig *kops.InstanceGroup, wellKnownAddre
 			}
 		}
 
-		if isMaster {
+		if hasEtcd {
 			if err := loadCertificates(keysets, "etcd-clients-ca", config, true); err != nil {
 				return nil, nil, err
 			}
@@ -275,6 +280,9 @@ func (n *nodeUpConfigBuilder) BuildConfig(ig *kops.InstanceGroup, wellKnownAddre
 					}
 				}
 			}
+		}
+
+		if isMaster {
 			config.KeypairIDs["service-account"] = keysets["service-account"].Primary.Id
 
 			// Add key for registering with the discovery service (if configured)
@@ -391,7 +399,8 @@ func (n *nodeUpConfigBuilder) BuildConfig(ig *kops.InstanceGroup, wellKnownAddre
 		}
 	}
 
-	useConfigServer := kopsmodel.UseKopsControllerForNodeConfig(cluster) && !ig.HasAPIServer()
+	// Etcd nodes read their configuration from the state store, so that etcd does not depend on kops-controller
+	useConfigServer := kopsmodel.UseKopsControllerForNodeConfig(cluster) && !ig.HasAPIServer() && !ig.IsEtcdOnly()
 	if useConfigServer {
 		hosts := []string{"kops-controller.internal." + cluster.ObjectMeta.Name}
 		if len(bootConfig.APIServerIPs) > 0 {
@@ -446,13 +455,23 @@ func (n *nodeUpConfigBuilder) BuildConfig(ig *kops.InstanceGroup, wellKnownAddre
 
 	config.Images = n.images[role]
 
-	if isMaster {
+	if config.ImageVerification != nil {
+		config.ImageVerificationMirrors = n.buildImageVerificationMirrors()
+	}
+
+	if hasEtcd {
 		for _, etcdCluster := range cluster.Spec.EtcdClusters {
 			config.EtcdClusterNames = append(config.EtcdClusterNames, etcdCluster.Name)
 		}
 		config.EtcdManifests = n.etcdManifests[ig.Name]
 	}
 
+	if isMaster {
+		if err := useRemoteEtcd(cluster, ig, config); err != nil {
+			return nil, nil, err
+		}
+	}
+
 	if cluster.Spec.CloudProvider.AWS != nil {
 		if ig.Spec.WarmPool != nil || cluster.Spec.CloudProvider.AWS.WarmPool != nil {
 			config.WarmPoolImages = n.buildWarmPoolImages(ig)
@@ -465,6 +484,67 @@ func (n *nodeUpConfigBuilder) BuildConfig(ig *kops.InstanceGroup, wellKnownAddre
 	return config, bootConfig, nil
 }
 
+// useRemoteEtcd points the API server of a control-plane node at the etcd clusters that have no member on the node,
+// as when etcd runs on instance groups with role Etcd. protokube resolves the client hostnames of those etcd clusters
+// to the addresses of the etcd nodes, so that the API server does not depend on dns-controller.
+func useRemoteEtcd(cluster *kops.Cluster, ig *kops.InstanceGroup, config *nodeup.Config) error {
+	if config.APIServerConfig == nil || config.APIServerConfig.KubeAPIServer == nil {
+		return nil
+	}
+
+	var kubeAPIServer *kops.KubeAPIServerConfig
+	for _, etcdCluster := range cluster.Spec.EtcdClusters {
+		local := false
+		var instanceGroups []string
+		for _, member := range etcdCluster.Members {
+			if fi.ValueOf(member.InstanceGroup) == ig.Name {
+				local = true
+			}
+			instanceGroups = append(instanceGroups, fi.ValueOf(member.InstanceGroup))
+		}
+		if local {
+			continue
+		}
+
+		if kubeAPIServer == nil {
+			// The config shares the KubeAPIServerConfig with the cluster spec
+			kubeAPIServer = config.APIServerConfig.KubeAPIServer.DeepCopy()
+			config.APIServerConfig.KubeAPIServer = kubeAPIServer
+		}
+
+		ports, err := etcdmanager.PortsForCluster(etcdCluster)
+		if err != nil {
+			return err
+		}
+		clientHost := etcdmanager.RemoteClientHost(cluster.ObjectMeta.Name, etcdCluster.Name)
+
+		switch etcdCluster.Name {
+		case "main":
+			kubeAPIServer.EtcdServers = []string{fmt.Sprintf("https://%s:%d", clientHost, ports.ClientPort)}
+		case "events":
+			scheme := "https"
+			if featureflag.EtcdEventsHTTP.Enabled() {
+				scheme = "http"
+			}
+			var overrides []string
+			for _, override := range kubeAPIServer.EtcdServersOverrides {
+				if !strings.HasPrefix(override, "/events#") {
+					overrides = append(overrides, override)
+				}
+			}
+			kubeAPIServer.EtcdServersOverrides = append(overrides, fmt.Sprintf("/events#%s://%s:%d", scheme, clientHost, ports.ClientPort))
+		default:
+			return fmt.Errorf("etcd cluster %q is not used by the API server and cannot run on etcd nodes", etcdCluster.Name)
+		}
+
+		if config.APIServerConfig.RemoteEtcdHosts == nil {
+			config.APIServerConfig.RemoteEtcdHosts = make(map[string][]string)
+		}
+		config.APIServerConfig.RemoteEtcdHosts[clientHost] = instanceGroups
+	}
+	return nil
+}
+
 func loadCertificates(keysets map[string]*fi.Keyset, name string, config *nodeup.Config, includeKeypairID bool) error {
 	keyset := keysets[name]
 	if keyset == nil {
@@ -484,6 +564,33 @@ func loadCertificates(keysets map[string]*fi.Keyset, name string, config *nodeup
 	return nil
 }
 
+// buildImageVerificationMirrors returns the repositories that images are copied from, by the repository they are copied to.
+func (n *nodeUpConfigBuilder) buildImageVerificationMirrors() map[string]string {
+	mirrors := make(map[string]string)
+	for _, image := range n.assetBuilder.ImageAssets {
+		if image.DownloadLocation == image.CanonicalLocation {
+			continue
+		}
+		target, err := name.ParseReference(image.DownloadLocation)
+		if err != nil {
+			klog.Warningf("unable to parse image %q: %v", image.DownloadLocation, err)
+			continue
+		}
+		source, err := name.ParseReference(image.CanonicalLocation)
+		if err != nil {
+			klog.Warningf("unable to parse image %q: %v", image.CanonicalLocation, err)
+			continue
+		}
+		if target.Context().Name() != source.Context().Name() {
+			mirrors[target.Context().Name()] = source.Context().Name()
+		}
+	}
+	if len(mirrors) == 0 {
+		return nil
+	}
+	return mirrors
+}
+
 // buildWarmPoolImages returns a list of container images that should be pre-pulled during instance pre-initialization
 func (n *nodeUpConfigBuilder) buildWarmPoolImages(ig *kops.InstanceGroup) []string {
 	if ig == nil || ig.Spec.Role == kops.InstanceGroupRoleControlPlane {
@@ -0,0 +1,139 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodemodel
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/apis/nodeup"
+	"k8s.io/kops/pkg/assets"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+func TestUseRemoteEtcd(t *testing.T) {
+	newEtcdCluster := func(name string, igs ...string) kops.EtcdClusterSpec {
+		etcdCluster := kops.EtcdClusterSpec{Name: name}
+		for _, ig := range igs {
+			etcdCluster.Members = append(etcdCluster.Members, kops.EtcdMemberSpec{Name: ig, InstanceGroup: fi.PtrTo(ig)})
+		}
+		return etcdCluster
+	}
+	newConfig := func(cluster *kops.Cluster) *nodeup.Config {
+		return &nodeup.Config{
+			APIServerConfig: &nodeup.APIServerConfig{
+				KubeAPIServer: cluster.Spec.KubeAPIServer,
+			},
+		}
+	}
+
+	cluster := &kops.Cluster{
+		ObjectMeta: v1.ObjectMeta{Name: "minimal.example.com"},
+		Spec: kops.ClusterSpec{
+			KubeAPIServer: &kops.KubeAPIServerConfig{
+				EtcdServers:          []string{"https://127.0.0.1:4001"},
+				EtcdServersOverrides: []string{"/events#https://127.0.0.1:4002"},
+			},
+		},
+	}
+	ig := &kops.InstanceGroup{
+		ObjectMeta: v1.ObjectMeta{Name: "control-plane-a"},
+		Spec:       kops.InstanceGroupSpec{Role: kops.InstanceGroupRoleControlPlane},
+	}
+
+	// etcd on the control-plane nodes
+	cluster.Spec.EtcdClusters = []kops.EtcdClusterSpec{
+		newEtcdCluster("main", "control-plane-a"),
+		newEtcdCluster("events", "control-plane-a"),
+	}
+	config := newConfig(cluster)
+	if err := useRemoteEtcd(cluster, ig, config); err != nil {
+		t.Fatalf("useRemoteEtcd: %v", err)
+	}
+	if config.APIServerConfig.KubeAPIServer != cluster.Spec.KubeAPIServer || config.APIServerConfig.RemoteEtcdHosts != nil {
+		t.Errorf("unexpected changes for etcd on the control-plane nodes")
+	}
+
+	// main moved to etcd nodes, events still on the control-plane nodes
+	cluster.Spec.EtcdClusters = []kops.EtcdClusterSpec{
+		newEtcdCluster("main", "etcd-a", "etcd-b", "etcd-c"),
+		newEtcdCluster("events", "control-plane-a"),
+	}
+	config = newConfig(cluster)
+	if err := useRemoteEtcd(cluster, ig, config); err != nil {
+		t.Fatalf("useRemoteEtcd: %v", err)
+	}
+	kubeAPIServer := config.APIServerConfig.KubeAPIServer
+	if !reflect.DeepEqual(kubeAPIServer.EtcdServers, []string{"https://main.etcd.internal.minimal.example.com:4001"}) {
+		t.Errorf("unexpected etcd servers %v", kubeAPIServer.EtcdServers)
+	}
+	if !reflect.DeepEqual(kubeAPIServer.EtcdServersOverrides, []string{"/events#https://127.0.0.1:4002"}) {
+		t.Errorf("unexpected etcd servers overrides %v", kubeAPIServer.EtcdServersOverrides)
+	}
+	expectedHosts := map[string][]string{
+		"main.etcd.internal.minimal.example.com": {"etcd-a", "etcd-b", "etcd-c"},
+	}
+	if !reflect.DeepEqual(config.APIServerConfig.RemoteEtcdHosts, expectedHosts) {
+		t.Errorf("unexpected remote etcd hosts %v", config.APIServerConfig.RemoteEtcdHosts)
+	}
+	if !reflect.DeepEqual(cluster.Spec.KubeAPIServer.EtcdServers, []string{"https://127.0.0.1:4001"}) {
+		t.Errorf("cluster spec was modified: %v", cluster.Spec.KubeAPIServer.EtcdServers)
+	}
+
+	// both moved to etcd nodes
+	cluster.Spec.EtcdClusters = []kops.EtcdClusterSpec{
+		newEtcdCluster("main", "etcd-a"),
+		newEtcdCluster("events", "etcd-a"),
+	}
+	config = newConfig(cluster)
+	if err := useRemoteEtcd(cluster, ig, config); err != nil {
+		t.Fatalf("useRemoteEtcd: %v", err)
+	}
+	if overrides := config.APIServerConfig.KubeAPIServer.EtcdServersOverrides; !reflect.DeepEqual(overrides, []string{"/events#https://events.etcd.internal.minimal.example.com:4002"}) {
+		t.Errorf("unexpected etcd servers overrides %v", overrides)
+	}
+	if len(config.APIServerConfig.RemoteEtcdHosts) != 2 {
+		t.Errorf("unexpected remote etcd hosts %v", config.APIServerConfig.RemoteEtcdHosts)
+	}
+
+	// Other etcd clusters are not used by the API server
+	cluster.Spec.EtcdClusters = append(cluster.Spec.EtcdClusters, newEtcdCluster("cilium", "etcd-a"))
+	if err := useRemoteEtcd(cluster, ig, newConfig(cluster)); err == nil {
+		t.Errorf("expected error for the cilium etcd cluster on etcd nodes")
+	}
+}
+
+func TestBuildImageVerificationMirrors(t *testing.T) {
+	n := &nodeUpConfigBuilder{
+		assetBuilder: &assets.AssetBuilder{
+			ImageAssets: []*assets.ImageAsset{
+				{DownloadLocation: "registry.example.com/kube-apiserver:v1.34.0", CanonicalLocation: "registry.k8s.io/kube-apiserver:v1.34.0"},
+				{DownloadLocation: "registry.example.com/etcd-manager-slim:v3.0.1", CanonicalLocation: "registry.k8s.io/etcd-manager/etcd-manager-slim:v3.0.1"},
+				{DownloadLocation: "registry.k8s.io/pause:3.10", CanonicalLocation: "registry.k8s.io/pause:3.10"},
+			},
+		},
+	}
+	expected := map[string]string{
+		"registry.example.com/kube-apiserver":    "registry.k8s.io/kube-apiserver",
+		"registry.example.com/etcd-manager-slim": "registry.k8s.io/etcd-manager/etcd-manager-slim",
+	}
+	if actual := n.buildImageVerificationMirrors(); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+}
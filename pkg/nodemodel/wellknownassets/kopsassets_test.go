@@ -70,3 +70,49 @@ func Test_BuildMirroredAsset(t *testing.T) {
 		})
 	}
 }
+
+func Test_BuildMirroredAsset_FallbackToCanonical(t *testing.T) {
+	h := hashing.MustFromString("0000000000000000000000000000000000000000000000000000000000000000")
+	canonical, err := url.Parse("https://artifacts.k8s.io/binaries/kops/" + kops.Version + "/linux/amd64/nodeup")
+	if err != nil {
+		t.Fatal(err)
+	}
+	download, err := url.Parse("https://artifactory.example.com/kops/" + kops.Version + "/linux/amd64/nodeup")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		fallbackToCanonical bool
+		expected            []string
+	}{
+		{
+			expected: []string{
+				"https://artifactory.example.com/kops/" + kops.Version + "/linux/amd64/nodeup",
+			},
+		},
+		{
+			fallbackToCanonical: true,
+			expected: []string{
+				"https://artifactory.example.com/kops/" + kops.Version + "/linux/amd64/nodeup",
+				"https://artifacts.k8s.io/binaries/kops/" + kops.Version + "/linux/amd64/nodeup",
+				"https://github.com/kubernetes/kops/releases/download/v" + kops.Version + "/nodeup-linux-amd64",
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(fmt.Sprintf("fallbackToCanonical=%v", tc.fallbackToCanonical), func(t *testing.T) {
+			asset := &assets.FileAsset{
+				DownloadURL:         download,
+				CanonicalURL:        canonical,
+				SHAValue:            h,
+				FallbackToCanonical: tc.fallbackToCanonical,
+			}
+			actual := assets.BuildMirroredAsset(asset)
+
+			if !reflect.DeepEqual(actual.Locations, tc.expected) {
+				t.Errorf("Locations differ:\nActual: %+v\nExpect: %+v", actual.Locations, tc.expected)
+			}
+		})
+	}
+}
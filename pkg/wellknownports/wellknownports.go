@@ -64,6 +64,17 @@ const (
 	// EtcdCiliumClientPort is the port were the Cilium etcd cluster listens
 	EtcdCiliumClientPort = 4003
 
+	// KopsControllerMetrics is the port where kops-controller serves metrics, when enabled
+	KopsControllerMetrics = 4004
+
+	// EtcdBackupVerificationClientPort and EtcdBackupVerificationPeerPort are used on the loopback interface
+	// by the temporary etcd that kops-controller restores backups into
+	EtcdBackupVerificationClientPort = 4005
+	EtcdBackupVerificationPeerPort   = 4006
+
+	// ProtokubeGossipStatus is the port where protokube serves the gossip status on control plane nodes
+	ProtokubeGossipStatus = 4007
+
 	// CiliumOperatorPrometheusPort is the port the Cilium Operator exposes metrics
 	CiliumPrometheusOperatorPort = 6942
 
@@ -17,11 +17,13 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"os"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/spf13/pflag"
 	"k8s.io/klog/v2"
@@ -56,7 +58,8 @@ func run() error {
 	var zones []string
 	var containerized, master, gossip bool
 	var cloud, clusterID, dnsInternalSuffix, gossipSecret, gossipListen, gossipProtocol, gossipSecretSecondary, gossipListenSecondary, gossipProtocolSecondary string
-	var flagChannels string
+	var flagChannels, gossipStatusListen string
+	var gossipStatusTLSCertFile, gossipStatusTLSKeyFile, gossipStatusClientCAFile string
 	var dnsUpdateInterval int
 
 	flag.BoolVar(&containerized, "containerized", containerized, "Set if we are running containerized")
@@ -73,6 +76,10 @@ func run() error {
 	flag.StringVar(&gossipProtocolSecondary, "gossip-protocol-secondary", "memberlist", "mesh/memberlist")
 	flag.StringVar(&gossipListenSecondary, "gossip-listen-secondary", fmt.Sprintf("0.0.0.0:%d", wellknownports.ProtokubeGossipMemberlist), "address:port on which to bind for gossip")
 	flags.StringVar(&gossipSecretSecondary, "gossip-secret-secondary", gossipSecret, "Secret to use to secure gossip")
+	flag.StringVar(&gossipStatusListen, "gossip-status-listen", gossipStatusListen, "address:port on which to serve the gossip status to the API server, for debugging; serves on the internal IP of the node if only :port is given; disabled if empty")
+	flag.StringVar(&gossipStatusTLSCertFile, "gossip-status-tls-cert-file", gossipStatusTLSCertFile, "serving certificate of the gossip status")
+	flag.StringVar(&gossipStatusTLSKeyFile, "gossip-status-tls-key-file", gossipStatusTLSKeyFile, "serving key of the gossip status")
+	flag.StringVar(&gossipStatusClientCAFile, "gossip-status-client-ca-file", gossipStatusClientCAFile, "CA of the API server client certificate, which is the only client allowed to read the gossip status")
 	flags.StringSliceVarP(&zones, "zone", "z", []string{}, "Configure permitted zones and their mappings")
 
 	bootstrapMasterNodeLabels := false
@@ -81,6 +88,9 @@ func run() error {
 	nodeName := ""
 	flag.StringVar(&nodeName, "node-name", nodeName, "name of the node as will be created in kubernetes; used with bootstrap-master-node-labels")
 
+	var etcdNodeHosts []string
+	flags.StringArrayVar(&etcdNodeHosts, "etcd-node-hosts", etcdNodeHosts, "hostname=instancegroup[,instancegroup...] of an etcd cluster on etcd nodes, resolved in /etc/hosts; may be repeated")
+
 	var removeDNSNames string
 	flag.StringVar(&removeDNSNames, "remove-dns-names", removeDNSNames, "If set, will remove the DNS records specified")
 
@@ -181,6 +191,23 @@ func run() error {
 			}
 		}()
 
+		if gossipStatusListen != "" {
+			statusServer := &protokube.GossipStatusServer{
+				Kubernetes:   protokube.NewKubernetesContext(),
+				NodeName:     nodeName,
+				Listen:       gossipStatusListen,
+				Handler:      gossiputils.NewStatusHandler(gossipName, gossipState),
+				CertFile:     gossipStatusTLSCertFile,
+				KeyFile:      gossipStatusTLSKeyFile,
+				ClientCAFile: gossipStatusClientCAFile,
+			}
+			go func() {
+				if err := statusServer.Run(context.Background()); err != nil {
+					klog.Warningf("error serving gossip status: %v", err)
+				}
+			}()
+		}
+
 		dnsView := gossipdns.NewDNSView(gossipState)
 		zoneInfo := gossipdns.DNSZoneInfo{
 			Name: gossipdns.DefaultZoneName,
@@ -195,6 +222,26 @@ func run() error {
 		}()
 	}
 
+	if len(etcdNodeHosts) != 0 {
+		if cloud != "aws" {
+			return fmt.Errorf("etcd nodes not supported with cloudprovider %q", cloud)
+		}
+		cloudProvider, err := protokube.NewAWSCloudProvider()
+		if err != nil {
+			return fmt.Errorf("error initializing cloud %q: %w", cloud, err)
+		}
+		hosts, err := protokube.ParseEtcdNodeHosts(etcdNodeHosts)
+		if err != nil {
+			return err
+		}
+		etcdHosts := &protokube.EtcdNodeHosts{
+			HostsPath: path.Join(rootfs, "etc/hosts"),
+			Hosts:     hosts,
+			Addresses: cloudProvider.InstanceGroupAddresses,
+		}
+		go etcdHosts.Run(context.Background(), time.Minute)
+	}
+
 	var channels []string
 	if flagChannels != "" {
 		channels = strings.Split(flagChannels, ",")
@@ -43,6 +43,9 @@ type MemberlistGossiper struct {
 
 	state *state
 	bcast func([]byte)
+
+	// members records when the members were last seen
+	members gossip.MemberTracker
 }
 
 func NewMemberlistGossiper(listen string, channelName string, nodeName string, password []byte, seeds gossip.SeedProvider) (*MemberlistGossiper, error) {
@@ -106,6 +109,7 @@ func (g *MemberlistGossiper) Start() error {
 	}()
 
 	g.peer.Settle(ctx, cluster.DefaultGossipInterval*10)
+	go g.runMemberTracking()
 	g.runSeeding()
 
 	return nil
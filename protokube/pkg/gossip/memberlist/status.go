@@ -0,0 +1,89 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package memberlist
+
+import (
+	"time"
+
+	"github.com/hashicorp/memberlist"
+	"k8s.io/kops/protokube/pkg/gossip"
+	"k8s.io/kops/protokube/pkg/gossip/mesh"
+)
+
+// memberTrackingInterval is the interval at which we record the members seen alive
+const memberTrackingInterval = 10 * time.Second
+
+var _ gossip.StatusReporter = &MemberlistGossiper{}
+
+// Status returns the members of the cluster and the replicated state
+func (g *MemberlistGossiper) Status() *gossip.GossipStatus {
+	g.members.Observe(g.aliveMembers(), time.Now())
+
+	self := g.peer.Self().Name
+	var members []gossip.MemberStatus
+	for _, node := range g.peer.Peers() {
+		member := gossip.MemberStatus{
+			Name:    node.Name,
+			Address: node.Address(),
+		}
+		switch {
+		case node.Name == self:
+			member.State = gossip.MemberStateSelf
+		case node.State == memberlist.StateAlive:
+			member.State = gossip.MemberStateAlive
+		case node.State == memberlist.StateSuspect:
+			member.State = gossip.MemberStateSuspect
+		default:
+			member.State = gossip.MemberStateGone
+		}
+		members = append(members, member)
+	}
+
+	return &gossip.GossipStatus{
+		Protocol: "memberlist",
+		Self:     self,
+		Members:  g.members.Members(members),
+		Records:  g.state.records(),
+	}
+}
+
+// runMemberTracking periodically records the members seen alive
+func (g *MemberlistGossiper) runMemberTracking() {
+	for {
+		g.members.Observe(g.aliveMembers(), time.Now())
+		time.Sleep(memberTrackingInterval)
+	}
+}
+
+// aliveMembers returns the names and addresses of the alive members
+func (g *MemberlistGossiper) aliveMembers() map[string]string {
+	alive := make(map[string]string)
+	for _, node := range g.peer.Peers() {
+		if node.State == memberlist.StateAlive {
+			alive[node.Name] = node.Address()
+		}
+	}
+	return alive
+}
+
+// records returns the replicated state, including the tombstones
+func (s *state) records() []gossip.RecordStatus {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	return mesh.RecordStatuses(&s.data)
+}
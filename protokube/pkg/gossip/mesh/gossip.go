@@ -40,6 +40,9 @@ type MeshGossiper struct {
 	router *mesh.Router
 	peer   *peer
 
+	// members records when the peers were last seen
+	members gossip.MemberTracker
+
 	// version uint64
 }
 
@@ -109,6 +112,7 @@ func NewMeshGossiper(listen string, channelName string, nodeName string, passwor
 func (g *MeshGossiper) Start() error {
 	// klog.Infof("mesh router starting (%s)", *meshListen)
 	g.router.Start()
+	go g.runMemberTracking()
 
 	defer func() {
 		klog.Infof("mesh router stopping")
@@ -0,0 +1,129 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mesh
+
+import (
+	"sort"
+	"time"
+
+	"github.com/weaveworks/mesh"
+	"k8s.io/kops/protokube/pkg/gossip"
+)
+
+// memberTrackingInterval is the interval at which we record the members seen alive
+const memberTrackingInterval = 10 * time.Second
+
+var _ gossip.StatusReporter = &MeshGossiper{}
+
+// Status returns the peers of the mesh and the replicated state.
+// Peers with an established connection from us are alive, other peers known from the topology are suspect.
+func (g *MeshGossiper) Status() *gossip.GossipStatus {
+	g.members.Observe(g.aliveMembers(), time.Now())
+
+	status := mesh.NewStatus(g.router)
+	connections := establishedConnections(status)
+
+	var members []gossip.MemberStatus
+	for _, peer := range status.Peers {
+		member := gossip.MemberStatus{
+			Name:  peer.NickName,
+			State: gossip.MemberStateSuspect,
+		}
+		if peer.Name == status.Name {
+			member.State = gossip.MemberStateSelf
+		} else if address, found := connections[peer.Name]; found {
+			member.State = gossip.MemberStateAlive
+			member.Address = address
+		}
+		members = append(members, member)
+	}
+
+	return &gossip.GossipStatus{
+		Protocol: "mesh",
+		Self:     status.NickName,
+		Members:  g.members.Members(members),
+		Records:  g.peer.st.records(),
+	}
+}
+
+// runMemberTracking periodically records the peers seen alive
+func (g *MeshGossiper) runMemberTracking() {
+	for {
+		g.members.Observe(g.aliveMembers(), time.Now())
+		time.Sleep(memberTrackingInterval)
+	}
+}
+
+// aliveMembers returns the nicknames and addresses of ourselves and of the peers with an established connection from us
+func (g *MeshGossiper) aliveMembers() map[string]string {
+	status := mesh.NewStatus(g.router)
+	connections := establishedConnections(status)
+
+	alive := make(map[string]string)
+	for _, peer := range status.Peers {
+		if peer.Name == status.Name {
+			alive[peer.NickName] = ""
+		} else if address, found := connections[peer.Name]; found {
+			alive[peer.NickName] = address
+		}
+	}
+	return alive
+}
+
+// establishedConnections returns the addresses of the peers with an established connection from us, by peer name
+func establishedConnections(status *mesh.Status) map[string]string {
+	connections := make(map[string]string)
+	for _, peer := range status.Peers {
+		if peer.Name != status.Name {
+			continue
+		}
+		for _, connection := range peer.Connections {
+			if connection.Established {
+				connections[connection.Name] = connection.Address
+			}
+		}
+	}
+	return connections
+}
+
+// records returns the replicated state, including the tombstones
+func (s *state) records() []gossip.RecordStatus {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	return RecordStatuses(&s.data)
+}
+
+// RecordStatuses returns the entries of a KVState, including the tombstones, sorted by key
+func RecordStatuses(data *KVState) []gossip.RecordStatus {
+	var records []gossip.RecordStatus
+	for k, v := range data.Records {
+		record := gossip.RecordStatus{
+			Key:     k,
+			Updated: time.Unix(int64(v.Version), 0).UTC(),
+			Deleted: v.Tombstone,
+		}
+		if !v.Tombstone {
+			record.Value = string(v.Data)
+		}
+		records = append(records, record)
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].Key < records[j].Key
+	})
+	return records
+}
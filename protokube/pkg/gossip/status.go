@@ -0,0 +1,175 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gossip
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+// StatusPath is the path of the status endpoint
+const StatusPath = "/gossip/status"
+
+// Member states reported in MemberStatus
+const (
+	// MemberStateSelf is the state of the member reporting the status
+	MemberStateSelf = "self"
+	// MemberStateAlive is the state of the members that can currently be reached
+	MemberStateAlive = "alive"
+	// MemberStateSuspect is the state of the members that failed to answer recently
+	MemberStateSuspect = "suspect"
+	// MemberStateGone is the state of the members that were seen before, but are no longer members
+	MemberStateGone = "gone"
+)
+
+// NodeStatus is the status of the gossip states of a node, as served by the status endpoint
+type NodeStatus struct {
+	// Node is the gossip name of the node
+	Node string `json:"node"`
+	// Time is the time of the status, according to the node
+	Time time.Time `json:"time"`
+	// States are the statuses of each gossip protocol
+	States []*GossipStatus `json:"states"`
+}
+
+// GossipStatus is the membership and replicated state of a gossip protocol, as seen by one member
+type GossipStatus struct {
+	// Protocol is the gossip protocol, mesh or memberlist
+	Protocol string `json:"protocol"`
+	// Self is the name of the member reporting the status
+	Self string `json:"self"`
+	// Members are the members known to this member, including itself
+	Members []MemberStatus `json:"members"`
+	// Records is the replicated state, including the deleted records
+	Records []RecordStatus `json:"records"`
+}
+
+// MemberStatus is the state of a member of the gossip, as seen by another member
+type MemberStatus struct {
+	Name    string `json:"name"`
+	Address string `json:"address,omitempty"`
+	State   string `json:"state"`
+	// LastSeen is the last time the member was seen alive, if it was
+	LastSeen *time.Time `json:"lastSeen,omitempty"`
+}
+
+// RecordStatus is an entry of the replicated state
+type RecordStatus struct {
+	Key   string `json:"key"`
+	Value string `json:"value,omitempty"`
+	// Updated is the time of the last update of the entry, which orders the updates
+	Updated time.Time `json:"updated"`
+	// Deleted is set for the tombstones of the deleted entries
+	Deleted bool `json:"deleted,omitempty"`
+}
+
+// StatusReporter is implemented by the GossipStates that can report their status
+type StatusReporter interface {
+	Status() *GossipStatus
+}
+
+// Statuses returns the status of each gossip protocol of state
+func Statuses(state GossipState) []*GossipStatus {
+	switch state := state.(type) {
+	case *MultiGossipState:
+		return append(Statuses(state.Primary), Statuses(state.Secondary)...)
+	case StatusReporter:
+		return []*GossipStatus{state.Status()}
+	default:
+		return nil
+	}
+}
+
+// NewStatusHandler returns the handler of the status endpoint, serving the NodeStatus of the node as JSON
+func NewStatusHandler(nodeName string, state GossipState) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(StatusPath, func(w http.ResponseWriter, r *http.Request) {
+		status := &NodeStatus{
+			Node:   nodeName,
+			Time:   time.Now().UTC(),
+			States: Statuses(state),
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(status); err != nil {
+			klog.Warningf("error writing gossip status: %v", err)
+		}
+	})
+	return mux
+}
+
+// MemberTracker remembers when the members were last seen alive, so that the members
+// which left the gossip are still reported
+type MemberTracker struct {
+	mutex    sync.Mutex
+	lastSeen map[string]time.Time
+	address  map[string]string
+}
+
+// Observe records that the members in alive were seen alive at now, with their addresses
+func (t *MemberTracker) Observe(alive map[string]string, now time.Time) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.lastSeen == nil {
+		t.lastSeen = make(map[string]time.Time)
+		t.address = make(map[string]string)
+	}
+	for name, address := range alive {
+		t.lastSeen[name] = now
+		if address != "" {
+			t.address[name] = address
+		}
+	}
+}
+
+// Members completes the current members with the last seen times, and the members that are gone.
+// The members are sorted by name.
+func (t *MemberTracker) Members(current []MemberStatus) []MemberStatus {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	members := make([]MemberStatus, 0, len(current))
+	found := make(map[string]bool)
+	for _, member := range current {
+		found[member.Name] = true
+		if lastSeen, ok := t.lastSeen[member.Name]; ok {
+			member.LastSeen = &lastSeen
+		}
+		members = append(members, member)
+	}
+	for name, lastSeen := range t.lastSeen {
+		if found[name] {
+			continue
+		}
+		lastSeen := lastSeen
+		members = append(members, MemberStatus{
+			Name:     name,
+			Address:  t.address[name],
+			State:    MemberStateGone,
+			LastSeen: &lastSeen,
+		})
+	}
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].Name < members[j].Name
+	})
+	return members
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/feature/ec2/imds"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	nodeidentityaws "k8s.io/kops/pkg/nodeidentity/aws"
 	"k8s.io/kops/protokube/pkg/gossip"
 	gossipaws "k8s.io/kops/protokube/pkg/gossip/aws"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
@@ -144,6 +145,36 @@ func (a *AWSCloudProvider) GossipSeeds() (gossip.SeedProvider, error) {
 	return gossipaws.NewSeedProvider(a.ec2, tags)
 }
 
+// InstanceGroupAddresses returns the addresses of the running instances of the instance groups of the cluster.
+func (a *AWSCloudProvider) InstanceGroupAddresses(ctx context.Context, instanceGroups []string) ([]string, error) {
+	request := &ec2.DescribeInstancesInput{
+		Filters: []ec2types.Filter{
+			awsup.NewEC2Filter("tag:"+awsup.TagClusterName, a.clusterTag),
+			awsup.NewEC2Filter("tag:"+nodeidentityaws.CloudTagInstanceGroupName, instanceGroups...),
+			awsup.NewEC2Filter("instance-state-name", string(ec2types.InstanceStateNameRunning)),
+		},
+	}
+
+	var addresses []string
+	paginator := ec2.NewDescribeInstancesPaginator(a.ec2, request)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("error querying for EC2 instances of instance groups %v: %w", instanceGroups, err)
+		}
+		for _, r := range page.Reservations {
+			for _, instance := range r.Instances {
+				if ip := aws.ToString(instance.PrivateIpAddress); ip != "" {
+					addresses = append(addresses, ip)
+				} else if ip := aws.ToString(instance.Ipv6Address); ip != "" {
+					addresses = append(addresses, ip)
+				}
+			}
+		}
+	}
+	return addresses, nil
+}
+
 func (a *AWSCloudProvider) InstanceID() string {
 	return a.instanceId
 }
@@ -0,0 +1,97 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package protokube
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/klog/v2"
+	"k8s.io/kops/protokube/pkg/gossip/dns/hosts"
+)
+
+// EtcdNodeHosts maintains hosts file entries for the etcd clusters that run on etcd nodes.
+// The API servers on the control-plane nodes reach those etcd clusters by name. Resolving the names
+// from the cloud means the API servers do not depend on dns-controller, which itself needs an API server.
+type EtcdNodeHosts struct {
+	// HostsPath is the path of the hosts file
+	HostsPath string
+	// Hosts maps each hostname to the instance groups whose nodes serve it
+	Hosts map[string][]string
+	// Addresses returns the addresses of the running nodes of the instance groups
+	Addresses func(ctx context.Context, instanceGroups []string) ([]string, error)
+}
+
+// ParseEtcdNodeHosts parses values of the form hostname=instancegroup[,instancegroup...].
+func ParseEtcdNodeHosts(values []string) (map[string][]string, error) {
+	etcdNodeHosts := make(map[string][]string)
+	for _, value := range values {
+		hostname, instanceGroups, ok := strings.Cut(value, "=")
+		if !ok || hostname == "" || instanceGroups == "" {
+			return nil, fmt.Errorf("cannot parse etcd node hosts %q, expected hostname=instancegroup[,instancegroup...]", value)
+		}
+		etcdNodeHosts[hostname] = strings.Split(instanceGroups, ",")
+	}
+	return etcdNodeHosts, nil
+}
+
+// Run updates the hosts file every interval; it does not return.
+func (h *EtcdNodeHosts) Run(ctx context.Context, interval time.Duration) {
+	for {
+		if err := h.Update(ctx); err != nil {
+			klog.Warningf("error updating hosts file for etcd nodes: %v", err)
+		}
+		time.Sleep(interval)
+	}
+}
+
+// Update writes the addresses of the running etcd nodes to the hosts file.
+// A hostname keeps its previous addresses while none of its nodes are running.
+func (h *EtcdNodeHosts) Update(ctx context.Context) error {
+	hostAddresses := make(map[string][]string)
+	for hostname, instanceGroups := range h.Hosts {
+		addresses, err := h.Addresses(ctx, instanceGroups)
+		if err != nil {
+			return fmt.Errorf("error finding the addresses of %q: %w", hostname, err)
+		}
+		if len(addresses) == 0 {
+			klog.Warningf("no running etcd nodes found for %q in instance groups %v", hostname, instanceGroups)
+			continue
+		}
+		sort.Strings(addresses)
+		hostAddresses[hostname] = addresses
+	}
+
+	mutator := func(existing []string) (*hosts.HostMap, error) {
+		hostMap := &hosts.HostMap{}
+		badLines := hostMap.Parse(existing)
+		if len(badLines) != 0 {
+			klog.Warningf("ignoring unexpected lines in /etc/hosts: %v", badLines)
+		}
+
+		for hostname, addresses := range hostAddresses {
+			hostMap.ReplaceRecords(hostname, addresses)
+		}
+
+		return hostMap, nil
+	}
+
+	return hosts.UpdateHostsFileWithRecords(h.HostsPath, mutator)
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package protokube
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseEtcdNodeHosts(t *testing.T) {
+	hosts, err := ParseEtcdNodeHosts([]string{
+		"main.etcd.internal.minimal.example.com=etcd-a,etcd-b,etcd-c",
+		"events.etcd.internal.minimal.example.com=etcd-a",
+	})
+	if err != nil {
+		t.Fatalf("ParseEtcdNodeHosts: %v", err)
+	}
+	expected := map[string][]string{
+		"main.etcd.internal.minimal.example.com":   {"etcd-a", "etcd-b", "etcd-c"},
+		"events.etcd.internal.minimal.example.com": {"etcd-a"},
+	}
+	if !reflect.DeepEqual(hosts, expected) {
+		t.Errorf("unexpected hosts %v", hosts)
+	}
+
+	for _, value := range []string{"main.etcd.internal.minimal.example.com", "=etcd-a", "main.etcd.internal.minimal.example.com="} {
+		if _, err := ParseEtcdNodeHosts([]string{value}); err == nil {
+			t.Errorf("expected error parsing %q", value)
+		}
+	}
+}
+
+func TestEtcdNodeHostsUpdate(t *testing.T) {
+	ctx := context.TODO()
+
+	hostsPath := filepath.Join(t.TempDir(), "hosts")
+	if err := os.WriteFile(hostsPath, []byte("127.0.0.1\tlocalhost\n"), 0644); err != nil {
+		t.Fatalf("error writing hosts file: %v", err)
+	}
+
+	addresses := map[string][]string{
+		"etcd-a": {"10.0.1.10"},
+		"etcd-b": {"10.0.2.10"},
+	}
+	h := &EtcdNodeHosts{
+		HostsPath: hostsPath,
+		Hosts: map[string][]string{
+			"main.etcd.internal.minimal.example.com":   {"etcd-a", "etcd-b"},
+			"events.etcd.internal.minimal.example.com": {"etcd-c"},
+		},
+		Addresses: func(ctx context.Context, instanceGroups []string) ([]string, error) {
+			var result []string
+			for _, ig := range instanceGroups {
+				result = append(result, addresses[ig]...)
+			}
+			return result, nil
+		},
+	}
+
+	if err := h.Update(ctx); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	expected := "127.0.0.1\tlocalhost\n\n" +
+		"# Begin host entries managed by kops - do not edit\n" +
+		"10.0.1.10\tmain.etcd.internal.minimal.example.com\n" +
+		"10.0.2.10\tmain.etcd.internal.minimal.example.com\n" +
+		"# End host entries managed by kops\n"
+	if b, err := os.ReadFile(hostsPath); err != nil {
+		t.Fatalf("error reading hosts file: %v", err)
+	} else if string(b) != expected {
+		t.Errorf("unexpected hosts file:\n%s", b)
+	}
+
+	// A replaced etcd node shows up with a new address
+	addresses["etcd-b"] = []string{"10.0.2.20"}
+	addresses["etcd-c"] = []string{"10.0.3.10"}
+	if err := h.Update(ctx); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	expected = "127.0.0.1\tlocalhost\n\n" +
+		"# Begin host entries managed by kops - do not edit\n" +
+		"10.0.1.10\tmain.etcd.internal.minimal.example.com\n" +
+		"10.0.2.20\tmain.etcd.internal.minimal.example.com\n" +
+		"10.0.3.10\tevents.etcd.internal.minimal.example.com\n" +
+		"# End host entries managed by kops\n"
+	if b, err := os.ReadFile(hostsPath); err != nil {
+		t.Fatalf("error reading hosts file: %v", err)
+	} else if string(b) != expected {
+		t.Errorf("unexpected hosts file:\n%s", b)
+	}
+}
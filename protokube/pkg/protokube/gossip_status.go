@@ -0,0 +1,157 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package protokube
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+)
+
+// GossipStatusClientName is the common name of the client certificate allowed to read the gossip status.
+// It is the certificate the API server uses to reach the nodes, so the status can only be read through the node proxy.
+const GossipStatusClientName = "kubelet-api"
+
+// GossipStatusServer serves the gossip status to the API server, on the internal IP of the node.
+type GossipStatusServer struct {
+	// Kubernetes is used to find the internal IP of the node
+	Kubernetes *KubernetesContext
+	// NodeName is the name of the node in kubernetes
+	NodeName string
+	// Listen is the address:port to serve on; if the host is empty, the internal IP of the node is used
+	Listen string
+	// Handler serves the gossip status
+	Handler http.Handler
+
+	// CertFile and KeyFile are the serving certificate and key
+	CertFile string
+	KeyFile  string
+	// ClientCAFile is the CA that issued the certificate of the API server
+	ClientCAFile string
+}
+
+// Run serves the gossip status; it only returns on error.
+func (s *GossipStatusServer) Run(ctx context.Context) error {
+	tlsConfig, err := s.tlsConfig()
+	if err != nil {
+		return err
+	}
+
+	host, port, err := net.SplitHostPort(s.Listen)
+	if err != nil {
+		return fmt.Errorf("cannot parse gossip status address %q: %w", s.Listen, err)
+	}
+	if host == "" {
+		// The API server is not up until gossip is, so the node may not be registered yet
+		host, err = s.waitForInternalIP(ctx, 10*time.Second)
+		if err != nil {
+			return err
+		}
+	}
+
+	server := &http.Server{
+		Addr:              net.JoinHostPort(host, port),
+		Handler:           s.Handler,
+		TLSConfig:         tlsConfig,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	klog.Infof("serving gossip status on %s", server.Addr)
+	return server.ListenAndServeTLS(s.CertFile, s.KeyFile)
+}
+
+// tlsConfig requires clients to present the certificate of the API server.
+func (s *GossipStatusServer) tlsConfig() (*tls.Config, error) {
+	caBytes, err := os.ReadFile(s.ClientCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("error reading client CA %q: %w", s.ClientCAFile, err)
+	}
+	clientCAs := x509.NewCertPool()
+	if !clientCAs.AppendCertsFromPEM(caBytes) {
+		return nil, fmt.Errorf("no certificates found in client CA %q", s.ClientCAFile)
+	}
+	return &tls.Config{
+		MinVersion:       tls.VersionTLS12,
+		ClientAuth:       tls.RequireAndVerifyClientCert,
+		ClientCAs:        clientCAs,
+		VerifyConnection: verifyGossipStatusClient,
+	}, nil
+}
+
+// verifyGossipStatusClient rejects the clients other than the API server; the other
+// certificates issued by the cluster CA, such as those of the kubelets, are not allowed.
+func verifyGossipStatusClient(state tls.ConnectionState) error {
+	if len(state.PeerCertificates) == 0 {
+		return fmt.Errorf("no client certificate")
+	}
+	if cn := state.PeerCertificates[0].Subject.CommonName; cn != GossipStatusClientName {
+		return fmt.Errorf("client %q is not allowed to read the gossip status", cn)
+	}
+	return nil
+}
+
+// waitForInternalIP polls until the node is registered with an internal IP.
+func (s *GossipStatusServer) waitForInternalIP(ctx context.Context, interval time.Duration) (string, error) {
+	for {
+		ip, err := s.internalIP(ctx)
+		if err != nil {
+			klog.V(2).Infof("waiting for the internal IP of node %q: %v", s.NodeName, err)
+		} else {
+			return ip, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+func (s *GossipStatusServer) internalIP(ctx context.Context) (string, error) {
+	client, err := s.Kubernetes.KubernetesClient()
+	if err != nil {
+		return "", err
+	}
+	node, err := client.CoreV1().Nodes().Get(ctx, s.NodeName, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	ip := NodeInternalIP(node)
+	if ip == "" {
+		return "", fmt.Errorf("node has no internal IP")
+	}
+	return ip, nil
+}
+
+// NodeInternalIP returns the first internal IP of the node, which is the address the API server proxies to.
+func NodeInternalIP(node *v1.Node) string {
+	for _, address := range node.Status.Addresses {
+		if address.Type == v1.NodeInternalIP {
+			return address.Address
+		}
+	}
+	return ""
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package protokube
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestVerifyGossipStatusClient(t *testing.T) {
+	grid := []struct {
+		name     string
+		client   string
+		expected bool
+	}{
+		{name: "api server", client: "kubelet-api", expected: true},
+		{name: "kubelet", client: "system:node:node-a"},
+		{name: "no certificate"},
+	}
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			state := tls.ConnectionState{}
+			if g.client != "" {
+				state.PeerCertificates = []*x509.Certificate{{Subject: pkix.Name{CommonName: g.client}}}
+			}
+			err := verifyGossipStatusClient(state)
+			if g.expected && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !g.expected && err == nil {
+				t.Errorf("expected client %q to be rejected", g.client)
+			}
+		})
+	}
+}
+
+func TestNodeInternalIP(t *testing.T) {
+	node := &v1.Node{
+		Status: v1.NodeStatus{
+			Addresses: []v1.NodeAddress{
+				{Type: v1.NodeExternalIP, Address: "203.0.113.10"},
+				{Type: v1.NodeInternalIP, Address: "172.20.1.10"},
+				{Type: v1.NodeInternalIP, Address: "172.20.2.10"},
+			},
+		},
+	}
+	if ip := NodeInternalIP(node); ip != "172.20.1.10" {
+		t.Errorf("unexpected internal IP %q", ip)
+	}
+	if ip := NodeInternalIP(&v1.Node{}); ip != "" {
+		t.Errorf("unexpected internal IP %q for a node without addresses", ip)
+	}
+}
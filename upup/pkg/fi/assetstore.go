@@ -17,6 +17,7 @@ limitations under the License.
 package fi
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -91,13 +92,16 @@ func (r *assetResource) GetSource() *Source {
 }
 
 type AssetStore struct {
-	cacheDir string
-	assets   []*asset
+	cacheDir   string
+	assets     []*asset
+	downloader *Downloader
 }
 
-func NewAssetStore(cacheDir string) *AssetStore {
+// NewAssetStore returns an AssetStore downloading the assets with the downloader, which may be nil.
+func NewAssetStore(cacheDir string, downloader *Downloader) *AssetStore {
 	a := &AssetStore{
-		cacheDir: cacheDir,
+		cacheDir:   cacheDir,
+		downloader: downloader,
 	}
 	return a
 }
@@ -176,9 +180,13 @@ func (a *AssetStore) AddForTest(id string, path string, content string) {
 	})
 }
 
-func hashFromHTTPHeader(url string) (*hashing.Hash, error) {
+func (a *AssetStore) hashFromHTTPHeader(url string) (*hashing.Hash, error) {
 	klog.Infof("Doing HTTP HEAD on %q", url)
-	response, err := http.Head(url)
+	httpClient, req, err := a.downloader.newDownloadRequest(context.Background(), http.MethodHead, url)
+	if err != nil {
+		return nil, err
+	}
+	response, err := httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error doing HEAD on %q: %v", url, err)
 	}
@@ -227,7 +235,7 @@ func (a *AssetStore) addURLs(urls []string, hash *hashing.Hash) error {
 	var err error
 	if hash == nil {
 		for _, url := range urls {
-			hash, err = hashFromHTTPHeader(url)
+			hash, err = a.hashFromHTTPHeader(url)
 			if err != nil {
 				klog.Warningf("unable to get hash from %q: %v", url, err)
 				continue
@@ -246,7 +254,7 @@ func (a *AssetStore) addURLs(urls []string, hash *hashing.Hash) error {
 	localFile := path.Join(a.cacheDir, hash.String()+"_"+utils.SanitizeString(key))
 
 	for _, url := range urls {
-		_, err = DownloadURL(url, localFile, hash)
+		_, err = a.downloader.DownloadURL(url, localFile, hash)
 		if err != nil {
 			klog.Warningf("error downloading url %q: %v", url, err)
 			continue
@@ -297,6 +297,19 @@ func (c *ApplyClusterCmd) Run(ctx context.Context) (*ApplyResults, error) {
 		return nil, err
 	}
 
+	if featureflag.EtcdNodes.Enabled() {
+		// The kops version is recorded once the cluster has been applied
+		_, err := configBase.Join(registry.PathKopsVersionUpdated).ReadFile(ctx)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("error reading last kops version used to update: %w", err)
+		}
+		if os.IsNotExist(err) {
+			if err := validation.ValidateNewClusterEtcdPlacement(c.Cluster, c.InstanceGroups); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	if cluster.Spec.KubernetesVersion == "" {
 		return nil, fmt.Errorf("KubernetesVersion not set")
 	}
@@ -502,7 +515,7 @@ func (c *ApplyClusterCmd) Run(ctx context.Context) (*ApplyResults, error) {
 	modelContext.Region = cloud.Region()
 
 	if cluster.PublishesDNSRecords() {
-		err = validateDNS(cluster, cloud)
+		err = validateDNS(cluster, cloud, secretStore)
 		if err != nil {
 			return nil, err
 		}
@@ -511,6 +524,7 @@ func (c *ApplyClusterCmd) Run(ctx context.Context) (*ApplyResults, error) {
 	tf := &TemplateFunctions{
 		KopsModelContext: *modelContext,
 		cloud:            cloud,
+		assetBuilder:     assetBuilder,
 	}
 
 	nodeUpAssets, err := nodemodel.BuildNodeUpAssets(ctx, assetBuilder)
@@ -821,7 +835,7 @@ func (c *ApplyClusterCmd) Run(ctx context.Context) (*ApplyResults, error) {
 	}
 
 	if shouldPrecreateDNS && clusterLifecycle != fi.LifecycleIgnore {
-		if err := precreateDNS(ctx, cluster, cloud); err != nil {
+		if err := precreateDNS(ctx, cluster, cloud, secretStore); err != nil {
 			klog.Warningf("unable to pre-create DNS records - cluster startup may be slower: %v", err)
 		}
 	}
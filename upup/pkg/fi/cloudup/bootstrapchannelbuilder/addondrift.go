@@ -0,0 +1,154 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bootstrapchannelbuilder
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/kops/channels/pkg/channels"
+	"k8s.io/kops/pkg/kubemanifest"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+// kopsControllerAddon is the name of the addon holding the ClusterRole of kops-controller
+const kopsControllerAddon = "kops-controller.addons.k8s.io"
+
+// addAddonDriftRules grants kops-controller access to the history of the addons and to the kinds of their objects,
+// which addon drift detection reads and re-applies, instead of access to all kinds.
+func (b *BootstrapChannelBuilder) addAddonDriftRules(addons *AddonList) error {
+	var kopsController *Addon
+	historySecrets := sets.New[string]()
+	var objects kubemanifest.ObjectList
+	for _, addon := range addons.Items {
+		name := fi.ValueOf(addon.Spec.Name)
+		if name == kopsControllerAddon {
+			kopsController = addon
+		}
+		historySecrets.Insert((&channels.Channel{Name: name}).HistorySecretName())
+
+		addonObjects, err := kubemanifest.LoadObjectsFrom(addon.ManifestData)
+		if err != nil {
+			return fmt.Errorf("error parsing manifest of addon %q: %w", name, err)
+		}
+		objects = append(objects, addonObjects...)
+	}
+	if kopsController == nil {
+		return fmt.Errorf("addon %q not found", kopsControllerAddon)
+	}
+
+	rules := []interface{}{
+		map[string]interface{}{
+			"apiGroups":     []interface{}{""},
+			"resources":     []interface{}{"secrets"},
+			"resourceNames": toInterfaceSlice(sets.List(historySecrets)),
+			"verbs":         []interface{}{"get"},
+		},
+	}
+	resources, err := addonResources(objects)
+	if err != nil {
+		return err
+	}
+	for _, group := range sets.List(sets.KeySet(resources)) {
+		rules = append(rules, map[string]interface{}{
+			"apiGroups": []interface{}{group},
+			"resources": toInterfaceSlice(sets.List(resources[group])),
+			"verbs":     []interface{}{"get", "list", "create", "patch"},
+		})
+	}
+
+	kopsControllerObjects, err := kubemanifest.LoadObjectsFrom(kopsController.ManifestData)
+	if err != nil {
+		return fmt.Errorf("error parsing manifest of addon %q: %w", kopsControllerAddon, err)
+	}
+	found := false
+	for _, object := range kopsControllerObjects {
+		if object.GroupVersionKind().GroupKind() != (schema.GroupKind{Group: "rbac.authorization.k8s.io", Kind: "ClusterRole"}) || object.GetName() != "kops-controller" {
+			continue
+		}
+		u := object.ToUnstructured()
+		existing, _, err := unstructured.NestedSlice(u.Object, "rules")
+		if err != nil {
+			return fmt.Errorf("error reading rules of ClusterRole kops-controller: %w", err)
+		}
+		if err := unstructured.SetNestedSlice(u.Object, append(existing, rules...), "rules"); err != nil {
+			return fmt.Errorf("error setting rules of ClusterRole kops-controller: %w", err)
+		}
+		found = true
+	}
+	if !found {
+		return fmt.Errorf("ClusterRole kops-controller not found in addon %q", kopsControllerAddon)
+	}
+
+	manifestBytes, err := kopsControllerObjects.ToYAML()
+	if err != nil {
+		return fmt.Errorf("error serializing manifest of addon %q: %w", kopsControllerAddon, err)
+	}
+	kopsController.ManifestData = []byte(strings.TrimSpace(string(manifestBytes)))
+	return nil
+}
+
+// addonResources returns the resources of the objects, by API group.
+// The resources of custom resources are read from their definitions, if they are part of the addons.
+func addonResources(objects kubemanifest.ObjectList) (map[string]sets.Set[string], error) {
+	crdResources := make(map[schema.GroupKind]string)
+	for _, object := range objects {
+		if object.GroupVersionKind().GroupKind() != (schema.GroupKind{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}) {
+			continue
+		}
+		u := object.ToUnstructured()
+		group, _, _ := unstructured.NestedString(u.Object, "spec", "group")
+		kind, _, _ := unstructured.NestedString(u.Object, "spec", "names", "kind")
+		plural, _, _ := unstructured.NestedString(u.Object, "spec", "names", "plural")
+		if plural != "" {
+			crdResources[schema.GroupKind{Group: group, Kind: kind}] = plural
+		}
+	}
+
+	resources := make(map[string]sets.Set[string])
+	for _, object := range objects {
+		if object.IsEmptyObject() {
+			continue
+		}
+		gvk := object.GroupVersionKind()
+		if gvk.Kind == "" {
+			return nil, fmt.Errorf("failed to get kind for object %q", object.GetName())
+		}
+		resource, found := crdResources[gvk.GroupKind()]
+		if !found {
+			plural, _ := meta.UnsafeGuessKindToResource(gvk)
+			resource = plural.Resource
+		}
+		if resources[gvk.Group] == nil {
+			resources[gvk.Group] = sets.New[string]()
+		}
+		resources[gvk.Group].Insert(resource)
+	}
+	return resources, nil
+}
+
+func toInterfaceSlice(values []string) []interface{} {
+	var s []interface{}
+	for _, v := range values {
+		s = append(s, v)
+	}
+	return s
+}
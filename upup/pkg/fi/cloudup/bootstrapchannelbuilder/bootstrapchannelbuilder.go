@@ -17,13 +17,16 @@ limitations under the License.
 package bootstrapchannelbuilder
 
 import (
+	"context"
 	"fmt"
+	"net/url"
 	"strings"
 
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/klog/v2"
 	channelsapi "k8s.io/kops/channels/pkg/api"
+	"k8s.io/kops/channels/pkg/channels"
 	"k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/pkg/assets"
 	"k8s.io/kops/pkg/featureflag"
@@ -106,6 +109,14 @@ func (b *BootstrapChannelBuilder) Build(c *fi.CloudupModelBuilderContext) error
 		return err
 	}
 
+	// The manifests are written once all addons are built, as the permissions of kops-controller depend on them
+	type pendingManifest struct {
+		addon        *Addon
+		name         string
+		manifestPath string
+	}
+	var pendingManifests []pendingManifest
+
 	for _, a := range addons.Items {
 		// Older versions of channels that may be running on the upgrading cluster requires Version to be set
 		// We hardcode version to a high version to ensure an update is triggered on first run, and from then on
@@ -117,17 +128,28 @@ func (b *BootstrapChannelBuilder) Build(c *fi.CloudupModelBuilderContext) error
 			key = key + "-" + a.Spec.Id
 		}
 		name := b.Cluster.ObjectMeta.Name + "-addons-" + key
-		manifestPath := "addons/" + *a.Spec.Manifest
 		klog.V(4).Infof("Addon %q", name)
 
-		manifestResource := b.templates.Find(manifestPath)
-		if manifestResource == nil {
-			return fmt.Errorf("unable to find manifest %s", manifestPath)
+		var manifestBytes []byte
+		if a.Spec.Helm != nil {
+			manifestBytes, err = b.renderHelmAddon(c.Context(), a)
+			if err != nil {
+				return fmt.Errorf("error building addon %q: %w", name, err)
+			}
 		}
 
-		manifestBytes, err := fi.ResourceAsBytes(manifestResource)
-		if err != nil {
-			return fmt.Errorf("error reading manifest %s: %v", manifestPath, err)
+		manifestPath := "addons/" + *a.Spec.Manifest
+
+		if manifestBytes == nil {
+			manifestResource := b.templates.Find(manifestPath)
+			if manifestResource == nil {
+				return fmt.Errorf("unable to find manifest %s", manifestPath)
+			}
+
+			manifestBytes, err = fi.ResourceAsBytes(manifestResource)
+			if err != nil {
+				return fmt.Errorf("error reading manifest %s: %v", manifestPath, err)
+			}
 		}
 
 		// Go through any transforms that are best expressed as code
@@ -143,22 +165,7 @@ func (b *BootstrapChannelBuilder) Build(c *fi.CloudupModelBuilderContext) error
 
 		a.ManifestData = manifestBytes
 
-		rawManifest := string(manifestBytes)
-		klog.V(4).Infof("Manifest %v", rawManifest)
-
-		manifestHash, err := utils.HashString(rawManifest)
-		klog.V(4).Infof("hash %s", manifestHash)
-		if err != nil {
-			return fmt.Errorf("error hashing manifest: %v", err)
-		}
-		a.Spec.ManifestHash = manifestHash
-
-		c.AddTask(&fitasks.ManagedFile{
-			Contents:  fi.NewBytesResource(manifestBytes),
-			Lifecycle: b.Lifecycle,
-			Location:  fi.PtrTo(manifestPath),
-			Name:      fi.PtrTo(name),
-		})
+		pendingManifests = append(pendingManifests, pendingManifest{addon: a, name: name, manifestPath: manifestPath})
 	}
 
 	if featureflag.UseAddonOperators.Enabled() {
@@ -267,7 +274,37 @@ func (b *BootstrapChannelBuilder) Build(c *fi.CloudupModelBuilderContext) error
 			Name:      fi.PtrTo(name),
 		})
 
-		addons.Add(a)
+		addon := addons.Add(a)
+		addon.ManifestData = manifestBytes
+	}
+
+	if b.Cluster.Spec.AddonDriftDetection != nil {
+		if err := b.addAddonDriftRules(addons); err != nil {
+			return err
+		}
+	}
+
+	for _, m := range pendingManifests {
+		rawManifest := string(m.addon.ManifestData)
+		klog.V(4).Infof("Manifest %v", rawManifest)
+
+		manifestHash, err := utils.HashString(rawManifest)
+		klog.V(4).Infof("hash %s", manifestHash)
+		if err != nil {
+			return fmt.Errorf("error hashing manifest: %v", err)
+		}
+		m.addon.Spec.ManifestHash = manifestHash
+
+		c.AddTask(&fitasks.ManagedFile{
+			Contents:  fi.NewBytesResource(m.addon.ManifestData),
+			Lifecycle: b.Lifecycle,
+			Location:  fi.PtrTo(m.manifestPath),
+			Name:      fi.PtrTo(m.name),
+		})
+	}
+
+	if err := b.checkAddonOverrides(addons); err != nil {
+		return err
 	}
 
 	if err := b.addPruneDirectives(addons); err != nil {
@@ -323,6 +360,82 @@ type Addon struct {
 
 	// BuildPrune is set if we should automatically build prune specifiers, based on the manifest.
 	BuildPrune bool
+
+	// HelmValues is the path of the template of the values of a Helm chart addon, rendered with the cluster spec.
+	HelmValues string
+}
+
+// checkAddonOverrides checks that every addon override applies to an addon whose manifest we render.
+func (b *BootstrapChannelBuilder) checkAddonOverrides(addons *AddonList) error {
+	for _, override := range b.Cluster.Spec.AddonOverrides {
+		found := false
+		for _, a := range addons.Items {
+			if fi.ValueOf(a.Spec.Name) == override.Name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("addon override %q does not match any addon", override.Name)
+		}
+	}
+	return nil
+}
+
+// renderHelmAddon renders the Helm chart of an addon, with values templated from the cluster spec.
+// The rendered chart replaces the chart in the addon spec, so that it is remapped, hashed and applied
+// like the manifest of any other addon, and the images of the chart are known to the asset builder.
+func (b *BootstrapChannelBuilder) renderHelmAddon(ctx context.Context, a *Addon) ([]byte, error) {
+	if a.HelmValues != "" {
+		valuesPath := "addons/" + a.HelmValues
+		valuesResource := b.templates.Find(valuesPath)
+		if valuesResource == nil {
+			return nil, fmt.Errorf("unable to find helm values %s", valuesPath)
+		}
+
+		valuesBytes, err := fi.ResourceAsBytes(valuesResource)
+		if err != nil {
+			return nil, fmt.Errorf("error reading helm values %s: %w", valuesPath, err)
+		}
+
+		values := make(map[string]interface{})
+		if err := utils.YamlUnmarshal(valuesBytes, &values); err != nil {
+			return nil, fmt.Errorf("error parsing helm values %s: %w", valuesPath, err)
+		}
+		a.Spec.Helm.Values = values
+	}
+
+	// Packaged charts are relative to the bootstrap channel, in the state store
+	channelLocation, err := url.Parse(strings.TrimSuffix(b.Cluster.Spec.ConfigStore.Base, "/") + "/addons/bootstrap-channel.yaml")
+	if err != nil {
+		return nil, fmt.Errorf("error parsing channel location: %w", err)
+	}
+	addon := &channels.Addon{
+		Name:            fi.ValueOf(a.Spec.Name),
+		ChannelLocation: *channelLocation,
+		Spec:            a.Spec,
+	}
+	renderer := &channels.HelmRenderer{
+		VFSContext:        vfs.Context,
+		KubernetesVersion: b.Cluster.Spec.KubernetesVersion,
+	}
+
+	chartData, err := renderer.FetchChart(ctx, addon)
+	if err != nil {
+		return nil, err
+	}
+	manifestBytes, err := renderer.RenderChart(ctx, addon, chartData)
+	if err != nil {
+		return nil, err
+	}
+
+	id := a.Spec.Id
+	if id == "" {
+		id = "helm"
+	}
+	a.Spec.Manifest = fi.PtrTo(fi.ValueOf(a.Spec.Name) + "/" + id + ".yaml")
+	a.Spec.Helm = nil
+	return manifestBytes, nil
 }
 
 func (b *BootstrapChannelBuilder) buildAddons(c *fi.CloudupModelBuilderContext) (*AddonList, map[types.NamespacedName]iam.Subject, error) {
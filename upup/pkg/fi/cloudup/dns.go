@@ -17,7 +17,9 @@ limitations under the License.
 package cloudup
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"net"
 	"os"
@@ -26,6 +28,8 @@ import (
 	"k8s.io/klog/v2"
 	"k8s.io/kops/dns-controller/pkg/dns"
 	"k8s.io/kops/dnsprovider/pkg/dnsprovider"
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider/providers/cloudflare"
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider/providers/rfc2136"
 	"k8s.io/kops/dnsprovider/pkg/dnsprovider/rrstype"
 	"k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/upup/pkg/fi"
@@ -46,8 +50,87 @@ type recordKey struct {
 	rrsType  rrstype.RrsType
 }
 
-func findZone(cluster *kops.Cluster, cloud fi.Cloud) (dnsprovider.Zone, error) {
-	dns, err := cloud.DNS()
+// DNSProviderSecretName is the secret holding the configuration of a DNS provider that is not the DNS service of the cloud provider.
+// It holds a JSON object of the variables configuring the provider, see DNSProviderEnvVars.
+const DNSProviderSecretName = "dnsprovider"
+
+// DNSProviderEnvVars returns the names of the variables configuring the named DNS provider.
+func DNSProviderEnvVars(name string) ([]string, error) {
+	switch name {
+	case cloudflare.ProviderName:
+		return cloudflare.EnvVars, nil
+	case rfc2136.ProviderName:
+		return rfc2136.EnvVars, nil
+	default:
+		return nil, fmt.Errorf("unknown DNS provider %q", name)
+	}
+}
+
+// dnsProviderConfig reads the configuration of the DNS provider of the cluster from the secret store.
+func dnsProviderConfig(cluster *kops.Cluster, secretStore fi.SecretStore) (map[string]string, error) {
+	name := cluster.Spec.ExternalDNS.DNSProvider
+	envVars, err := DNSProviderEnvVars(name)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := secretStore.FindSecret(DNSProviderSecretName)
+	if err != nil {
+		return nil, fmt.Errorf("could not load the %s secret: %w", DNSProviderSecretName, err)
+	}
+	if secret == nil {
+		return nil, fmt.Errorf("the %s DNS provider is configured by the %s secret, which has not been set; see `kops create secret dnsprovider -h`", name, DNSProviderSecretName)
+	}
+
+	values := make(map[string]string)
+	if err := json.Unmarshal(secret.Data, &values); err != nil {
+		return nil, fmt.Errorf("error parsing the %s secret: %w", DNSProviderSecretName, err)
+	}
+	config := make(map[string]string)
+	for _, k := range envVars {
+		if v := values[k]; v != "" {
+			config[k] = v
+		}
+	}
+
+	// Check that the configuration is complete
+	if _, err := newDNSProvider(name, config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// newDNSProvider builds the named DNS provider from its variables.
+func newDNSProvider(name string, config map[string]string) (dnsprovider.Interface, error) {
+	data, err := json.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+	provider, err := dnsprovider.GetDnsProvider(name, bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("could not init DNS provider %q: %w", name, err)
+	}
+	if provider == nil {
+		return nil, fmt.Errorf("unknown DNS provider %q", name)
+	}
+	return provider, nil
+}
+
+// BuildDNSProvider returns the DNS provider in which the DNS records of the cluster are managed.
+// A DNS provider other than the cloud's is configured from the dnsprovider secret.
+func BuildDNSProvider(cluster *kops.Cluster, cloud fi.Cloud, secretStore fi.SecretStore) (dnsprovider.Interface, error) {
+	if cluster.UsesCloudDNSProvider() {
+		return cloud.DNS()
+	}
+	config, err := dnsProviderConfig(cluster, secretStore)
+	if err != nil {
+		return nil, err
+	}
+	return newDNSProvider(cluster.Spec.ExternalDNS.DNSProvider, config)
+}
+
+func findZone(cluster *kops.Cluster, cloud fi.Cloud, secretStore fi.SecretStore) (dnsprovider.Zone, error) {
+	dns, err := BuildDNSProvider(cluster, cloud, secretStore)
 	if err != nil {
 		return nil, fmt.Errorf("error building DNS provider: %v", err)
 	}
@@ -91,13 +174,13 @@ func findZone(cluster *kops.Cluster, cloud fi.Cloud) (dnsprovider.Zone, error) {
 	return zone, nil
 }
 
-func validateDNS(cluster *kops.Cluster, cloud fi.Cloud) error {
+func validateDNS(cluster *kops.Cluster, cloud fi.Cloud, secretStore fi.SecretStore) error {
 	if !cluster.PublishesDNSRecords() || cluster.UsesPrivateDNS() {
 		klog.V(2).Infof("Skipping DNS validation for non-public DNS")
 		return nil
 	}
 
-	zone, err := findZone(cluster, cloud)
+	zone, err := findZone(cluster, cloud, secretStore)
 	if err != nil {
 		return err
 	}
@@ -128,7 +211,7 @@ func validateDNS(cluster *kops.Cluster, cloud fi.Cloud) error {
 	return nil
 }
 
-func precreateDNS(ctx context.Context, cluster *kops.Cluster, cloud fi.Cloud) error {
+func precreateDNS(ctx context.Context, cluster *kops.Cluster, cloud fi.Cloud, secretStore fi.SecretStore) error {
 	// TODO: Move to update
 
 	// We precreate some DNS names (where they don't exist), with a dummy IP address
@@ -147,7 +230,7 @@ func precreateDNS(ctx context.Context, cluster *kops.Cluster, cloud fi.Cloud) er
 	}
 
 	klog.V(2).Infof("Checking DNS records")
-	zone, err := findZone(cluster, cloud)
+	zone, err := findZone(cluster, cloud, secretStore)
 	if err != nil {
 		return err
 	}
@@ -1039,7 +1039,8 @@ func setupControlPlane(opt *NewClusterOptions, cluster *api.Cluster, zoneToSubne
 	return controlPlanes, nil
 }
 
-func trimCommonPrefix(names []string) []string {
+// TrimCommonPrefix shortens names by removing the prefix they share, as used to name etcd members after their instance groups.
+func TrimCommonPrefix(names []string) []string {
 	// Trim shared prefix to keep the lengths sane
 	// (this only applies to new clusters...)
 	for len(names) != 0 && len(names[0]) > 1 {
@@ -1592,7 +1593,7 @@ func createEtcdCluster(etcdCluster string, controlPlanes []*api.InstanceGroup, e
 		names = append(names, name)
 	}
 
-	names = trimCommonPrefix(names)
+	names = TrimCommonPrefix(names)
 
 	for i, ig := range controlPlanes {
 		m := api.EtcdMemberSpec{}
@@ -61,7 +61,7 @@ func TestRemoveSharedPrefix(t *testing.T) {
 		},
 	}
 	for _, g := range grid {
-		actual := trimCommonPrefix(g.Input)
+		actual := TrimCommonPrefix(g.Input)
 		if !reflect.DeepEqual(actual, g.Output) {
 			t.Errorf("unexpected result from %q.  actual=%v, expected=%v", g.Input, actual, g.Output)
 		}
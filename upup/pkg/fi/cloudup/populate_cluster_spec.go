@@ -259,7 +259,7 @@ func (c *populateClusterSpec) run(ctx context.Context, clientset simple.Clientse
 	}
 
 	if cluster.Spec.DNSZone == "" && cluster.PublishesDNSRecords() {
-		dns, err := cloud.DNS()
+		dns, err := BuildDNSProvider(cluster, cloud, secretStore)
 		if err != nil {
 			return err
 		}
@@ -105,6 +105,22 @@ func PopulateInstanceGroupSpec(cluster *kops.Cluster, input *kops.InstanceGroup,
 		if ig.Spec.MaxSize == nil {
 			ig.Spec.MaxSize = fi.PtrTo(int32(1))
 		}
+	} else if ig.IsEtcdOnly() {
+		if !featureflag.EtcdNodes.Enabled() {
+			return nil, fmt.Errorf("etcd nodes requires the EtcdNodes feature flag to be enabled")
+		}
+		if ig.Spec.MachineType == "" {
+			ig.Spec.MachineType, err = defaultMachineType(cloud, cluster, ig)
+			if err != nil {
+				return nil, fmt.Errorf("assigning default machine type for etcd nodes: %v", err)
+			}
+		}
+		if ig.Spec.MinSize == nil {
+			ig.Spec.MinSize = fi.PtrTo(int32(1))
+		}
+		if ig.Spec.MaxSize == nil {
+			ig.Spec.MaxSize = fi.PtrTo(int32(1))
+		}
 	} else if ig.Spec.Role == kops.InstanceGroupRoleBastion {
 		if ig.Spec.MachineType == "" {
 			ig.Spec.MachineType, err = defaultMachineType(cloud, cluster, ig)
@@ -162,6 +178,10 @@ func PopulateInstanceGroupSpec(cluster *kops.Cluster, input *kops.InstanceGroup,
 		if len(ig.Spec.Subnets) == 0 {
 			return nil, fmt.Errorf("control-plane InstanceGroup %s did not specify any Subnets", ig.ObjectMeta.Name)
 		}
+	} else if ig.IsEtcdOnly() {
+		if len(ig.Spec.Subnets) == 0 {
+			return nil, fmt.Errorf("etcd InstanceGroup %s did not specify any Subnets", ig.ObjectMeta.Name)
+		}
 	} else if ig.IsAPIServerOnly() && cluster.Spec.IsIPv6Only() {
 		if len(ig.Spec.Subnets) == 0 {
 			for _, subnet := range cluster.Spec.Networking.Subnets {
@@ -287,6 +307,10 @@ func PopulateInstanceGroupSpec(cluster *kops.Cluster, input *kops.InstanceGroup,
 			// (Even though the value is empty, we still expect <Key>=<Value>:<Effect>)
 			taints.Insert(nodelabels.RoleLabelAPIServer16 + "=:" + string(v1.TaintEffectNoSchedule))
 		}
+		if ig.IsEtcdOnly() {
+			// (Even though the value is empty, we still expect <Key>=<Value>:<Effect>)
+			taints.Insert(nodelabels.RoleLabelEtcd + "=:" + string(v1.TaintEffectNoSchedule))
+		}
 	}
 
 	igKubeletConfig.Taints = taints.List()
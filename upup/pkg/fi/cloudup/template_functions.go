@@ -38,10 +38,12 @@ import (
 	"strconv"
 	"strings"
 	"text/template"
+	"time"
 
 	"github.com/Masterminds/sprig/v3"
 	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 	kopsroot "k8s.io/kops"
@@ -49,11 +51,14 @@ import (
 	"k8s.io/kops/pkg/apis/kops"
 	apiModel "k8s.io/kops/pkg/apis/kops/model"
 	"k8s.io/kops/pkg/apis/kops/util"
+	"k8s.io/kops/pkg/assets"
 	"k8s.io/kops/pkg/bootstrap/pkibootstrap"
+	etcdmanagerbackups "k8s.io/kops/pkg/etcdmanager"
 	"k8s.io/kops/pkg/featureflag"
 	"k8s.io/kops/pkg/flagbuilder"
 	"k8s.io/kops/pkg/kubemanifest"
 	"k8s.io/kops/pkg/model"
+	"k8s.io/kops/pkg/model/components/etcdmanager"
 	"k8s.io/kops/pkg/model/components/kopscontroller"
 	"k8s.io/kops/pkg/model/iam"
 	"k8s.io/kops/pkg/resources/spotinst"
@@ -73,11 +78,16 @@ import (
 	"sigs.k8s.io/yaml"
 )
 
+// defaultAddonDriftInterval is how often kops-controller checks the addons for drift, if the cluster doesn't specify it.
+const defaultAddonDriftInterval = 10 * time.Minute
+
 // TemplateFunctions provides a collection of methods used throughout the templates
 type TemplateFunctions struct {
 	model.KopsModelContext
 
 	cloud fi.Cloud
+	// assetBuilder remaps images that are passed in configuration rather than in manifests
+	assetBuilder *assets.AssetBuilder
 }
 
 // AddTo defines the available functions we can use in our YAML models.
@@ -173,9 +183,26 @@ func (tf *TemplateFunctions) AddTo(dest template.FuncMap, secretStore fi.SecretS
 	dest["OpenStackCCMTag"] = tf.OpenStackCCMTag
 	dest["OpenStackCSITag"] = tf.OpenStackCSITag
 	dest["DNSControllerEnvs"] = tf.DNSControllerEnvs
+	dest["DNSProviderEnvs"] = func() (map[string]string, error) {
+		return tf.DNSProviderEnvs(secretStore)
+	}
+	dest["DNSControllerWatchGateway"] = func() bool {
+		return cluster.Spec.ExternalDNS != nil && fi.ValueOf(cluster.Spec.ExternalDNS.WatchGateway)
+	}
 	dest["ProxyEnv"] = tf.ProxyEnv
 
 	dest["KopsControllerEnv"] = tf.KopsControllerEnv
+	dest["AddonDriftDetectionEnabled"] = func() bool {
+		return cluster.Spec.AddonDriftDetection != nil
+	}
+	dest["EtcdBackupVerificationEnabled"] = func() bool {
+		for _, etcdCluster := range cluster.Spec.EtcdClusters {
+			if etcdCluster.Backups != nil && etcdCluster.Backups.Verification != nil {
+				return true
+			}
+		}
+		return false
+	}
 
 	dest["DO_TOKEN"] = func() string {
 		return os.Getenv("DIGITALOCEAN_ACCESS_TOKEN")
@@ -419,6 +446,7 @@ func (tf *TemplateFunctions) AddTo(dest template.FuncMap, secretStore fi.SecretS
 	dest["KopsFeatureEnabled"] = tf.kopsFeatureEnabled
 	dest["KopsVersion"] = func() string { return kopsroot.Version }
 	dest["KopsVersionImageTag"] = func() string { return kopsroot.KopsVersionImageTag() }
+	dest["KopsControllerImage"] = KopsControllerImage
 	dest["KopsVersionForLabel"] = func() string {
 		// Labels follow strict rules: a valid label must be an empty string or consist of alphanumeric characters, '-', '_' or '.', and must start and end with an alphanumeric character
 		// By convention we use a v prefix here
@@ -579,6 +607,16 @@ func (tf *TemplateFunctions) DNSControllerArgv() ([]string, error) {
 			klog.Warningln("this may cause problems with previously defined services: https://github.com/kubernetes/kops/issues/2496")
 		}
 		argv = append(argv, fmt.Sprintf("--watch-ingress=%t", watchIngress))
+		if fi.ValueOf(cluster.Spec.ExternalDNS.WatchGateway) {
+			argv = append(argv, "--watch-gateway=true")
+		}
+		if cluster.Spec.ExternalDNS.TXTOwnerID != "" {
+			argv = append(argv, "--txt-owner-id="+cluster.Spec.ExternalDNS.TXTOwnerID)
+			argv = append(argv, "--cluster-name="+cluster.Name)
+			if cluster.Spec.ExternalDNS.TXTAdoptUnowned {
+				argv = append(argv, "--txt-adopt-unowned=true")
+			}
+		}
 		if cluster.Spec.ExternalDNS.WatchNamespace != "" {
 			argv = append(argv, fmt.Sprintf("--watch-namespace=%s", cluster.Spec.ExternalDNS.WatchNamespace))
 		}
@@ -630,6 +668,8 @@ func (tf *TemplateFunctions) DNSControllerArgv() ([]string, error) {
 			argv = append(argv, fmt.Sprintf("--gossip-listen-secondary=0.0.0.0:%d", wellknownports.DNSControllerGossipMemberlist))
 			argv = append(argv, fmt.Sprintf("--gossip-seed-secondary=127.0.0.1:%d", wellknownports.ProtokubeGossipMemberlist))
 		}
+	} else if !cluster.UsesCloudDNSProvider() {
+		argv = append(argv, "--dns="+cluster.Spec.ExternalDNS.DNSProvider)
 	} else {
 		switch cluster.GetCloudProvider() {
 		case kops.CloudProviderAWS:
@@ -805,6 +845,17 @@ func (tf *TemplateFunctions) KopsControllerConfig() (string, error) {
 		}
 	}
 
+	if err := tf.buildEtcdBackupVerificationConfig(config); err != nil {
+		return "", err
+	}
+	tf.buildAddonDriftConfig(config)
+
+	// The backup drills and the addon drift are reported as metrics.
+	// kops-controller uses host networking, so we only listen on loopback.
+	if config.EtcdBackupVerification != nil || config.AddonDrift != nil {
+		config.MetricsBindAddress = fmt.Sprintf("127.0.0.1:%d", wellknownports.KopsControllerMetrics)
+	}
+
 	// To avoid indentation problems, we marshal as json.  json is a subset of yaml
 	b, err := json.Marshal(config)
 	if err != nil {
@@ -814,6 +865,98 @@ func (tf *TemplateFunctions) KopsControllerConfig() (string, error) {
 	return string(b), nil
 }
 
+// buildEtcdBackupVerificationConfig configures kops-controller to verify the backups of the etcd clusters that opt in.
+func (tf *TemplateFunctions) buildEtcdBackupVerificationConfig(config *kopscontrollerconfig.Options) error {
+	cluster := tf.Cluster
+
+	var etcdClusters []kopscontrollerconfig.EtcdBackupVerificationClusterOptions
+	for _, etcdCluster := range cluster.Spec.EtcdClusters {
+		if etcdCluster.Backups == nil || etcdCluster.Backups.Verification == nil {
+			continue
+		}
+		verification := etcdCluster.Backups.Verification
+
+		etcdImage := etcdmanager.EtcdImage(etcdCluster.Version)
+		if etcdImage == "" {
+			return fmt.Errorf("cannot verify backups of etcd cluster %q: no image known for etcd version %q", etcdCluster.Name, etcdCluster.Version)
+		}
+
+		options := kopscontrollerconfig.EtcdBackupVerificationClusterOptions{
+			Name:             etcdCluster.Name,
+			BackupStore:      etcdCluster.Backups.BackupStore,
+			EtcdImage:        tf.remapImage(etcdImage),
+			EtcdVersion:      strings.TrimPrefix(etcdCluster.Version, "v"),
+			Interval:         metav1.Duration{Duration: etcdmanagerbackups.DefaultVerificationInterval},
+			MinKeys:          1,
+			RequiredPrefixes: verification.RequiredPrefixes,
+		}
+		if verification.Interval != nil {
+			options.Interval = *verification.Interval
+		}
+		if verification.MinKeys != nil {
+			options.MinKeys = *verification.MinKeys
+		}
+		etcdClusters = append(etcdClusters, options)
+	}
+	if len(etcdClusters) == 0 {
+		return nil
+	}
+
+	env := make(map[string]string)
+	for _, envVar := range tf.KopsControllerEnv() {
+		env[envVar.Name] = envVar.Value
+	}
+
+	config.EtcdBackupVerification = &kopscontrollerconfig.EtcdBackupVerificationOptions{
+		Image:        tf.remapImage(KopsControllerImage()),
+		Namespace:    "kube-system",
+		ClientPort:   wellknownports.EtcdBackupVerificationClientPort,
+		PeerPort:     wellknownports.EtcdBackupVerificationPeerPort,
+		Env:          env,
+		EtcdClusters: etcdClusters,
+	}
+	return nil
+}
+
+// buildAddonDriftConfig configures kops-controller to check the installed addons for drift, if the cluster opts in.
+func (tf *TemplateFunctions) buildAddonDriftConfig(config *kopscontrollerconfig.Options) {
+	spec := tf.Cluster.Spec.AddonDriftDetection
+	if spec == nil {
+		return
+	}
+
+	options := &kopscontrollerconfig.AddonDriftOptions{
+		Interval: metav1.Duration{Duration: defaultAddonDriftInterval},
+		Policy:   string(kops.AddonDriftPolicyReport),
+	}
+	if spec.Interval != nil {
+		options.Interval = *spec.Interval
+	}
+	if spec.Policy != "" {
+		options.Policy = string(spec.Policy)
+	}
+	for name, policy := range spec.Addons {
+		if options.Addons == nil {
+			options.Addons = make(map[string]string)
+		}
+		options.Addons[name] = string(policy)
+	}
+
+	config.AddonDrift = options
+}
+
+// KopsControllerImage returns the kops-controller image matching this version of kOps, before remapping.
+func KopsControllerImage() string {
+	return "registry.k8s.io/kops/kops-controller:" + kopsroot.KopsVersionImageTag()
+}
+
+func (tf *TemplateFunctions) remapImage(image string) string {
+	if tf.assetBuilder == nil {
+		return image
+	}
+	return tf.assetBuilder.RemapImage(image)
+}
+
 // KopsControllerArgv returns the args to kops-controller
 func (tf *TemplateFunctions) KopsControllerArgv() ([]string, error) {
 	var argv []string
@@ -878,6 +1021,15 @@ func (tf *TemplateFunctions) DNSControllerEnvs() map[string]string {
 	return out
 }
 
+// DNSProviderEnvs returns the environment variables configuring the DNS provider of dns-controller,
+// read from the dnsprovider secret, or nil if the DNS service of the cloud provider is used.
+func (tf *TemplateFunctions) DNSProviderEnvs(secretStore fi.SecretStore) (map[string]string, error) {
+	if tf.Cluster.UsesCloudDNSProvider() || !tf.Cluster.PublishesDNSRecords() {
+		return nil, nil
+	}
+	return dnsProviderConfig(tf.Cluster, secretStore)
+}
+
 func (tf *TemplateFunctions) ProxyEnv() map[string]string {
 	cluster := tf.Cluster
 
@@ -920,6 +1072,14 @@ func (tf *TemplateFunctions) KopsControllerEnv() []corev1.EnvVar {
 		envMap["KOPS_BASE_URL"] = v
 	}
 
+	// kops-controller reads a WebDAV state store with the credentials that nodeup writes to its hostPath
+	if strings.HasPrefix(tf.Cluster.Spec.ConfigStore.Base, "webdav") {
+		if v := os.Getenv("WEBDAV_USERNAME"); v != "" {
+			envMap["WEBDAV_USERNAME"] = v
+			envMap["WEBDAV_PASSWORD_FILE"] = "/etc/kubernetes/kops-controller/pki/webdav-password"
+		}
+	}
+
 	return envMap.ToEnvVars()
 }
 
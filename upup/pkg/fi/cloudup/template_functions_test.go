@@ -17,6 +17,7 @@ limitations under the License.
 package cloudup
 
 import (
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"testing"
@@ -24,6 +25,8 @@ import (
 	"k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/pkg/featureflag"
 	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/secrets"
+	"k8s.io/kops/util/pkg/vfs"
 )
 
 func Test_TemplateFunctions_CloudControllerConfigArgv(t *testing.T) {
@@ -313,3 +316,96 @@ func TestKopsFeatureEnabled(t *testing.T) {
 		})
 	}
 }
+
+func TestDNSProviderEnvs(t *testing.T) {
+	tests := []struct {
+		name        string
+		clusterName string
+		dnsProvider string
+		secret      map[string]string
+		expected    map[string]string
+		expectError bool
+	}{
+		{
+			name:        "Cloud DNS provider",
+			clusterName: "minimal.example.com",
+		},
+		{
+			name:        "Gossip cluster",
+			clusterName: "minimal.k8s.local",
+			dnsProvider: "cloudflare",
+		},
+		{
+			name:        "Cloudflare",
+			clusterName: "minimal.example.com",
+			dnsProvider: "cloudflare",
+			secret:      map[string]string{"CLOUDFLARE_API_TOKEN": "token", "HOME": "/root"},
+			expected:    map[string]string{"CLOUDFLARE_API_TOKEN": "token"},
+		},
+		{
+			name:        "Cloudflare without secret",
+			clusterName: "minimal.example.com",
+			dnsProvider: "cloudflare",
+			expectError: true,
+		},
+		{
+			name:        "Cloudflare without token",
+			clusterName: "minimal.example.com",
+			dnsProvider: "cloudflare",
+			secret:      map[string]string{"CLOUDFLARE_ACCOUNT_ID": "account"},
+			expectError: true,
+		},
+		{
+			name:        "RFC2136",
+			clusterName: "minimal.example.com",
+			dnsProvider: "rfc2136",
+			secret: map[string]string{
+				"RFC2136_NAMESERVER":   "ns1.example.com",
+				"RFC2136_ZONES":        "example.com",
+				"RFC2136_TSIG_KEYNAME": "kops",
+				"RFC2136_TSIG_SECRET":  "c2VjcmV0",
+			},
+			expected: map[string]string{
+				"RFC2136_NAMESERVER":   "ns1.example.com",
+				"RFC2136_ZONES":        "example.com",
+				"RFC2136_TSIG_KEYNAME": "kops",
+				"RFC2136_TSIG_SECRET":  "c2VjcmV0",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tf := &TemplateFunctions{}
+			tf.Cluster = &kops.Cluster{}
+			tf.Cluster.Name = tc.clusterName
+			if tc.dnsProvider != "" {
+				tf.Cluster.Spec.ExternalDNS = &kops.ExternalDNSConfig{DNSProvider: tc.dnsProvider}
+			}
+
+			secretStore := secrets.NewVFSSecretStore(tf.Cluster, vfs.NewMemFSPath(vfs.NewMemFSContext(), "memfs://tests/secrets"))
+			if tc.secret != nil {
+				data, err := json.Marshal(tc.secret)
+				if err != nil {
+					t.Fatalf("error encoding secret: %v", err)
+				}
+				if _, err := secretStore.ReplaceSecret(DNSProviderSecretName, &fi.Secret{Data: data}); err != nil {
+					t.Fatalf("error storing secret: %v", err)
+				}
+			}
+
+			actual, err := tf.DNSProviderEnvs(secretStore)
+			if err != nil && !tc.expectError {
+				t.Errorf("unexpected error: %s", err)
+			}
+			if err == nil && tc.expectError {
+				t.Errorf("expected error, got nil")
+			}
+			if len(actual) != 0 || len(tc.expected) != 0 {
+				if !reflect.DeepEqual(actual, tc.expected) {
+					t.Errorf("expected %v, got %v", tc.expected, actual)
+				}
+			}
+		})
+	}
+}
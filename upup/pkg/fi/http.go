@@ -18,21 +18,264 @@ package fi
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"fmt"
 	"io"
 	"net"
 	"net/http"
+	"net/url"
 	"os"
 	"path"
+	"strings"
+	"sync"
 	"time"
 
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/klog/v2"
 	"k8s.io/kops/util/pkg/hashing"
 )
 
-// DownloadURL will download the file at the given url and store it as dest.
+// DownloadAuth holds the CAs, client certificate and credentials used to download files from a repository.
+type DownloadAuth struct {
+	// Prefix is the location of the repository. The DownloadAuth is used for the URLs under it.
+	Prefix string
+	// CA is the PEM encoded bundle of CA certificates that are trusted to serve the repository.
+	CA []byte
+	// Certificate and Key are the PEM encoded client certificate and key to present to the repository.
+	Certificate []byte
+	Key         []byte
+	// Username and Password are sent to the repository with basic authentication.
+	Username string
+	Password string
+	// Token is sent to the repository as a bearer token.
+	Token string
+
+	// host is the host of the repository, the only one that tlsConfig is used for.
+	host      string
+	tlsConfig *tls.Config
+}
+
+// Downloader downloads files with the CAs, client certificates and credentials of the repositories holding them.
+// A nil Downloader downloads every file anonymously.
+type Downloader struct {
+	mutex sync.Mutex
+	// auths are the DownloadAuths of the repositories, by the host of the repository.
+	auths map[string][]*DownloadAuth
+}
+
+// NewDownloader returns a Downloader without any DownloadAuth.
+func NewDownloader() *Downloader {
+	return &Downloader{
+		auths: make(map[string][]*DownloadAuth),
+	}
+}
+
+// downloadBackoff is the backoff between the attempts to download a file.
+var downloadBackoff = wait.Backoff{
+	Duration: 2 * time.Second,
+	Factor:   2,
+	Steps:    3,
+}
+
+// AddAuth registers the credentials of a repository, which are then used to download the files under its prefix.
+func (d *Downloader) AddAuth(auth *DownloadAuth) error {
+	if auth.Prefix == "" {
+		return fmt.Errorf("download auth requires a prefix")
+	}
+	prefix, err := url.Parse(auth.Prefix)
+	if err != nil || prefix.Host == "" {
+		return fmt.Errorf("download auth prefix %q is not a URL", auth.Prefix)
+	}
+	auth.host = prefix.Host
+
+	if len(auth.CA) != 0 || len(auth.Certificate) != 0 {
+		auth.tlsConfig = &tls.Config{
+			MinVersion: tls.VersionTLS12,
+		}
+		if len(auth.CA) != 0 {
+			// The repository may redirect to hosts with public certificates, so the system CAs are still trusted
+			pool, err := x509.SystemCertPool()
+			if err != nil {
+				klog.Warningf("unable to load the system CA certificates: %v", err)
+				pool = x509.NewCertPool()
+			}
+			if !pool.AppendCertsFromPEM(auth.CA) {
+				return fmt.Errorf("no CA certificates found for %q", auth.Prefix)
+			}
+			auth.tlsConfig.RootCAs = pool
+		}
+		if len(auth.Certificate) != 0 {
+			certificate, err := tls.X509KeyPair(auth.Certificate, auth.Key)
+			if err != nil {
+				return fmt.Errorf("loading client certificate for %q: %w", auth.Prefix, err)
+			}
+			auth.tlsConfig.Certificates = []tls.Certificate{certificate}
+		}
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.auths[auth.host] = append(d.auths[auth.host], auth)
+	return nil
+}
+
+// findDownloadAuth returns the DownloadAuth with the longest prefix matching url, or nil.
+func (d *Downloader) findDownloadAuth(url *url.URL) *DownloadAuth {
+	if d == nil {
+		return nil
+	}
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return findHostDownloadAuth(url, d.auths[url.Host], false)
+}
+
+// findTLSConfig returns the TLS configuration of the repository on the host of url, or nil to use the defaults.
+// The DownloadAuth matching url is preferred, but the configuration applies to any URL on the host of the repository,
+// as the repository may redirect to it.
+func (d *Downloader) findTLSConfig(url *url.URL) *tls.Config {
+	if d == nil {
+		return nil
+	}
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	auths := d.auths[url.Host]
+	if auth := findHostDownloadAuth(url, auths, true); auth != nil {
+		return auth.tlsConfig
+	}
+	for _, auth := range auths {
+		if auth.tlsConfig != nil {
+			return auth.tlsConfig
+		}
+	}
+	return nil
+}
+
+// findHostDownloadAuth returns the DownloadAuth among auths with the longest prefix matching url, or nil.
+// If withTLS is true, only the DownloadAuths with a TLS configuration are considered.
+func findHostDownloadAuth(u *url.URL, auths []*DownloadAuth, withTLS bool) *DownloadAuth {
+	location := u.String()
+	var found *DownloadAuth
+	for _, auth := range auths {
+		if withTLS && auth.tlsConfig == nil {
+			continue
+		}
+		// Only match whole path segments, so https://example.com/files doesn't match https://example.com/files-other
+		prefix := strings.TrimSuffix(auth.Prefix, "/")
+		if location != prefix && !strings.HasPrefix(location, prefix+"/") {
+			continue
+		}
+		if found == nil || len(auth.Prefix) > len(found.Prefix) {
+			found = auth
+		}
+	}
+	return found
+}
+
+// newDownloadTransport returns a transport using the TLS configuration, or the defaults if it is nil.
+func newDownloadTransport(tlsConfig *tls.Config) *http.Transport {
+	// Create a transport with custom timeouts
+	// to avoid idle downloads to hang the program
+	transport := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ResponseHeaderTimeout: 30 * time.Second,
+		IdleConnTimeout:       30 * time.Second,
+	}
+	if tlsConfig != nil {
+		transport.TLSClientConfig = tlsConfig.Clone()
+	}
+	return transport
+}
+
+// downloadTransport sends each request with the TLS configuration of the repository on its host,
+// so that the CAs and client certificate of a repository are not used for the hosts it redirects to.
+type downloadTransport struct {
+	downloader *Downloader
+	mutex      sync.Mutex
+	transports map[*tls.Config]*http.Transport
+}
+
+var _ http.RoundTripper = &downloadTransport{}
+
+func (t *downloadTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	tlsConfig := t.downloader.findTLSConfig(req.URL)
+
+	t.mutex.Lock()
+	transport := t.transports[tlsConfig]
+	if transport == nil {
+		transport = newDownloadTransport(tlsConfig)
+		t.transports[tlsConfig] = transport
+	}
+	t.mutex.Unlock()
+
+	return transport.RoundTrip(req)
+}
+
+// newDownloadRequest builds a request for url, and the client to send it with,
+// using the CAs, client certificate and credentials of the repository holding url.
+func (d *Downloader) newDownloadRequest(ctx context.Context, method string, url string) (*http.Client, *http.Request, error) {
+	httpClient := &http.Client{
+		Transport: &downloadTransport{
+			downloader: d,
+			transports: make(map[*tls.Config]*http.Transport),
+		},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Cannot create request: %v", err)
+	}
+
+	// The client doesn't forward these headers on redirects to other hosts, such as presigned bucket URLs
+	if auth := d.findDownloadAuth(req.URL); auth != nil {
+		if auth.Username != "" {
+			req.SetBasicAuth(auth.Username, auth.Password)
+		} else if auth.Token != "" {
+			req.Header.Set("Authorization", "Bearer "+auth.Token)
+		}
+	}
+
+	return httpClient, req, nil
+}
+
+// httpStatusError is returned when a download fails with an HTTP error status.
+type httpStatusError struct {
+	url        string
+	statusCode int
+}
+
+func (e *httpStatusError) Error() string {
+	return fmt.Sprintf("error response from %q: HTTP %v", e.url, e.statusCode)
+}
+
+// isRetryableDownloadError returns false for the errors that won't go away by retrying, such as HTTP 404.
+func isRetryableDownloadError(err error) bool {
+	var statusErr *httpStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.statusCode >= 500 || statusErr.statusCode == http.StatusTooManyRequests
+	}
+	return true
+}
+
+// DownloadURL will download the file at the given url and store it as dest, without credentials.
 // If hash is non-nil, it will also verify that it matches the hash of the downloaded file.
 func DownloadURL(url string, dest string, hash *hashing.Hash) (*hashing.Hash, error) {
+	var d *Downloader
+	return d.DownloadURL(url, dest, hash)
+}
+
+// DownloadURL will download the file at the given url and store it as dest.
+// If hash is non-nil, it will also verify that it matches the hash of the downloaded file.
+// Transient failures, such as network errors or HTTP 5xx responses, are retried.
+func (d *Downloader) DownloadURL(url string, dest string, hash *hashing.Hash) (*hashing.Hash, error) {
 	if hash != nil {
 		match, err := fileHasHash(dest, hash)
 		if err != nil {
@@ -44,9 +287,18 @@ func DownloadURL(url string, dest string, hash *hashing.Hash) (*hashing.Hash, er
 	}
 
 	dirMode := os.FileMode(0o755)
-	err := downloadURLAlways(url, dest, dirMode)
-	if err != nil {
-		return nil, err
+	backoff := downloadBackoff
+	for {
+		err := d.downloadURLAlways(url, dest, dirMode)
+		if err == nil {
+			break
+		}
+		if !isRetryableDownloadError(err) || backoff.Steps <= 1 {
+			return nil, err
+		}
+		delay := backoff.Step()
+		klog.Warningf("error downloading %q, retrying in %v: %v", url, delay, err)
+		time.Sleep(delay)
 	}
 
 	if hash != nil {
@@ -58,6 +310,7 @@ func DownloadURL(url string, dest string, hash *hashing.Hash) (*hashing.Hash, er
 			return nil, fmt.Errorf("downloaded from %q but hash did not match expected %q", url, hash)
 		}
 	} else {
+		var err error
 		hash, err = hashing.HashAlgorithmSHA256.HashFile(dest)
 		if err != nil {
 			return nil, err
@@ -67,7 +320,7 @@ func DownloadURL(url string, dest string, hash *hashing.Hash) (*hashing.Hash, er
 	return hash, nil
 }
 
-func downloadURLAlways(url string, destPath string, dirMode os.FileMode) error {
+func (d *Downloader) downloadURLAlways(url string, destPath string, dirMode os.FileMode) error {
 	err := os.MkdirAll(path.Dir(destPath), dirMode)
 	if err != nil {
 		return fmt.Errorf("error creating directories for destination file %q: %v", destPath, err)
@@ -81,29 +334,14 @@ func downloadURLAlways(url string, destPath string, dirMode os.FileMode) error {
 
 	klog.V(2).Infof("Downloading %q", url)
 
-	// Create a client with custom timeouts
-	// to avoid idle downloads to hang the program
-	httpClient := &http.Client{
-		Transport: &http.Transport{
-			Proxy: http.ProxyFromEnvironment,
-			DialContext: (&net.Dialer{
-				Timeout:   30 * time.Second,
-				KeepAlive: 30 * time.Second,
-			}).DialContext,
-			TLSHandshakeTimeout:   10 * time.Second,
-			ResponseHeaderTimeout: 30 * time.Second,
-			IdleConnTimeout:       30 * time.Second,
-		},
-	}
-
 	// this will stop slow downloads after 3 minutes
 	// and interrupt reading of the Response.Body
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
 	defer cancel()
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	httpClient, req, err := d.newDownloadRequest(ctx, http.MethodGet, url)
 	if err != nil {
-		return fmt.Errorf("Cannot create request: %v", err)
+		return err
 	}
 
 	response, err := httpClient.Do(req)
@@ -113,7 +351,7 @@ func downloadURLAlways(url string, destPath string, dirMode os.FileMode) error {
 	defer response.Body.Close()
 
 	if response.StatusCode >= 400 {
-		return fmt.Errorf("error response from %q: HTTP %v", url, response.StatusCode)
+		return &httpStatusError{url: url, statusCode: response.StatusCode}
 	}
 
 	start := time.Now()
@@ -0,0 +1,288 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fi
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/kops/util/pkg/hashing"
+)
+
+// fastDownloadRetries makes retries fast, for the duration of the test.
+func fastDownloadRetries(t *testing.T) {
+	oldBackoff := downloadBackoff
+	downloadBackoff = wait.Backoff{Duration: time.Millisecond, Factor: 1, Steps: 3}
+	t.Cleanup(func() {
+		downloadBackoff = oldBackoff
+	})
+}
+
+// buildClientCertificate returns a CA and a client certificate signed by it, PEM encoded.
+func buildClientCertificate(t *testing.T) (caCertificate *x509.Certificate, certificatePEM []byte, keyPEM []byte) {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caCertificate, err = x509.ParseCertificate(caDER)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "nodeup"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, caCertificate, &key.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return caCertificate, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func TestDownloadURLWithAuth(t *testing.T) {
+	fastDownloadRetries(t)
+	downloader := NewDownloader()
+
+	clientCA, certificatePEM, keyPEM := buildClientCertificate(t)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(clientCA)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, ok := r.BasicAuth()
+		switch {
+		case r.URL.Path == "/basic/file" && ok && username == "puller" && password == "secret":
+		case r.URL.Path == "/token/file" && r.Header.Get("Authorization") == "Bearer token":
+		default:
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, "contents")
+	}))
+	server.TLS = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  clientCAs,
+	}
+	server.StartTLS()
+	defer server.Close()
+
+	serverCA := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := downloader.AddAuth(&DownloadAuth{Prefix: server.URL + "/basic/", CA: serverCA, Certificate: certificatePEM, Key: keyPEM, Username: "puller", Password: "secret"}); err != nil {
+		t.Fatalf("error adding download auth: %v", err)
+	}
+	if err := downloader.AddAuth(&DownloadAuth{Prefix: server.URL + "/token", CA: serverCA, Certificate: certificatePEM, Key: keyPEM, Token: "token"}); err != nil {
+		t.Fatalf("error adding download auth: %v", err)
+	}
+
+	expectedHash, err := hashing.HashAlgorithmSHA256.Hash(strings.NewReader("contents"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, p := range []string{"/basic/file", "/token/file"} {
+		t.Run(p, func(t *testing.T) {
+			dest := filepath.Join(t.TempDir(), "file")
+			hash, err := downloader.DownloadURL(server.URL+p, dest, expectedHash)
+			if err != nil {
+				t.Fatalf("error downloading: %v", err)
+			}
+			if hash.String() != expectedHash.String() {
+				t.Errorf("unexpected hash %v", hash)
+			}
+			b, err := os.ReadFile(dest)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(b) != "contents" {
+				t.Errorf("unexpected contents %q", string(b))
+			}
+		})
+	}
+
+	// Without a matching DownloadAuth, no credentials are sent
+	if _, err := downloader.DownloadURL(server.URL+"/other/file", filepath.Join(t.TempDir(), "file"), nil); err == nil {
+		t.Errorf("expected error downloading without auth")
+	}
+
+	// Without the Downloader, the server certificate isn't trusted
+	if _, err := DownloadURL(server.URL+"/basic/file", filepath.Join(t.TempDir(), "file"), nil); err == nil {
+		t.Errorf("expected error downloading without the downloader")
+	}
+
+	// The CA of the repository is trusted in addition to the system CAs
+	expectedCAs, err := x509.SystemCertPool()
+	if err != nil {
+		expectedCAs = x509.NewCertPool()
+	}
+	expectedCAs.AppendCertsFromPEM(serverCA)
+	serverURL, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tlsConfig := downloader.findTLSConfig(serverURL); tlsConfig == nil || !tlsConfig.RootCAs.Equal(expectedCAs) {
+		t.Errorf("expected the system CAs and the CA of the repository to be trusted")
+	}
+}
+
+func TestDownloadURLRetries(t *testing.T) {
+	fastDownloadRetries(t)
+
+	requests := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests[r.URL.Path]++
+		switch r.URL.Path {
+		case "/flaky":
+			if requests[r.URL.Path] < 3 {
+				http.Error(w, "unavailable", http.StatusServiceUnavailable)
+				return
+			}
+			fmt.Fprint(w, "contents")
+		case "/unavailable":
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	grid := []struct {
+		Path             string
+		ExpectError      bool
+		ExpectedRequests int
+	}{
+		{Path: "/flaky", ExpectedRequests: 3},
+		{Path: "/unavailable", ExpectError: true, ExpectedRequests: 3},
+		{Path: "/missing", ExpectError: true, ExpectedRequests: 1},
+	}
+	for _, g := range grid {
+		t.Run(g.Path, func(t *testing.T) {
+			_, err := DownloadURL(server.URL+g.Path, filepath.Join(t.TempDir(), "file"), nil)
+			if g.ExpectError && err == nil {
+				t.Errorf("expected error")
+			}
+			if !g.ExpectError && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if requests[g.Path] != g.ExpectedRequests {
+				t.Errorf("expected %d requests, got %d", g.ExpectedRequests, requests[g.Path])
+			}
+		})
+	}
+}
+
+func TestFindDownloadAuth(t *testing.T) {
+	downloader := NewDownloader()
+
+	for _, prefix := range []string{"https://example.com/files", "https://example.com/files/kops/"} {
+		if err := downloader.AddAuth(&DownloadAuth{Prefix: prefix, Token: prefix}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	grid := map[string]string{
+		"https://example.com/files":                      "https://example.com/files",
+		"https://example.com/files/kubelet":              "https://example.com/files",
+		"https://example.com/files/kops/1.35.0/nodeup":   "https://example.com/files/kops/",
+		"https://example.com/files-other/kubelet":        "",
+		"https://other.example.com/files/kops/1.35.0/xx": "",
+	}
+	for location, expected := range grid {
+		u, err := url.Parse(location)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var actual string
+		if auth := downloader.findDownloadAuth(u); auth != nil {
+			actual = auth.Prefix
+		}
+		if actual != expected {
+			t.Errorf("expected %q to use %q, got %q", location, expected, actual)
+		}
+	}
+}
+
+func TestFindTLSConfig(t *testing.T) {
+	downloader := NewDownloader()
+
+	_, certificatePEM, keyPEM := buildClientCertificate(t)
+	files := &DownloadAuth{Prefix: "https://example.com/files", Certificate: certificatePEM, Key: keyPEM}
+	kops := &DownloadAuth{Prefix: "https://example.com/files/kops", Certificate: certificatePEM, Key: keyPEM}
+	for _, auth := range []*DownloadAuth{files, kops, {Prefix: "https://example.com/files/token", Token: "token"}} {
+		if err := downloader.AddAuth(auth); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	grid := map[string]*tls.Config{
+		"https://example.com/files/kubelet":                      files.tlsConfig,
+		"https://example.com/files/kops/1.35.0/nodeup":           kops.tlsConfig,
+		"https://example.com/files/token/kubelet":                files.tlsConfig,
+		"https://example.com/redirected/kubelet":                 files.tlsConfig,
+		"https://bucket.s3.amazonaws.com/files/kubelet?X-Amz-=1": nil,
+	}
+	for location, expected := range grid {
+		u, err := url.Parse(location)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if actual := downloader.findTLSConfig(u); actual != expected {
+			t.Errorf("unexpected TLS configuration for %q", location)
+		}
+	}
+}
@@ -180,15 +180,6 @@ func (c *NodeUpCommand) Run(out io.Writer) error {
 		return fmt.Errorf("error determining OS distribution: %v", err)
 	}
 
-	configAssets := nodeupConfig.Assets[architecture]
-	assetStore := fi.NewAssetStore(c.CacheDir)
-	for _, asset := range configAssets {
-		err := assetStore.Add(asset)
-		if err != nil {
-			return fmt.Errorf("error adding asset %q: %v", asset, err)
-		}
-	}
-
 	var cloud fi.Cloud
 
 	if bootConfig.CloudProvider == api.CloudProviderAWS {
@@ -202,7 +193,6 @@ func (c *NodeUpCommand) Run(out io.Writer) error {
 	modelContext := &model.NodeupModelContext{
 		Cloud:        cloud,
 		Architecture: architecture,
-		Assets:       assetStore,
 		ConfigBase:   configBase,
 		Distribution: distribution,
 		BootConfig:   &bootConfig,
@@ -242,6 +232,22 @@ func (c *NodeUpCommand) Run(out io.Writer) error {
 		return fmt.Errorf("KeyStore not set")
 	}
 
+	// The secret store is needed to download the assets from a file repository that requires authentication
+	downloader := fi.NewDownloader()
+	if err := addFileRepositoryAuth(downloader, &nodeupConfig, modelContext.SecretStore); err != nil {
+		return err
+	}
+
+	configAssets := nodeupConfig.Assets[architecture]
+	assetStore := fi.NewAssetStore(c.CacheDir, downloader)
+	for _, asset := range configAssets {
+		err := assetStore.Add(asset)
+		if err != nil {
+			return fmt.Errorf("error adding asset %q: %v", asset, err)
+		}
+	}
+	modelContext.Assets = assetStore
+
 	if err := modelContext.Init(); err != nil {
 		return err
 	}
@@ -336,6 +342,7 @@ func (c *NodeUpCommand) Run(out io.Writer) error {
 	loader.Builders = append(loader.Builders, &networking.KuberouterBuilder{NodeupModelContext: modelContext})
 
 	loader.Builders = append(loader.Builders, &model.BootstrapClientBuilder{NodeupModelContext: modelContext})
+	loader.Builders = append(loader.Builders, &model.ImageVerificationBuilder{NodeupModelContext: modelContext})
 	taskMap, err := loader.Build()
 	if err != nil {
 		return fmt.Errorf("error building loader: %v", err)
@@ -354,8 +361,9 @@ func (c *NodeUpCommand) Run(out io.Writer) error {
 	switch c.Target {
 	case "direct":
 		target = &local.LocalTarget{
-			CacheDir: c.CacheDir,
-			Cloud:    cloud,
+			CacheDir:   c.CacheDir,
+			Cloud:      cloud,
+			Downloader: downloader,
 		}
 	case "dryrun":
 		assetBuilder := assets.NewAssetBuilder(vfs.Context, nil, false)
@@ -446,6 +454,33 @@ func completeWarmingLifecycleAction(ctx context.Context, cloud awsup.AWSCloud, m
 	return nil
 }
 
+// addFileRepositoryAuth registers the CA, client certificate and credentials of the file repository with the downloader,
+// so that files are downloaded from it with them.
+func addFileRepositoryAuth(downloader *fi.Downloader, nodeupConfig *nodeup.Config, secretStore fi.SecretStoreReader) error {
+	if nodeupConfig.FileRepositorySecret == "" {
+		return nil
+	}
+
+	kopsSecret, err := secretStore.Secret(nodeupConfig.FileRepositorySecret)
+	if err != nil {
+		return fmt.Errorf("loading file repository secret %q: %w", nodeupConfig.FileRepositorySecret, err)
+	}
+	secret, err := nodeup.ParseFileRepositorySecret(kopsSecret.Data)
+	if err != nil {
+		return fmt.Errorf("secret %q: %w", nodeupConfig.FileRepositorySecret, err)
+	}
+
+	return downloader.AddAuth(&fi.DownloadAuth{
+		Prefix:      nodeupConfig.FileRepository,
+		CA:          []byte(secret.CA),
+		Certificate: []byte(secret.Certificate),
+		Key:         []byte(secret.Key),
+		Username:    secret.Username,
+		Password:    secret.Password,
+		Token:       secret.Token,
+	})
+}
+
 func evaluateSpec(nodeupConfig *nodeup.Config, cloudProvider api.CloudProviderID) error {
 	hostnameOverride, err := evaluateHostnameOverride(cloudProvider)
 	if err != nil {
@@ -25,6 +25,8 @@ import (
 type LocalTarget struct {
 	CacheDir string
 	Cloud    fi.Cloud
+	// Downloader downloads the files with the credentials of the file repository.
+	Downloader *fi.Downloader
 }
 
 var _ fi.NodeupTarget = &LocalTarget{}
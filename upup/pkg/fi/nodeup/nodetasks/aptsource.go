@@ -67,7 +67,7 @@ func (f *AptSource) RenderLocal(t *local.LocalTarget, a, e, changes *AptSource)
 	}()
 	filename := path.Join(tmpDir, f.Name+".gpg")
 
-	if _, err := fi.DownloadURL(f.Keyring, filename, nil); err != nil {
+	if _, err := t.Downloader.DownloadURL(f.Keyring, filename, nil); err != nil {
 		return err
 	}
 
@@ -150,7 +150,7 @@ func (_ *Archive) RenderLocal(t *local.LocalTarget, a, e, changes *Archive) erro
 			}
 			hash = parsed
 		}
-		if _, err := fi.DownloadURL(e.Source, localFile, hash); err != nil {
+		if _, err := t.Downloader.DownloadURL(e.Source, localFile, hash); err != nil {
 			return err
 		}
 
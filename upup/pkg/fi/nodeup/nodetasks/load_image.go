@@ -104,7 +104,7 @@ func (_ *LoadImageTask) RenderLocal(t *local.LocalTarget, a, e, changes *LoadIma
 	localFile := filepath.Join(t.CacheDir, hash.String()+"_"+utils.SanitizeString(key))
 
 	for _, url := range urls {
-		_, err = fi.DownloadURL(url, localFile, hash)
+		_, err = t.Downloader.DownloadURL(url, localFile, hash)
 		if err != nil {
 			klog.Warningf("error downloading url %q: %v", url, err)
 			continue
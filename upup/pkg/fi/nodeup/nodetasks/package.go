@@ -314,7 +314,7 @@ func (_ *Package) RenderLocal(t *local.LocalTarget, a, e, changes *Package) erro
 					}
 					hash = parsed
 				}
-				_, err = fi.DownloadURL(fi.ValueOf(pkg.Source), local, hash)
+				_, err = t.Downloader.DownloadURL(fi.ValueOf(pkg.Source), local, hash)
 				if err != nil {
 					return err
 				}
@@ -0,0 +1,124 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodetasks
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/imageverification"
+	"k8s.io/kops/pkg/kubemanifest"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+// StaticPodManifestDir is the directory holding the manifests of the static pods.
+const StaticPodManifestDir = "/etc/kubernetes/manifests/"
+
+// VerifyImage verifies the signatures of a container image that is run by a static pod.
+// The static pod manifests are written after the images are verified, referring to the verified digests.
+type VerifyImage struct {
+	Name     string
+	Verifier *imageverification.Verifier `json:"-"`
+
+	// verified is the reference to the verified digest of the image, set once the image is verified.
+	verified string
+}
+
+var _ fi.NodeupTask = &VerifyImage{}
+
+func (t *VerifyImage) GetName() *string {
+	return &t.Name
+}
+
+func (t *VerifyImage) String() string {
+	return fmt.Sprintf("VerifyImage: %s", t.Name)
+}
+
+// Verified returns the reference to the verified digest of the image, or the name of the image if it isn't verified.
+func (t *VerifyImage) Verified() string {
+	if t.verified == "" {
+		return t.Name
+	}
+	return t.verified
+}
+
+func (t *VerifyImage) Run(c *fi.NodeupContext) error {
+	digest, err := t.Verifier.Verify(c.Context(), t.Name, remote.WithAuthFromKeychain(authn.DefaultKeychain))
+	if err == nil {
+		t.verified = imageverification.PinDigest(t.Name, digest)
+		klog.Infof("verified signatures of image %q", t.verified)
+		return nil
+	}
+	if t.Verifier.Enforced() {
+		return fmt.Errorf("refusing image %q: %w", t.Name, err)
+	}
+	klog.Warningf("image %q failed verification: %v", t.Name, err)
+	return nil
+}
+
+// VerifiedManifest is a static pod manifest whose images are replaced by the verified digests,
+// so that the kubelet can't pull another image if a tag is moved after verification.
+type VerifiedManifest struct {
+	Manifest []byte
+	Images   map[string]*VerifyImage `json:"-"`
+}
+
+var (
+	_ fi.Resource              = &VerifiedManifest{}
+	_ fi.NodeupHasDependencies = &VerifiedManifest{}
+)
+
+func (r *VerifiedManifest) GetDependencies(tasks map[string]fi.NodeupTask) []fi.NodeupTask {
+	var deps []fi.NodeupTask
+	for _, task := range r.Images {
+		deps = append(deps, task)
+	}
+	return deps
+}
+
+// Open returns the manifest with the verified images. It must only be called once the images are verified.
+func (r *VerifiedManifest) Open() (io.Reader, error) {
+	objects, err := kubemanifest.LoadObjectsFrom(r.Manifest)
+	if err != nil {
+		return nil, fmt.Errorf("parsing static pod manifest: %w", err)
+	}
+	pinned := false
+	for _, obj := range objects {
+		err := obj.RemapImages(func(image string) string {
+			if task, ok := r.Images[image]; ok && task.Verified() != image {
+				pinned = true
+				return task.Verified()
+			}
+			return image
+		})
+		if err != nil {
+			return nil, fmt.Errorf("remapping images of static pod manifest: %w", err)
+		}
+	}
+	if !pinned {
+		return bytes.NewReader(r.Manifest), nil
+	}
+	data, err := objects.ToYAML()
+	if err != nil {
+		return nil, fmt.Errorf("encoding static pod manifest: %w", err)
+	}
+	return bytes.NewReader(data), nil
+}
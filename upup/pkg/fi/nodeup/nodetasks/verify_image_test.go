@@ -0,0 +1,73 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodetasks
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+func TestVerifiedManifest(t *testing.T) {
+	manifest := `apiVersion: v1
+kind: Pod
+metadata:
+  name: kube-apiserver
+spec:
+  containers:
+  - name: kube-apiserver
+    image: registry.k8s.io/kube-apiserver:v1.34.0
+  - name: healthcheck
+    image: registry.k8s.io/kops/kube-apiserver-healthcheck:1.35.0
+`
+	digest := "sha256:" + strings.Repeat("1", 64)
+	verified := &VerifyImage{Name: "registry.k8s.io/kube-apiserver:v1.34.0"}
+	unverified := &VerifyImage{Name: "registry.k8s.io/kops/kube-apiserver-healthcheck:1.35.0"}
+	r := &VerifiedManifest{
+		Manifest: []byte(manifest),
+		Images: map[string]*VerifyImage{
+			verified.Name:   verified,
+			unverified.Name: unverified,
+		},
+	}
+
+	// Before any image is verified, the manifest is unchanged
+	actual, err := fi.ResourceAsString(r)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if actual != manifest {
+		t.Errorf("expected unchanged manifest, got %q", actual)
+	}
+
+	verified.verified = verified.Name + "@" + digest
+	actual, err = fi.ResourceAsString(r)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(actual, "image: registry.k8s.io/kube-apiserver:v1.34.0@"+digest) {
+		t.Errorf("expected verified image to be pinned, got %q", actual)
+	}
+	if !strings.Contains(actual, "image: registry.k8s.io/kops/kube-apiserver-healthcheck:1.35.0\n") {
+		t.Errorf("expected unverified image to be unchanged, got %q", actual)
+	}
+
+	if deps := r.GetDependencies(nil); len(deps) != 2 {
+		t.Errorf("expected the manifest to depend on the verification of both images, got %v", deps)
+	}
+}
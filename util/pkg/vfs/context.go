@@ -59,6 +59,9 @@ type vfsContextState struct {
 	swiftClient *gophercloud.ServiceClient
 
 	azureClient *azblob.Client
+
+	// webdavClient is the HTTP client for WebDAV requests, if overridden
+	webdavClient *http.Client
 }
 
 // Context holds the global VFS state.
@@ -90,6 +93,54 @@ func (v *VFSContext) WithGCSClient(gcsClient *storage.Service) *VFSContext {
 	return v2
 }
 
+// WithSwiftClient returns a copy of the VFSContext that uses the given client for Swift requests.
+func (v *VFSContext) WithSwiftClient(swiftClient *gophercloud.ServiceClient) *VFSContext {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	v2 := &VFSContext{
+		vfsContextState: v.vfsContextState,
+	}
+	v2.swiftClient = swiftClient
+	return v2
+}
+
+// WithAzureBlobClient returns a copy of the VFSContext that uses the given client for Azure Blob requests.
+func (v *VFSContext) WithAzureBlobClient(azureClient *azblob.Client) *VFSContext {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	v2 := &VFSContext{
+		vfsContextState: v.vfsContextState,
+	}
+	v2.azureClient = azureClient
+	return v2
+}
+
+// WithS3Config returns a copy of the VFSContext that reaches S3 as configured by s3Config, instead of the S3_* environment variables.
+func (v *VFSContext) WithS3Config(s3Config *S3Config) *VFSContext {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	v2 := &VFSContext{
+		vfsContextState: v.vfsContextState,
+	}
+	v2.s3Context = NewS3ContextWithConfig(s3Config)
+	return v2
+}
+
+// WithWebDAVClient returns a copy of the VFSContext that uses the given HTTP client for WebDAV requests.
+func (v *VFSContext) WithWebDAVClient(webdavClient *http.Client) *VFSContext {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	v2 := &VFSContext{
+		vfsContextState: v.vfsContextState,
+	}
+	v2.webdavClient = webdavClient
+	return v2
+}
+
 type vfsOptions struct {
 	backoff wait.Backoff
 }
@@ -213,6 +264,10 @@ func (c *VFSContext) BuildVfsPath(p string) (Path, error) {
 		return c.buildSCWPath(p)
 	}
 
+	if strings.HasPrefix(p, "webdav://") || strings.HasPrefix(p, "webdavs://") {
+		return c.buildWebDAVPath(p)
+	}
+
 	return nil, fmt.Errorf("unknown / unhandled path type: %q", p)
 }
 
@@ -540,3 +595,29 @@ func (c *VFSContext) buildSCWPath(p string) (*S3Path, error) {
 	s3path := newS3Path(c.s3Context, u.Scheme, bucket, u.Path, false)
 	return s3path, nil
 }
+
+func (c *VFSContext) buildWebDAVPath(p string) (*WebDAVPath, error) {
+	u, err := url.Parse(p)
+	if err != nil {
+		return nil, fmt.Errorf("invalid WebDAV path: %q", p)
+	}
+	if u.Scheme != "webdav" && u.Scheme != "webdavs" {
+		return nil, fmt.Errorf("invalid WebDAV path: %q", p)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("invalid WebDAV path: %q", p)
+	}
+
+	return NewWebDAVPath(c, u.Scheme, u.Host, u.Path), nil
+}
+
+// getWebDAVClient returns the HTTP client for WebDAV requests.
+func (c *VFSContext) getWebDAVClient() *http.Client {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.webdavClient != nil {
+		return c.webdavClient
+	}
+	return http.DefaultClient
+}
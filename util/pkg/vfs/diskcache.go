@@ -0,0 +1,424 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vfs
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"k8s.io/klog/v2"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
+	"k8s.io/kops/util/pkg/hashing"
+)
+
+// DiskCache is a read-through cache of VFS files on the local disk, shared between invocations.
+//
+// File contents are used without contacting the backing store for ttl after they were fetched;
+// after that they are revalidated, using a conditional read where the backend supports one
+// (ETag or generation), so unchanged files are not downloaded again.
+// Directory listings (ReadTree) are cached for treeTTL.
+// Writes and removals through a cached path invalidate the affected entries.
+//
+// Files under a pki or secrets directory hold private keys and secrets, and are not cached unless cacheSecrets is set.
+// Entries are only readable by the current user.
+type DiskCache struct {
+	dir          string
+	ttl          time.Duration
+	treeTTL      time.Duration
+	cacheSecrets bool
+}
+
+// NewDiskCache builds a DiskCache that stores its entries under dir.
+// Private keys and secrets are only stored on disk if cacheSecrets is true.
+func NewDiskCache(dir string, ttl time.Duration, treeTTL time.Duration, cacheSecrets bool) *DiskCache {
+	return &DiskCache{
+		dir:          dir,
+		ttl:          ttl,
+		treeTTL:      treeTTL,
+		cacheSecrets: cacheSecrets,
+	}
+}
+
+// CachesSecrets returns true if the cache may hold private keys and secrets.
+func (c *DiskCache) CachesSecrets() bool {
+	return c.cacheSecrets
+}
+
+// Wrap returns a Path that reads through the cache.
+// Local filesystem paths are not cached, and neither are private keys and secrets unless the cache was built to hold them.
+func (c *DiskCache) Wrap(p Path) Path {
+	switch p := p.(type) {
+	case *CachedPath:
+		return p
+	case *FSPath:
+		return p
+	}
+	if !c.cacheSecrets && isSecretPath(p) {
+		return p
+	}
+	return &CachedPath{cache: c, inner: p}
+}
+
+// isSecretPath returns true if p is in a pki or secrets directory of the state store.
+func isSecretPath(p Path) bool {
+	s := p.Path()
+	if i := strings.Index(s, "://"); i != -1 {
+		s = s[i+len("://"):]
+	}
+	tokens := strings.Split(s, "/")
+	// The first token is the bucket (or equivalent), which can be named anything
+	for _, token := range tokens[1:] {
+		if token == "pki" || token == "secrets" {
+			return true
+		}
+	}
+	return false
+}
+
+// diskCacheFile is the on-disk form of a cached file.
+type diskCacheFile struct {
+	Path     string    `json:"path"`
+	Version  string    `json:"version,omitempty"`
+	Fetched  time.Time `json:"fetched"`
+	Contents []byte    `json:"contents"`
+}
+
+// diskCacheTree is the on-disk form of a cached ReadTree listing.
+type diskCacheTree struct {
+	Path    string    `json:"path"`
+	Fetched time.Time `json:"fetched"`
+	// Files holds the paths of the files, relative to Path
+	Files []string `json:"files"`
+}
+
+// entryPath returns the file holding the cache entry for the path with the given key.
+func (c *DiskCache) entryPath(kind string, key string) string {
+	h := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, kind, hex.EncodeToString(h[:])+".json")
+}
+
+// readEntry reads a cache entry, returning false if there is no usable entry for p.
+func (c *DiskCache) readEntry(kind string, p Path, into any) bool {
+	data, err := os.ReadFile(c.entryPath(kind, p.Path()))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			klog.V(2).Infof("ignoring unreadable cache entry for %s: %v", p, err)
+		}
+		return false
+	}
+	if err := json.Unmarshal(data, into); err != nil {
+		klog.V(2).Infof("ignoring invalid cache entry for %s: %v", p, err)
+		return false
+	}
+	return true
+}
+
+// writeEntry atomically replaces a cache entry.  Failures are logged, because the cache is only an optimization.
+func (c *DiskCache) writeEntry(kind string, p Path, entry any) {
+	if err := c.writeEntryFile(c.entryPath(kind, p.Path()), entry); err != nil {
+		klog.V(2).Infof("unable to write cache entry for %s: %v", p, err)
+	}
+}
+
+func (c *DiskCache) writeEntryFile(name string, entry any) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(name)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(dir, "tmp")
+	if err != nil {
+		return err
+	}
+	tempfile := f.Name()
+	_, err = f.Write(data)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tempfile, name)
+	}
+	if err != nil {
+		os.Remove(tempfile)
+		return err
+	}
+	return nil
+}
+
+func (c *DiskCache) removeEntry(kind string, key string) {
+	if err := os.Remove(c.entryPath(kind, key)); err != nil && !os.IsNotExist(err) {
+		klog.Warningf("unable to invalidate cache entry for %s: %v", key, err)
+	}
+}
+
+// invalidate removes the cached contents of p, and the cached listings of all its parents.
+func (c *DiskCache) invalidate(p Path) {
+	s := p.Path()
+	c.removeEntry("files", s)
+
+	rootEnd := strings.Index(s, "://") + len("://")
+	for {
+		i := strings.LastIndex(s, "/")
+		if i < rootEnd {
+			break
+		}
+		s = s[:i]
+		c.removeEntry("trees", s)
+	}
+}
+
+// CachedPath is a Path that reads through a DiskCache.
+type CachedPath struct {
+	cache *DiskCache
+	inner Path
+}
+
+var (
+	_ Path                = &CachedPath{}
+	_ HasClusterReadable  = &CachedPath{}
+	_ HasConditionalWrite = &CachedPath{}
+	_ TerraformPath       = &CachedPath{}
+	_ HasHash             = &CachedPath{}
+)
+
+// Unwrap returns the underlying path if p is a cached path, otherwise p itself.
+// Code that needs to know the backend of a path should unwrap it first.
+func Unwrap(p Path) Path {
+	if cached, ok := p.(*CachedPath); ok {
+		return cached.inner
+	}
+	return p
+}
+
+func (p *CachedPath) String() string {
+	return p.inner.Path()
+}
+
+// Path returns a string representing the full path.
+func (p *CachedPath) Path() string {
+	return p.inner.Path()
+}
+
+// Base returns the base name (last element).
+func (p *CachedPath) Base() string {
+	return p.inner.Base()
+}
+
+// Join returns a cached path that joins the current path and given relative paths.
+func (p *CachedPath) Join(relativePath ...string) Path {
+	return p.cache.Wrap(p.inner.Join(relativePath...))
+}
+
+// IsClusterReadable implements HasClusterReadable::IsClusterReadable
+func (p *CachedPath) IsClusterReadable() bool {
+	return IsClusterReadable(p.inner)
+}
+
+// RenderTerraform implements TerraformPath::RenderTerraform, if the underlying path does.
+func (p *CachedPath) RenderTerraform(w *terraformWriter.TerraformWriter, name string, data io.Reader, acl ACL) error {
+	terraformPath, ok := p.inner.(TerraformPath)
+	if !ok {
+		return fmt.Errorf("path %q must be of a type that can render in Terraform", p)
+	}
+	return terraformPath.RenderTerraform(w, name, data, acl)
+}
+
+// PreferredHash implements HasHash::PreferredHash, returning nil if the underlying path cannot compute a hash.
+func (p *CachedPath) PreferredHash() (*hashing.Hash, error) {
+	hasHash, ok := p.inner.(HasHash)
+	if !ok {
+		return nil, nil
+	}
+	return hasHash.PreferredHash()
+}
+
+// Hash implements HasHash::Hash, returning nil if the underlying path cannot compute a hash.
+func (p *CachedPath) Hash(algorithm hashing.HashAlgorithm) (*hashing.Hash, error) {
+	hasHash, ok := p.inner.(HasHash)
+	if !ok {
+		return nil, nil
+	}
+	return hasHash.Hash(algorithm)
+}
+
+// ReadFile returns the file contents from the cache if they are fresh or still current, otherwise from the backing store.
+func (p *CachedPath) ReadFile(ctx context.Context) ([]byte, error) {
+	entry := &diskCacheFile{}
+	found := p.cache.readEntry("files", p, entry) && entry.Path == p.Path()
+	if found && time.Since(entry.Fetched) < p.cache.ttl {
+		klog.V(8).Infof("using cached %s", p)
+		return entry.Contents, nil
+	}
+
+	var data []byte
+	var version string
+	var err error
+	if conditionalRead, ok := p.inner.(HasConditionalRead); ok {
+		ifVersion := ""
+		if found {
+			ifVersion = entry.Version
+		}
+		data, version, err = conditionalRead.ReadFileIfChanged(ctx, ifVersion)
+		if errors.Is(err, ErrNotModified) {
+			klog.V(8).Infof("cached %s is still current", p)
+			entry.Fetched = time.Now()
+			p.cache.writeEntry("files", p, entry)
+			return entry.Contents, nil
+		}
+	} else {
+		data, err = p.inner.ReadFile(ctx)
+	}
+	if err != nil {
+		if os.IsNotExist(err) && found {
+			p.cache.removeEntry("files", p.Path())
+		}
+		return nil, err
+	}
+
+	p.cache.writeEntry("files", p, &diskCacheFile{
+		Path:     p.Path(),
+		Version:  version,
+		Fetched:  time.Now(),
+		Contents: data,
+	})
+	return data, nil
+}
+
+// WriteTo implements io.WriterTo
+func (p *CachedPath) WriteTo(out io.Writer) (int64, error) {
+	data, err := p.ReadFile(context.TODO())
+	if err != nil {
+		return 0, err
+	}
+	n, err := out.Write(data)
+	return int64(n), err
+}
+
+// WriteFile writes the file to the backing store and invalidates the cache.
+func (p *CachedPath) WriteFile(ctx context.Context, data io.ReadSeeker, acl ACL) error {
+	defer p.cache.invalidate(p)
+	return p.inner.WriteFile(ctx, data, acl)
+}
+
+// CreateFile creates the file in the backing store and invalidates the cache.
+func (p *CachedPath) CreateFile(ctx context.Context, data io.ReadSeeker, acl ACL) error {
+	defer p.cache.invalidate(p)
+	return p.inner.CreateFile(ctx, data, acl)
+}
+
+// ReadFileWithVersion implements HasConditionalWrite::ReadFileWithVersion
+// The file is read from the backing store, as the version must be that of the current contents.
+func (p *CachedPath) ReadFileWithVersion(ctx context.Context) ([]byte, string, error) {
+	return ReadFileWithVersion(ctx, p.inner)
+}
+
+// WriteFileIfMatch implements HasConditionalWrite::WriteFileIfMatch
+// The cache is invalidated even if the precondition fails, so that a retry reads the current contents.
+func (p *CachedPath) WriteFileIfMatch(ctx context.Context, data io.ReadSeeker, acl ACL, version string) (string, error) {
+	defer p.cache.invalidate(p)
+	return WriteFileIfMatch(ctx, p.inner, data, acl, version)
+}
+
+// Remove deletes the file and invalidates the cache.
+func (p *CachedPath) Remove(ctx context.Context) error {
+	defer p.cache.invalidate(p)
+	return p.inner.Remove(ctx)
+}
+
+// RemoveAll deletes all files in the subtree and invalidates the cache.
+func (p *CachedPath) RemoveAll(ctx context.Context) error {
+	tree, err := p.inner.ReadTree(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		for _, f := range tree {
+			p.cache.invalidate(f)
+		}
+		p.cache.removeEntry("trees", p.Path())
+	}()
+	return p.inner.RemoveAll(ctx)
+}
+
+// RemoveAllVersions deletes the file with all its versions, and invalidates the cache.
+func (p *CachedPath) RemoveAllVersions(ctx context.Context) error {
+	defer p.cache.invalidate(p)
+	return p.inner.RemoveAllVersions(ctx)
+}
+
+// ReadDir lists the files in the path; listings by ReadDir are not cached.
+func (p *CachedPath) ReadDir() ([]Path, error) {
+	paths, err := p.inner.ReadDir()
+	if err != nil {
+		return nil, err
+	}
+	for i := range paths {
+		paths[i] = p.cache.Wrap(paths[i])
+	}
+	return paths, nil
+}
+
+// ReadTree lists all files in the subtree, from the cache if the listing is fresh.
+func (p *CachedPath) ReadTree(ctx context.Context) ([]Path, error) {
+	entry := &diskCacheTree{}
+	if p.cache.readEntry("trees", p, entry) && entry.Path == p.Path() && time.Since(entry.Fetched) < p.cache.treeTTL {
+		klog.V(8).Infof("using cached listing of %s", p)
+		paths := make([]Path, 0, len(entry.Files))
+		for _, f := range entry.Files {
+			paths = append(paths, p.Join(f))
+		}
+		return paths, nil
+	}
+
+	tree, err := p.inner.ReadTree(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	entry = &diskCacheTree{
+		Path:    p.Path(),
+		Fetched: time.Now(),
+	}
+	paths := make([]Path, 0, len(tree))
+	cacheable := true
+	for _, f := range tree {
+		relativePath, err := RelativePath(p.inner, f)
+		if err != nil {
+			// We can only cache listings we can rebuild with Join
+			cacheable = false
+		}
+		entry.Files = append(entry.Files, relativePath)
+		paths = append(paths, p.cache.Wrap(f))
+	}
+	if cacheable {
+		p.cache.writeEntry("trees", p, entry)
+	}
+	return paths, nil
+}
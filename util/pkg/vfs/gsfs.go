@@ -25,6 +25,7 @@ import (
 	"net/http"
 	"os"
 	"path"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -49,9 +50,10 @@ type GSPath struct {
 }
 
 var (
-	_ Path          = &GSPath{}
-	_ TerraformPath = &GSPath{}
-	_ HasHash       = &GSPath{}
+	_ Path                = &GSPath{}
+	_ TerraformPath       = &GSPath{}
+	_ HasHash             = &GSPath{}
+	_ HasConditionalWrite = &GSPath{}
 )
 
 // gcsReadBackoff is the backoff strategy for GCS read retries
@@ -173,11 +175,43 @@ func (p *GSPath) Join(relativePath ...string) Path {
 }
 
 func (p *GSPath) WriteFile(ctx context.Context, data io.ReadSeeker, acl ACL) error {
+	_, err := p.writeFile(ctx, data, acl, 0)
+	return err
+}
+
+// ReadFileWithVersion implements HasConditionalWrite::ReadFileWithVersion, using the object generation as the version.
+func (p *GSPath) ReadFileWithVersion(ctx context.Context) ([]byte, string, error) {
+	data, generation, err := p.ReadFileIfChanged(ctx, "")
+	if err != nil {
+		return nil, "", err
+	}
+	if generation == "" {
+		return nil, "", fmt.Errorf("no generation returned when reading %s", p)
+	}
+	return data, generation, nil
+}
+
+// WriteFileIfMatch implements HasConditionalWrite::WriteFileIfMatch
+// We write with an ifGenerationMatch precondition, so a concurrent write since the file was read is detected by GCS.
+func (p *GSPath) WriteFileIfMatch(ctx context.Context, data io.ReadSeeker, acl ACL, version string) (string, error) {
+	generation, err := strconv.ParseInt(version, 10, 64)
+	if err != nil || generation == 0 {
+		return "", fmt.Errorf("invalid generation %q for %s", version, p)
+	}
+
+	return p.writeFile(ctx, data, acl, generation)
+}
+
+// writeFile writes the file, returning the generation of the new contents.
+// If ifGenerationMatch is non-zero the write only succeeds if the object still has that generation.
+func (p *GSPath) writeFile(ctx context.Context, data io.ReadSeeker, acl ACL, ifGenerationMatch int64) (string, error) {
 	md5Hash, err := hashing.HashAlgorithmMD5.Hash(data)
 	if err != nil {
-		return err
+		return "", err
 	}
 
+	var generation int64
+
 	done, err := RetryWithBackoff(gcsWriteBackoff, func() (bool, error) {
 		obj := &storage.Object{
 			Name:    p.key,
@@ -204,20 +238,29 @@ func (p *GSPath) WriteFile(ctx context.Context, data io.ReadSeeker, acl ACL) err
 			return false, err
 		}
 
-		_, err = client.Objects.Insert(p.bucket, obj).Context(ctx).Media(data).Do()
+		call := client.Objects.Insert(p.bucket, obj).Context(ctx).Media(data)
+		if ifGenerationMatch != 0 {
+			call = call.IfGenerationMatch(ifGenerationMatch)
+		}
+		written, err := call.Do()
 		if err != nil {
+			if ae, ok := err.(*googleapi.Error); ok && ae.Code == http.StatusPreconditionFailed {
+				// Not recoverable
+				return true, ErrPreconditionFailed
+			}
 			return false, fmt.Errorf("error writing %s: %v", p, err)
 		}
 
+		generation = written.Generation
 		return true, nil
 	})
 	if err != nil {
-		return err
+		return "", err
 	} else if done {
-		return nil
+		return strconv.FormatInt(generation, 10), nil
 	} else {
 		// Shouldn't happen - we always return a non-nil error with false
-		return wait.ErrWaitTimeout
+		return "", wait.ErrWaitTimeout
 	}
 }
 
@@ -296,6 +339,42 @@ func (p *GSPath) WriteTo(out io.Writer) (int64, error) {
 	return io.Copy(out, response.Body)
 }
 
+// ReadFileIfChanged implements HasConditionalRead::ReadFileIfChanged, using the object generation as the version.
+func (p *GSPath) ReadFileIfChanged(ctx context.Context, version string) ([]byte, string, error) {
+	client, err := p.getStorageClient(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+
+	klog.V(4).Infof("Reading file %q if changed from generation %q", p, version)
+
+	call := client.Objects.Get(p.bucket, p.key).Context(ctx)
+	if version != "" {
+		generation, err := strconv.ParseInt(version, 10, 64)
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid generation %q for %s", version, p)
+		}
+		call = call.IfGenerationNotMatch(generation)
+	}
+	response, err := call.Download()
+	if err != nil {
+		if isGCSNotFound(err) {
+			return nil, "", os.ErrNotExist
+		}
+		if ae, ok := err.(*googleapi.Error); ok && ae.Code == http.StatusNotModified {
+			return nil, "", ErrNotModified
+		}
+		return nil, "", fmt.Errorf("error reading %s: %v", p, err)
+	}
+	defer response.Body.Close()
+
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, "", fmt.Errorf("error reading %s: %v", p, err)
+	}
+	return data, response.Header.Get("X-Goog-Generation"), nil
+}
+
 // ReadDir implements Path::ReadDir
 func (p *GSPath) ReadDir() ([]Path, error) {
 	ctx := context.TODO()
@@ -39,8 +39,9 @@ type MemFSPath struct {
 }
 
 var (
-	_ Path          = &MemFSPath{}
-	_ TerraformPath = &MemFSPath{}
+	_ Path                = &MemFSPath{}
+	_ TerraformPath       = &MemFSPath{}
+	_ HasConditionalWrite = &MemFSPath{}
 )
 
 type MemFSContext struct {
@@ -124,6 +125,35 @@ func (p *MemFSPath) CreateFile(ctx context.Context, data io.ReadSeeker, acl ACL)
 	return p.WriteFile(ctx, data, acl)
 }
 
+// ReadFileWithVersion implements HasConditionalWrite::ReadFileWithVersion, using the ContentVersion as the version.
+func (p *MemFSPath) ReadFileWithVersion(ctx context.Context) ([]byte, string, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.contents == nil {
+		return nil, "", os.ErrNotExist
+	}
+	return p.contents, ContentVersion(p.contents), nil
+}
+
+// WriteFileIfMatch implements HasConditionalWrite::WriteFileIfMatch
+func (p *MemFSPath) WriteFileIfMatch(ctx context.Context, data io.ReadSeeker, acl ACL, version string) (string, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.contents == nil {
+		return "", ErrPreconditionFailed
+	}
+	if err := checkContentVersion(p.contents, version); err != nil {
+		return "", err
+	}
+
+	if err := p.WriteFile(ctx, data, acl); err != nil {
+		return "", err
+	}
+	return ContentVersion(p.contents), nil
+}
+
 // ReadFile implements Path::ReadFile
 func (p *MemFSPath) ReadFile(ctx context.Context) ([]byte, error) {
 	if p.contents == nil {
@@ -65,6 +65,51 @@ func TestMemFsCreateFile(t *testing.T) {
 	}
 }
 
+func TestMemFsWriteFileIfMatch(t *testing.T) {
+	ctx := testcontext.ForTest(t)
+
+	memfspath := NewMemFSPath(NewMemFSContext(), "/root/subdir/test1.data")
+
+	// Conditional write of a file that does not exist should fail
+	_, err := WriteFileIfMatch(ctx, memfspath, bytes.NewReader([]byte("v1")), nil, ContentVersion(nil))
+	if err != ErrPreconditionFailed {
+		t.Errorf("Expected to get ErrPreconditionFailed, got: %v", err)
+	}
+
+	if err := memfspath.WriteFile(ctx, bytes.NewReader([]byte("v1")), nil); err != nil {
+		t.Fatalf("Failed writing path: %v", err)
+	}
+	_, v1, err := ReadFileWithVersion(ctx, memfspath)
+	if err != nil {
+		t.Fatalf("Failed reading path: %v", err)
+	}
+
+	v2, err := WriteFileIfMatch(ctx, memfspath, bytes.NewReader([]byte("v2")), nil, v1)
+	if err != nil {
+		t.Fatalf("Failed conditional write: %v", err)
+	}
+	if v2 == v1 {
+		t.Errorf("Expected the version to change after a write")
+	}
+
+	// The file has changed, so a write based on v1 should fail
+	_, err = WriteFileIfMatch(ctx, memfspath, bytes.NewReader([]byte("v3")), nil, v1)
+	if err != ErrPreconditionFailed {
+		t.Errorf("Expected to get ErrPreconditionFailed, got: %v", err)
+	}
+
+	data, version, err := ReadFileWithVersion(ctx, memfspath)
+	if err != nil {
+		t.Fatalf("Failed reading path: %v", err)
+	}
+	if string(data) != "v2" {
+		t.Errorf("Expected path content %q, got %q", "v2", string(data))
+	}
+	if version != v2 {
+		t.Errorf("Expected version %q returned by the write, got %q", v2, version)
+	}
+}
+
 func TestMemFsReadDir(t *testing.T) {
 	tests := []struct {
 		path     string
@@ -66,6 +66,22 @@ type S3Context struct {
 	mutex         sync.Mutex
 	clients       map[string]*s3.Client
 	bucketDetails map[string]*S3BucketDetails
+
+	// config overrides the S3_* environment variables, if set
+	config *S3Config
+}
+
+// S3Config configures how an S3Context reaches S3, instead of the S3_* environment variables.
+type S3Config struct {
+	// Endpoint is a custom (non-AWS) S3 endpoint; if empty, AWS S3 is used
+	Endpoint string
+	// Region is the region to use with a custom endpoint
+	Region string
+	// AccessKeyID and SecretAccessKey are the credentials for a custom endpoint
+	AccessKeyID     string
+	SecretAccessKey string
+	// Profile is the AWS shared config profile to load credentials from when using AWS S3
+	Profile string
 }
 
 func NewS3Context() *S3Context {
@@ -75,6 +91,26 @@ func NewS3Context() *S3Context {
 	}
 }
 
+// NewS3ContextWithConfig builds an S3Context that is configured by config rather than the environment.
+func NewS3ContextWithConfig(config *S3Config) *S3Context {
+	s := NewS3Context()
+	s.config = config
+	return s
+}
+
+// getConfig returns the S3 configuration, read from the S3_* environment variables unless it was set explicitly.
+func (s *S3Context) getConfig() *S3Config {
+	if s.config != nil {
+		return s.config
+	}
+	return &S3Config{
+		Endpoint:        os.Getenv("S3_ENDPOINT"),
+		Region:          os.Getenv("S3_REGION"),
+		AccessKeyID:     os.Getenv("S3_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("S3_SECRET_ACCESS_KEY"),
+	}
+}
+
 type ResolverV2 struct{}
 
 func (*ResolverV2) ResolveEndpoint(ctx context.Context, params s3.EndpointParameters) (
@@ -95,16 +131,21 @@ func (s *S3Context) getClient(ctx context.Context, region string) (*s3.Client, e
 
 		var config aws.Config
 		var err error
-		endpoint := os.Getenv("S3_ENDPOINT")
+		s3Config := s.getConfig()
+		endpoint := s3Config.Endpoint
 		if endpoint == "" {
-			config, err = awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(region))
+			loadOptions := []func(*awsconfig.LoadOptions) error{awsconfig.WithRegion(region)}
+			if s3Config.Profile != "" {
+				loadOptions = append(loadOptions, awsconfig.WithSharedConfigProfile(s3Config.Profile))
+			}
+			config, err = awsconfig.LoadDefaultConfig(ctx, loadOptions...)
 			if err != nil {
 				return nil, fmt.Errorf("error loading AWS config: %v", err)
 			}
 		} else {
 			// Use customized S3 storage
 			klog.V(2).Infof("Found S3_ENDPOINT=%q, using as non-AWS S3 backend", endpoint)
-			config, err = getCustomS3Config(ctx, region)
+			config, err = getCustomS3Config(ctx, s3Config, region)
 			if err != nil {
 				return nil, err
 			}
@@ -124,12 +165,12 @@ func (s *S3Context) getClient(ctx context.Context, region string) (*s3.Client, e
 	return s3Client, nil
 }
 
-func getCustomS3Config(ctx context.Context, region string) (aws.Config, error) {
-	accessKeyID := os.Getenv("S3_ACCESS_KEY_ID")
+func getCustomS3Config(ctx context.Context, config *S3Config, region string) (aws.Config, error) {
+	accessKeyID := config.AccessKeyID
 	if accessKeyID == "" {
 		return aws.Config{}, fmt.Errorf("S3_ACCESS_KEY_ID cannot be empty when S3_ENDPOINT is not empty")
 	}
-	secretAccessKey := os.Getenv("S3_SECRET_ACCESS_KEY")
+	secretAccessKey := config.SecretAccessKey
 	if secretAccessKey == "" {
 		return aws.Config{}, fmt.Errorf("S3_SECRET_ACCESS_KEY cannot be empty when S3_ENDPOINT is not empty")
 	}
@@ -163,10 +204,10 @@ func (s *S3Context) getDetailsForBucket(ctx context.Context, bucket string) (*S3
 	}
 
 	// Probe to find correct region for bucket
-	endpoint := os.Getenv("S3_ENDPOINT")
-	if endpoint != "" {
+	s3Config := s.getConfig()
+	if s3Config.Endpoint != "" {
 		// If customized S3 storage is set, return user-defined region
-		bucketDetails.region = os.Getenv("S3_REGION")
+		bucketDetails.region = s3Config.Region
 		if bucketDetails.region == "" {
 			bucketDetails.region = "us-east-1"
 		}
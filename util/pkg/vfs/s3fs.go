@@ -54,9 +54,11 @@ type S3Path struct {
 }
 
 var (
-	_ Path          = &S3Path{}
-	_ TerraformPath = &S3Path{}
-	_ HasHash       = &S3Path{}
+	_ Path                = &S3Path{}
+	_ TerraformPath       = &S3Path{}
+	_ HasHash             = &S3Path{}
+	_ HasConditionalWrite = &S3Path{}
+	_ HasConditionalRead  = &S3Path{}
 )
 
 // S3Acl is an ACL implementation for objects on S3
@@ -315,9 +317,46 @@ func (p *S3Path) WriteFile(ctx context.Context, data io.ReadSeeker, aclObj ACL)
 	ctx, span := tracer.Start(ctx, "S3Path::WriteFile", trace.WithAttributes(attribute.String("path", p.String())))
 	defer span.End()
 
+	_, err := p.writeFile(ctx, data, aclObj, nil)
+	return err
+}
+
+// ReadFileWithVersion implements HasConditionalWrite::ReadFileWithVersion, using the ETag as the version.
+func (p *S3Path) ReadFileWithVersion(ctx context.Context) ([]byte, string, error) {
+	data, etag, err := p.ReadFileIfChanged(ctx, "")
+	if err != nil {
+		return nil, "", err
+	}
+	if etag == "" {
+		return nil, "", fmt.Errorf("no ETag returned when reading %s", p)
+	}
+	return data, etag, nil
+}
+
+// warnUnenforcedConditionalWrite warns once that a custom S3 endpoint may not enforce If-Match.
+var warnUnenforcedConditionalWrite sync.Once
+
+// WriteFileIfMatch implements HasConditionalWrite::WriteFileIfMatch
+// We use a conditional PutObject with the ETag, so a concurrent write since the file was read is detected by S3.
+func (p *S3Path) WriteFileIfMatch(ctx context.Context, data io.ReadSeeker, aclObj ACL, version string) (string, error) {
+	ctx, span := tracer.Start(ctx, "S3Path::WriteFileIfMatch", trace.WithAttributes(attribute.String("path", p.String())))
+	defer span.End()
+
+	if endpoint := p.s3Context.getConfig().Endpoint; endpoint != "" {
+		warnUnenforcedConditionalWrite.Do(func() {
+			klog.Warningf("S3_ENDPOINT %q may ignore If-Match on PutObject; if it does, concurrent changes to the state store are overwritten without a conflict", endpoint)
+		})
+	}
+
+	return p.writeFile(ctx, data, aclObj, aws.String(version))
+}
+
+// writeFile writes the file, returning the ETag of the new contents.
+// If ifMatch is non-nil the write only succeeds if the object still has that ETag.
+func (p *S3Path) writeFile(ctx context.Context, data io.ReadSeeker, aclObj ACL, ifMatch *string) (string, error) {
 	client, err := p.client(ctx)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	klog.V(4).Infof("Writing file %q", p)
@@ -326,13 +365,14 @@ func (p *S3Path) WriteFile(ctx context.Context, data io.ReadSeeker, aclObj ACL)
 	request.Body = data
 	request.Bucket = aws.String(p.bucket)
 	request.Key = aws.String(p.key)
+	request.IfMatch = ifMatch
 
 	var sseLog string
 	request.ServerSideEncryption, sseLog, _ = p.getServerSideEncryption(ctx)
 
 	acl, err := p.getRequestACL(aclObj)
 	if err != nil {
-		return err
+		return "", err
 	}
 	if acl != nil {
 		request.ACL = *acl
@@ -342,15 +382,21 @@ func (p *S3Path) WriteFile(ctx context.Context, data io.ReadSeeker, aclObj ACL)
 
 	klog.V(8).Infof("Calling S3 PutObject Bucket=%q Key=%q SSE=%q ACL=%q", p.bucket, p.key, sseLog, request.ACL)
 
-	_, err = client.PutObject(ctx, request)
+	response, err := client.PutObject(ctx, request)
 	if err != nil {
+		if ifMatch != nil {
+			switch AWSErrorCode(err) {
+			case "PreconditionFailed", "ConditionalRequestConflict", "NoSuchKey":
+				return "", ErrPreconditionFailed
+			}
+		}
 		if len(request.ACL) > 0 {
-			return fmt.Errorf("error writing %s (with ACL=%q): %v", p, request.ACL, err)
+			return "", fmt.Errorf("error writing %s (with ACL=%q): %v", p, request.ACL, err)
 		}
-		return fmt.Errorf("error writing %s: %v", p, err)
+		return "", fmt.Errorf("error writing %s: %v", p, err)
 	}
 
-	return nil
+	return aws.ToString(response.ETag), nil
 }
 
 // To prevent concurrent creates on the same file while maintaining atomicity of writes,
@@ -424,6 +470,41 @@ func (p *S3Path) WriteToWithContext(ctx context.Context, out io.Writer) (int64,
 	return n, nil
 }
 
+// ReadFileIfChanged implements HasConditionalRead::ReadFileIfChanged, using the ETag as the version.
+func (p *S3Path) ReadFileIfChanged(ctx context.Context, version string) ([]byte, string, error) {
+	client, err := p.client(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+
+	klog.V(4).Infof("Reading file %q if changed from %q", p, version)
+
+	request := &s3.GetObjectInput{}
+	request.Bucket = aws.String(p.bucket)
+	request.Key = aws.String(p.key)
+	if version != "" {
+		request.IfNoneMatch = aws.String(version)
+	}
+
+	response, err := client.GetObject(ctx, request)
+	if err != nil {
+		switch AWSErrorCode(err) {
+		case "NoSuchKey":
+			return nil, "", os.ErrNotExist
+		case "NotModified":
+			return nil, "", ErrNotModified
+		}
+		return nil, "", fmt.Errorf("error fetching %s: %v", p, err)
+	}
+	defer response.Body.Close()
+
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, "", fmt.Errorf("error reading %s: %v", p, err)
+	}
+	return data, aws.ToString(response.ETag), nil
+}
+
 func (p *S3Path) ReadDir() ([]Path, error) {
 	ctx := context.TODO()
 	client, err := p.client(ctx)
@@ -705,7 +786,7 @@ func (p *S3Path) RenderTerraform(w *terraformWriter.TerraformWriter, name string
 		}
 
 		// retrieve space region from endpoint
-		endpoint := os.Getenv("S3_ENDPOINT")
+		endpoint := p.s3Context.getConfig().Endpoint
 		if endpoint == "" {
 			return errors.New("S3 Endpoint is empty")
 		}
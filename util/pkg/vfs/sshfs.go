@@ -19,6 +19,7 @@ package vfs
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"math/rand"
@@ -310,6 +311,9 @@ func (p *SSHPath) WriteTo(out io.Writer) (int64, error) {
 
 	f, err := sftpClient.Open(p.path)
 	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return 0, os.ErrNotExist
+		}
 		return 0, fmt.Errorf("error opening file %s over sftp: %w", p, err)
 	}
 	defer f.Close()
@@ -362,14 +366,16 @@ func readSFTPTree(sftpClient *sftp.Client, p *SSHPath, dest *[]Path) error {
 	for _, f := range files {
 		child := NewSSHPath(p.client, p.server, path.Join(p.path, f.Name()), p.sudo)
 
-		*dest = append(*dest, child)
-
+		// Like the other backends, the tree holds only files
 		if f.IsDir() {
 			err = readSFTPTree(sftpClient, child, dest)
 			if err != nil {
 				return err
 			}
+			continue
 		}
+
+		*dest = append(*dest, child)
 	}
 	return nil
 }
@@ -0,0 +1,320 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tests
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/gophercloud/gophercloud/v2"
+	"google.golang.org/api/option"
+	"google.golang.org/api/storage/v1"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+// TestConformance runs the same checks against each backend, using local stand-ins for the remote stores.
+func TestConformance(t *testing.T) {
+	grid := []struct {
+		name  string
+		build func(t *testing.T) vfs.Path
+	}{
+		{
+			name: "memfs",
+			build: func(t *testing.T) vfs.Path {
+				vfsContext := vfs.NewTestingVFSContext()
+				return mustBuildVfsPath(t, vfsContext, "memfs://tests/conformance")
+			},
+		},
+		{
+			name: "fs",
+			build: func(t *testing.T) vfs.Path {
+				return mustBuildVfsPath(t, vfs.NewVFSContext(), "file://"+t.TempDir()+"/conformance")
+			},
+		},
+		{
+			name: "s3-compatible",
+			build: func(t *testing.T) vfs.Path {
+				server := httptest.NewServer(newFakeS3Server("state"))
+				t.Cleanup(server.Close)
+
+				t.Setenv("S3_ENDPOINT", server.URL)
+				t.Setenv("S3_REGION", "us-test-1")
+				t.Setenv("S3_ACCESS_KEY_ID", "access-key")
+				t.Setenv("S3_SECRET_ACCESS_KEY", "secret-key")
+				t.Setenv("KOPS_STATE_S3_ACL", "")
+				return mustBuildVfsPath(t, vfs.NewVFSContext(), "s3://state/conformance")
+			},
+		},
+		{
+			name: "webdav",
+			build: func(t *testing.T) vfs.Path {
+				server := httptest.NewServer(newFakeWebDAVServer("kops", "secret"))
+				t.Cleanup(server.Close)
+
+				t.Setenv("WEBDAV_USERNAME", "kops")
+				t.Setenv("WEBDAV_PASSWORD", "secret")
+				host := strings.TrimPrefix(server.URL, "http://")
+				return mustBuildVfsPath(t, vfs.NewVFSContext(), "webdav://"+host+"/conformance")
+			},
+		},
+		{
+			name: "gcs",
+			build: func(t *testing.T) vfs.Path {
+				server := httptest.NewServer(newFakeGCSServer("state"))
+				t.Cleanup(server.Close)
+
+				client, err := storage.NewService(context.TODO(), option.WithEndpoint(server.URL+"/storage/v1/"), option.WithoutAuthentication())
+				if err != nil {
+					t.Fatalf("error building GCS client: %v", err)
+				}
+				return mustBuildVfsPath(t, vfs.NewVFSContext().WithGCSClient(client), "gs://state/conformance")
+			},
+		},
+		{
+			name: "swift",
+			build: func(t *testing.T) vfs.Path {
+				server := httptest.NewServer(newFakeSwiftServer("state"))
+				t.Cleanup(server.Close)
+
+				client := &gophercloud.ServiceClient{
+					ProviderClient: &gophercloud.ProviderClient{},
+					Endpoint:       server.URL + "/",
+				}
+				return mustBuildVfsPath(t, vfs.NewVFSContext().WithSwiftClient(client), "swift://state/conformance")
+			},
+		},
+		{
+			name: "azureblob",
+			build: func(t *testing.T) vfs.Path {
+				server := httptest.NewServer(newFakeAzureBlobServer())
+				t.Cleanup(server.Close)
+
+				client, err := azblob.NewClientWithNoCredential(server.URL+"/", nil)
+				if err != nil {
+					t.Fatalf("error building Azure Blob client: %v", err)
+				}
+				return mustBuildVfsPath(t, vfs.NewVFSContext().WithAzureBlobClient(client), "azureblob://state/conformance")
+			},
+		},
+		{
+			name: "ssh",
+			build: func(t *testing.T) vfs.Path {
+				client := newFakeSSHClient(t)
+				return vfs.NewSSHPath(client, "127.0.0.1", t.TempDir()+"/conformance", false)
+			},
+		},
+		// Kubernetes paths are read-only placeholders for objects served by the apiserver; see TestKubernetesPathUnsupported
+	}
+
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			testConformance(t, g.build(t))
+		})
+	}
+}
+
+// TestKubernetesPathUnsupported checks that Kubernetes paths, which have no backing store, fail rather than lose data.
+func TestKubernetesPathUnsupported(t *testing.T) {
+	ctx := context.TODO()
+
+	p := mustBuildVfsPath(t, vfs.NewVFSContext(), "k8s://kubernetes.default/conformance/config")
+	if err := p.WriteFile(ctx, strings.NewReader("cluster"), nil); err == nil {
+		t.Errorf("WriteFile(%s): expected error", p)
+	}
+	if err := p.CreateFile(ctx, strings.NewReader("cluster"), nil); err == nil {
+		t.Errorf("CreateFile(%s): expected error", p)
+	}
+	if _, err := p.ReadFile(ctx); err == nil {
+		t.Errorf("ReadFile(%s): expected error", p)
+	}
+	if _, err := p.ReadTree(ctx); err == nil {
+		t.Errorf("ReadTree(%s): expected error", p)
+	}
+	if err := p.Remove(ctx); err == nil {
+		t.Errorf("Remove(%s): expected error", p)
+	}
+	if p.Base() != "config" {
+		t.Errorf("Base(%s): unexpected %q", p, p.Base())
+	}
+}
+
+func mustBuildVfsPath(t *testing.T, vfsContext *vfs.VFSContext, p string) vfs.Path {
+	t.Helper()
+
+	base, err := vfsContext.BuildVfsPath(p)
+	if err != nil {
+		t.Fatalf("error building path %q: %v", p, err)
+	}
+	return base
+}
+
+// testConformance checks the behaviour that callers of vfs.Path rely on, for a backend rooted at base.
+func testConformance(t *testing.T, base vfs.Path) {
+	ctx := context.TODO()
+
+	files := map[string]string{
+		"config":             "cluster",
+		"instancegroup/a":    "ig-a",
+		"instancegroup/b":    "ig-b",
+		"pki/private/ca/key": "key",
+	}
+	for k, v := range files {
+		p := base.Join(k)
+		if err := p.WriteFile(ctx, strings.NewReader(v), nil); err != nil {
+			t.Fatalf("WriteFile(%s): %v", p, err)
+		}
+	}
+
+	for k, v := range files {
+		p := base.Join(k)
+		data, err := p.ReadFile(ctx)
+		if err != nil {
+			t.Fatalf("ReadFile(%s): %v", p, err)
+		}
+		if string(data) != v {
+			t.Errorf("ReadFile(%s): expected %q, got %q", p, v, string(data))
+		}
+
+		var b bytes.Buffer
+		if _, err := p.WriteTo(&b); err != nil {
+			t.Fatalf("WriteTo(%s): %v", p, err)
+		}
+		if b.String() != v {
+			t.Errorf("WriteTo(%s): expected %q, got %q", p, v, b.String())
+		}
+
+		if relativePath, err := vfs.RelativePath(base, p); err != nil || relativePath != k {
+			t.Errorf("RelativePath(%s): expected %q, got %q (err=%v)", p, k, relativePath, err)
+		}
+		if p.Base() != k[strings.LastIndex(k, "/")+1:] {
+			t.Errorf("Base(%s): unexpected %q", p, p.Base())
+		}
+	}
+
+	// Overwrite
+	config := base.Join("config")
+	if err := config.WriteFile(ctx, strings.NewReader("cluster-v2"), nil); err != nil {
+		t.Fatalf("WriteFile(%s): %v", config, err)
+	}
+	if data, err := config.ReadFile(ctx); err != nil || string(data) != "cluster-v2" {
+		t.Errorf("ReadFile(%s) after overwrite: got %q (err=%v)", config, string(data), err)
+	}
+	files["config"] = "cluster-v2"
+
+	// CreateFile
+	if err := config.CreateFile(ctx, strings.NewReader("other"), nil); !os.IsExist(err) {
+		t.Errorf("CreateFile(%s) of existing file: expected exists error, got %v", config, err)
+	}
+	created := base.Join("history", "00000001", "revision.json")
+	if err := created.CreateFile(ctx, strings.NewReader("{}"), nil); err != nil {
+		t.Fatalf("CreateFile(%s): %v", created, err)
+	}
+	if data, err := created.ReadFile(ctx); err != nil || string(data) != "{}" {
+		t.Errorf("ReadFile(%s) after CreateFile: got %q (err=%v)", created, string(data), err)
+	}
+	files["history/00000001/revision.json"] = "{}"
+
+	// Conditional writes
+	data, version, err := vfs.ReadFileWithVersion(ctx, config)
+	if err != nil || string(data) != "cluster-v2" || version == "" {
+		t.Fatalf("ReadFileWithVersion(%s): got %q, version %q (err=%v)", config, string(data), version, err)
+	}
+	newVersion, err := vfs.WriteFileIfMatch(ctx, config, strings.NewReader("cluster-v3"), nil, version)
+	if err != nil {
+		t.Fatalf("WriteFileIfMatch(%s) with current version: %v", config, err)
+	}
+	files["config"] = "cluster-v3"
+	if _, err := vfs.WriteFileIfMatch(ctx, config, strings.NewReader("cluster-v4"), nil, version); !errors.Is(err, vfs.ErrPreconditionFailed) {
+		t.Errorf("WriteFileIfMatch(%s) with stale version: expected ErrPreconditionFailed, got %v", config, err)
+	}
+	if data, readVersion, err := vfs.ReadFileWithVersion(ctx, config); err != nil || string(data) != "cluster-v3" || readVersion != newVersion {
+		t.Errorf("ReadFileWithVersion(%s) after conditional writes: got %q, version %q, expected version %q (err=%v)", config, string(data), readVersion, newVersion, err)
+	}
+
+	// Listing
+	tree, err := base.ReadTree(ctx)
+	if err != nil {
+		t.Fatalf("ReadTree(%s): %v", base, err)
+	}
+	var expectedTree []string
+	for k := range files {
+		expectedTree = append(expectedTree, k)
+	}
+	sort.Strings(expectedTree)
+	if actual := relativePaths(t, base, tree); !reflect.DeepEqual(actual, expectedTree) {
+		t.Errorf("ReadTree(%s): expected %v, got %v", base, expectedTree, actual)
+	}
+
+	igs := base.Join("instancegroup")
+	dir, err := igs.ReadDir()
+	if err != nil {
+		t.Fatalf("ReadDir(%s): %v", igs, err)
+	}
+	if actual := relativePaths(t, igs, dir); !reflect.DeepEqual(actual, []string{"a", "b"}) {
+		t.Errorf("ReadDir(%s): expected [a b], got %v", igs, actual)
+	}
+
+	// Removal
+	a := igs.Join("a")
+	if err := a.Remove(ctx); err != nil {
+		t.Fatalf("Remove(%s): %v", a, err)
+	}
+	if _, err := a.ReadFile(ctx); !os.IsNotExist(err) {
+		t.Errorf("ReadFile(%s) after Remove: expected not-exist error, got %v", a, err)
+	}
+	if err := base.RemoveAll(ctx); err != nil {
+		t.Fatalf("RemoveAll(%s): %v", base, err)
+	}
+	for k := range files {
+		p := base.Join(k)
+		if _, err := p.ReadFile(ctx); !os.IsNotExist(err) {
+			t.Errorf("ReadFile(%s) after RemoveAll: expected not-exist error, got %v", p, err)
+		}
+	}
+
+	// Paths that were never written; memfs lists joined paths, so we only check these at the end
+	missing := base.Join("missing")
+	if _, err := missing.ReadFile(ctx); !os.IsNotExist(err) {
+		t.Errorf("ReadFile(%s): expected not-exist error, got %v", missing, err)
+	}
+	if _, err := vfs.WriteFileIfMatch(ctx, missing, strings.NewReader("x"), nil, version); !errors.Is(err, vfs.ErrPreconditionFailed) {
+		t.Errorf("WriteFileIfMatch(%s) of missing file: expected ErrPreconditionFailed, got %v", missing, err)
+	}
+}
+
+func relativePaths(t *testing.T, base vfs.Path, paths []vfs.Path) []string {
+	t.Helper()
+
+	var relativePaths []string
+	for _, p := range paths {
+		relativePath, err := vfs.RelativePath(base, p)
+		if err != nil {
+			t.Fatalf("RelativePath(%s, %s): %v", base, p, err)
+		}
+		relativePaths = append(relativePaths, relativePath)
+	}
+	sort.Strings(relativePaths)
+	return relativePaths
+}
@@ -0,0 +1,227 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tests
+
+import (
+	"context"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+// countingHandler records the requests made to the wrapped handler, so we can tell when the cache was used.
+type countingHandler struct {
+	handler http.Handler
+
+	mutex    sync.Mutex
+	requests []string
+}
+
+func (h *countingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	recorder := httptest.NewRecorder()
+	h.handler.ServeHTTP(recorder, r)
+
+	h.mutex.Lock()
+	h.requests = append(h.requests, r.Method+" "+r.URL.Path+" "+http.StatusText(recorder.Code))
+	h.mutex.Unlock()
+
+	for k, v := range recorder.Header() {
+		w.Header()[k] = v
+	}
+	w.WriteHeader(recorder.Code)
+	w.Write(recorder.Body.Bytes())
+}
+
+// reset returns the requests made since the last call.
+func (h *countingHandler) reset() []string {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	requests := h.requests
+	h.requests = nil
+	return requests
+}
+
+func TestDiskCache(t *testing.T) {
+	ctx := context.TODO()
+
+	server := &countingHandler{handler: newFakeWebDAVServer("kops", "secret")}
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	t.Setenv("WEBDAV_USERNAME", "kops")
+	t.Setenv("WEBDAV_PASSWORD", "secret")
+	host := strings.TrimPrefix(httpServer.URL, "http://")
+	remote := mustBuildVfsPath(t, vfs.NewVFSContext(), "webdav://"+host+"/state")
+
+	cacheDir := t.TempDir()
+	cached := vfs.NewDiskCache(cacheDir, time.Hour, time.Hour, false).Wrap(remote)
+	// revalidating shares the cache entries, but with no TTL it checks the backing store on every read
+	revalidating := vfs.NewDiskCache(cacheDir, 0, 0, false).Wrap(remote)
+
+	config := cached.Join("cluster", "config")
+	if err := config.WriteFile(ctx, strings.NewReader("v1"), nil); err != nil {
+		t.Fatalf("WriteFile(%s): %v", config, err)
+	}
+	server.reset()
+
+	assertRead := func(p vfs.Path, expected string) {
+		t.Helper()
+		data, err := p.ReadFile(ctx)
+		if err != nil {
+			t.Fatalf("ReadFile(%s): %v", p, err)
+		}
+		if string(data) != expected {
+			t.Errorf("ReadFile(%s): expected %q, got %q", p, expected, string(data))
+		}
+	}
+	assertRequests := func(expected ...string) {
+		t.Helper()
+		actual := server.reset()
+		if strings.Join(actual, "\n") != strings.Join(expected, "\n") {
+			t.Errorf("expected requests %q, got %q", expected, actual)
+		}
+	}
+
+	// The first read populates the cache; the second is served from disk
+	assertRead(config, "v1")
+	assertRequests("GET /state/cluster/config OK")
+	assertRead(config, "v1")
+	assertRequests()
+
+	// Once the TTL has expired, an unchanged file is revalidated without being downloaded again
+	assertRead(revalidating.Join("cluster", "config"), "v1")
+	assertRequests("GET /state/cluster/config Not Modified")
+
+	// Changes made by others are picked up after the TTL
+	if err := remote.Join("cluster", "config").WriteFile(ctx, strings.NewReader("v2"), nil); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	server.reset()
+	assertRead(config, "v1")
+	assertRequests()
+	assertRead(revalidating.Join("cluster", "config"), "v2")
+	assertRequests("GET /state/cluster/config OK")
+
+	// Local writes invalidate the cached contents
+	if err := config.WriteFile(ctx, strings.NewReader("v3"), nil); err != nil {
+		t.Fatalf("WriteFile(%s): %v", config, err)
+	}
+	server.reset()
+	assertRead(config, "v3")
+	assertRequests("GET /state/cluster/config OK")
+
+	// Listings are cached, and invalidated by local writes below them
+	ig := cached.Join("cluster", "instancegroup", "nodes")
+	if err := ig.WriteFile(ctx, strings.NewReader("nodes"), nil); err != nil {
+		t.Fatalf("WriteFile(%s): %v", ig, err)
+	}
+	assertTree := func(expected ...string) {
+		t.Helper()
+		tree, err := cached.ReadTree(ctx)
+		if err != nil {
+			t.Fatalf("ReadTree(%s): %v", cached, err)
+		}
+		if actual := relativePaths(t, cached, tree); strings.Join(actual, ",") != strings.Join(expected, ",") {
+			t.Errorf("ReadTree(%s): expected %v, got %v", cached, expected, actual)
+		}
+		for _, p := range tree {
+			if _, ok := p.(*vfs.CachedPath); !ok {
+				t.Errorf("ReadTree(%s): expected cached path, got %T", cached, p)
+			}
+		}
+	}
+	assertTree("cluster/config", "cluster/instancegroup/nodes")
+	server.reset()
+	assertTree("cluster/config", "cluster/instancegroup/nodes")
+	assertRequests()
+
+	if err := ig.Remove(ctx); err != nil {
+		t.Fatalf("Remove(%s): %v", ig, err)
+	}
+	assertTree("cluster/config")
+	if _, err := ig.ReadFile(ctx); !os.IsNotExist(err) {
+		t.Errorf("ReadFile(%s) after Remove: expected not-exist error, got %v", ig, err)
+	}
+
+	// Private keys and secrets are not cached unless the cache was built to hold them
+	for _, name := range []string{"pki/private/ca/keyset.yaml", "secrets/admin"} {
+		if err := remote.Join("cluster", name).WriteFile(ctx, strings.NewReader(name), nil); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+		server.reset()
+
+		p := cached.Join("cluster", name)
+		if _, ok := p.(*vfs.CachedPath); ok {
+			t.Errorf("expected %s not to be cached", p)
+		}
+		assertRead(p, name)
+		assertRead(p, name)
+		assertRequests("GET /state/cluster/"+name+" OK", "GET /state/cluster/"+name+" OK")
+
+		withSecrets := vfs.NewDiskCache(cacheDir, time.Hour, time.Hour, true).Wrap(remote).Join("cluster", name)
+		assertRead(withSecrets, name)
+		assertRead(withSecrets, name)
+		assertRequests("GET /state/cluster/" + name + " OK")
+	}
+
+	// Cache entries may hold secrets, so they are private to the user
+	err := filepath.WalkDir(cacheDir, func(name string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		if name != cacheDir && info.Mode().Perm()&0o077 != 0 {
+			t.Errorf("cache entry %s has mode %v", name, info.Mode())
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("error walking cache: %v", err)
+	}
+}
+
+func TestCachedPathForwardsOptionalInterfaces(t *testing.T) {
+	cache := vfs.NewDiskCache(t.TempDir(), time.Hour, time.Hour, false)
+
+	p := cache.Wrap(mustBuildVfsPath(t, vfs.NewVFSContext(), "s3://bucket/cluster.example.com/config"))
+	if _, ok := p.(*vfs.CachedPath); !ok {
+		t.Fatalf("expected cached path, got %T", p)
+	}
+	if _, ok := p.(vfs.TerraformPath); !ok {
+		t.Errorf("expected cached S3 path to implement TerraformPath")
+	}
+	hasHash, ok := p.(vfs.HasHash)
+	if !ok {
+		t.Fatalf("expected cached S3 path to implement HasHash")
+	}
+	// Without a listing, S3 has no ETag to report as the hash
+	if hash, err := hasHash.PreferredHash(); err != nil || hash != nil {
+		t.Errorf("PreferredHash(%s): expected no hash, got %v, %v", p, hash, err)
+	}
+}
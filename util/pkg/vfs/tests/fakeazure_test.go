@@ -0,0 +1,219 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tests
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// fakeAzureBlobServer is an in-memory server for the subset of the Azure Blob Storage API used by the Azure Blob vfs backend.
+// It serves a single account at the root of the server, as Azurite does with path-style URLs.
+type fakeAzureBlobServer struct {
+	mutex      sync.Mutex
+	containers map[string]map[string][]byte
+	// blocks holds the staged, uncommitted blocks of each blob
+	blocks map[string]map[string][]byte
+}
+
+func newFakeAzureBlobServer() *fakeAzureBlobServer {
+	return &fakeAzureBlobServer{
+		containers: make(map[string]map[string][]byte),
+		blocks:     make(map[string]map[string][]byte),
+	}
+}
+
+func writeAzureError(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("x-ms-error-code", code)
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	io.WriteString(w, xml.Header+"<Error><Code>"+code+"</Code><Message>"+code+"</Message></Error>")
+}
+
+func azureETag(data []byte) string {
+	h := md5.Sum(data)
+	return `"` + hex.EncodeToString(h[:]) + `"`
+}
+
+func (s *fakeAzureBlobServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	containerName, blobName, _ := strings.Cut(strings.TrimPrefix(r.URL.EscapedPath(), "/"), "/")
+	containerName, _ = url.PathUnescape(containerName)
+	blobName, _ = url.PathUnescape(blobName)
+	query := r.URL.Query()
+	container := s.containers[containerName]
+
+	w.Header().Set("x-ms-version", r.Header.Get("x-ms-version"))
+	w.Header().Set("Date", time.Now().UTC().Format(http.TimeFormat))
+
+	if blobName == "" {
+		switch {
+		case r.Method == http.MethodPut && query.Get("restype") == "container":
+			if container != nil {
+				writeAzureError(w, http.StatusConflict, "ContainerAlreadyExists")
+				return
+			}
+			s.containers[containerName] = make(map[string][]byte)
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == http.MethodGet && query.Get("comp") == "list":
+			if container == nil {
+				writeAzureError(w, http.StatusNotFound, "ContainerNotFound")
+				return
+			}
+			s.listBlobs(w, query, containerName, container)
+		default:
+			writeAzureError(w, http.StatusBadRequest, "UnsupportedHttpVerb")
+		}
+		return
+	}
+
+	if container == nil {
+		writeAzureError(w, http.StatusNotFound, "ContainerNotFound")
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodPut && query.Get("comp") == "block":
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeAzureError(w, http.StatusBadRequest, "InvalidInput")
+			return
+		}
+		key := containerName + "/" + blobName
+		if s.blocks[key] == nil {
+			s.blocks[key] = make(map[string][]byte)
+		}
+		s.blocks[key][query.Get("blockid")] = data
+		w.WriteHeader(http.StatusCreated)
+
+	case r.Method == http.MethodPut && query.Get("comp") == "blocklist":
+		var blockList struct {
+			Latest      []string `xml:"Latest"`
+			Uncommitted []string `xml:"Uncommitted"`
+		}
+		if err := xml.NewDecoder(r.Body).Decode(&blockList); err != nil {
+			writeAzureError(w, http.StatusBadRequest, "InvalidXmlDocument")
+			return
+		}
+		key := containerName + "/" + blobName
+		var data []byte
+		for _, id := range append(blockList.Latest, blockList.Uncommitted...) {
+			block, found := s.blocks[key][id]
+			if !found {
+				writeAzureError(w, http.StatusBadRequest, "InvalidBlockList")
+				return
+			}
+			data = append(data, block...)
+		}
+		delete(s.blocks, key)
+		container[blobName] = data
+		w.Header().Set("ETag", azureETag(data))
+		w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+		w.WriteHeader(http.StatusCreated)
+
+	case r.Method == http.MethodPut:
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeAzureError(w, http.StatusBadRequest, "InvalidInput")
+			return
+		}
+		container[blobName] = data
+		w.Header().Set("ETag", azureETag(data))
+		w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+		w.WriteHeader(http.StatusCreated)
+
+	case r.Method == http.MethodGet || r.Method == http.MethodHead:
+		data, found := container[blobName]
+		if !found {
+			writeAzureError(w, http.StatusNotFound, "BlobNotFound")
+			return
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+		w.Header().Set("ETag", azureETag(data))
+		w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+		w.Header().Set("x-ms-blob-type", "BlockBlob")
+		w.WriteHeader(http.StatusOK)
+		if r.Method == http.MethodGet {
+			w.Write(data)
+		}
+
+	case r.Method == http.MethodDelete:
+		if _, found := container[blobName]; !found {
+			writeAzureError(w, http.StatusNotFound, "BlobNotFound")
+			return
+		}
+		delete(container, blobName)
+		w.WriteHeader(http.StatusAccepted)
+
+	default:
+		writeAzureError(w, http.StatusBadRequest, "UnsupportedHttpVerb")
+	}
+}
+
+type fakeAzureBlobList struct {
+	XMLName       xml.Name            `xml:"EnumerationResults"`
+	ContainerName string              `xml:"ContainerName,attr"`
+	Prefix        string              `xml:"Prefix"`
+	Blobs         []fakeAzureBlobItem `xml:"Blobs>Blob"`
+	NextMarker    string              `xml:"NextMarker"`
+}
+
+type fakeAzureBlobItem struct {
+	Name          string `xml:"Name"`
+	ContentLength int    `xml:"Properties>Content-Length"`
+	BlobType      string `xml:"Properties>BlobType"`
+}
+
+func (s *fakeAzureBlobServer) listBlobs(w http.ResponseWriter, query url.Values, containerName string, container map[string][]byte) {
+	prefix := query.Get("prefix")
+
+	var names []string
+	for name := range container {
+		if strings.HasPrefix(name, prefix) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	result := &fakeAzureBlobList{
+		ContainerName: containerName,
+		Prefix:        prefix,
+	}
+	for _, name := range names {
+		result.Blobs = append(result.Blobs, fakeAzureBlobItem{
+			Name:          name,
+			ContentLength: len(container[name]),
+			BlobType:      "BlockBlob",
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(http.StatusOK)
+	io.WriteString(w, xml.Header)
+	xml.NewEncoder(w).Encode(result)
+}
@@ -0,0 +1,236 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tests
+
+import (
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// fakeGCSServer is an in-memory server for the subset of the GCS JSON API used by the GCS vfs backend.
+// Clients are pointed at it with option.WithEndpoint.
+type fakeGCSServer struct {
+	mutex      sync.Mutex
+	buckets    map[string]map[string]*fakeGCSObject
+	generation int64
+}
+
+type fakeGCSObject struct {
+	data       []byte
+	generation int64
+}
+
+func newFakeGCSServer(buckets ...string) *fakeGCSServer {
+	s := &fakeGCSServer{
+		buckets: make(map[string]map[string]*fakeGCSObject),
+	}
+	for _, bucket := range buckets {
+		s.buckets[bucket] = make(map[string]*fakeGCSObject)
+	}
+	return s
+}
+
+func writeGCSError(w http.ResponseWriter, status int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]any{
+		"error": map[string]any{
+			"code":    status,
+			"message": http.StatusText(status),
+		},
+	})
+}
+
+func writeGCSJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(v)
+}
+
+func gcsObjectResource(bucket string, name string, o *fakeGCSObject) map[string]any {
+	h := md5.Sum(o.data)
+	return map[string]any{
+		"bucket":     bucket,
+		"name":       name,
+		"generation": strconv.FormatInt(o.generation, 10),
+		"size":       strconv.Itoa(len(o.data)),
+		"md5Hash":    base64.StdEncoding.EncodeToString(h[:]),
+	}
+}
+
+func (s *fakeGCSServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	// Object names are escaped, and may contain slashes
+	escapedPath := r.URL.EscapedPath()
+	upload := false
+	if strings.HasPrefix(escapedPath, "/upload/") {
+		upload = true
+		escapedPath = strings.TrimPrefix(escapedPath, "/upload")
+	}
+	tokens := strings.Split(strings.TrimPrefix(escapedPath, "/storage/v1/"), "/")
+	if len(tokens) < 3 || tokens[0] != "b" || tokens[2] != "o" {
+		writeGCSError(w, http.StatusNotFound)
+		return
+	}
+	bucketName, _ := url.PathUnescape(tokens[1])
+	bucket := s.buckets[bucketName]
+	if bucket == nil {
+		writeGCSError(w, http.StatusNotFound)
+		return
+	}
+
+	query := r.URL.Query()
+	if len(tokens) == 3 {
+		switch {
+		case upload && r.Method == http.MethodPost:
+			s.insertObject(w, r, bucketName, bucket)
+		case r.Method == http.MethodGet:
+			s.listObjects(w, query, bucketName, bucket)
+		default:
+			writeGCSError(w, http.StatusMethodNotAllowed)
+		}
+		return
+	}
+
+	name, err := url.PathUnescape(strings.Join(tokens[3:], "/"))
+	if err != nil {
+		writeGCSError(w, http.StatusBadRequest)
+		return
+	}
+	o := bucket[name]
+	if o == nil {
+		writeGCSError(w, http.StatusNotFound)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		if v := query.Get("ifGenerationNotMatch"); v != "" && v == strconv.FormatInt(o.generation, 10) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		if query.Get("alt") != "media" {
+			writeGCSJSON(w, gcsObjectResource(bucketName, name, o))
+			return
+		}
+		w.Header().Set("X-Goog-Generation", strconv.FormatInt(o.generation, 10))
+		w.WriteHeader(http.StatusOK)
+		w.Write(o.data)
+
+	case http.MethodDelete:
+		delete(bucket, name)
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		writeGCSError(w, http.StatusMethodNotAllowed)
+	}
+}
+
+// insertObject handles a multipart upload, which holds the object metadata followed by the media.
+func (s *fakeGCSServer) insertObject(w http.ResponseWriter, r *http.Request, bucketName string, bucket map[string]*fakeGCSObject) {
+	_, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || params["boundary"] == "" {
+		writeGCSError(w, http.StatusBadRequest)
+		return
+	}
+	reader := multipart.NewReader(r.Body, params["boundary"])
+
+	var metadata struct {
+		Name string `json:"name"`
+	}
+	part, err := reader.NextPart()
+	if err != nil {
+		writeGCSError(w, http.StatusBadRequest)
+		return
+	}
+	if err := json.NewDecoder(part).Decode(&metadata); err != nil || metadata.Name == "" {
+		writeGCSError(w, http.StatusBadRequest)
+		return
+	}
+	part, err = reader.NextPart()
+	if err != nil {
+		writeGCSError(w, http.StatusBadRequest)
+		return
+	}
+	data, err := io.ReadAll(part)
+	if err != nil {
+		writeGCSError(w, http.StatusBadRequest)
+		return
+	}
+
+	if v := r.URL.Query().Get("ifGenerationMatch"); v != "" {
+		existing := bucket[metadata.Name]
+		if existing == nil || v != strconv.FormatInt(existing.generation, 10) {
+			writeGCSError(w, http.StatusPreconditionFailed)
+			return
+		}
+	}
+
+	s.generation++
+	o := &fakeGCSObject{data: data, generation: s.generation}
+	bucket[metadata.Name] = o
+	writeGCSJSON(w, gcsObjectResource(bucketName, metadata.Name, o))
+}
+
+func (s *fakeGCSServer) listObjects(w http.ResponseWriter, query url.Values, bucketName string, bucket map[string]*fakeGCSObject) {
+	prefix := query.Get("prefix")
+	delimiter := query.Get("delimiter")
+
+	var names []string
+	prefixes := make(map[string]bool)
+	for name := range bucket {
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		if delimiter != "" {
+			if i := strings.Index(name[len(prefix):], delimiter); i >= 0 {
+				prefixes[name[:len(prefix)+i+len(delimiter)]] = true
+				continue
+			}
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var items []any
+	for _, name := range names {
+		items = append(items, gcsObjectResource(bucketName, name, bucket[name]))
+	}
+	var prefixList []string
+	for p := range prefixes {
+		prefixList = append(prefixList, p)
+	}
+	sort.Strings(prefixList)
+
+	writeGCSJSON(w, map[string]any{
+		"kind":     "storage#objects",
+		"items":    items,
+		"prefixes": prefixList,
+	})
+}
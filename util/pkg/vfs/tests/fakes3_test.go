@@ -0,0 +1,281 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tests
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// fakeS3Server is an in-memory S3-compatible server, standing in for MinIO and similar stores.
+// Like those stores it is addressed with path-style requests (S3_ENDPOINT), and it implements
+// only the subset of the S3 API used by the S3 vfs backend.
+type fakeS3Server struct {
+	mutex   sync.Mutex
+	buckets map[string]map[string][]byte
+}
+
+func newFakeS3Server(buckets ...string) *fakeS3Server {
+	s := &fakeS3Server{
+		buckets: make(map[string]map[string][]byte),
+	}
+	for _, bucket := range buckets {
+		s.buckets[bucket] = make(map[string][]byte)
+	}
+	return s
+}
+
+type fakeS3Error struct {
+	XMLName xml.Name `xml:"Error"`
+	Code    string   `xml:"Code"`
+	Message string   `xml:"Message"`
+}
+
+func writeS3Error(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	xml.NewEncoder(w).Encode(&fakeS3Error{Code: code, Message: code})
+}
+
+func writeS3XML(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(http.StatusOK)
+	xml.NewEncoder(w).Encode(v)
+}
+
+func s3ETag(data []byte) string {
+	h := md5.Sum(data)
+	return `"` + hex.EncodeToString(h[:]) + `"`
+}
+
+func (s *fakeS3Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 ") {
+		writeS3Error(w, http.StatusForbidden, "AccessDenied")
+		return
+	}
+
+	bucketName, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	bucket := s.buckets[bucketName]
+	if bucket == nil {
+		writeS3Error(w, http.StatusNotFound, "NoSuchBucket")
+		return
+	}
+
+	query := r.URL.Query()
+	switch {
+	case key == "" && r.Method == http.MethodGet && query.Has("encryption"):
+		writeS3Error(w, http.StatusNotFound, "ServerSideEncryptionConfigurationNotFoundError")
+
+	case key == "" && r.Method == http.MethodGet:
+		s.listObjects(w, bucketName, bucket, query.Get("prefix"), query.Get("delimiter"))
+
+	case key == "" && r.Method == http.MethodPost && query.Has("delete"):
+		s.deleteObjects(w, r, bucket)
+
+	case key != "" && (r.Method == http.MethodGet || r.Method == http.MethodHead):
+		data, found := bucket[key]
+		if !found {
+			writeS3Error(w, http.StatusNotFound, "NoSuchKey")
+			return
+		}
+		w.Header().Set("ETag", s3ETag(data))
+		w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+		// Like MinIO, return a checksum so the SDK can validate the response
+		checksum := make([]byte, 4)
+		binary.BigEndian.PutUint32(checksum, crc32.ChecksumIEEE(data))
+		w.Header().Set("X-Amz-Checksum-Crc32", base64.StdEncoding.EncodeToString(checksum))
+		w.WriteHeader(http.StatusOK)
+		if r.Method == http.MethodGet {
+			w.Write(data)
+		}
+
+	case key != "" && r.Method == http.MethodPut:
+		data, err := readS3Body(r)
+		if err != nil {
+			writeS3Error(w, http.StatusBadRequest, "IncompleteBody")
+			return
+		}
+		current, found := bucket[key]
+		if ifMatch := r.Header.Get("If-Match"); ifMatch != "" {
+			if !found {
+				writeS3Error(w, http.StatusNotFound, "NoSuchKey")
+				return
+			}
+			if ifMatch != s3ETag(current) {
+				writeS3Error(w, http.StatusPreconditionFailed, "PreconditionFailed")
+				return
+			}
+		}
+		if r.Header.Get("If-None-Match") == "*" && found {
+			writeS3Error(w, http.StatusPreconditionFailed, "PreconditionFailed")
+			return
+		}
+		bucket[key] = data
+		w.Header().Set("ETag", s3ETag(data))
+		w.WriteHeader(http.StatusOK)
+
+	case key != "" && r.Method == http.MethodDelete:
+		delete(bucket, key)
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		writeS3Error(w, http.StatusNotImplemented, "NotImplemented")
+	}
+}
+
+type fakeS3ListBucketResult struct {
+	XMLName        xml.Name `xml:"ListBucketResult"`
+	Name           string   `xml:"Name"`
+	Prefix         string   `xml:"Prefix"`
+	KeyCount       int      `xml:"KeyCount"`
+	MaxKeys        int      `xml:"MaxKeys"`
+	IsTruncated    bool     `xml:"IsTruncated"`
+	Contents       []fakeS3Object
+	CommonPrefixes []fakeS3CommonPrefix
+}
+
+type fakeS3Object struct {
+	Key  string `xml:"Key"`
+	ETag string `xml:"ETag"`
+	Size int    `xml:"Size"`
+}
+
+type fakeS3CommonPrefix struct {
+	Prefix string `xml:"Prefix"`
+}
+
+func (s *fakeS3Server) listObjects(w http.ResponseWriter, bucketName string, bucket map[string][]byte, prefix, delimiter string) {
+	result := &fakeS3ListBucketResult{
+		Name:    bucketName,
+		Prefix:  prefix,
+		MaxKeys: 1000,
+	}
+
+	var keys []string
+	for key := range bucket {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	seenPrefixes := make(map[string]bool)
+	for _, key := range keys {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		if delimiter != "" {
+			if i := strings.Index(key[len(prefix):], delimiter); i != -1 {
+				commonPrefix := key[:len(prefix)+i+len(delimiter)]
+				if !seenPrefixes[commonPrefix] {
+					seenPrefixes[commonPrefix] = true
+					result.CommonPrefixes = append(result.CommonPrefixes, fakeS3CommonPrefix{Prefix: commonPrefix})
+				}
+				continue
+			}
+		}
+		result.Contents = append(result.Contents, fakeS3Object{
+			Key:  key,
+			ETag: s3ETag(bucket[key]),
+			Size: len(bucket[key]),
+		})
+	}
+	result.KeyCount = len(result.Contents) + len(result.CommonPrefixes)
+
+	writeS3XML(w, result)
+}
+
+func (s *fakeS3Server) deleteObjects(w http.ResponseWriter, r *http.Request, bucket map[string][]byte) {
+	var request struct {
+		Objects []struct {
+			Key string `xml:"Key"`
+		} `xml:"Object"`
+	}
+	body, err := readS3Body(r)
+	if err != nil {
+		writeS3Error(w, http.StatusBadRequest, "IncompleteBody")
+		return
+	}
+	if err := xml.Unmarshal(body, &request); err != nil {
+		writeS3Error(w, http.StatusBadRequest, "MalformedXML")
+		return
+	}
+
+	type deleted struct {
+		Key string `xml:"Key"`
+	}
+	result := struct {
+		XMLName xml.Name  `xml:"DeleteResult"`
+		Deleted []deleted `xml:"Deleted"`
+	}{}
+	for _, o := range request.Objects {
+		delete(bucket, o.Key)
+		result.Deleted = append(result.Deleted, deleted{Key: o.Key})
+	}
+
+	writeS3XML(w, &result)
+}
+
+// readS3Body reads the request body, decoding the aws-chunked encoding the SDK uses when it sends trailing checksums.
+func readS3Body(r *http.Request) ([]byte, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(r.Header.Get("X-Amz-Content-Sha256"), "STREAMING-") {
+		return body, nil
+	}
+
+	var data bytes.Buffer
+	reader := bufio.NewReader(bytes.NewReader(body))
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, fmt.Errorf("error reading chunk header: %w", err)
+		}
+		sizeField, _, _ := strings.Cut(strings.TrimSpace(line), ";")
+		size, err := strconv.ParseInt(sizeField, 16, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing chunk size %q: %w", sizeField, err)
+		}
+		if size == 0 {
+			// The remainder holds the trailers, which we don't verify
+			return data.Bytes(), nil
+		}
+		if _, err := io.CopyN(&data, reader, size); err != nil {
+			return nil, fmt.Errorf("error reading chunk: %w", err)
+		}
+		if _, err := reader.ReadString('\n'); err != nil {
+			return nil, fmt.Errorf("error reading chunk terminator: %w", err)
+		}
+	}
+}
@@ -0,0 +1,208 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tests
+
+import (
+	"bufio"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// fakeSwiftServer is an in-memory server for the subset of the Swift object storage API used by the Swift vfs backend.
+// It serves a single account; clients are pointed at it by setting the endpoint of the service client.
+type fakeSwiftServer struct {
+	mutex      sync.Mutex
+	containers map[string]map[string][]byte
+}
+
+func newFakeSwiftServer(containers ...string) *fakeSwiftServer {
+	s := &fakeSwiftServer{
+		containers: make(map[string]map[string][]byte),
+	}
+	for _, container := range containers {
+		s.containers[container] = make(map[string][]byte)
+	}
+	return s
+}
+
+func swiftHash(data []byte) string {
+	h := md5.Sum(data)
+	return hex.EncodeToString(h[:])
+}
+
+func (s *fakeSwiftServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if r.Method == http.MethodPost && r.URL.Query().Has("bulk-delete") {
+		s.bulkDelete(w, r)
+		return
+	}
+
+	containerName, objectName, _ := strings.Cut(strings.TrimPrefix(r.URL.EscapedPath(), "/"), "/")
+	containerName, _ = url.PathUnescape(containerName)
+	objectName, _ = url.PathUnescape(objectName)
+	container := s.containers[containerName]
+
+	if objectName == "" {
+		switch r.Method {
+		case http.MethodPut:
+			if container == nil {
+				s.containers[containerName] = make(map[string][]byte)
+			}
+			w.WriteHeader(http.StatusCreated)
+		case http.MethodHead, http.MethodGet:
+			if container == nil {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			if r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			s.listObjects(w, r.URL.Query(), container)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+		return
+	}
+
+	if container == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		container[objectName] = data
+		w.Header().Set("ETag", swiftHash(data))
+		w.WriteHeader(http.StatusCreated)
+
+	case http.MethodHead, http.MethodGet:
+		data, found := container[objectName]
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("ETag", swiftHash(data))
+		w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+		if r.Method == http.MethodHead {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write(data)
+
+	case http.MethodDelete:
+		if _, found := container[objectName]; !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		delete(container, objectName)
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// listObjects returns the JSON listing of a container, honoring prefix, path and marker.
+func (s *fakeSwiftServer) listObjects(w http.ResponseWriter, query url.Values, container map[string][]byte) {
+	prefix := query.Get("prefix")
+	dirPath := query.Get("path")
+	marker := query.Get("marker")
+
+	var names []string
+	for name := range container {
+		if !strings.HasPrefix(name, prefix) || name <= marker {
+			continue
+		}
+		// path returns only the objects directly under the pseudo-directory
+		if query.Has("path") && (!strings.HasPrefix(name, dirPath) || strings.Contains(name[len(dirPath):], "/")) {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	type objectInfo struct {
+		Name         string `json:"name"`
+		Hash         string `json:"hash"`
+		Bytes        int    `json:"bytes"`
+		ContentType  string `json:"content_type"`
+		LastModified string `json:"last_modified"`
+	}
+	objects := []objectInfo{}
+	for _, name := range names {
+		objects = append(objects, objectInfo{
+			Name:         name,
+			Hash:         swiftHash(container[name]),
+			Bytes:        len(container[name]),
+			ContentType:  "application/octet-stream",
+			LastModified: time.Now().UTC().Format("2006-01-02T15:04:05.000000"),
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(objects)
+}
+
+// bulkDelete removes the newline-separated container/object paths in the body.
+func (s *fakeSwiftServer) bulkDelete(w http.ResponseWriter, r *http.Request) {
+	deleted := 0
+	notFound := 0
+	scanner := bufio.NewScanner(r.Body)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		containerName, objectName, _ := strings.Cut(line, "/")
+		containerName, _ = url.PathUnescape(containerName)
+		objectName, _ = url.PathUnescape(objectName)
+		if _, found := s.containers[containerName][objectName]; found {
+			delete(s.containers[containerName], objectName)
+			deleted++
+		} else {
+			notFound++
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]any{
+		"Number Deleted":   deleted,
+		"Number Not Found": notFound,
+		"Response Status":  "200 OK",
+		"Response Body":    "",
+		"Errors":           [][]string{},
+	})
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tests
+
+import (
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	"golang.org/x/net/webdav"
+)
+
+// fakeWebDAVServer is an in-memory WebDAV server.
+// It adds the behaviour we rely on from real servers that golang.org/x/net/webdav does not implement:
+// basic authentication, If-Match / If-None-Match on PUT, and the ACL method.
+type fakeWebDAVServer struct {
+	username string
+	password string
+
+	handler *webdav.Handler
+
+	// mutex serializes writes, so precondition checks are atomic
+	mutex sync.Mutex
+	// acls holds the principals granted read access by the ACL method, by path
+	acls map[string][]string
+}
+
+func newFakeWebDAVServer(username, password string) *fakeWebDAVServer {
+	return &fakeWebDAVServer{
+		username: username,
+		password: password,
+		handler: &webdav.Handler{
+			FileSystem: webdav.NewMemFS(),
+			LockSystem: webdav.NewMemLS(),
+		},
+		acls: make(map[string][]string),
+	}
+}
+
+func (s *fakeWebDAVServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if username, password, ok := r.BasicAuth(); !ok || username != s.username || password != s.password {
+		w.Header().Set("WWW-Authenticate", `Basic realm="fake"`)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+
+		if !s.checkPreconditions(r) {
+			http.Error(w, "precondition failed", http.StatusPreconditionFailed)
+			return
+		}
+		s.handler.ServeHTTP(w, r)
+
+	case "ACL":
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+
+		if s.currentETag(r) == "" {
+			http.NotFound(w, r)
+			return
+		}
+		var acl struct {
+			ACEs []struct {
+				Href string `xml:"principal>href"`
+			} `xml:"ace"`
+		}
+		if err := xml.NewDecoder(r.Body).Decode(&acl); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		var principals []string
+		for _, ace := range acl.ACEs {
+			principals = append(principals, ace.Href)
+		}
+		s.acls[r.URL.Path] = principals
+		w.WriteHeader(http.StatusOK)
+
+	default:
+		s.handler.ServeHTTP(w, r)
+	}
+}
+
+// checkPreconditions evaluates the If-Match and If-None-Match headers against the current file.
+func (s *fakeWebDAVServer) checkPreconditions(r *http.Request) bool {
+	ifMatch := r.Header.Get("If-Match")
+	ifNoneMatch := r.Header.Get("If-None-Match")
+	if ifMatch == "" && ifNoneMatch == "" {
+		return true
+	}
+
+	etag := s.currentETag(r)
+	if ifMatch != "" && (etag == "" || (ifMatch != "*" && ifMatch != etag)) {
+		return false
+	}
+	if ifNoneMatch != "" && etag != "" && (ifNoneMatch == "*" || ifNoneMatch == etag) {
+		return false
+	}
+	return true
+}
+
+// currentETag returns the ETag of the file at the request path, or "" if it does not exist.
+func (s *fakeWebDAVServer) currentETag(r *http.Request) string {
+	head, err := http.NewRequestWithContext(r.Context(), http.MethodHead, r.URL.String(), nil)
+	if err != nil {
+		return ""
+	}
+	recorder := httptest.NewRecorder()
+	s.handler.ServeHTTP(recorder, head)
+	if recorder.Code != http.StatusOK {
+		return ""
+	}
+	return recorder.Header().Get("ETag")
+}
+
+func (s *fakeWebDAVServer) getACL(p string) []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.acls[p]
+}
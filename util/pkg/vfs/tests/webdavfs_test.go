@@ -0,0 +1,81 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tests
+
+import (
+	"context"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+func TestWebDAVACL(t *testing.T) {
+	ctx := context.TODO()
+
+	fake := newFakeWebDAVServer("kops", "secret")
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	t.Setenv("WEBDAV_USERNAME", "kops")
+	t.Setenv("WEBDAV_PASSWORD", "secret")
+	host := strings.TrimPrefix(server.URL, "http://")
+	p := mustBuildVfsPath(t, vfs.NewVFSContext(), "webdav://"+host+"/state/cluster/config")
+
+	acl := &vfs.WebDAVACL{ReadPrincipals: []string{"/principals/nodes"}}
+	if err := p.WriteFile(ctx, strings.NewReader("cluster"), acl); err != nil {
+		t.Fatalf("WriteFile(%s): %v", p, err)
+	}
+	if actual := fake.getACL("/state/cluster/config"); !reflect.DeepEqual(actual, acl.ReadPrincipals) {
+		t.Errorf("expected ACL %v, got %v", acl.ReadPrincipals, actual)
+	}
+
+	if err := p.WriteFile(ctx, strings.NewReader("cluster"), &vfs.S3Acl{}); err == nil {
+		t.Errorf("expected error writing with an S3 ACL")
+	}
+}
+
+func TestWebDAVAuthentication(t *testing.T) {
+	ctx := context.TODO()
+
+	server := httptest.NewServer(newFakeWebDAVServer("kops", "secret"))
+	defer server.Close()
+
+	t.Setenv("WEBDAV_USERNAME", "kops")
+	t.Setenv("WEBDAV_PASSWORD", "wrong")
+	host := strings.TrimPrefix(server.URL, "http://")
+	p := mustBuildVfsPath(t, vfs.NewVFSContext(), "webdav://"+host+"/state/config")
+
+	err := p.WriteFile(ctx, strings.NewReader("cluster"), nil)
+	if err == nil || !strings.Contains(err.Error(), "401") {
+		t.Errorf("expected unauthorized error, got %v", err)
+	}
+
+	// The password file takes precedence, as used by kops-controller
+	passwordFile := filepath.Join(t.TempDir(), "password")
+	if err := os.WriteFile(passwordFile, []byte("secret\n"), 0600); err != nil {
+		t.Fatalf("error writing password file: %v", err)
+	}
+	t.Setenv("WEBDAV_PASSWORD_FILE", passwordFile)
+	if err := p.WriteFile(ctx, strings.NewReader("cluster"), nil); err != nil {
+		t.Errorf("WriteFile(%s) with password file: %v", p, err)
+	}
+}
@@ -17,9 +17,14 @@ limitations under the License.
 package vfs
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
+	"os"
 	"strings"
 
 	"k8s.io/klog/v2"
@@ -92,6 +97,90 @@ type HasHash interface {
 	Hash(algorithm hashing.HashAlgorithm) (*hashing.Hash, error)
 }
 
+// ErrPreconditionFailed is returned by a conditional write when the file no longer has the expected version.
+var ErrPreconditionFailed = errors.New("file was modified since it was read")
+
+// HasConditionalWrite is implemented by Paths that can atomically replace a file,
+// but only if it has not changed since it was read.
+type HasConditionalWrite interface {
+	// ReadFileWithVersion returns the contents of the file, with an opaque identifier for the stored version
+	// (such as an ETag or generation) to pass to WriteFileIfMatch.
+	// If the file does not exist, os.ErrNotExist is returned.
+	ReadFileWithVersion(ctx context.Context) ([]byte, string, error)
+	// WriteFileIfMatch writes the file, but only if it still has the version returned by ReadFileWithVersion,
+	// returning the version of the new contents.
+	// If the file has been changed or removed, ErrPreconditionFailed is returned.
+	WriteFileIfMatch(ctx context.Context, data io.ReadSeeker, acl ACL, version string) (string, error)
+}
+
+// ErrNotModified is returned by a conditional read when the file still has the expected version.
+var ErrNotModified = errors.New("file has not been modified")
+
+// HasConditionalRead is implemented by Paths that can skip downloading a file that has not changed.
+type HasConditionalRead interface {
+	// ReadFileIfChanged returns the contents of the file, with an opaque identifier for the stored version (such as an ETag or generation).
+	// If version is non-empty and the file still has that version, ErrNotModified is returned instead.
+	// If the file does not exist, os.ErrNotExist is returned.
+	ReadFileIfChanged(ctx context.Context, version string) ([]byte, string, error)
+}
+
+// ContentVersion returns an opaque version identifier for the given file contents.
+// It is the version used by backends that don't store a version of their own.
+func ContentVersion(data []byte) string {
+	h := sha256.Sum256(data)
+	return hex.EncodeToString(h[:])
+}
+
+// ReadFileWithVersion returns the contents of the file, with the version to pass to WriteFileIfMatch.
+func ReadFileWithVersion(ctx context.Context, p Path) ([]byte, string, error) {
+	if cw, ok := p.(HasConditionalWrite); ok {
+		return cw.ReadFileWithVersion(ctx)
+	}
+
+	data, err := p.ReadFile(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	return data, ContentVersion(data), nil
+}
+
+// WriteFileIfMatch writes the file, but only if it still has the version returned by ReadFileWithVersion,
+// returning the version of the new contents.
+// Backends implementing HasConditionalWrite perform the check atomically using object-store preconditions;
+// for other backends we fall back to a (racy) read-compare-write.
+func WriteFileIfMatch(ctx context.Context, p Path, data io.ReadSeeker, acl ACL, version string) (string, error) {
+	if cw, ok := p.(HasConditionalWrite); ok {
+		return cw.WriteFileIfMatch(ctx, data, acl, version)
+	}
+
+	existing, err := p.ReadFile(ctx)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", ErrPreconditionFailed
+		}
+		return "", err
+	}
+	if err := checkContentVersion(existing, version); err != nil {
+		return "", err
+	}
+	contents, err := io.ReadAll(data)
+	if err != nil {
+		return "", fmt.Errorf("error reading data for %s: %w", p, err)
+	}
+	if err := p.WriteFile(ctx, bytes.NewReader(contents), acl); err != nil {
+		return "", err
+	}
+	return ContentVersion(contents), nil
+}
+
+// checkContentVersion returns ErrPreconditionFailed if current does not have the expected version.
+func checkContentVersion(current []byte, version string) error {
+	if ContentVersion(current) != version {
+		return ErrPreconditionFailed
+	}
+	return nil
+}
+
 func RelativePath(base Path, child Path) (string, error) {
 	basePath := base.Path()
 	childPath := child.Path()
@@ -113,7 +202,7 @@ func IsClusterReadable(p Path) bool {
 	}
 
 	switch p.(type) {
-	case *S3Path, *GSPath, *SwiftPath, *FSPath, *AzureBlobPath:
+	case *S3Path, *GSPath, *SwiftPath, *FSPath, *AzureBlobPath, *WebDAVPath:
 		return true
 
 	case *KubernetesPath:
@@ -0,0 +1,562 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vfs
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"sync"
+
+	"k8s.io/klog/v2"
+)
+
+// WebDAVPath is a path in the VFS space backed by a WebDAV server (RFC 4918).
+// webdav:// paths are accessed over HTTP and webdavs:// paths over HTTPS.
+//
+// Credentials for HTTP basic authentication are read from WEBDAV_USERNAME and either WEBDAV_PASSWORD
+// or WEBDAV_PASSWORD_FILE, the path of a file holding the password.
+type WebDAVPath struct {
+	vfsContext *VFSContext
+	scheme     string
+	host       string
+	path       string
+}
+
+var (
+	_ Path                = &WebDAVPath{}
+	_ HasConditionalWrite = &WebDAVPath{}
+	_ HasConditionalRead  = &WebDAVPath{}
+)
+
+// WebDAVACL is the ACL for a file on a WebDAV server, built by the acls strategy for WebDAV.
+// WebDAV servers normally manage permissions themselves; if ReadPrincipals is set,
+// we additionally apply a WebDAV ACL (RFC 3744) granting read access to those principals.
+type WebDAVACL struct {
+	// ReadPrincipals are the hrefs of the principals that should be granted DAV:read
+	ReadPrincipals []string
+}
+
+// NewWebDAVPath returns a new WebDAVPath.
+func NewWebDAVPath(vfsContext *VFSContext, scheme string, host string, p string) *WebDAVPath {
+	if !strings.HasPrefix(p, "/") {
+		p = "/" + p
+	}
+	if p != "/" {
+		p = strings.TrimSuffix(p, "/")
+	}
+	return &WebDAVPath{
+		vfsContext: vfsContext,
+		scheme:     scheme,
+		host:       host,
+		path:       p,
+	}
+}
+
+// Path returns a string representing the full path.
+func (p *WebDAVPath) Path() string {
+	return p.scheme + "://" + p.host + p.path
+}
+
+func (p *WebDAVPath) String() string {
+	return p.Path()
+}
+
+// Base returns the base name (last element).
+func (p *WebDAVPath) Base() string {
+	return path.Base(p.path)
+}
+
+// Join returns a new path that joins the current path and given relative paths.
+func (p *WebDAVPath) Join(relativePath ...string) Path {
+	args := []string{p.path}
+	args = append(args, relativePath...)
+	joined := path.Join(args...)
+	return NewWebDAVPath(p.vfsContext, p.scheme, p.host, joined)
+}
+
+// url returns the HTTP(S) URL of the path.
+func (p *WebDAVPath) url(collection bool) string {
+	httpScheme := "http"
+	if p.scheme == "webdavs" {
+		httpScheme = "https"
+	}
+	u := url.URL{
+		Scheme: httpScheme,
+		Host:   p.host,
+		Path:   p.path,
+	}
+	if collection && !strings.HasSuffix(u.Path, "/") {
+		u.Path += "/"
+	}
+	return u.String()
+}
+
+// do performs a WebDAV request against the path.
+func (p *WebDAVPath) do(ctx context.Context, method string, collection bool, body io.Reader, headers map[string]string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, p.url(collection), body)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	if username := os.Getenv("WEBDAV_USERNAME"); username != "" {
+		password, err := webdavPassword()
+		if err != nil {
+			return nil, err
+		}
+		req.SetBasicAuth(username, password)
+	}
+
+	klog.V(8).Infof("Performing WebDAV request: %s %s", method, req.URL)
+	return p.vfsContext.getWebDAVClient().Do(req)
+}
+
+// webdavPassword returns the password for HTTP basic authentication, from WEBDAV_PASSWORD or WEBDAV_PASSWORD_FILE.
+// The file is read on each request, so a rotated password is picked up without a restart.
+func webdavPassword() (string, error) {
+	if passwordFile := os.Getenv("WEBDAV_PASSWORD_FILE"); passwordFile != "" {
+		b, err := os.ReadFile(passwordFile)
+		if err != nil {
+			return "", fmt.Errorf("error reading WEBDAV_PASSWORD_FILE: %w", err)
+		}
+		return strings.TrimSpace(string(b)), nil
+	}
+	return os.Getenv("WEBDAV_PASSWORD"), nil
+}
+
+// responseError builds an error for an unexpected response, including the start of the body to help debugging.
+func (p *WebDAVPath) responseError(method string, response *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+	return fmt.Errorf("unexpected response code %q for %s %s: %s", response.Status, method, p, strings.TrimSpace(string(body)))
+}
+
+// ReadFile returns the contents of the file.
+func (p *WebDAVPath) ReadFile(ctx context.Context) ([]byte, error) {
+	var b bytes.Buffer
+	_, err := p.writeTo(ctx, &b)
+	if err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// WriteTo implements io.WriterTo
+func (p *WebDAVPath) WriteTo(out io.Writer) (int64, error) {
+	ctx := context.TODO()
+	return p.writeTo(ctx, out)
+}
+
+func (p *WebDAVPath) writeTo(ctx context.Context, out io.Writer) (int64, error) {
+	klog.V(4).Infof("Reading file %q", p)
+
+	response, err := p.do(ctx, http.MethodGet, false, nil, nil)
+	if err != nil {
+		return 0, fmt.Errorf("error fetching %s: %w", p, err)
+	}
+	defer response.Body.Close()
+
+	switch response.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return 0, os.ErrNotExist
+	default:
+		return 0, p.responseError(http.MethodGet, response)
+	}
+
+	n, err := io.Copy(out, response.Body)
+	if err != nil {
+		return n, fmt.Errorf("error reading %s: %w", p, err)
+	}
+	return n, nil
+}
+
+// ReadFileIfChanged implements HasConditionalRead::ReadFileIfChanged, using the ETag as the version.
+func (p *WebDAVPath) ReadFileIfChanged(ctx context.Context, version string) ([]byte, string, error) {
+	var headers map[string]string
+	if version != "" {
+		headers = map[string]string{"If-None-Match": version}
+	}
+	response, err := p.do(ctx, http.MethodGet, false, nil, headers)
+	if err != nil {
+		return nil, "", fmt.Errorf("error fetching %s: %w", p, err)
+	}
+	defer response.Body.Close()
+
+	switch response.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotModified:
+		return nil, "", ErrNotModified
+	case http.StatusNotFound:
+		return nil, "", os.ErrNotExist
+	default:
+		return nil, "", p.responseError(http.MethodGet, response)
+	}
+
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, "", fmt.Errorf("error reading %s: %w", p, err)
+	}
+	return data, response.Header.Get("ETag"), nil
+}
+
+// WriteFile writes the file, creating any missing parent collections.
+func (p *WebDAVPath) WriteFile(ctx context.Context, data io.ReadSeeker, acl ACL) error {
+	_, err := p.writeFile(ctx, data, acl, nil)
+	return err
+}
+
+// To prevent concurrent creates on the same file from this process we take a process-wide lock;
+// concurrent creates from other processes are detected by the server if it honors If-None-Match.
+var createFileLockWebDAV sync.Mutex
+
+// CreateFile writes the file contents, but only if the file does not already exist.
+func (p *WebDAVPath) CreateFile(ctx context.Context, data io.ReadSeeker, acl ACL) error {
+	createFileLockWebDAV.Lock()
+	defer createFileLockWebDAV.Unlock()
+
+	// Check if exists; not all servers implement If-None-Match on PUT
+	_, err := p.ReadFile(ctx)
+	if err == nil {
+		return os.ErrExist
+	}
+	if !os.IsNotExist(err) {
+		return err
+	}
+
+	_, err = p.writeFile(ctx, data, acl, map[string]string{"If-None-Match": "*"})
+	if err == ErrPreconditionFailed {
+		return os.ErrExist
+	}
+	return err
+}
+
+// ReadFileWithVersion implements HasConditionalWrite::ReadFileWithVersion, using the ETag as the version.
+func (p *WebDAVPath) ReadFileWithVersion(ctx context.Context) ([]byte, string, error) {
+	data, etag, err := p.ReadFileIfChanged(ctx, "")
+	if err != nil {
+		return nil, "", err
+	}
+	if etag == "" {
+		return nil, "", fmt.Errorf("no ETag returned when reading %s", p)
+	}
+	return data, etag, nil
+}
+
+// WriteFileIfMatch implements HasConditionalWrite::WriteFileIfMatch
+// We use a conditional PUT with the ETag, so a concurrent write since the file was read is detected by the server.
+func (p *WebDAVPath) WriteFileIfMatch(ctx context.Context, data io.ReadSeeker, acl ACL, version string) (string, error) {
+	etag, err := p.writeFile(ctx, data, acl, map[string]string{"If-Match": version})
+	if err != nil {
+		return "", err
+	}
+	if etag == "" {
+		// Not all servers return the ETag of the new contents, so we ask for it
+		response, err := p.do(ctx, http.MethodHead, false, nil, nil)
+		if err != nil {
+			return "", fmt.Errorf("error fetching %s: %w", p, err)
+		}
+		response.Body.Close()
+		if response.StatusCode != http.StatusOK {
+			return "", p.responseError(http.MethodHead, response)
+		}
+		etag = response.Header.Get("ETag")
+	}
+	return etag, nil
+}
+
+// writeFile PUTs the file, with any conditional headers, returning the ETag of the new contents if the server sent it.
+// A failed precondition is reported as ErrPreconditionFailed.
+func (p *WebDAVPath) writeFile(ctx context.Context, data io.ReadSeeker, acl ACL, headers map[string]string) (string, error) {
+	readPrincipals, err := p.getReadPrincipals(acl)
+	if err != nil {
+		return "", err
+	}
+
+	klog.V(4).Infof("Writing file %q", p)
+
+	for attempt := 0; ; attempt++ {
+		if _, err := data.Seek(0, io.SeekStart); err != nil {
+			return "", fmt.Errorf("error seeking to start of data: %w", err)
+		}
+
+		response, err := p.do(ctx, http.MethodPut, false, data, headers)
+		if err != nil {
+			return "", fmt.Errorf("error writing %s: %w", p, err)
+		}
+		response.Body.Close()
+
+		switch response.StatusCode {
+		case http.StatusOK, http.StatusCreated, http.StatusNoContent:
+			if len(readPrincipals) != 0 {
+				if err := p.applyACL(ctx, readPrincipals); err != nil {
+					return "", err
+				}
+			}
+			return response.Header.Get("ETag"), nil
+
+		case http.StatusPreconditionFailed:
+			return "", ErrPreconditionFailed
+
+		case http.StatusConflict:
+			// RFC 4918 9.7.1: the parent collection does not exist
+			if attempt == 0 && headers["If-Match"] == "" {
+				parent := NewWebDAVPath(p.vfsContext, p.scheme, p.host, path.Dir(p.path))
+				if err := parent.mkcolAll(ctx); err != nil {
+					return "", err
+				}
+				continue
+			}
+		}
+
+		return "", p.responseError(http.MethodPut, response)
+	}
+}
+
+// mkcolAll creates the collection and any missing parent collections.
+func (p *WebDAVPath) mkcolAll(ctx context.Context) error {
+	if p.path == "/" {
+		return nil
+	}
+
+	response, err := p.do(ctx, "MKCOL", true, nil, nil)
+	if err != nil {
+		return fmt.Errorf("error creating collection %s: %w", p, err)
+	}
+	response.Body.Close()
+
+	switch response.StatusCode {
+	case http.StatusCreated:
+		return nil
+	case http.StatusMethodNotAllowed:
+		// The collection already exists
+		return nil
+	case http.StatusConflict:
+		// The parent collection does not exist
+		parent := NewWebDAVPath(p.vfsContext, p.scheme, p.host, path.Dir(p.path))
+		if err := parent.mkcolAll(ctx); err != nil {
+			return err
+		}
+		return p.mkcolAll(ctx)
+	default:
+		return p.responseError("MKCOL", response)
+	}
+}
+
+// getReadPrincipals returns the principals to grant read access from the ACL.
+func (p *WebDAVPath) getReadPrincipals(aclObj ACL) ([]string, error) {
+	if aclObj == nil {
+		return nil, nil
+	}
+	webdavACL, ok := aclObj.(*WebDAVACL)
+	if !ok {
+		return nil, fmt.Errorf("write to %s with ACL of unexpected type %T", p, aclObj)
+	}
+	return webdavACL.ReadPrincipals, nil
+}
+
+// applyACL grants DAV:read to the principals using the ACL method (RFC 3744 8.1).
+func (p *WebDAVPath) applyACL(ctx context.Context, principals []string) error {
+	var b bytes.Buffer
+	b.WriteString(xml.Header)
+	b.WriteString(`<D:acl xmlns:D="DAV:">`)
+	for _, principal := range principals {
+		b.WriteString(`<D:ace><D:principal><D:href>`)
+		if err := xml.EscapeText(&b, []byte(principal)); err != nil {
+			return err
+		}
+		b.WriteString(`</D:href></D:principal><D:grant><D:privilege><D:read/></D:privilege></D:grant></D:ace>`)
+	}
+	b.WriteString(`</D:acl>`)
+
+	klog.V(8).Infof("Setting WebDAV ACL on %s to grant read to %v", p, principals)
+
+	response, err := p.do(ctx, "ACL", false, &b, map[string]string{"Content-Type": "application/xml; charset=utf-8"})
+	if err != nil {
+		return fmt.Errorf("error setting ACL on %s: %w", p, err)
+	}
+	defer response.Body.Close()
+
+	switch response.StatusCode {
+	case http.StatusOK, http.StatusNoContent:
+		return nil
+	case http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		return fmt.Errorf("WebDAV server does not support ACLs, which are required to set permissions on %s", p)
+	default:
+		return p.responseError("ACL", response)
+	}
+}
+
+// Remove deletes the file.
+func (p *WebDAVPath) Remove(ctx context.Context) error {
+	klog.V(8).Infof("removing file %s", p)
+
+	response, err := p.do(ctx, http.MethodDelete, false, nil, nil)
+	if err != nil {
+		return fmt.Errorf("error deleting %s: %w", p, err)
+	}
+	defer response.Body.Close()
+
+	switch response.StatusCode {
+	case http.StatusOK, http.StatusAccepted, http.StatusNoContent:
+		return nil
+	case http.StatusNotFound:
+		return os.ErrNotExist
+	default:
+		return p.responseError(http.MethodDelete, response)
+	}
+}
+
+// RemoveAll deletes all the files in the subtree rooted at the current Path.
+func (p *WebDAVPath) RemoveAll(ctx context.Context) error {
+	tree, err := p.ReadTree(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, filePath := range tree {
+		err := filePath.Remove(ctx)
+		if err != nil {
+			return fmt.Errorf("error removing file %s: %w", filePath, err)
+		}
+	}
+
+	return nil
+}
+
+// RemoveAllVersions deletes the file; WebDAV does not keep versions.
+func (p *WebDAVPath) RemoveAllVersions(ctx context.Context) error {
+	return p.Remove(ctx)
+}
+
+// webdavMultistatus is the subset of a PROPFIND response (RFC 4918 14.16) that we use.
+type webdavMultistatus struct {
+	Responses []struct {
+		Href     string `xml:"href"`
+		Propstat []struct {
+			Prop struct {
+				ResourceType struct {
+					Collection *struct{} `xml:"collection"`
+				} `xml:"resourcetype"`
+			} `xml:"prop"`
+			Status string `xml:"status"`
+		} `xml:"propstat"`
+	} `xml:"response"`
+}
+
+const webdavPropfindBody = xml.Header + `<D:propfind xmlns:D="DAV:"><D:prop><D:resourcetype/></D:prop></D:propfind>`
+
+// ReadDir lists the files and collections in the current Path.
+func (p *WebDAVPath) ReadDir() ([]Path, error) {
+	ctx := context.TODO()
+
+	files, collections, err := p.propfind(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return append(files, collections...), nil
+}
+
+// ReadTree lists all files (recursively) in the subtree rooted at the current Path.
+// We recurse with Depth: 1, because many servers refuse Depth: infinity.
+func (p *WebDAVPath) ReadTree(ctx context.Context) ([]Path, error) {
+	files, collections, err := p.propfind(ctx)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	paths := files
+	for _, collection := range collections {
+		children, err := collection.(*WebDAVPath).ReadTree(ctx)
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, children...)
+	}
+	return paths, nil
+}
+
+// propfind lists the members of the collection at the current Path, split into files and collections.
+func (p *WebDAVPath) propfind(ctx context.Context) ([]Path, []Path, error) {
+	headers := map[string]string{
+		"Depth":        "1",
+		"Content-Type": "application/xml; charset=utf-8",
+	}
+	response, err := p.do(ctx, "PROPFIND", true, strings.NewReader(webdavPropfindBody), headers)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error listing %s: %w", p, err)
+	}
+	defer response.Body.Close()
+
+	switch response.StatusCode {
+	case http.StatusMultiStatus:
+	case http.StatusNotFound:
+		return nil, nil, os.ErrNotExist
+	default:
+		return nil, nil, p.responseError("PROPFIND", response)
+	}
+
+	var multistatus webdavMultistatus
+	if err := xml.NewDecoder(response.Body).Decode(&multistatus); err != nil {
+		return nil, nil, fmt.Errorf("error parsing listing of %s: %w", p, err)
+	}
+
+	var files, collections []Path
+	for _, r := range multistatus.Responses {
+		u, err := url.Parse(r.Href)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error parsing href %q in listing of %s: %w", r.Href, p, err)
+		}
+		childPath := path.Clean("/" + u.Path)
+		if childPath == p.path {
+			continue
+		}
+		if path.Dir(childPath) != p.path {
+			klog.V(4).Infof("ignoring unexpected href %q in listing of %s", r.Href, p)
+			continue
+		}
+
+		child := NewWebDAVPath(p.vfsContext, p.scheme, p.host, childPath)
+		isCollection := false
+		for _, propstat := range r.Propstat {
+			if !strings.Contains(propstat.Status, " 200 ") {
+				continue
+			}
+			if propstat.Prop.ResourceType.Collection != nil {
+				isCollection = true
+			}
+		}
+		if isCollection {
+			collections = append(collections, child)
+		} else {
+			files = append(files, child)
+		}
+	}
+	klog.V(8).Infof("Listed files in %v: %v", p, files)
+	return files, collections, nil
+}